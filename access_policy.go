@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+)
+
+// SetAPIKeyIPAllowlist restricts the source addresses from which a user's API key may be used.
+//
+// The list replaces any previous allowlist; pass an empty list to remove the restriction.
+//
+// Example:
+//
+//	_, err := client.SetAPIKeyIPAllowlist(ctx, &sdk.APIKeyIPAllowlistSetRequest{
+//		UserID: 123,
+//		CIDRs:  []string{"10.0.0.0/8", "203.0.113.7"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+func (c *RawClient) SetAPIKeyIPAllowlist(ctx context.Context, req *APIKeyIPAllowlistSetRequest, opts ...CallOption) (*APIKeyIPAllowlistSetResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp APIKeyIPAllowlistSetResponse
+	if err := c.postJSON(ctx, "/user/access_policy/ip_allowlist/set", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetAPIKeyIPAllowlist retrieves the IP allowlist of a user's API key.
+//
+// An empty CIDRs list in the response means the key is not restricted.
+//
+// Example:
+//
+//	info, err := client.GetAPIKeyIPAllowlist(ctx, &sdk.APIKeyIPAllowlistInfoRequest{UserID: 123})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("allowed: %v\n", info.CIDRs)
+func (c *RawClient) GetAPIKeyIPAllowlist(ctx context.Context, req *APIKeyIPAllowlistInfoRequest, opts ...CallOption) (*APIKeyIPAllowlistInfoResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp APIKeyIPAllowlistInfoResponse
+	if err := c.postJSON(ctx, "/user/access_policy/ip_allowlist/info", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetSessionPolicy sets the session max age, idle timeout and concurrent session limit.
+//
+// Leave UserID zero to set the account-wide default that applies to users without a policy of their own.
+//
+// Example:
+//
+//	_, err := client.SetSessionPolicy(ctx, &sdk.SessionPolicySetRequest{
+//		SessionPolicy: sdk.SessionPolicy{
+//			MaxAgeSeconds:         8 * 3600,
+//			MaxConcurrentSessions: 3,
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+func (c *RawClient) SetSessionPolicy(ctx context.Context, req *SessionPolicySetRequest, opts ...CallOption) (*SessionPolicySetResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp SessionPolicySetResponse
+	if err := c.postJSON(ctx, "/user/access_policy/session/set", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSessionPolicy retrieves the effective session policy of a user or the account-wide default.
+//
+// The response reports whether the user's policy is inherited from the account-wide default.
+//
+// Example:
+//
+//	info, err := client.GetSessionPolicy(ctx, &sdk.SessionPolicyInfoRequest{UserID: 123})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("max age: %ds (inherited: %v)\n", info.MaxAgeSeconds, info.Inherited)
+func (c *RawClient) GetSessionPolicy(ctx context.Context, req *SessionPolicyInfoRequest, opts ...CallOption) (*SessionPolicyInfoResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp SessionPolicyInfoResponse
+	if err := c.postJSON(ctx, "/user/access_policy/session/info", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
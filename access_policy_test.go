@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessPolicyNilRequestErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"SetIPAllowlist", func() error { _, err := client.SetAPIKeyIPAllowlist(ctx, nil); return err }},
+		{"GetIPAllowlist", func() error { _, err := client.GetAPIKeyIPAllowlist(ctx, nil); return err }},
+		{"SetSessionPolicy", func() error { _, err := client.SetSessionPolicy(ctx, nil); return err }},
+		{"GetSessionPolicy", func() error { _, err := client.GetSessionPolicy(ctx, nil); return err }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, tc.call(), ErrNilRequest)
+		})
+	}
+}
+
+func TestSetSessionPolicy(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/user/access_policy/session/set", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		// The embedded policy is flattened and a zero UserID is omitted
+		require.NotContains(t, body, "user_id")
+		require.EqualValues(t, 28800, body["max_age_seconds"])
+		require.EqualValues(t, 3, body["max_concurrent_sessions"])
+		return envelopeResponse(`{"user_id":0}`), nil
+	})
+
+	_, err := client.SetSessionPolicy(context.Background(), &SessionPolicySetRequest{
+		SessionPolicy: SessionPolicy{MaxAgeSeconds: 8 * 3600, MaxConcurrentSessions: 3},
+	})
+	require.NoError(t, err)
+}
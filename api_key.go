@@ -0,0 +1,79 @@
+package sdk
+
+import (
+	"context"
+)
+
+// CreateUserAPIKey creates an additional API key for a user, optionally with an
+// expiration date and limited to some privilege codes.
+//
+// This requires the privilege to manage users. The secret is only present in the
+// response to this call, so store it right away.
+//
+// Example:
+//
+//	resp, err := client.CreateUserAPIKey(ctx, &sdk.APIKeyCreateRequest{
+//		UserID:      123,
+//		Description: "nightly-export",
+//		ExpiresAt:   time.Now().Add(90 * 24 * time.Hour).Format(time.RFC3339),
+//		Scopes:      []string{"DT8"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Key %s: %s\n", resp.KeyID, resp.Key)
+func (c *RawClient) CreateUserAPIKey(ctx context.Context, req *APIKeyCreateRequest, opts ...CallOption) (*APIKeyCreateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp APIKeyCreateResponse
+	if err := c.postJSON(ctx, "/user/api-key/create", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RevokeUserAPIKey revokes one of a user's API keys.
+//
+// Requests made with the key are rejected as soon as the call returns.
+//
+// Example:
+//
+//	_, err := client.RevokeUserAPIKey(ctx, &sdk.APIKeyRevokeRequest{
+//		UserID: 123,
+//		KeyID:  "key-abc",
+//	})
+func (c *RawClient) RevokeUserAPIKey(ctx context.Context, req *APIKeyRevokeRequest, opts ...CallOption) (*APIKeyRevokeResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp APIKeyRevokeResponse
+	if err := c.postJSON(ctx, "/user/api-key/revoke", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListUserAPIKeys lists the API keys of a user, without their secrets.
+//
+// Revoked and expired keys are only listed when IncludeRevoked is set.
+//
+// Example:
+//
+//	resp, err := client.ListUserAPIKeys(ctx, &sdk.APIKeyListRequest{UserID: 123})
+//	if err != nil {
+//		return err
+//	}
+//	for _, key := range resp.List {
+//		fmt.Printf("%s (%s) expires %s\n", key.KeyID, key.Prefix, key.ExpiresAt)
+//	}
+func (c *RawClient) ListUserAPIKeys(ctx context.Context, req *APIKeyListRequest, opts ...CallOption) (*APIKeyListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp APIKeyListResponse
+	if err := c.postJSON(ctx, "/user/api-key/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
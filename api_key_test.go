@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyNilRequestErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Create", func() error { _, err := client.CreateUserAPIKey(ctx, nil); return err }},
+		{"Revoke", func() error { _, err := client.RevokeUserAPIKey(ctx, nil); return err }},
+		{"List", func() error { _, err := client.ListUserAPIKeys(ctx, nil); return err }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, tc.call(), ErrNilRequest)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// TokenRefresher returns a new API key for a client whose key was rejected, e.g. by
+// reading the current key from a secret store after a rotation.
+type TokenRefresher func(ctx context.Context) (string, error)
+
+// AuthProvider supplies the credential sent with every request of a client configured
+// with WithAuthProvider, including streaming requests.
+//
+// Token is called for each request and must be safe for concurrent use; providers
+// that obtain tokens over the network should cache them. By default the token is
+// sent in the moi-key header, like an API key. A provider can choose another header
+// by implementing AuthHeaderProvider, and can replace a token the service rejects by
+// implementing AuthRefresher. StaticAPIKey, OAuth2ClientCredentials and SessionLogin
+// are the built-in providers.
+type AuthProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// AuthHeaderProvider is implemented by an AuthProvider whose tokens are not sent in
+// the moi-key header.
+type AuthHeaderProvider interface {
+	AuthProvider
+	// AuthHeader returns the name and value of the header that carries token.
+	AuthHeader(token string) (name, value string)
+}
+
+// AuthRefresher is implemented by an AuthProvider that can replace a token the
+// service rejected with 401 Unauthorized. The rejected request is then sent once
+// more, under the same conditions as with WithTokenRefresher.
+type AuthRefresher interface {
+	AuthProvider
+	// Refresh returns a token to use instead of rejected. When the provider already
+	// holds another token, e.g. because a concurrent request refreshed it, Refresh
+	// returns that token without obtaining a new one.
+	Refresh(ctx context.Context, rejected string) (string, error)
+}
+
+// StaticAPIKey returns an AuthProvider that always supplies key, the behaviour of
+// a client created with an API key and no other authentication option.
+func StaticAPIKey(key string) AuthProvider {
+	return staticAPIKey(strings.TrimSpace(key))
+}
+
+type staticAPIKey string
+
+func (k staticAPIKey) Token(context.Context) (string, error) {
+	return string(k), nil
+}
+
+// apiKeySource holds the API key of a client configured with WithTokenRefresher and
+// replaces it when the service rejects it.
+type apiKeySource struct {
+	mu      sync.Mutex
+	key     string
+	refresh TokenRefresher
+}
+
+func (s *apiKeySource) current() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.key
+}
+
+func (s *apiKeySource) Token(context.Context) (string, error) {
+	return s.current(), nil
+}
+
+// Refresh replaces rejected with a new key and returns it. When several requests are
+// rejected at once, only the first one calls the refresher; the others get its key.
+func (s *apiKeySource) Refresh(ctx context.Context, rejected string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.key != rejected {
+		return s.key, nil
+	}
+	key, err := s.refresh(ctx)
+	if err != nil {
+		return "", fmt.Errorf("refresh api key: %w", err)
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", fmt.Errorf("refresh api key: %w", ErrAPIKeyRequired)
+	}
+	s.key = key
+	return key, nil
+}
+
+// currentAPIKey returns the API key to send with the next request.
+func (c *RawClient) currentAPIKey() string {
+	if c.keySource != nil {
+		return c.keySource.current()
+	}
+	return c.apiKey
+}
+
+// authProvider returns the provider of the client's credentials.
+func (c *RawClient) authProvider() AuthProvider {
+	switch {
+	case c.auth != nil:
+		return c.auth
+	case c.keySource != nil:
+		return c.keySource
+	default:
+		return staticAPIKey(c.apiKey)
+	}
+}
+
+// authHeader returns the header that carries token of provider p.
+func authHeader(p AuthProvider, token string) (name, value string) {
+	if hp, ok := p.(AuthHeaderProvider); ok {
+		return hp.AuthHeader(token)
+	}
+	return headerAPIKey, token
+}
+
+// setAuth adds the client's credentials to req.
+func (c *RawClient) setAuth(req *http.Request) error {
+	p := c.authProvider()
+	token, err := p.Token(req.Context())
+	if err != nil {
+		return fmt.Errorf("get auth token: %w", err)
+	}
+	name, value := authHeader(p, token)
+	req.Header.Set(name, value)
+	return nil
+}
+
+// canReplay reports whether req can be sent again after its API key was refreshed:
+// its body must be rewindable, and a mutating request must carry an idempotency key
+// so that the service does not apply it twice. Reads sent with POST, like
+// /catalog/info, change nothing and are always replayed.
+func canReplay(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return isReadOnlyEndpoint(req.URL.Path) || req.Header.Get(headerIdempotencyKey) != ""
+}
+
+// retryUnauthorized handles a 401 response to req when the client's credentials can
+// be refreshed: it refreshes them and, if req can be replayed, sends it once more
+// with the new ones using client. Otherwise resp is returned unchanged.
+func (c *RawClient) retryUnauthorized(client *http.Client, req *http.Request, resp *http.Response) (*http.Response, error) {
+	if resp.StatusCode != http.StatusUnauthorized || isRetryAttempt(req.Context()) {
+		return resp, nil
+	}
+	refresher, ok := c.authProvider().(AuthRefresher)
+	if !ok {
+		return resp, nil
+	}
+	token, err := refresher.Token(req.Context())
+	name, value := authHeader(refresher, token)
+	if err == nil && req.Header.Get(name) == value {
+		token, err = refresher.Refresh(req.Context(), token)
+	}
+	if err != nil {
+		c.log(req.Context(), LogLevelWarn, "moi api key refresh failed", "error", err)
+		return resp, nil
+	}
+	if !canReplay(req) {
+		return resp, nil
+	}
+
+	retry := req.Clone(withRetryAttempt(req.Context()))
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	name, value = authHeader(refresher, token)
+	retry.Header.Set(name, value)
+	resp.Body.Close()
+	return client.Do(retry)
+}
@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	t.Parallel()
+	var issued atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		require.Equal(t, "catalog genai", r.PostForm.Get("scope"))
+		id, secret, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "etl", id)
+		require.Equal(t, "s%3Ac", secret)
+		n := issued.Add(1)
+		w.Header().Set(headerContentType, mimeJSON)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": []string{"", "tok-1", "tok-2"}[n],
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	var auths []string
+	client, err := NewRawClient("https://moi.test", "",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			auths = append(auths, r.Header.Get("Authorization"))
+			require.Empty(t, r.Header.Get(headerAPIKey))
+			if r.Header.Get("Authorization") == "Bearer tok-1" && len(auths) > 2 {
+				return unauthorizedResponse(), nil
+			}
+			return envelopeResponse(`{"request_id":"req-1","status":"completed"}`), nil
+		})}),
+		WithAuthProvider(OAuth2ClientCredentials(OAuth2Config{
+			TokenURL:     tokenServer.URL,
+			ClientID:     "etl",
+			ClientSecret: "s:c",
+			Scopes:       []string{"catalog", "genai"},
+		})))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = client.GetAnalyzeRequest(context.Background(), "req-1")
+		require.NoError(t, err)
+	}
+	// The cached token is reused until the service rejects it
+	require.Equal(t, []string{"Bearer tok-1", "Bearer tok-1", "Bearer tok-1", "Bearer tok-2"}, auths)
+	require.Equal(t, int32(2), issued.Load())
+}
+
+func TestOAuth2ClientCredentials_Error(t *testing.T) {
+	t.Parallel()
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerContentType, mimeJSON)
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"unknown client"}`))
+	}))
+	defer tokenServer.Close()
+
+	client, err := NewRawClient("https://moi.test", "",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			t.Fatal("request sent without a token")
+			return nil, nil
+		})}),
+		WithAuthProvider(OAuth2ClientCredentials(OAuth2Config{TokenURL: tokenServer.URL})))
+	require.NoError(t, err)
+
+	_, err = client.GetAnalyzeRequest(context.Background(), "req-1")
+	require.ErrorContains(t, err, "invalid_client unknown client")
+}
+
+func TestSessionLogin(t *testing.T) {
+	t.Parallel()
+	var logins atomic.Int32
+	loginServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var creds map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&creds))
+		require.Equal(t, map[string]string{"username": "bot", "password": "pw"}, creds)
+		logins.Add(1)
+		w.Header().Set(headerContentType, mimeJSON)
+		_, _ = w.Write([]byte(`{"code":"OK","data":{"token":"session-1"}}`))
+	}))
+	defer loginServer.Close()
+
+	var keys []string
+	client, err := NewRawClient("https://moi.test", "",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			keys = append(keys, r.Header.Get(headerAPIKey))
+			return envelopeResponse(`{}`), nil
+		})}),
+		WithAuthProvider(SessionLogin(SessionLoginConfig{LoginURL: loginServer.URL, Username: "bot", Password: "pw"})))
+	require.NoError(t, err)
+
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"session-1", "session-1"}, keys)
+	require.Equal(t, int32(1), logins.Load())
+}
+
+type failingAuth struct{}
+
+func (failingAuth) Token(context.Context) (string, error) {
+	return "", errors.New("vault sealed")
+}
+
+func TestWithAuthProvider(t *testing.T) {
+	t.Parallel()
+	_, err := NewRawClient("https://moi.test", "")
+	require.ErrorIs(t, err, ErrAPIKeyRequired)
+
+	client, err := NewRawClient("https://moi.test", "",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			t.Fatal("request sent without a token")
+			return nil, nil
+		})}),
+		WithAuthProvider(failingAuth{}))
+	require.NoError(t, err)
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	require.ErrorContains(t, err, "vault sealed")
+
+	// Streaming and LLM proxy requests are authenticated too
+	var key string
+	client, err = NewRawClient("https://moi.test", "",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			key = r.Header.Get(headerAPIKey)
+			return envelopeResponse(`{}`), nil
+		})}),
+		WithAuthProvider(StaticAPIKey("static-key")))
+	require.NoError(t, err)
+	require.NoError(t, client.doLLMJSON(context.Background(), http.MethodGet, "/sessions", nil, nil))
+	require.Equal(t, "static-key", key)
+}
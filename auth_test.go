@@ -0,0 +1,173 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newRefreshingClient(t *testing.T, handler roundTripperFunc, refresh TokenRefresher) *RawClient {
+	t.Helper()
+	client, err := NewRawClient("https://moi.test", "old-key",
+		WithHTTPClient(&http.Client{Transport: handler}),
+		WithTokenRefresher(refresh))
+	require.NoError(t, err)
+	return client
+}
+
+func unauthorizedResponse() *http.Response {
+	return &http.Response{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(strings.NewReader("unauthorized"))}
+}
+
+func TestTokenRefresher_ReplaysIdempotentRequest(t *testing.T) {
+	t.Parallel()
+
+	var refreshes atomic.Int32
+	var keys []string
+	client := newRefreshingClient(t, func(r *http.Request) (*http.Response, error) {
+		keys = append(keys, r.Header.Get(headerAPIKey))
+		if r.Header.Get(headerAPIKey) == "old-key" {
+			return unauthorizedResponse(), nil
+		}
+		return envelopeResponse(`{"request_id":"req-1","status":"completed"}`), nil
+	}, func(ctx context.Context) (string, error) {
+		refreshes.Add(1)
+		return "new-key", nil
+	})
+
+	info, err := client.GetAnalyzeRequest(context.Background(), "req-1")
+	require.NoError(t, err)
+	require.Equal(t, AnalyzeRequestStatusCompleted, info.Status)
+	require.Equal(t, []string{"old-key", "new-key"}, keys)
+	require.Equal(t, int32(1), refreshes.Load())
+
+	// Later requests use the new key right away
+	_, err = client.GetAnalyzeRequest(context.Background(), "req-1")
+	require.NoError(t, err)
+	require.Equal(t, "new-key", keys[2])
+	require.Equal(t, int32(1), refreshes.Load())
+}
+
+func TestTokenRefresher_PostRequiresIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	client := newRefreshingClient(t, func(r *http.Request) (*http.Response, error) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if r.Header.Get(headerAPIKey) == "old-key" {
+			return unauthorizedResponse(), nil
+		}
+		return envelopeResponse(`{"id":"vol-1"}`), nil
+	}, func(ctx context.Context) (string, error) {
+		return "new-key", nil
+	})
+	req := &VolumeCreateRequest{Name: "v", DatabaseID: 1}
+
+	_, err := client.CreateVolume(context.Background(), req)
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+	require.Len(t, bodies, 1)
+
+	bodies = nil
+	client.keySource.key = "old-key"
+	resp, err := client.CreateVolume(context.Background(), req, WithHeader(headerIdempotencyKey, "create-v"))
+	require.NoError(t, err)
+	require.Equal(t, VolumeID("vol-1"), resp.VolumeID)
+	require.Len(t, bodies, 2)
+	require.Equal(t, bodies[0], bodies[1])
+}
+
+func TestTokenRefresher_ReplaysPostRead(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	client := newRefreshingClient(t, func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodPost, r.Method)
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(data))
+		if r.Header.Get(headerAPIKey) == "old-key" {
+			return unauthorizedResponse(), nil
+		}
+		return envelopeResponse(`{"id":1,"name":"sales"}`), nil
+	}, func(ctx context.Context) (string, error) {
+		return "new-key", nil
+	})
+
+	info, err := client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "sales", info.CatalogName)
+	require.Len(t, bodies, 2)
+	require.Equal(t, bodies[0], bodies[1])
+}
+
+func TestTokenRefresher_ReplaysConnectorAndLLMRequests(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	client := newRefreshingClient(t, func(r *http.Request) (*http.Response, error) {
+		paths = append(paths, r.URL.Path)
+		if r.Header.Get(headerAPIKey) == "old-key" {
+			return unauthorizedResponse(), nil
+		}
+		if r.URL.Path == "/connectors/file/preview" {
+			return envelopeResponse(`{}`), nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"message_id":10}`))}, nil
+	}, func(ctx context.Context) (string, error) {
+		return "new-key", nil
+	})
+
+	// The LLM request is sent once, with the key refreshed by the first one
+	_, err := client.FilePreview(context.Background(), &FilePreviewRequest{ConnFileId: "f1"})
+	require.NoError(t, err)
+	resp, err := client.ModifyLLMSessionMessageResponse(context.Background(), 1, 10, "edited")
+	require.NoError(t, err)
+	require.Equal(t, int64(10), resp.MessageID)
+	require.Equal(t, []string{
+		"/connectors/file/preview", "/connectors/file/preview",
+		"/llm-proxy/api/sessions/1/messages/10/modify-response",
+	}, paths)
+}
+
+func TestTokenRefresher_RetriesOnlyOnce(t *testing.T) {
+	t.Parallel()
+
+	var calls, refreshes int
+	client := newRefreshingClient(t, func(r *http.Request) (*http.Response, error) {
+		calls++
+		return unauthorizedResponse(), nil
+	}, func(ctx context.Context) (string, error) {
+		refreshes++
+		return "new-key", nil
+	})
+
+	_, err := client.GetAnalyzeRequest(context.Background(), "req-1")
+	require.Error(t, err)
+	require.Equal(t, 2, calls)
+	require.Equal(t, 1, refreshes)
+}
+
+func TestTokenRefresher_RefreshFailure(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	client := newRefreshingClient(t, func(r *http.Request) (*http.Response, error) {
+		calls++
+		return unauthorizedResponse(), nil
+	}, func(ctx context.Context) (string, error) {
+		return "", errors.New("secret store unavailable")
+	})
+
+	_, err := client.GetAnalyzeRequest(context.Background(), "req-1")
+	require.True(t, IsPermissionDenied(err))
+	require.Equal(t, 1, calls)
+	require.Equal(t, "old-key", client.currentAPIKey())
+}
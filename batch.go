@@ -0,0 +1,339 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// BatchItemResult is the outcome of a single item in a batch operation.
+type BatchItemResult struct {
+	// Index is the position of the item in the input slice.
+	Index int
+	// ID identifies the affected object: the input ID for deletes, the new ID for creates.
+	ID string
+	// Err is nil when the item succeeded.
+	Err error
+}
+
+// BatchResult reports per-item outcomes of a batch operation, ordered by input index.
+//
+// A batch call only returns an error for failures that affect the whole batch; failures
+// of individual items are reported here.
+//
+// Example:
+//
+//	result, err := client.DeleteFilesBatch(ctx, fileIDs)
+//	if err != nil {
+//		return err
+//	}
+//	for _, item := range result.Failed() {
+//		fmt.Printf("failed to delete %s: %v\n", item.ID, item.Err)
+//	}
+type BatchResult struct {
+	Items []BatchItemResult
+}
+
+// Succeeded returns the items that completed successfully.
+func (r *BatchResult) Succeeded() []BatchItemResult {
+	return r.filter(func(item BatchItemResult) bool { return item.Err == nil })
+}
+
+// Failed returns the items that failed.
+func (r *BatchResult) Failed() []BatchItemResult {
+	return r.filter(func(item BatchItemResult) bool { return item.Err != nil })
+}
+
+// Err returns nil if every item succeeded, or an error joining all item failures.
+func (r *BatchResult) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	errs := make([]error, len(failed))
+	for i, item := range failed {
+		errs[i] = fmt.Errorf("item %d (%s): %w", item.Index, item.ID, item.Err)
+	}
+	return errors.Join(errs...)
+}
+
+func (r *BatchResult) filter(keep func(BatchItemResult) bool) []BatchItemResult {
+	if r == nil {
+		return nil
+	}
+	var out []BatchItemResult
+	for _, item := range r.Items {
+		if keep(item) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// batchItemStatus is the per-item status returned by server batch endpoints.
+type batchItemStatus struct {
+	Index int    `json:"index"`
+	ID    string `json:"id"`
+	Code  string `json:"code"`
+	Msg   string `json:"msg"`
+}
+
+// batchResponse is the payload returned by server batch endpoints.
+type batchResponse struct {
+	Items []batchItemStatus `json:"items"`
+}
+
+// toBatchResult converts a server batch response into a BatchResult with one entry per input.
+func (r *batchResponse) toBatchResult(n int) *BatchResult {
+	result := &BatchResult{Items: make([]BatchItemResult, n)}
+	for i := range result.Items {
+		result.Items[i] = BatchItemResult{Index: i, Err: fmt.Errorf("no result returned for item")}
+	}
+	for _, status := range r.Items {
+		if status.Index < 0 || status.Index >= n {
+			continue
+		}
+		item := BatchItemResult{Index: status.Index, ID: status.ID}
+		if status.Code != "" && !isOKCode(status.Code) {
+			item.Err = &APIError{Code: status.Code, Message: status.Msg, HTTPStatus: http.StatusOK}
+		}
+		result.Items[status.Index] = item
+	}
+	return result
+}
+
+// isBatchEndpointUnsupported reports whether err indicates that the server does not
+// provide the batch endpoint, in which case callers fall back to per-item requests.
+func isBatchEndpointUnsupported(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return true
+		}
+	}
+	return false
+}
+
+// runBatch calls fn for every index in [0, n) with at most concurrency calls in flight.
+// Items not started because ctx was cancelled are reported with the context error.
+func runBatch(ctx context.Context, n, concurrency int, fn func(ctx context.Context, i int) (string, error)) *BatchResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	result := &BatchResult{Items: make([]BatchItemResult, n)}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < n; j++ {
+				result.Items[j] = BatchItemResult{Index: j, Err: ctx.Err()}
+			}
+			wg.Wait()
+			return result
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			id, err := fn(ctx, i)
+			result.Items[i] = BatchItemResult{Index: i, ID: id, Err: err}
+		}(i)
+	}
+	wg.Wait()
+	return result
+}
+
+// maxBatchOps is the maximum number of operations packed into one request to the
+// batch endpoint; larger batches are split.
+const maxBatchOps = 100
+
+// BatchOp is a single metadata operation of a BatchRequest.
+type BatchOp struct {
+	// Path is the API endpoint of the operation, e.g. "/catalog/create".
+	Path string
+	// Request is the JSON request payload.
+	Request interface{}
+	// Response, if non-nil, receives the decoded response payload when the operation succeeds.
+	Response interface{}
+	// ID optionally labels the operation; it is reported as BatchItemResult.ID.
+	ID string
+}
+
+// NewBatchOp returns a batch operation calling path with req and decoding the response into resp.
+func NewBatchOp(path string, req, resp interface{}) BatchOp {
+	return BatchOp{Path: path, Request: req, Response: resp}
+}
+
+// BatchRequest collects metadata operations to be sent together. Create one with
+// RawClient.Batch.
+type BatchRequest struct {
+	client *RawClient
+	ctx    context.Context
+	opts   []CallOption
+	ops    []BatchOp
+}
+
+// Batch starts a batch of metadata operations.
+//
+// Do packs the operations into as few HTTP round trips as possible using the gateway
+// batch endpoint. When the gateway does not provide it, the operations are sent as
+// individual requests, at most WithConcurrency at a time, behind the same API. The
+// call options apply to every operation.
+//
+// The operations must be independent of each other: they may run concurrently and in
+// any order, so an operation cannot use an object created by another one of the same
+// batch. Send dependent operations in successive batches.
+//
+// Example:
+//
+//	var sales, hr sdk.CatalogCreateResponse
+//	result, err := client.Batch(ctx).
+//		Add(sdk.NewBatchOp("/catalog/create", &sdk.CatalogCreateRequest{CatalogName: "sales"}, &sales)).
+//		Add(sdk.NewBatchOp("/catalog/create", &sdk.CatalogCreateRequest{CatalogName: "hr"}, &hr)).
+//		Do()
+//	if err != nil {
+//		return err
+//	}
+//	if err := result.Err(); err != nil {
+//		fmt.Printf("some operations failed: %v\n", err)
+//	}
+func (c *RawClient) Batch(ctx context.Context, opts ...CallOption) *BatchRequest {
+	return &BatchRequest{client: c, ctx: ctx, opts: opts}
+}
+
+// Add appends operations to the batch and returns the batch for chaining.
+func (b *BatchRequest) Add(ops ...BatchOp) *BatchRequest {
+	b.ops = append(b.ops, ops...)
+	return b
+}
+
+// Do sends the batch and returns one result per operation, in the order they were added.
+//
+// Failures of individual operations are reported in the BatchResult; the returned error
+// is reserved for failures that affect the whole batch.
+func (b *BatchRequest) Do() (*BatchResult, error) {
+	if b == nil || b.client == nil {
+		return nil, fmt.Errorf("sdk client is nil")
+	}
+	for i, op := range b.ops {
+		if strings.TrimSpace(op.Path) == "" {
+			return nil, fmt.Errorf("batch op %d: path is required", i)
+		}
+	}
+	opts := b.opts
+	if len(b.ops) > maxBatchOps {
+		opts = withoutIdempotencyKey(opts)
+	}
+	result := &BatchResult{Items: make([]BatchItemResult, 0, len(b.ops))}
+	for start := 0; start < len(b.ops); start += maxBatchOps {
+		end := start + maxBatchOps
+		if end > len(b.ops) {
+			end = len(b.ops)
+		}
+		chunk, err := b.client.doBatchOps(b.ctx, b.ops[start:end], opts...)
+		if err != nil {
+			if !isBatchEndpointUnsupported(err) {
+				return nil, err
+			}
+			// Emulate the remaining operations with individual requests
+			rest := b.client.runBatchOps(b.ctx, b.ops[start:], opts...)
+			for _, item := range rest.Items {
+				item.Index += start
+				result.Items = append(result.Items, item)
+			}
+			return result, nil
+		}
+		for _, item := range chunk.Items {
+			item.Index += start
+			result.Items = append(result.Items, item)
+		}
+	}
+	return result, nil
+}
+
+// batchOpPayload is a single operation sent to the batch endpoint.
+type batchOpPayload struct {
+	Path string      `json:"path"`
+	Body interface{} `json:"body"`
+}
+
+// batchOpResult is the per-operation result returned by the batch endpoint.
+type batchOpResult struct {
+	Index     int             `json:"index"`
+	Code      string          `json:"code"`
+	Msg       string          `json:"msg"`
+	RequestID string          `json:"request_id"`
+	Data      json.RawMessage `json:"data"`
+	Details   json.RawMessage `json:"details"`
+}
+
+// doBatchOps sends ops to the batch endpoint in a single request.
+func (c *RawClient) doBatchOps(ctx context.Context, ops []BatchOp, opts ...CallOption) (*BatchResult, error) {
+	payload := make([]batchOpPayload, len(ops))
+	for i, op := range ops {
+		payload[i] = batchOpPayload{Path: op.Path, Body: op.Request}
+	}
+	// The batch endpoint is not a catalog path: drop the cached entries that each
+	// operation may change
+	defer func() {
+		for _, op := range ops {
+			if kind := mutatedKind(http.MethodPost, op.Path); kind != "" {
+				c.cache.invalidate(kind)
+			}
+		}
+	}()
+	var resp struct {
+		Items []batchOpResult `json:"items"`
+	}
+	if err := c.postJSON(ctx, "/batch", map[string]interface{}{"ops": payload}, &resp, opts...); err != nil {
+		return nil, err
+	}
+
+	result := &BatchResult{Items: make([]BatchItemResult, len(ops))}
+	for i := range result.Items {
+		result.Items[i] = BatchItemResult{Index: i, ID: ops[i].ID, Err: fmt.Errorf("no result returned for item")}
+	}
+	for _, r := range resp.Items {
+		if r.Index < 0 || r.Index >= len(ops) {
+			continue
+		}
+		item := BatchItemResult{Index: r.Index, ID: ops[r.Index].ID}
+		switch {
+		case r.Code != "" && !isOKCode(r.Code):
+			item.Err = &APIError{
+				Code:        r.Code,
+				Message:     r.Msg,
+				RequestID:   r.RequestID,
+				HTTPStatus:  http.StatusOK,
+				Details:     r.Details,
+				FieldErrors: parseFieldErrors(r.Details),
+			}
+		case ops[r.Index].Response != nil && len(r.Data) > 0 && string(r.Data) != "null":
+			if err := json.Unmarshal(r.Data, ops[r.Index].Response); err != nil {
+				item.Err = fmt.Errorf("decode data field: %w", err)
+			}
+		}
+		result.Items[r.Index] = item
+	}
+	return result, nil
+}
+
+// runBatchOps sends ops as individual requests with bounded concurrency.
+func (c *RawClient) runBatchOps(ctx context.Context, ops []BatchOp, opts ...CallOption) *BatchResult {
+	opts = withoutIdempotencyKey(opts)
+	callOpts := newCallOptions(opts...)
+	return runBatch(ctx, len(ops), callOpts.concurrency, func(ctx context.Context, i int) (string, error) {
+		return ops[i].ID, c.postJSON(ctx, ops[i].Path, ops[i].Request, ops[i].Response, opts...)
+	})
+}
+
+func isOKCode(code string) bool {
+	return strings.EqualFold(code, "OK")
+}
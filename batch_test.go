@@ -0,0 +1,132 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBatch_BoundedConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight int32
+	result := runBatch(context.Background(), 20, 3, func(ctx context.Context, i int) (string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&inFlight, -1)
+		if i%5 == 0 {
+			return "", errors.New("boom")
+		}
+		return "ok", nil
+	})
+
+	require.LessOrEqual(t, maxInFlight, int32(3))
+	require.Len(t, result.Items, 20)
+	require.Len(t, result.Failed(), 4)
+	require.Len(t, result.Succeeded(), 16)
+	for i, item := range result.Items {
+		require.Equal(t, i, item.Index)
+	}
+	require.ErrorContains(t, result.Err(), "item 5")
+}
+
+func TestRunBatch_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := runBatch(ctx, 3, 1, func(ctx context.Context, i int) (string, error) {
+		return "ok", nil
+	})
+	require.Len(t, result.Items, 3)
+	require.ErrorIs(t, result.Err(), context.Canceled)
+}
+
+func TestBatchResponse_ToBatchResult(t *testing.T) {
+	t.Parallel()
+
+	resp := &batchResponse{Items: []batchItemStatus{
+		{Index: 0, ID: "a", Code: "OK"},
+		{Index: 2, ID: "c", Code: "ErrNotFound", Msg: "file not exist"},
+	}}
+	result := resp.toBatchResult(3)
+	require.NoError(t, result.Items[0].Err)
+	require.ErrorContains(t, result.Items[1].Err, "no result returned")
+	require.True(t, IsNotFound(result.Items[2].Err))
+	require.Nil(t, (&BatchResult{}).Err())
+}
+
+func TestBatchRequest_Packed(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		require.Equal(t, "/batch", r.URL.Path)
+		var body struct {
+			Ops []struct {
+				Path string          `json:"path"`
+				Body json.RawMessage `json:"body"`
+			} `json:"ops"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Ops, 2)
+		require.Equal(t, "/catalog/create", body.Ops[0].Path)
+		require.JSONEq(t, `{"name":"sales","description":""}`, string(body.Ops[0].Body))
+		return envelopeResponse(`{"items":[
+			{"index":0,"code":"OK","data":{"id":7}},
+			{"index":1,"code":"ErrNotFound","msg":"catalog 1 not exist"}]}`), nil
+	})
+
+	var catalog CatalogCreateResponse
+	result, err := client.Batch(context.Background()).
+		Add(NewBatchOp("/catalog/create", &CatalogCreateRequest{CatalogName: "sales"}, &catalog)).
+		Add(BatchOp{Path: "/catalog/database/create", Request: &DatabaseCreateRequest{CatalogID: 1, DatabaseName: "orders"}, ID: "orders"}).
+		Do()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	require.EqualValues(t, 7, catalog.CatalogID)
+	require.NoError(t, result.Items[0].Err)
+	require.Equal(t, "orders", result.Items[1].ID)
+	require.True(t, IsNotFound(result.Items[1].Err))
+}
+
+func TestBatchRequest_EmulatedWhenUnsupported(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/batch":
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("404 page not found"))}, nil
+		case "/catalog/create":
+			return envelopeResponse(`{"id":9}`), nil
+		}
+		return errorEnvelopeResponse("ErrInternal", "boom"), nil
+	})
+
+	var catalog CatalogCreateResponse
+	result, err := client.Batch(context.Background(), WithConcurrency(2)).
+		Add(NewBatchOp("/catalog/create", &CatalogCreateRequest{CatalogName: "sales"}, &catalog),
+			NewBatchOp("/catalog/delete", &CatalogDeleteRequest{CatalogID: 1}, nil)).
+		Do()
+	require.NoError(t, err)
+	require.Len(t, result.Items, 2)
+	require.EqualValues(t, 9, catalog.CatalogID)
+	require.NoError(t, result.Items[0].Err)
+	require.Error(t, result.Items[1].Err)
+
+	_, err = client.Batch(context.Background()).Add(BatchOp{}).Do()
+	require.ErrorContains(t, err, "path is required")
+}
@@ -0,0 +1,257 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithCache caches the responses of GetCatalog, GetDatabase, GetVolume and GetTable
+// for ttl, and expires the names resolved by SDKClient.Resolve and ResolveTable after
+// the same time. A zero or negative ttl, the default, caches no responses and keeps
+// resolved names until they are invalidated.
+//
+// A create, update or delete call made with the client drops the cached entries it
+// may change: those of the objects of its kind, of the objects they contain and of
+// their catalog and database, whose counts change. Changes made elsewhere are seen
+// once the entries expire. Call InvalidateCache to drop every entry, or pass
+// WithNoCache to read a call from the server.
+//
+// Entries are kept per workspace (see WithWorkspace). Clients derived with
+// WithSpecialUser start with an empty cache of their own.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithCache(30*time.Second))
+func WithCache(ttl time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.cacheTTL = ttl
+	}
+}
+
+// WithNoCache makes the call read from the server rather than from the cache set with
+// WithCache, and caches the fresh response.
+//
+// Example:
+//
+//	info, err := client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: 1}, sdk.WithNoCache())
+func WithNoCache() CallOption {
+	return func(co *callOptions) {
+		co.noCache = true
+	}
+}
+
+// InvalidateCache drops every response and resolved name cached by the client.
+func (c *RawClient) InvalidateCache() {
+	if c == nil {
+		return
+	}
+	c.cache.clear()
+}
+
+// cachedInfoKinds maps the endpoints whose responses are cached to the kind of
+// object they describe.
+var cachedInfoKinds = map[string]string{
+	"/catalog/info":          "catalog",
+	"/catalog/database/info": "database",
+	"/catalog/volume/info":   "volume",
+	"/catalog/table/info":    "table",
+}
+
+// cacheInvalidations lists, by kind of object, the cached kinds that a change of an
+// object of the kind may affect. Files are only cached as resolved names.
+var cacheInvalidations = map[string][]string{
+	"catalog":  {"catalog", "database", "volume", "table", "file"},
+	"database": {"catalog", "database", "volume", "table", "file"},
+	"volume":   {"catalog", "database", "volume", "file"},
+	"table":    {"catalog", "database", "table"},
+	"file":     {"volume", "file"},
+	"folder":   {"volume", "file"},
+}
+
+// readOnlyOps are the last path segments of the endpoints that change nothing.
+var readOnlyOps = map[string]bool{
+	"info": true, "list": true, "children": true, "ref_list": true, "stats": true,
+	"search": true, "tree": true, "full_path": true, "exist": true, "multi_info": true,
+	"overview": true, "data": true, "query": true, "usage": true, "download": true,
+	"preview": true, "preview_link": true, "preview_stream": true, "upload_link": true,
+	"rotation_status": true,
+}
+
+// isReadOnlyEndpoint reports whether a call to the endpoint at path only reads,
+// like /catalog/info or /catalog/file/list, even though it is sent with POST.
+func isReadOnlyEndpoint(path string) bool {
+	path, _, _ = strings.Cut(path, "?")
+	return readOnlyOps[path[strings.LastIndex(path, "/")+1:]]
+}
+
+// mutatedKind returns the kind of the objects changed by a call to the endpoint at
+// path, or "" if the call changes no cached kind, e.g. /catalog/database/update
+// changes databases and /catalog/update catalogs.
+func mutatedKind(method, path string) string {
+	if method == http.MethodGet {
+		return ""
+	}
+	path, _, _ = strings.Cut(path, "?")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) < 2 || segments[0] != "catalog" || readOnlyOps[segments[len(segments)-1]] {
+		return ""
+	}
+	if len(segments) == 2 {
+		return "catalog"
+	}
+	if _, ok := cacheInvalidations[segments[1]]; ok {
+		return segments[1]
+	}
+	return ""
+}
+
+// cacheKey identifies a cached response by the kind of object and the request, or a
+// resolved name by the kind of object, the ID of its parent and its name.
+type cacheKey struct {
+	kind      string
+	workspace string
+	request   string // Request body of a cached response
+	parent    string
+	name      string
+}
+
+type cacheEntry struct {
+	value   interface{} // JSON of a response, or a nameEntry
+	expires time.Time   // Zero for entries that do not expire
+}
+
+// clientCache holds the cached responses and resolved names of a client.
+type clientCache struct {
+	ttl time.Duration    // Set by WithCache; zero caches names only, without expiry
+	now func() time.Time // Overridden in tests
+
+	mu      sync.Mutex
+	gen     uint64 // Incremented by every invalidation
+	entries map[cacheKey]cacheEntry
+}
+
+func newClientCache(ttl time.Duration) *clientCache {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return &clientCache{ttl: ttl, now: time.Now, entries: make(map[cacheKey]cacheEntry)}
+}
+
+// clone returns an empty cache with the configuration of c.
+func (c *clientCache) clone() *clientCache {
+	if c == nil {
+		return newClientCache(0)
+	}
+	return newClientCache(c.ttl)
+}
+
+// cachesResponses reports whether responses are cached, rather than names only.
+func (c *clientCache) cachesResponses() bool {
+	return c != nil && c.ttl > 0
+}
+
+// generation returns the number of invalidations so far, to be passed to put.
+func (c *clientCache) generation() uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+func (c *clientCache) get(key cacheKey) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// put caches values read while the cache was at generation gen. They are dropped if
+// an invalidation happened since, as they may predate the change.
+func (c *clientCache) put(gen uint64, values map[cacheKey]interface{}) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+	for key, value := range values {
+		c.entries[key] = cacheEntry{value: value, expires: expires}
+	}
+}
+
+// invalidate drops the entries of the kinds affected by a change of objects of kind.
+func (c *clientCache) invalidate(kind string) {
+	kinds := cacheInvalidations[kind]
+	if c == nil || len(kinds) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	for key := range c.entries {
+		for _, k := range kinds {
+			if key.kind == k {
+				delete(c.entries, key)
+				break
+			}
+		}
+	}
+}
+
+func (c *clientCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	c.entries = make(map[cacheKey]cacheEntry)
+}
+
+// cachedResponse decodes into respBody the cached response of a call to the endpoint
+// at path with the given request body. It returns the key to cache the response of
+// the call under, or ok=false if the endpoint is not cached.
+func (c *RawClient) cachedResponse(ctx context.Context, method, path string, payload []byte, respBody interface{}, callOpts callOptions) (key cacheKey, ok, hit bool) {
+	kind, cached := cachedInfoKinds[path]
+	if !cached || method != http.MethodPost || !c.cache.cachesResponses() {
+		return cacheKey{}, false, false
+	}
+	key = cacheKey{kind: kind, workspace: c.workspaceFor(ctx), request: string(payload)}
+	if callOpts.noCache {
+		return key, true, false
+	}
+	data, found := c.cache.get(key)
+	if !found {
+		return key, true, false
+	}
+	return key, true, json.Unmarshal(data.([]byte), respBody) == nil
+}
+
+// cacheResponse caches the response of a call read at cache generation gen.
+func (c *RawClient) cacheResponse(gen uint64, key cacheKey, respBody interface{}) {
+	if data, err := json.Marshal(respBody); err == nil {
+		c.cache.put(gen, map[cacheKey]interface{}{key: data})
+	}
+}
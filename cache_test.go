@@ -0,0 +1,179 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCache(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	calls := map[string]int{}
+	name := "sales"
+	client, err := NewRawClient("https://moi.test", "key",
+		WithCache(time.Minute),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[r.URL.Path]++
+			if r.URL.Path == "/catalog/info" {
+				return envelopeResponse(`{"id":1,"name":"` + name + `"}`), nil
+			}
+			return envelopeResponse(`{}`), nil
+		})}))
+	require.NoError(t, err)
+	now := time.Now()
+	client.cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	getName := func(ctx context.Context, opts ...CallOption) string {
+		info, err := client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1}, opts...)
+		require.NoError(t, err)
+		return info.CatalogName
+	}
+	require.Equal(t, "sales", getName(ctx))
+	name = "sales-eu"
+	require.Equal(t, "sales", getName(ctx))
+	require.Equal(t, 1, calls["/catalog/info"])
+
+	// Responses are cached per request and per workspace
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 2})
+	require.NoError(t, err)
+	require.Equal(t, "sales-eu", getName(ContextWithWorkspace(ctx, "ws-other")))
+	require.Equal(t, 3, calls["/catalog/info"])
+
+	// WithNoCache reads from the server and refreshes the cache
+	require.Equal(t, "sales-eu", getName(ctx, WithNoCache()))
+	require.Equal(t, "sales-eu", getName(ctx))
+	require.Equal(t, 4, calls["/catalog/info"])
+
+	// Entries expire
+	name = "sales-us"
+	now = now.Add(time.Minute)
+	require.Equal(t, "sales-us", getName(ctx))
+	require.Equal(t, 5, calls["/catalog/info"])
+
+	// Mutating calls drop the entries they affect
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1})
+	require.NoError(t, err)
+	_, err = client.TruncateTable(ctx, &TableTruncateRequest{TableID: 1})
+	require.NoError(t, err)
+	name = "sales-apac"
+	require.Equal(t, "sales-apac", getName(ctx))
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls["/catalog/table/info"])
+	_, err = client.CreateFile(ctx, &FileCreateRequest{Name: "a.txt", VolumeID: "v1"})
+	require.NoError(t, err)
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls["/catalog/table/info"])
+
+	client.InvalidateCache()
+	_, err = client.GetTable(ctx, &TableInfoRequest{TableID: 1})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls["/catalog/table/info"])
+}
+
+func TestCache_InvalidatedByBatch(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	calls := map[string]int{}
+	client, err := NewRawClient("https://moi.test", "key",
+		WithCache(time.Minute),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[r.URL.Path]++
+			if r.URL.Path == "/batch" {
+				return envelopeResponse(`{"items":[{"index":0,"code":"OK"}]}`), nil
+			}
+			return envelopeResponse(`{"id":1,"name":"sales"}`), nil
+		})}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	_, err = client.Batch(ctx).Add(NewBatchOp("/catalog/update", &CatalogUpdateRequest{CatalogID: 1, CatalogName: "sales-eu"}, nil)).Do()
+	require.NoError(t, err)
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls["/catalog/info"])
+	require.Equal(t, 1, calls["/batch"])
+}
+
+func TestCache_Disabled(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return envelopeResponse(`{"id":1,"name":"sales"}`), nil
+	})
+	for i := 0; i < 2; i++ {
+		_, err := client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, calls)
+}
+
+func TestCache_StalePut(t *testing.T) {
+	t.Parallel()
+	cache := newClientCache(time.Minute)
+	key := cacheKey{kind: "catalog", request: `{"id":1}`}
+	gen := cache.generation()
+	cache.invalidate("table")
+	cache.put(gen, map[cacheKey]interface{}{key: []byte(`{}`)})
+	_, ok := cache.get(key)
+	require.False(t, ok)
+}
+
+func TestMutatedKind(t *testing.T) {
+	t.Parallel()
+	for path, want := range map[string]string{
+		"/catalog/create":                   "catalog",
+		"/catalog/info":                     "",
+		"/catalog/database/update":          "database",
+		"/catalog/database/children":        "",
+		"/catalog/volume/snapshot/restore":  "volume",
+		"/catalog/table/truncate":           "table",
+		"/catalog/folder/delete":            "folder",
+		"/catalog/file/list":                "",
+		"/catalog/nl2sql_knowledge/create":  "",
+		"/catalog/retention/update":         "",
+		"/byoa/api/v1/data_asking/analyze":  "",
+		"/catalog/file/delete?force=true":   "file",
+		"/catalog/volume/encryption/rotate": "volume",
+	} {
+		require.Equal(t, want, mutatedKind(http.MethodPost, path), path)
+	}
+	require.Empty(t, mutatedKind(http.MethodGet, "/catalog/create"))
+}
+
+func TestResolve_InvalidatedByMutations(t *testing.T) {
+	t.Parallel()
+	client, calls := newResolveFake(t)
+	ctx := context.Background()
+
+	_, err := client.Resolve(ctx, "sales/orders/raw/README.md")
+	require.NoError(t, err)
+	_, err = client.Resolve(ctx, "sales/orders/raw/README.md")
+	require.NoError(t, err)
+	require.Equal(t, 1, calls["/catalog/file/list"])
+
+	_, err = client.raw.DeleteFile(ctx, &FileDeleteRequest{FileID: "f0"})
+	require.NoError(t, err)
+	_, err = client.Resolve(ctx, "sales/orders/raw/README.md")
+	require.NoError(t, err)
+	require.Equal(t, 2, calls["/catalog/file/list"])
+	require.Equal(t, 1, calls["/catalog/list"])
+
+	_, err = client.Resolve(ctx, "sales", WithNoCache())
+	require.NoError(t, err)
+	require.Equal(t, 2, calls["/catalog/list"])
+}
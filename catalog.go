@@ -102,7 +102,8 @@ func (c *RawClient) GetCatalog(ctx context.Context, req *CatalogInfoRequest, opt
 
 // ListCatalogs lists all catalogs.
 //
-// Returns a list of all catalogs in the system.
+// Returns a list of all catalogs in the system. Use ListCatalogsFiltered on large
+// deployments to filter by name and paginate.
 //
 // Example:
 //
@@ -124,6 +125,7 @@ func (c *RawClient) ListCatalogs(ctx context.Context, opts ...CallOption) (*Cata
 // GetCatalogTree retrieves the hierarchical tree structure of catalogs, databases, tables, and volumes.
 //
 // The tree structure shows the complete organizational hierarchy of all resources.
+// Use GetCatalogTreeFiltered to limit the depth or load a single subtree.
 //
 // Example:
 //
@@ -143,6 +145,75 @@ func (c *RawClient) GetCatalogTree(ctx context.Context, opts ...CallOption) (*Ca
 	return &resp, nil
 }
 
+// ListCatalogsFiltered lists the catalogs matching a keyword, one page at a time.
+//
+// Filters of the embedded CommonCondition apply as for the other list calls, and
+// the response reports the total number of matching catalogs.
+//
+// Example:
+//
+//	resp, err := client.ListCatalogsFiltered(ctx, &sdk.CatalogListRequest{
+//		Keyword:         "sales",
+//		CommonCondition: sdk.CommonCondition{Page: 1, PageSize: 20},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("showing %d of %d catalogs\n", len(resp.List), resp.Total)
+func (c *RawClient) ListCatalogsFiltered(ctx context.Context, req *CatalogListRequest, opts ...CallOption) (*CatalogListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp CatalogListResponse
+	if err := c.postJSON(ctx, "/catalog/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetCatalogTreeFiltered retrieves part of the catalog tree: down to a given depth,
+// matching a keyword, or below a single catalog or database.
+//
+// Nodes cut off by the depth limit report their ChildrenCount, so a UI can show them
+// as expandable and load their children on demand with ParentType and ParentID.
+//
+// Example:
+//
+//	// Catalogs and databases only
+//	resp, err := client.GetCatalogTreeFiltered(ctx, &sdk.CatalogTreeRequest{
+//		Depth: sdk.CatalogTreeDepthDatabases,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	// Later, when a database is expanded
+//	children, err := client.GetCatalogTreeFiltered(ctx, &sdk.CatalogTreeRequest{
+//		ParentType: "database",
+//		ParentID:   resp.Tree[0].NodeList[0].ID,
+//		Depth:      1,
+//	})
+func (c *RawClient) GetCatalogTreeFiltered(ctx context.Context, req *CatalogTreeRequest, opts ...CallOption) (*CatalogTreeResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Depth < 0 {
+		return nil, fmt.Errorf("depth cannot be negative")
+	}
+	if (req.ParentType == "") != (req.ParentID == "") {
+		return nil, fmt.Errorf("parent_type and parent_id must be set together")
+	}
+	switch req.ParentType {
+	case "", "catalog", "database":
+	default:
+		return nil, fmt.Errorf("unsupported parent_type %q", req.ParentType)
+	}
+	var resp CatalogTreeResponse
+	if err := c.postJSON(ctx, "/catalog/tree", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetCatalogRefList retrieves the list of references to the specified catalog.
 //
 // Returns a list of volume references associated with the catalog.
@@ -163,6 +234,29 @@ func (c *RawClient) GetCatalogRefList(ctx context.Context, req *CatalogRefListRe
 	return &resp, nil
 }
 
+// GetCatalogStats retrieves the storage usage of a catalog.
+//
+// The service maintains the figures, so this is a single call regardless of the
+// size of the catalog. The response breaks the totals down by database.
+//
+// Example:
+//
+//	stats, err := client.GetCatalogStats(ctx, &sdk.CatalogStatsRequest{CatalogID: 123})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%d bytes in %d files and %d rows\n", stats.StorageBytes, stats.FileCount, stats.TableRowCount)
+func (c *RawClient) GetCatalogStats(ctx context.Context, req *CatalogStatsRequest, opts ...CallOption) (*CatalogStatsResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp CatalogStatsResponse
+	if err := c.postJSON(ctx, "/catalog/stats", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // DownloadTableData downloads table data as a CSV file stream.
 //
 // Returns a FileStream that must be closed by the caller. The stream contains
@@ -209,11 +303,12 @@ func (c *RawClient) DownloadTableData(ctx context.Context, req *TableDownloadDat
 	// Create a client with no timeout for downloading large files
 	// The download can still be cancelled via context
 	downloadClient := &http.Client{
-		Timeout: 0, // No timeout - allows downloading large files
+		Timeout:   0,                      // No timeout - allows downloading large files
+		Transport: c.httpClient.Transport, // Reuse the transport from the original client
 	}
 
 	// Execute the request
-	resp, err := downloadClient.Do(httpReq)
+	resp, err := c.send(downloadClient, httpReq, callOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -222,7 +317,7 @@ func (c *RawClient) DownloadTableData(ctx context.Context, req *TableDownloadDat
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		data, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
+		return nil, newHTTPError(resp, data)
 	}
 
 	return &FileStream{
@@ -2,7 +2,9 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -76,6 +78,9 @@ func TestCatalogNilRequestErrors(t *testing.T) {
 		{"Update", func() error { _, err := client.UpdateCatalog(ctx, nil); return err }},
 		{"Get", func() error { _, err := client.GetCatalog(ctx, nil); return err }},
 		{"RefList", func() error { _, err := client.GetCatalogRefList(ctx, nil); return err }},
+		{"Stats", func() error { _, err := client.GetCatalogStats(ctx, nil); return err }},
+		{"ListFiltered", func() error { _, err := client.ListCatalogsFiltered(ctx, nil); return err }},
+		{"TreeFiltered", func() error { _, err := client.GetCatalogTreeFiltered(ctx, nil); return err }},
 	}
 
 	for _, tc := range tests {
@@ -85,6 +90,48 @@ func TestCatalogNilRequestErrors(t *testing.T) {
 	}
 }
 
+func TestGetCatalogStats(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/stats", r.URL.Path)
+		return envelopeResponse(`{"id":1,"database_count":1,"storage_bytes":300,"file_count":4,"table_row_count":20,
+			"last_modified_at":"2026-10-01T00:00:00Z","database_list":[{"id":10,"name":"orders","storage_bytes":300,
+			"table_list":[{"id":100,"name":"items","row_count":20,"size_bytes":100}]}]}`), nil
+	})
+
+	stats, err := client.GetCatalogStats(context.Background(), &CatalogStatsRequest{CatalogID: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(300), stats.StorageBytes)
+	require.Equal(t, int64(4), stats.FileCount)
+	require.Equal(t, "2026-10-01T00:00:00Z", stats.LastModifiedAt)
+	require.Len(t, stats.Databases, 1)
+	require.Equal(t, int64(20), stats.Databases[0].Tables[0].RowCount)
+}
+
+func TestGetCatalogTreeFiltered(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/tree", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.EqualValues(t, 2, body["depth"])
+		require.NotContains(t, body, "parent_id")
+		return envelopeResponse(`{"total":1,"tree":[{"type":"catalog","id":"1","name":"sales","children_count":2,"node_list":[]}]}`), nil
+	})
+
+	resp, err := client.GetCatalogTreeFiltered(context.Background(), &CatalogTreeRequest{Depth: CatalogTreeDepthDatabases})
+	require.NoError(t, err)
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, 2, resp.Tree[0].ChildrenCount)
+
+	_, err = client.GetCatalogTreeFiltered(context.Background(), &CatalogTreeRequest{ParentID: "1"})
+	require.ErrorContains(t, err, "must be set together")
+	_, err = client.GetCatalogTreeFiltered(context.Background(), &CatalogTreeRequest{ParentType: "table", ParentID: "1"})
+	require.ErrorContains(t, err, "unsupported parent_type")
+}
+
 func TestCatalogNameExists(t *testing.T) {
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -25,6 +25,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -38,8 +39,13 @@ const (
 	headerUserAgent   = "User-Agent"
 	headerContentType = "Content-Type"
 	headerAccept      = "Accept"
+	headerIfMatch     = "If-Match"
+	headerLastEventID = "Last-Event-ID"
 
-	mimeJSON = "application/json"
+	headerIdempotencyKey = "Idempotency-Key"
+
+	mimeJSON   = "application/json"
+	mimeNDJSON = "application/x-ndjson"
 )
 
 // RawClient provides typed access to the catalog service HTTP APIs.
@@ -50,6 +56,18 @@ type RawClient struct {
 	userAgent       string
 	defaultHeaders  http.Header
 	llmProxyBaseURL string // Optional: direct LLM Proxy base URL for direct connection
+	stats           *clientStats
+	logger          Logger
+	metrics         MetricsRecorder
+	errorTranslator ErrorTranslator
+	defaultPageSize int // Page size applied to list requests that leave it zero
+	maxPages        int // Page limit for auto-paginating helpers; 0 means unlimited
+	requestIDFunc   func(context.Context) string
+	keySource       *apiKeySource // Set by WithTokenRefresher; holds the current API key
+	auth            AuthProvider  // Set by WithAuthProvider; overrides apiKey and keySource
+	autoIdempotency bool          // Set by WithAutoIdempotencyKeys
+	workspace       string        // Set by WithWorkspace; ContextWithWorkspace overrides it
+	cache           *clientCache  // Responses cached with WithCache, and names resolved by SDKClient
 }
 
 // NewRawClient creates a new client using the provided baseURL and apiKey.
@@ -59,11 +77,6 @@ func NewRawClient(baseURL, apiKey string, opts ...ClientOption) (*RawClient, err
 	if trimmedBase == "" {
 		return nil, ErrBaseURLRequired
 	}
-	trimmedKey := strings.TrimSpace(apiKey)
-	if trimmedKey == "" {
-		return nil, ErrAPIKeyRequired
-	}
-
 	parsed, err := url.Parse(trimmedBase)
 	if err != nil {
 		return nil, fmt.Errorf("invalid baseURL: %w", err)
@@ -84,6 +97,10 @@ func NewRawClient(baseURL, apiKey string, opts ...ClientOption) (*RawClient, err
 			opt(&cfg)
 		}
 	}
+	trimmedKey := strings.TrimSpace(apiKey)
+	if trimmedKey == "" && cfg.authProvider == nil {
+		return nil, ErrAPIKeyRequired
+	}
 	httpClient := cfg.httpClient
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
@@ -91,14 +108,58 @@ func NewRawClient(baseURL, apiKey string, opts ...ClientOption) (*RawClient, err
 	if cfg.defaultHeaders == nil {
 		cfg.defaultHeaders = make(http.Header)
 	}
+	if cfg.customTransport() {
+		tuned, err := cfg.tuneTransport(httpClient.Transport)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = withTransport(httpClient, tuned)
+	}
+	stats := newClientStats(normalized, cfg.llmProxyBaseURL)
+	hosts := serviceHosts(normalized, cfg.llmProxyBaseURL)
+	transport := httpClient.Transport
+	timeouts := cfg.timeoutPolicy
+	timeouts.Total = 0 // Enforced by the http.Client, so that streams are exempt
+	transport = &timeoutTransport{base: transport, defaults: timeouts}
+	if cfg.requestSigner != nil {
+		transport = &signingTransport{base: transport, hosts: hosts, signer: cfg.requestSigner}
+	}
+	if cfg.logger != nil {
+		transport = &loggingTransport{base: transport, logger: cfg.logger, bodyBytes: cfg.logBodies}
+	}
+	if cfg.metrics != nil {
+		transport = &metricsTransport{base: transport, stats: stats, recorder: cfg.metrics}
+	}
+	transport = &statsTransport{base: transport, stats: stats}
+	httpClient = withTransport(httpClient, newRateLimitTransport(transport, hosts, cfg.rateLimit, cfg.endpointRateLimits))
+	var keySource *apiKeySource
+	if cfg.tokenRefresher != nil && cfg.authProvider == nil {
+		keySource = &apiKeySource{key: trimmedKey, refresh: cfg.tokenRefresher}
+	}
+	userAgent := cfg.userAgent
+	if cfg.appInfo != "" {
+		userAgent = composeUserAgent(cfg.appInfo, userAgent)
+	}
 
 	return &RawClient{
 		baseURL:         normalized,
 		apiKey:          trimmedKey,
 		httpClient:      httpClient,
-		userAgent:       cfg.userAgent,
+		userAgent:       userAgent,
 		defaultHeaders:  cloneHeader(cfg.defaultHeaders),
 		llmProxyBaseURL: cfg.llmProxyBaseURL,
+		stats:           stats,
+		logger:          cfg.logger,
+		metrics:         cfg.metrics,
+		errorTranslator: cfg.errorTranslator,
+		defaultPageSize: cfg.defaultPageSize,
+		maxPages:        cfg.maxPages,
+		requestIDFunc:   cfg.requestIDFunc,
+		keySource:       keySource,
+		auth:            cfg.authProvider,
+		autoIdempotency: cfg.autoIdempotency,
+		workspace:       cfg.workspace,
+		cache:           newClientCache(cfg.cacheTTL),
 	}, nil
 }
 
@@ -121,6 +182,16 @@ func (c *RawClient) WithSpecialUser(apiKey string) *RawClient {
 		userAgent:       c.userAgent,
 		defaultHeaders:  cloneHeader(c.defaultHeaders),
 		llmProxyBaseURL: c.llmProxyBaseURL,
+		stats:           c.stats, // Share the counters of the original client
+		logger:          c.logger,
+		metrics:         c.metrics,
+		errorTranslator: c.errorTranslator,
+		defaultPageSize: c.defaultPageSize,
+		maxPages:        c.maxPages,
+		requestIDFunc:   c.requestIDFunc,
+		autoIdempotency: c.autoIdempotency,
+		workspace:       c.workspace,
+		cache:           c.cache.clone(),
 	}
 }
 
@@ -139,16 +210,28 @@ func (c *RawClient) doJSON(ctx context.Context, method, path string, body interf
 		return fmt.Errorf("sdk client is nil")
 	}
 	callOpts := newCallOptions(opts...)
+	c.applyPageDefaults(body)
 
 	var reader io.Reader
+	var payload []byte
 	if body != nil {
-		payload, err := json.Marshal(body)
+		var err error
+		payload, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("marshal request body: %w", err)
 		}
 		reader = bytes.NewReader(payload)
 	}
 
+	key, cached, hit := c.cachedResponse(ctx, method, path, payload, respBody, callOpts)
+	if hit {
+		return nil
+	}
+	gen := c.cache.generation()
+	if kind := mutatedKind(method, path); kind != "" {
+		defer c.cache.invalidate(kind)
+	}
+
 	resp, err := c.doRaw(ctx, method, path, reader, callOpts, func(req *http.Request) {
 		req.Header.Set(headerAccept, mimeJSON)
 		if body != nil {
@@ -160,6 +243,31 @@ func (c *RawClient) doJSON(ctx context.Context, method, path string, body interf
 	}
 	defer resp.Body.Close()
 
+	if err := c.decodeResponse(resp, respBody); err != nil {
+		return err
+	}
+	if cached {
+		c.cacheResponse(gen, key, respBody)
+	}
+	return nil
+}
+
+// decodeResponse decodes the response envelope like decodeEnvelope and applies the
+// client's error handling, statistics, metrics and message localization, to API errors.
+func (c *RawClient) decodeResponse(resp *http.Response, respBody interface{}) error {
+	err := decodeEnvelope(resp, respBody)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		c.stats.recordAPIError(resp.Request, apiErr)
+		c.recordAPIErrorMetric(resp.Request, apiErr)
+		c.localizeAPIError(resp.Request, apiErr)
+	}
+	return err
+}
+
+// decodeEnvelope decodes the standard response envelope from resp and unmarshals
+// its data field into respBody. It does not close the response body.
+func decodeEnvelope(resp *http.Response, respBody interface{}) error {
 	var envelope apiEnvelope
 	decoder := json.NewDecoder(resp.Body)
 	if err := decoder.Decode(&envelope); err != nil {
@@ -173,11 +281,17 @@ func (c *RawClient) doJSON(ctx context.Context, method, path string, body interf
 	// Check for error code (case-insensitive comparison)
 	// Some services return "ok" (lowercase) while others return "OK" (uppercase)
 	if envelope.Code != "" && strings.ToUpper(envelope.Code) != "OK" {
+		requestID := envelope.RequestID
+		if requestID == "" {
+			requestID = responseRequestID(resp)
+		}
 		return &APIError{
-			Code:       envelope.Code,
-			Message:    envelope.Msg,
-			RequestID:  envelope.RequestID,
-			HTTPStatus: resp.StatusCode,
+			Code:        envelope.Code,
+			Message:     envelope.Msg,
+			RequestID:   requestID,
+			HTTPStatus:  resp.StatusCode,
+			Details:     envelope.Details,
+			FieldErrors: parseFieldErrors(envelope.Details),
 		}
 	}
 
@@ -198,26 +312,46 @@ func (c *RawClient) doRaw(ctx context.Context, method, path string, body io.Read
 		prepare(req)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(c.httpClient, req, opts)
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		data, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
+		return nil, newHTTPError(resp, data)
 	}
 	return resp, nil
 }
 
+// send sends req with client, sending it once more if its credentials had to be
+// refreshed, and captures the response metadata requested in opts.
+func (c *RawClient) send(client *http.Client, req *http.Request, opts callOptions) (*http.Response, error) {
+	resp, err := client.Do(req)
+	if err == nil {
+		resp, err = c.retryUnauthorized(client, req, resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+	opts.captureResponse(resp)
+	return resp, nil
+}
+
 func (c *RawClient) buildRequest(ctx context.Context, method, path string, body io.Reader, opts callOptions) (*http.Request, error) {
+	return c.buildRequestTo(ctx, method, c.baseURL, path, body, opts)
+}
+
+// buildRequestTo builds a request to path on the service at baseURL, with the
+// client's credentials and headers and those of opts.
+func (c *RawClient) buildRequestTo(ctx context.Context, method, baseURL, path string, body io.Reader, opts callOptions) (*http.Request, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	if path == "" {
 		return nil, fmt.Errorf("request path cannot be empty")
 	}
-	fullURL := c.baseURL + ensureLeadingSlash(path)
+	fullURL := baseURL + ensureLeadingSlash(path)
 	if len(opts.query) > 0 {
 		delimiter := "?"
 		if strings.Contains(fullURL, "?") {
@@ -226,23 +360,41 @@ func (c *RawClient) buildRequest(ctx context.Context, method, path string, body
 		fullURL = fullURL + delimiter + opts.query.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
+	req, err := http.NewRequestWithContext(opts.requestContext(ctx), method, fullURL, body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set(headerAPIKey, c.apiKey)
+	if err := c.setAuth(req); err != nil {
+		return nil, err
+	}
 	if c.userAgent != "" {
 		req.Header.Set(headerUserAgent, c.userAgent)
 	}
 	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if opts.requestID != "" {
-		req.Header.Set(headerRequestID, opts.requestID)
+	if id := c.requestIDFor(ctx, opts); id != "" {
+		req.Header.Set(headerRequestID, id)
+	}
+	if ws := c.workspaceFor(ctx); ws != "" {
+		req.Header.Set(headerWorkspaceID, ws)
 	}
 	mergeHeaders(req.Header, opts.headers, true)
+	c.setIdempotencyKey(req, path)
 	return req, nil
 }
 
+// serviceHosts returns the hosts of baseURLs, the ones the client's own requests go to
+// as opposed to presigned storage links.
+func serviceHosts(baseURLs ...string) map[string]bool {
+	hosts := make(map[string]bool, len(baseURLs))
+	for _, raw := range baseURLs {
+		if u, err := url.Parse(raw); err == nil && u.Host != "" {
+			hosts[u.Host] = true
+		}
+	}
+	return hosts
+}
+
 func ensureLeadingSlash(p string) string {
 	if strings.HasPrefix(p, "/") {
 		return p
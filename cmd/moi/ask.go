@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func newAskCmd(a *app) *cobra.Command {
+	var sessionID string
+	var rawEvents bool
+	cmd := &cobra.Command{
+		Use:   "ask <question>...",
+		Short: "Ask a question about the data and stream the answer",
+		Long: `Ask a question about the data. The answer is streamed to stdout as it is
+generated; the analysis steps and generated SQL are reported on stderr.`,
+		Example: `  moi ask "Why did the revenue drop in 2024?"
+  moi ask --raw which customers churned last month`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			req := &sdk.DataAnalysisRequest{Question: strings.Join(args, " ")}
+			if sessionID != "" {
+				req.SessionID = &sessionID
+			}
+			stream, err := client.AnalyzeDataStream(cmd.Context(), req, sdk.WithStreamAutoReconnect(3, time.Second))
+			if err != nil {
+				return err
+			}
+			defer stream.Close()
+
+			out, progress := cmd.OutOrStdout(), cmd.ErrOrStderr()
+			answered := false
+			for event, err := range stream.All() {
+				if err != nil {
+					return err
+				}
+				if rawEvents {
+					fmt.Fprintln(out, string(event.RawData))
+					continue
+				}
+				typed, err := event.Typed()
+				if err != nil {
+					return err
+				}
+				switch ev := typed.(type) {
+				case *sdk.StepStartEvent:
+					fmt.Fprintf(progress, "> %s\n", firstNonEmpty(ev.Question, ev.StepName))
+				case *sdk.SQLStepEvent:
+					if ev.SQL != "" {
+						fmt.Fprintf(progress, "SQL: %s\n", ev.SQL)
+					}
+				case *sdk.AnswerChunkEvent:
+					if ev.Content != "" {
+						fmt.Fprint(out, ev.Content)
+						answered = true
+					}
+				case *sdk.CompleteEvent:
+					// The final answer is only printed when it was not streamed in chunks
+					if !answered {
+						fmt.Fprint(out, firstNonEmpty(ev.Answer, ev.Summary))
+					}
+					fmt.Fprintln(out)
+				case *sdk.ErrorEvent:
+					return ev
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&sessionID, "session", "", "ID of the session to continue")
+	cmd.Flags().BoolVar(&rawEvents, "raw", false, "print the raw events as JSON lines")
+	return cmd
+}
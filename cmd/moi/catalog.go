@@ -0,0 +1,130 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func newCatalogCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "catalog",
+		Aliases: []string{"catalogs"},
+		Short:   "Manage catalogs",
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List the catalogs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.ListCatalogs(cmd.Context())
+			if err != nil {
+				return err
+			}
+			var rows [][]string
+			for _, c := range resp.List {
+				rows = append(rows, []string{itoa(c.CatalogID), c.CatalogName, itoa(c.DatabaseCount), itoa(c.TableCount), itoa(c.VolumeCount), c.Comment})
+			}
+			return a.printTable(cmd, resp, []string{"ID", "NAME", "DATABASES", "TABLES", "VOLUMES", "DESCRIPTION"}, rows)
+		},
+	}
+
+	get := &cobra.Command{
+		Use:   "get <catalog-id>",
+		Short: "Show a catalog",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.CatalogID]("catalog", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetCatalog(cmd.Context(), &sdk.CatalogInfoRequest{CatalogID: id})
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), resp)
+		},
+	}
+
+	var description string
+	create := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a catalog and print its ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.CreateCatalog(cmd.Context(), &sdk.CatalogCreateRequest{CatalogName: args[0], Comment: description})
+			if err != nil {
+				return err
+			}
+			return a.printID(cmd, resp, resp.CatalogID)
+		},
+	}
+	create.Flags().StringVar(&description, "description", "", "description of the catalog")
+
+	var newName, newDescription string
+	update := &cobra.Command{
+		Use:   "update <catalog-id>",
+		Short: "Rename a catalog or change its description",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.CatalogID]("catalog", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			// The update replaces both the name and the description
+			cur, err := client.GetCatalog(cmd.Context(), &sdk.CatalogInfoRequest{CatalogID: id})
+			if err != nil {
+				return err
+			}
+			req := &sdk.CatalogUpdateRequest{CatalogID: id, CatalogName: cur.CatalogName, Comment: cur.Comment}
+			if cmd.Flags().Changed("name") {
+				req.CatalogName = newName
+			}
+			if cmd.Flags().Changed("description") {
+				req.Comment = newDescription
+			}
+			_, err = client.UpdateCatalog(cmd.Context(), req)
+			return err
+		},
+	}
+	update.Flags().StringVar(&newName, "name", "", "new name of the catalog")
+	update.Flags().StringVar(&newDescription, "description", "", "new description of the catalog")
+
+	del := &cobra.Command{
+		Use:   "delete <catalog-id>",
+		Short: "Delete a catalog",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.CatalogID]("catalog", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteCatalog(cmd.Context(), &sdk.CatalogDeleteRequest{CatalogID: id})
+			return err
+		},
+	}
+
+	cmd.AddCommand(list, get, create, update, del)
+	return cmd
+}
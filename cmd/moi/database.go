@@ -0,0 +1,131 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func newDatabaseCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "database",
+		Aliases: []string{"databases", "db"},
+		Short:   "Manage databases",
+	}
+
+	var listCatalog string
+	list := &cobra.Command{
+		Use:   "list --catalog <catalog-id>",
+		Short: "List the databases of a catalog",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogID, err := parseID[sdk.CatalogID]("catalog", listCatalog)
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.ListDatabases(cmd.Context(), &sdk.DatabaseListRequest{CatalogID: catalogID})
+			if err != nil {
+				return err
+			}
+			var rows [][]string
+			for _, db := range resp.List {
+				rows = append(rows, []string{itoa(db.DatabaseID), db.DatabaseName, itoa(db.TableCount), itoa(db.VolumeCount), db.Comment})
+			}
+			return a.printTable(cmd, resp, []string{"ID", "NAME", "TABLES", "VOLUMES", "DESCRIPTION"}, rows)
+		},
+	}
+	list.Flags().StringVar(&listCatalog, "catalog", "", "ID of the catalog")
+	_ = list.MarkFlagRequired("catalog")
+
+	get := &cobra.Command{
+		Use:   "get <database-id>",
+		Short: "Show a database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.DatabaseID]("database", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetDatabase(cmd.Context(), &sdk.DatabaseInfoRequest{DatabaseID: id})
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), resp)
+		},
+	}
+
+	var createCatalog, description string
+	create := &cobra.Command{
+		Use:   "create <name> --catalog <catalog-id>",
+		Short: "Create a database and print its ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			catalogID, err := parseID[sdk.CatalogID]("catalog", createCatalog)
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.CreateDatabase(cmd.Context(), &sdk.DatabaseCreateRequest{DatabaseName: args[0], CatalogID: catalogID, Comment: description})
+			if err != nil {
+				return err
+			}
+			return a.printID(cmd, resp, resp.DatabaseID)
+		},
+	}
+	create.Flags().StringVar(&createCatalog, "catalog", "", "ID of the catalog")
+	create.Flags().StringVar(&description, "description", "", "description of the database")
+	_ = create.MarkFlagRequired("catalog")
+
+	var newDescription string
+	update := &cobra.Command{
+		Use:   "update <database-id> --description <text>",
+		Short: "Change the description of a database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.DatabaseID]("database", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateDatabase(cmd.Context(), &sdk.DatabaseUpdateRequest{DatabaseID: id, Comment: newDescription})
+			return err
+		},
+	}
+	update.Flags().StringVar(&newDescription, "description", "", "new description of the database")
+	_ = update.MarkFlagRequired("description")
+
+	del := &cobra.Command{
+		Use:   "delete <database-id>",
+		Short: "Delete a database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.DatabaseID]("database", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteDatabase(cmd.Context(), &sdk.DatabaseDeleteRequest{DatabaseID: id})
+			return err
+		},
+	}
+
+	cmd.AddCommand(list, get, create, update, del)
+	return cmd
+}
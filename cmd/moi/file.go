@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// filePageSize is the page size of "file list".
+const filePageSize = 100
+
+func newFileCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "file",
+		Aliases: []string{"files"},
+		Short:   "Manage the files and folders of volumes",
+	}
+
+	var listVolume, listFolder string
+	var recursive bool
+	list := &cobra.Command{
+		Use:   "list --volume <volume-id> [--folder <folder-id> | --recursive]",
+		Short: "List the files and folders of a volume folder",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			header := []string{"ID", "NAME", "TYPE", "SIZE", "UPDATED"}
+			row := func(entry sdk.VolumeChildrenResponse, name string) []string {
+				typ := entry.FileType
+				if entry.IsFolder() {
+					typ = "folder"
+				}
+				return []string{entry.ID, name, typ, itoa(entry.Size), entry.UpdatedAt}
+			}
+			if recursive {
+				client, err := a.sdkClient(cmd)
+				if err != nil {
+					return err
+				}
+				var entries []sdk.VolumeChildrenResponse
+				var rows [][]string
+				err = client.WalkVolume(cmd.Context(), sdk.VolumeID(listVolume), func(entry sdk.VolumeChildrenResponse, path string) error {
+					entries = append(entries, entry)
+					rows = append(rows, row(entry, path))
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+				return a.printTable(cmd, entries, header, rows)
+			}
+
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			var entries []sdk.VolumeChildrenResponse
+			for page := 1; ; page++ {
+				resp, err := client.ListFiles(cmd.Context(), &sdk.FileListRequest{
+					CommonCondition: sdk.CommonCondition{
+						Page:     page,
+						PageSize: filePageSize,
+						Filters: []sdk.CommonFilter{
+							{Name: "volume_id", Values: []string{listVolume}},
+							{Name: "parent_id", Values: []string{listFolder}},
+						},
+					},
+				})
+				if err != nil {
+					return err
+				}
+				entries = append(entries, resp.List...)
+				if len(resp.List) == 0 || len(entries) >= resp.Total {
+					break
+				}
+			}
+			rows := make([][]string, len(entries))
+			for i, entry := range entries {
+				rows[i] = row(entry, entry.Name)
+			}
+			return a.printTable(cmd, entries, header, rows)
+		},
+	}
+	list.Flags().StringVar(&listVolume, "volume", "", "ID of the volume")
+	list.Flags().StringVar(&listFolder, "folder", "", "ID of the folder (default the root of the volume)")
+	list.Flags().BoolVarP(&recursive, "recursive", "r", false, "list the whole volume, with the paths of the entries")
+	_ = list.MarkFlagRequired("volume")
+	list.MarkFlagsMutuallyExclusive("folder", "recursive")
+
+	get := &cobra.Command{
+		Use:   "get <file-id>",
+		Short: "Show a file or folder",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetFile(cmd.Context(), &sdk.FileInfoRequest{FileID: sdk.FileID(args[0])})
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), resp)
+		},
+	}
+
+	var mkdirVolume string
+	mkdir := &cobra.Command{
+		Use:   "mkdir <path> --volume <volume-id>",
+		Short: "Create a folder and its missing parents, and print its ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdkClient(cmd)
+			if err != nil {
+				return err
+			}
+			folderID, created, err := client.EnsureFolderPath(cmd.Context(), sdk.VolumeID(mkdirVolume), args[0])
+			if err != nil {
+				return err
+			}
+			return a.printID(cmd, map[string]interface{}{"id": folderID, "created": created}, folderID)
+		},
+	}
+	mkdir.Flags().StringVar(&mkdirVolume, "volume", "", "ID of the volume")
+	_ = mkdir.MarkFlagRequired("volume")
+
+	var uploadVolume, uploadFolder, uploadName string
+	upload := &cobra.Command{
+		Use:   "upload <local-file> --volume <volume-id>",
+		Short: "Upload a local file and print its ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			stat, err := f.Stat()
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			name := uploadName
+			if name == "" {
+				name = filepath.Base(args[0])
+			}
+			resp, err := client.UploadFileContent(cmd.Context(), &sdk.FileContentUploadRequest{
+				VolumeID: sdk.VolumeID(uploadVolume),
+				ParentID: sdk.FileID(uploadFolder),
+				Name:     name,
+				Reader:   f,
+				Size:     stat.Size(),
+			})
+			if err != nil {
+				return err
+			}
+			return a.printID(cmd, resp, resp.FileID)
+		},
+	}
+	upload.Flags().StringVar(&uploadVolume, "volume", "", "ID of the volume")
+	upload.Flags().StringVar(&uploadFolder, "folder", "", "ID of the folder (default the root of the volume)")
+	upload.Flags().StringVar(&uploadName, "name", "", "name of the file in the volume (default the local file name)")
+	_ = upload.MarkFlagRequired("volume")
+
+	var downloadTo string
+	download := &cobra.Command{
+		Use:   "download <file-id> [--to <path>]",
+		Short: "Download a file",
+		Long:  `Download a file to --to, "-" for stdout, or to a local file with its name.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			fileID := sdk.FileID(args[0])
+			info, err := client.GetFile(cmd.Context(), &sdk.FileInfoRequest{FileID: fileID})
+			if err != nil {
+				return err
+			}
+			var w io.Writer = cmd.OutOrStdout()
+			if downloadTo != "-" {
+				to := downloadTo
+				if to == "" {
+					to = filepath.Base(info.Name)
+				}
+				f, err := os.Create(to)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			result, err := client.DownloadFile(cmd.Context(), &sdk.FileDownloadRequest{FileID: fileID, VolumeID: sdk.VolumeID(info.VolumeID)}, w)
+			if err != nil {
+				return err
+			}
+			if downloadTo != "-" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "downloaded %s (%d bytes, sha256 %s)\n", info.Name, result.Size, result.SHA256)
+			}
+			return nil
+		},
+	}
+	download.Flags().StringVar(&downloadTo, "to", "", `local path to write the file to, or "-" for stdout`)
+
+	var isFolder bool
+	del := &cobra.Command{
+		Use:   "delete <file-id>",
+		Short: "Delete a file, or a folder and its content",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			if isFolder {
+				_, err = client.DeleteFolder(cmd.Context(), &sdk.FolderDeleteRequest{FolderID: sdk.FileID(args[0])})
+			} else {
+				_, err = client.DeleteFile(cmd.Context(), &sdk.FileDeleteRequest{FileID: sdk.FileID(args[0])})
+			}
+			return err
+		},
+	}
+	del.Flags().BoolVar(&isFolder, "folder", false, "the ID is a folder")
+
+	cmd.AddCommand(list, get, mkdir, upload, download, del)
+	return cmd
+}
@@ -0,0 +1,31 @@
+// Command moi is a command-line client for MOI, built on the Go SDK, for quick
+// operations and debugging.
+//
+// It manages catalogs, databases, tables, volumes and files, uploads and downloads
+// files, creates and lists workflows, and streams the answers of data asking
+// questions:
+//
+//	export MOI_BASE_URL=https://moi.example.com MOI_API_KEY=...
+//	moi catalog list
+//	moi volume create raw --database 12
+//	moi file upload ./report.pdf --volume 1234567890
+//	moi ask "Why did revenue drop in 2024?"
+//
+// The connection is configured like sdk.NewRawClient: --base-url and --api-key, or
+// the environment variables MOI_BASE_URL and MOI_API_KEY, plus --workspace
+// (MOI_WORKSPACE) for sdk.WithWorkspace. Run "moi help" for the list of commands.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := newRootCmd().ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
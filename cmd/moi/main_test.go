@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+	"github.com/matrixorigin/moi-go-sdk/mock"
+)
+
+// runCLI runs the command line against baseURL and returns its stdout and stderr.
+func runCLI(t *testing.T, baseURL string, args ...string) (string, string, error) {
+	t.Helper()
+	root := newRootCmd()
+	var stdout, stderr bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(&stderr)
+	root.SetArgs(append([]string{"--base-url", baseURL, "--api-key", mock.APIKey}, args...))
+	err := root.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestCatalogCommands(t *testing.T) {
+	t.Parallel()
+	server := mock.NewServer()
+	defer server.Close()
+
+	out, _, err := runCLI(t, server.URL, "catalog", "create", "sales", "--description", "sales data")
+	require.NoError(t, err)
+	id := strings.TrimSpace(out)
+
+	out, _, err = runCLI(t, server.URL, "catalog", "list")
+	require.NoError(t, err)
+	require.Contains(t, out, "NAME")
+	require.Contains(t, out, "sales data")
+
+	_, _, err = runCLI(t, server.URL, "catalog", "update", id, "--name", "revenue")
+	require.NoError(t, err)
+	out, _, err = runCLI(t, server.URL, "-o", "json", "catalog", "get", id)
+	require.NoError(t, err)
+	require.Contains(t, out, `"name": "revenue"`)
+	require.Contains(t, out, `"description": "sales data"`)
+
+	_, _, err = runCLI(t, server.URL, "catalog", "delete", id)
+	require.NoError(t, err)
+	_, _, err = runCLI(t, server.URL, "catalog", "get", id)
+	require.Error(t, err)
+
+	_, _, err = runCLI(t, server.URL, "catalog", "get", "abc")
+	require.EqualError(t, err, `invalid catalog id "abc"`)
+}
+
+func TestFileCommands(t *testing.T) {
+	t.Parallel()
+	server := mock.NewServer()
+	defer server.Close()
+
+	out, _, err := runCLI(t, server.URL, "file", "mkdir", "docs/2024", "--volume", "vol-1")
+	require.NoError(t, err)
+	folderID := strings.TrimSpace(out)
+	require.NotEmpty(t, folderID)
+
+	out, _, err = runCLI(t, server.URL, "file", "list", "--volume", "vol-1")
+	require.NoError(t, err)
+	require.Contains(t, out, "docs")
+	require.NotContains(t, out, "2024")
+
+	out, _, err = runCLI(t, server.URL, "file", "list", "--volume", "vol-1", "--recursive")
+	require.NoError(t, err)
+	require.Contains(t, out, "docs/2024")
+
+	_, _, err = runCLI(t, server.URL, "file", "delete", folderID, "--folder")
+	require.NoError(t, err)
+	out, _, err = runCLI(t, server.URL, "-o", "json", "file", "list", "--volume", "vol-1", "--recursive")
+	require.NoError(t, err)
+	require.NotContains(t, out, "2024")
+}
+
+func TestWorkflowCommands(t *testing.T) {
+	t.Parallel()
+	server := mock.NewServer()
+	defer server.Close()
+
+	out, _, err := runCLI(t, server.URL, "workflow", "create", "--name", "docs", "--source", "vol-1", "--target", "vol-2")
+	require.NoError(t, err)
+	id := strings.TrimSpace(out)
+	require.NotEmpty(t, id)
+
+	file := filepath.Join(t.TempDir(), "workflow.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"name":"reports","source_volume_ids":["vol-3"],"source_volume_names":[],"target_volume_id":"vol-4"}`), 0o600))
+	_, _, err = runCLI(t, server.URL, "workflow", "create", "--file", file)
+	require.NoError(t, err)
+
+	out, _, err = runCLI(t, server.URL, "workflow", "list")
+	require.NoError(t, err)
+	require.Contains(t, out, id)
+	require.Contains(t, out, "reports")
+
+	out, _, err = runCLI(t, server.URL, "workflow", "list", "--name", "rep")
+	require.NoError(t, err)
+	require.NotContains(t, out, id)
+
+	_, _, err = runCLI(t, server.URL, "workflow", "create", "--name", "docs", "--source", "vol-1")
+	require.Error(t, err)
+}
+
+func TestAskCommand(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/byoa/api/v1/data_asking/analyze" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: init\ndata: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-1\",\"session_title\":\"t\"}}\n\n")
+		fmt.Fprint(w, "data: {\"source\":\"nl2sql\",\"step_type\":\"sql_generated\",\"step_name\":\"generate\",\"sql\":\"select 1\"}\n\n")
+		fmt.Fprint(w, "data: {\"source\":\"rag\",\"data\":{\"content\":\"Revenue \"}}\n\n")
+		fmt.Fprint(w, "data: {\"source\":\"rag\",\"data\":{\"content\":\"dropped.\"}}\n\n")
+		fmt.Fprint(w, "event: complete\ndata: {\"type\":\"complete\",\"data\":{\"summary\":\"done\"}}\n\n")
+	}))
+	defer server.Close()
+
+	stdout, stderr, err := runCLI(t, server.URL, "ask", "why", "did", "revenue", "drop?")
+	require.NoError(t, err)
+	require.Equal(t, "Revenue dropped.\n", stdout)
+	require.Equal(t, "SQL: select 1\n", stderr)
+
+	stdout, _, err = runCLI(t, server.URL, "ask", "--raw", "why?")
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 5)
+}
+
+func TestMissingBaseURL(t *testing.T) {
+	t.Setenv("MOI_BASE_URL", "")
+	root := newRootCmd()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"catalog", "list"})
+	err := root.Execute()
+	require.ErrorIs(t, err, sdk.ErrBaseURLRequired)
+	require.Contains(t, err.Error(), "MOI_BASE_URL")
+}
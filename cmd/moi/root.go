@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// Output formats of the --output flag
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// app holds the global flags and the client shared by the commands.
+type app struct {
+	baseURL   string
+	apiKey    string
+	workspace string
+	timeout   time.Duration
+	output    string
+	verbose   bool
+
+	raw *sdk.RawClient
+}
+
+func newRootCmd() *cobra.Command {
+	a := &app{}
+	root := &cobra.Command{
+		Use:          "moi",
+		Short:        "Command-line client for MOI",
+		Long:         "moi manages MOI catalogs, databases, tables, volumes, files and workflows, and asks questions about data.",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if a.output != outputTable && a.output != outputJSON {
+				return fmt.Errorf("invalid output format %q: use %s or %s", a.output, outputTable, outputJSON)
+			}
+			return nil
+		},
+	}
+	flags := root.PersistentFlags()
+	// The defaults are read from the environment when the client is created, so that
+	// the API key is never shown in the help
+	flags.StringVar(&a.baseURL, "base-url", "", "base URL of the MOI service (default $MOI_BASE_URL)")
+	flags.StringVar(&a.apiKey, "api-key", "", "API key (default $MOI_API_KEY)")
+	flags.StringVar(&a.workspace, "workspace", "", "workspace to route the requests to (default $MOI_WORKSPACE)")
+	flags.DurationVar(&a.timeout, "timeout", 0, "timeout of each request (default 30s)")
+	flags.StringVarP(&a.output, "output", "o", outputTable, "output format: table or json")
+	flags.BoolVarP(&a.verbose, "verbose", "v", false, "log every request to stderr")
+
+	root.AddCommand(
+		newCatalogCmd(a),
+		newDatabaseCmd(a),
+		newTableCmd(a),
+		newVolumeCmd(a),
+		newFileCmd(a),
+		newWorkflowCmd(a),
+		newAskCmd(a),
+		newResolveCmd(a),
+		newVersionCmd(),
+	)
+	root.SetErrPrefix("moi:")
+	return root
+}
+
+// client returns the client configured by the global flags, creating it on first use.
+func (a *app) client(cmd *cobra.Command) (*sdk.RawClient, error) {
+	if a.raw != nil {
+		return a.raw, nil
+	}
+	baseURL := firstNonEmpty(a.baseURL, os.Getenv("MOI_BASE_URL"))
+	apiKey := firstNonEmpty(a.apiKey, os.Getenv("MOI_API_KEY"))
+	opts := []sdk.ClientOption{sdk.WithAppInfo("moi-cli", sdk.Version())}
+	if workspace := firstNonEmpty(a.workspace, os.Getenv("MOI_WORKSPACE")); workspace != "" {
+		opts = append(opts, sdk.WithWorkspace(workspace))
+	}
+	if a.timeout > 0 {
+		opts = append(opts, sdk.WithHTTPTimeout(a.timeout))
+	}
+	if a.verbose {
+		handler := slog.NewTextHandler(cmd.ErrOrStderr(), &slog.HandlerOptions{Level: slog.LevelDebug})
+		opts = append(opts, sdk.WithLogger(sdk.NewSlogLogger(slog.New(handler))))
+	}
+	raw, err := sdk.NewRawClient(baseURL, apiKey, opts...)
+	switch {
+	case errors.Is(err, sdk.ErrBaseURLRequired):
+		return nil, fmt.Errorf("%w: set --base-url or MOI_BASE_URL", err)
+	case errors.Is(err, sdk.ErrAPIKeyRequired):
+		return nil, fmt.Errorf("%w: set --api-key or MOI_API_KEY", err)
+	case err != nil:
+		return nil, err
+	}
+	a.raw = raw
+	return raw, nil
+}
+
+// sdkClient returns the high-level client configured by the global flags.
+func (a *app) sdkClient(cmd *cobra.Command) (*sdk.SDKClient, error) {
+	raw, err := a.client(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewSDKClient(raw), nil
+}
+
+// printTable writes rows under header as aligned columns, or v as JSON with
+// --output json.
+func (a *app) printTable(cmd *cobra.Command, v interface{}, header []string, rows [][]string) error {
+	if a.output == outputJSON {
+		return printJSON(cmd.OutOrStdout(), v)
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// printID writes the ID of a created object, or v as JSON with --output json.
+func (a *app) printID(cmd *cobra.Command, v interface{}, id interface{}) error {
+	if a.output == outputJSON {
+		return printJSON(cmd.OutOrStdout(), v)
+	}
+	_, err := fmt.Fprintln(cmd.OutOrStdout(), id)
+	return err
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// parseID parses the numeric ID of a catalog, database or table.
+func parseID[T ~int64](kind, s string) (T, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s id %q", kind, s)
+	}
+	return T(id), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func itoa[T ~int64 | ~int](v T) string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+func newVersionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the SDK version",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), sdk.Version())
+			return err
+		},
+	}
+}
+
+func newResolveCmd(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resolve <catalog/database/volume/path | catalog.database.table>",
+		Short: "Print the IDs of an object given by name",
+		Long: `Print the IDs of an object given by name. A path with "/" resolves a catalog,
+database, volume, folder or file; a name with "." resolves a table.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.sdkClient(cmd)
+			if err != nil {
+				return err
+			}
+			var resolved interface{}
+			if strings.Contains(args[0], "/") || !strings.Contains(args[0], ".") {
+				resolved, err = client.Resolve(cmd.Context(), args[0])
+			} else {
+				resolved, err = client.ResolveTable(cmd.Context(), args[0])
+			}
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), resolved)
+		},
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func newTableCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "table",
+		Aliases: []string{"tables"},
+		Short:   "Manage tables",
+	}
+
+	var listDatabase string
+	list := &cobra.Command{
+		Use:   "list --database <database-id>",
+		Short: "List the tables of a database",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.listDatabaseChildren(cmd, listDatabase, "table")
+		},
+	}
+	list.Flags().StringVar(&listDatabase, "database", "", "ID of the database")
+	_ = list.MarkFlagRequired("database")
+
+	get := &cobra.Command{
+		Use:   "get <table-id>",
+		Short: "Show a table and its columns",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.TableID]("table", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetTable(cmd.Context(), &sdk.TableInfoRequest{TableID: id})
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), resp)
+		},
+	}
+
+	var createDatabase, comment, columnsFile string
+	var columnSpecs []string
+	create := &cobra.Command{
+		Use:   "create <name> --database <database-id> --column <name:type[:pk]>...",
+		Short: "Create a table and print its ID",
+		Example: `  moi table create line_items --database 12 --column id:int:pk --column sku:varchar(64)
+  moi table create line_items --database 12 --columns-file columns.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			databaseID, err := parseID[sdk.DatabaseID]("database", createDatabase)
+			if err != nil {
+				return err
+			}
+			columns, err := parseColumns(columnSpecs, columnsFile)
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.CreateTable(cmd.Context(), &sdk.TableCreateRequest{DatabaseID: databaseID, Name: args[0], Columns: columns, Comment: comment})
+			if err != nil {
+				return err
+			}
+			return a.printID(cmd, resp, resp.TableID)
+		},
+	}
+	create.Flags().StringVar(&createDatabase, "database", "", "ID of the database")
+	create.Flags().StringArrayVar(&columnSpecs, "column", nil, "column as name:type, or name:type:pk for a primary key column; repeatable")
+	create.Flags().StringVar(&columnsFile, "columns-file", "", "JSON file with the columns, as an array of {name, type, is_pk, default, comment}")
+	create.Flags().StringVar(&comment, "comment", "", "comment of the table")
+	_ = create.MarkFlagRequired("database")
+
+	var lines int
+	preview := &cobra.Command{
+		Use:   "preview <table-id>",
+		Short: "Show the first rows of a table",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.TableID]("table", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.PreviewTable(cmd.Context(), &sdk.TablePreviewRequest{TableID: id, Lines: lines})
+			if err != nil {
+				return err
+			}
+			header := make([]string, len(resp.Columns))
+			for i, column := range resp.Columns {
+				header[i] = column.Name
+			}
+			rows := make([][]string, len(resp.Data))
+			for i, values := range resp.Data {
+				rows[i] = make([]string, len(values))
+				for j, v := range values {
+					if v != nil {
+						rows[i][j] = fmt.Sprint(v)
+					}
+				}
+			}
+			return a.printTable(cmd, resp, header, rows)
+		},
+	}
+	preview.Flags().IntVar(&lines, "lines", 10, "number of rows to show")
+
+	del := &cobra.Command{
+		Use:   "delete <table-id>",
+		Short: "Delete a table",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID[sdk.TableID]("table", args[0])
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteTable(cmd.Context(), &sdk.TableDeleteRequest{TableID: id})
+			return err
+		},
+	}
+
+	cmd.AddCommand(list, get, create, preview, del)
+	return cmd
+}
+
+// parseColumns returns the columns given with --column, followed by those of
+// --columns-file.
+func parseColumns(specs []string, file string) ([]sdk.Column, error) {
+	var columns []sdk.Column
+	for _, spec := range specs {
+		name, rest, ok := strings.Cut(spec, ":")
+		if !ok || name == "" || rest == "" {
+			return nil, fmt.Errorf("invalid column %q: use name:type or name:type:pk", spec)
+		}
+		column := sdk.Column{Name: name, Type: rest}
+		// The type is the rest of the spec, without the ":pk" suffix of a primary key
+		if typ, ok := strings.CutSuffix(rest, ":pk"); ok {
+			column.Type, column.IsPk = typ, true
+		}
+		columns = append(columns, column)
+	}
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var fromFile []sdk.Column
+		if err := json.Unmarshal(data, &fromFile); err != nil {
+			return nil, fmt.Errorf("invalid columns file %s: %w", file, err)
+		}
+		columns = append(columns, fromFile...)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("columns are required: use --column or --columns-file")
+	}
+	return columns, nil
+}
+
+// listDatabaseChildren prints the tables or volumes of a database.
+func (a *app) listDatabaseChildren(cmd *cobra.Command, database, typ string) error {
+	databaseID, err := parseID[sdk.DatabaseID]("database", database)
+	if err != nil {
+		return err
+	}
+	client, err := a.client(cmd)
+	if err != nil {
+		return err
+	}
+	resp, err := client.GetDatabaseChildren(cmd.Context(), &sdk.DatabaseChildrenRequest{DatabaseID: databaseID})
+	if err != nil {
+		return err
+	}
+	var children []sdk.DatabaseChildrenResponse
+	var rows [][]string
+	for _, child := range resp.List {
+		if !strings.EqualFold(child.Typ, typ) {
+			continue
+		}
+		children = append(children, child)
+		rows = append(rows, []string{child.ID, child.Name, itoa(child.Size), child.Comment})
+	}
+	return a.printTable(cmd, children, []string{"ID", "NAME", "SIZE", "DESCRIPTION"}, rows)
+}
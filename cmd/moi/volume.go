@@ -0,0 +1,116 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func newVolumeCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "volume",
+		Aliases: []string{"volumes"},
+		Short:   "Manage volumes",
+	}
+
+	var listDatabase string
+	list := &cobra.Command{
+		Use:   "list --database <database-id>",
+		Short: "List the volumes of a database",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.listDatabaseChildren(cmd, listDatabase, "volume")
+		},
+	}
+	list.Flags().StringVar(&listDatabase, "database", "", "ID of the database")
+	_ = list.MarkFlagRequired("database")
+
+	get := &cobra.Command{
+		Use:   "get <volume-id>",
+		Short: "Show a volume",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetVolume(cmd.Context(), &sdk.VolumeInfoRequest{VolumeID: sdk.VolumeID(args[0])})
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), resp)
+		},
+	}
+
+	var createDatabase, description string
+	create := &cobra.Command{
+		Use:   "create <name> --database <database-id>",
+		Short: "Create a volume and print its ID",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			databaseID, err := parseID[sdk.DatabaseID]("database", createDatabase)
+			if err != nil {
+				return err
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.CreateVolume(cmd.Context(), &sdk.VolumeCreateRequest{Name: args[0], DatabaseID: databaseID, Comment: description})
+			if err != nil {
+				return err
+			}
+			return a.printID(cmd, resp, resp.VolumeID)
+		},
+	}
+	create.Flags().StringVar(&createDatabase, "database", "", "ID of the database")
+	create.Flags().StringVar(&description, "description", "", "description of the volume")
+	_ = create.MarkFlagRequired("database")
+
+	var newName, newDescription string
+	update := &cobra.Command{
+		Use:   "update <volume-id>",
+		Short: "Rename a volume or change its description",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := sdk.VolumeID(args[0])
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			// The update replaces both the name and the description
+			cur, err := client.GetVolume(cmd.Context(), &sdk.VolumeInfoRequest{VolumeID: id})
+			if err != nil {
+				return err
+			}
+			req := &sdk.VolumeUpdateRequest{VolumeID: id, Name: cur.VolumeName, Comment: cur.Comment}
+			if cmd.Flags().Changed("name") {
+				req.Name = newName
+			}
+			if cmd.Flags().Changed("description") {
+				req.Comment = newDescription
+			}
+			_, err = client.UpdateVolume(cmd.Context(), req)
+			return err
+		},
+	}
+	update.Flags().StringVar(&newName, "name", "", "new name of the volume")
+	update.Flags().StringVar(&newDescription, "description", "", "new description of the volume")
+
+	del := &cobra.Command{
+		Use:   "delete <volume-id>",
+		Short: "Delete a volume and its files",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteVolume(cmd.Context(), &sdk.VolumeDeleteRequest{VolumeID: sdk.VolumeID(args[0])})
+			return err
+		},
+	}
+
+	cmd.AddCommand(list, get, create, update, del)
+	return cmd
+}
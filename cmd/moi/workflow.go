@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// workflowPageSize is the page size of "workflow list".
+const workflowPageSize = 100
+
+func newWorkflowCmd(a *app) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "workflow",
+		Aliases: []string{"workflows"},
+		Short:   "Manage document processing workflows",
+	}
+
+	var listName, listSource string
+	list := &cobra.Command{
+		Use:   "list [--name <substring>] [--source-volume <volume-id>]",
+		Short: "List the workflows",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			var workflows []sdk.WorkflowResponse
+			for page := 1; ; page++ {
+				resp, err := client.ListWorkflows(cmd.Context(), &sdk.WorkflowListRequest{
+					Name:           listName,
+					SourceVolumeID: listSource,
+					Page:           page,
+					PageSize:       workflowPageSize,
+				})
+				if err != nil {
+					return err
+				}
+				workflows = append(workflows, resp.Workflows...)
+				if len(resp.Workflows) == 0 || len(workflows) >= resp.Total {
+					break
+				}
+			}
+			rows := make([][]string, len(workflows))
+			for i, wf := range workflows {
+				rows[i] = []string{wf.ID, wf.Name, wf.SourceVolumeIDs, wf.TargetVolumeID, wf.UpdatedAt}
+			}
+			return a.printTable(cmd, workflows, []string{"ID", "NAME", "SOURCES", "TARGET", "UPDATED"}, rows)
+		},
+	}
+	list.Flags().StringVar(&listName, "name", "", "only list the workflows whose name contains this text")
+	list.Flags().StringVar(&listSource, "source-volume", "", "only list the workflows reading this volume")
+
+	get := &cobra.Command{
+		Use:   "get <workflow-id>",
+		Short: "Show a workflow",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetWorkflow(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return printJSON(cmd.OutOrStdout(), resp)
+		},
+	}
+
+	var metadataFile, createName, createSource, createTarget string
+	create := &cobra.Command{
+		Use:   "create (--file <metadata.json> | --name <name> --source <volume-id> --target <volume-id>)",
+		Short: "Create a workflow and print its ID",
+		Long: `Create a workflow from a JSON workflow definition, or a default document
+processing workflow from --source to --target.`,
+		Example: `  moi workflow create --file workflow.json
+  moi workflow create --name docs --source 1a2b --target 3c4d`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if metadataFile == "" {
+				client, err := a.sdkClient(cmd)
+				if err != nil {
+					return err
+				}
+				id, err := client.CreateDocumentProcessingWorkflow(cmd.Context(), createName, sdk.VolumeID(createSource), sdk.VolumeID(createTarget))
+				if err != nil {
+					return err
+				}
+				return a.printID(cmd, map[string]string{"id": id}, id)
+			}
+
+			metadata, err := readWorkflowMetadata(cmd.InOrStdin(), metadataFile)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("name") {
+				metadata.Name = createName
+			}
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			resp, err := client.CreateWorkflow(cmd.Context(), metadata)
+			if err != nil {
+				return err
+			}
+			return a.printID(cmd, resp, resp.ID)
+		},
+	}
+	create.Flags().StringVarP(&metadataFile, "file", "f", "", `JSON file with the workflow definition, or "-" for stdin`)
+	create.Flags().StringVar(&createName, "name", "", "name of the workflow")
+	create.Flags().StringVar(&createSource, "source", "", "ID of the volume to process")
+	create.Flags().StringVar(&createTarget, "target", "", "ID of the volume to write the results to")
+	create.MarkFlagsMutuallyExclusive("file", "source")
+	create.MarkFlagsMutuallyExclusive("file", "target")
+	create.MarkFlagsOneRequired("file", "source")
+	create.MarkFlagsRequiredTogether("source", "target")
+
+	del := &cobra.Command{
+		Use:   "delete <workflow-id>",
+		Short: "Delete a workflow",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := a.client(cmd)
+			if err != nil {
+				return err
+			}
+			_, err = client.DeleteWorkflow(cmd.Context(), args[0])
+			return err
+		},
+	}
+
+	cmd.AddCommand(list, get, create, del)
+	return cmd
+}
+
+// readWorkflowMetadata decodes a workflow definition from file, or from stdin
+// when file is "-".
+func readWorkflowMetadata(stdin io.Reader, file string) (*sdk.WorkflowMetadata, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var metadata sdk.WorkflowMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("invalid workflow file %s: %w", file, err)
+	}
+	return &metadata, nil
+}
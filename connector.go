@@ -309,45 +309,14 @@ func (c *RawClient) UploadLocalFiles(ctx context.Context, files []FileUploadItem
 	}
 
 	// Make request
-	callOpts := newCallOptions(opts...)
-	fullURL := c.baseURL + ensureLeadingSlash("/connectors/file/upload")
-	if len(callOpts.query) > 0 {
-		delimiter := "?"
-		if strings.Contains(fullURL, "?") {
-			delimiter = "&"
-		}
-		fullURL = fullURL + delimiter + callOpts.query.Encode()
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, body)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", contentType)
-	req.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		req.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		req.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(req.Header, callOpts.headers, true)
-
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doRaw(ctx, http.MethodPost, "/connectors/file/upload", body, newCallOptions(opts...), func(req *http.Request) {
+		req.Header.Set(headerContentType, contentType)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		data, _ := io.ReadAll(resp.Body)
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
-	}
-
 	// Parse response
 	var envelope apiEnvelope
 	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
@@ -467,44 +436,19 @@ func (c *RawClient) FilePreview(ctx context.Context, req *FilePreviewRequest, op
 	}
 
 	// Make request
-	callOpts := newCallOptions(opts...)
-	fullURL := c.baseURL + ensureLeadingSlash("/connectors/file/preview")
-
 	reqBody, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, strings.NewReader(string(reqBody)))
+	resp, err := c.doRaw(ctx, http.MethodPost, "/connectors/file/preview", bytes.NewReader(reqBody), newCallOptions(opts...), func(httpReq *http.Request) {
+		httpReq.Header.Set(headerContentType, mimeJSON)
+		httpReq.Header.Set(headerAccept, mimeJSON)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers
-	httpReq.Header.Set(headerContentType, mimeJSON)
-	httpReq.Header.Set(headerAccept, mimeJSON)
-	httpReq.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		httpReq.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(httpReq.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		httpReq.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(httpReq.Header, callOpts.headers, true)
-
-	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		data, _ := io.ReadAll(resp.Body)
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
-	}
-
 	// Parse response
 	var envelope apiEnvelope
 	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
@@ -699,45 +643,14 @@ func (c *RawClient) UploadConnectorFile(ctx context.Context, req *UploadFileRequ
 	}
 
 	// Make request
-	callOpts := newCallOptions(opts...)
-	fullURL := c.baseURL + ensureLeadingSlash("/connectors/upload")
-	if len(callOpts.query) > 0 {
-		delimiter := "?"
-		if strings.Contains(fullURL, "?") {
-			delimiter = "&"
-		}
-		fullURL = fullURL + delimiter + callOpts.query.Encode()
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, body)
+	resp, err := c.doRaw(ctx, http.MethodPost, "/connectors/upload", body, newCallOptions(opts...), func(req *http.Request) {
+		req.Header.Set(headerContentType, contentType)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers
-	httpReq.Header.Set("Content-Type", contentType)
-	httpReq.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		httpReq.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(httpReq.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		httpReq.Header.Set(headerRequestID, callOpts.requestID)
-	}
-	mergeHeaders(httpReq.Header, callOpts.headers, true)
-
-	// Execute request
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		data, _ := io.ReadAll(resp.Body)
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
-	}
-
 	// Parse response
 	var envelope apiEnvelope
 	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
@@ -5,17 +5,22 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 // DataAnalysisStream wraps a streaming HTTP response for data analysis API.
 //
 // The stream returns Server-Sent Events (SSE) format. Use ReadEvent to read
-// individual events from the stream.
+// individual events from the stream, and Typed or As to decode them.
 //
 // Example:
 //
@@ -36,7 +41,18 @@ import (
 //		if err != nil {
 //			return err
 //		}
-//		fmt.Printf("Event type: %s\n", event.Type)
+//		typed, err := event.Typed()
+//		if err != nil {
+//			return err
+//		}
+//		switch ev := typed.(type) {
+//		case *sdk.AnswerChunkEvent:
+//			fmt.Print(ev.Content)
+//		case *sdk.SQLStepEvent:
+//			fmt.Printf("\n[%s] %s\n", ev.StepName, ev.SQL)
+//		case *sdk.ErrorEvent:
+//			return ev
+//		}
 //	}
 //
 // timeoutReader wraps an io.ReadCloser and provides timeout control that resets on each successful read.
@@ -122,14 +138,35 @@ type DataAnalysisStream struct {
 	// readTimeout is the timeout between messages in streaming responses
 	// This timeout is reset each time data is successfully read
 	readTimeout time.Duration
+	// err records why Events or All stopped
+	errMu sync.Mutex
+	err   error
+
+	// Reconnection state, set up by WithStreamAutoReconnect
+	reconnect        func(ctx context.Context, lastEventID, requestID string) (*http.Response, error)
+	reconnectCtx     context.Context
+	reconnectRetries int
+	reconnectBackoff time.Duration
+	lastEventID      string // ID of the last event received, sent as Last-Event-ID
+	requestID        string // Analysis request ID from the init event
+	bodyMu           sync.Mutex
+	closed           bool
+	longPoll         bool // Events are read with GetAnalysisEvents instead of SSE
 }
 
 // Close releases the underlying HTTP response body.
 func (s *DataAnalysisStream) Close() error {
-	if s == nil || s.Body == nil {
+	if s == nil {
 		return nil
 	}
-	return s.Body.Close()
+	s.bodyMu.Lock()
+	s.closed = true
+	body := s.Body
+	s.bodyMu.Unlock()
+	if body == nil {
+		return nil
+	}
+	return body.Close()
 }
 
 // ReadEvent reads the next SSE event from the stream.
@@ -201,6 +238,61 @@ func (s *DataAnalysisStream) readLine() (string, error) {
 }
 
 func (s *DataAnalysisStream) ReadEvent() (*DataAnalysisStreamEvent, error) {
+	for attempt := 1; ; attempt++ {
+		event, err := s.readEvent()
+		if err == nil {
+			if event.ID != "" {
+				s.lastEventID = event.ID
+			}
+			if init := event.GetInitEventData(); init != nil {
+				s.requestID = init.RequestID
+			}
+			return event, nil
+		}
+		if err == io.EOF || s.reconnect == nil || attempt > s.reconnectRetries {
+			return nil, err
+		}
+		if rerr := s.resume(attempt); rerr != nil {
+			return nil, fmt.Errorf("%w (reconnect failed: %v)", err, rerr)
+		}
+	}
+}
+
+// resume replaces the interrupted connection with a new one that continues after the
+// last event received. The delay before reconnecting grows linearly with attempt.
+func (s *DataAnalysisStream) resume(attempt int) error {
+	s.bodyMu.Lock()
+	closed := s.closed
+	old := s.Body
+	s.bodyMu.Unlock()
+	if closed {
+		return fmt.Errorf("stream is closed")
+	}
+	if old != nil {
+		old.Close()
+	}
+	if err := sleepWithContext(s.reconnectCtx, s.reconnectBackoff*time.Duration(attempt)); err != nil {
+		return err
+	}
+	resp, err := s.reconnect(s.reconnectCtx, s.lastEventID, s.requestID)
+	if err != nil {
+		return err
+	}
+
+	s.bodyMu.Lock()
+	defer s.bodyMu.Unlock()
+	if s.closed {
+		resp.Body.Close()
+		return fmt.Errorf("stream is closed")
+	}
+	s.Body = resp.Body
+	s.Header = resp.Header.Clone()
+	s.StatusCode = resp.StatusCode
+	s.reader = nil
+	return nil
+}
+
+func (s *DataAnalysisStream) readEvent() (*DataAnalysisStreamEvent, error) {
 	var event DataAnalysisStreamEvent
 	var dataLines []string
 	var eventType string
@@ -257,11 +349,111 @@ func (s *DataAnalysisStream) ReadEvent() (*DataAnalysisStreamEvent, error) {
 			dataLines = append(dataLines, data)
 		} else if strings.HasPrefix(line, "event: ") {
 			eventType = strings.TrimPrefix(line, "event: ")
+		} else if strings.HasPrefix(line, "id:") {
+			event.ID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		}
+		// Ignore other SSE fields (retry, etc.)
+	}
+}
+
+// LongPolling reports whether the stream reads events by long polling because the
+// event stream could not be established (see WithLongPollFallback).
+func (s *DataAnalysisStream) LongPolling() bool {
+	return s != nil && s.longPoll
+}
+
+// Events reads the stream in the background and delivers its events on the returned
+// channel, so that streams can be consumed in a select alongside other channels.
+//
+// The channel is closed at the end of the stream, on a read error or when ctx is done;
+// Err then reports why. Cancelling ctx closes the stream. Events must not be combined
+// with ReadEvent or All on the same stream.
+//
+// Example:
+//
+//	events := stream.Events(ctx)
+//	for event := range events {
+//		fmt.Printf("Event type: %s\n", event.Kind())
+//	}
+//	if err := stream.Err(); err != nil {
+//		return err
+//	}
+func (s *DataAnalysisStream) Events(ctx context.Context) <-chan DataAnalysisStreamEvent {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ch := make(chan DataAnalysisStreamEvent)
+	stop := context.AfterFunc(ctx, func() { s.Close() })
+	go func() {
+		defer close(ch)
+		defer stop()
+		for {
+			event, err := s.ReadEvent()
+			if err != nil {
+				if ctx.Err() != nil {
+					s.setErr(ctx.Err())
+				} else if err != io.EOF {
+					s.setErr(err)
+				}
+				return
+			}
+			select {
+			case ch <- *event:
+			case <-ctx.Done():
+				s.setErr(ctx.Err())
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// All returns an iterator over the events of the stream for use with range.
+//
+// Iteration ends at the end of the stream. A read error is yielded once with a nil
+// event and ends the iteration. Breaking out of the loop leaves the stream open.
+//
+// Example:
+//
+//	for event, err := range stream.All() {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Printf("Event type: %s\n", event.Kind())
+//	}
+func (s *DataAnalysisStream) All() iter.Seq2[*DataAnalysisStreamEvent, error] {
+	return func(yield func(*DataAnalysisStreamEvent, error) bool) {
+		for {
+			event, err := s.ReadEvent()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				s.setErr(err)
+				yield(nil, err)
+				return
+			}
+			if !yield(event, nil) {
+				return
+			}
 		}
-		// Ignore other SSE fields (id, retry, etc.)
 	}
 }
 
+// Err returns the error that ended Events or All, or nil if the stream ended normally
+// or is still being read.
+func (s *DataAnalysisStream) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	return s.err
+}
+
+func (s *DataAnalysisStream) setErr(err error) {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	s.err = err
+}
+
 // AnalyzeDataStream performs data analysis and returns a streaming response.
 //
 // This method sends a POST request to /byoa/api/v1/data_asking/analyze and
@@ -279,6 +471,10 @@ func (s *DataAnalysisStream) ReadEvent() (*DataAnalysisStreamEvent, error) {
 //   - complete: Analysis complete
 //   - error: Error information
 //
+// Use WithStreamAutoReconnect to resume the stream when the connection drops during a
+// long-running analysis, and WithLongPollFallback to fall back to GetAnalysisEvents
+// when a proxy prevents the event stream from being established.
+//
 // Example:
 //
 //	stream, err := client.AnalyzeDataStream(ctx, &sdk.DataAnalysisRequest{
@@ -313,6 +509,11 @@ func (c *RawClient) AnalyzeDataStream(ctx context.Context, req *DataAnalysisRequ
 	if strings.TrimSpace(req.Question) == "" {
 		return nil, fmt.Errorf("question cannot be empty")
 	}
+	if req.Config != nil {
+		if err := req.Config.DataScope.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid data scope: %w", err)
+		}
+	}
 
 	callOpts := newCallOptions(opts...)
 
@@ -321,36 +522,143 @@ func (c *RawClient) AnalyzeDataStream(ctx context.Context, req *DataAnalysisRequ
 	if err != nil {
 		return nil, fmt.Errorf("marshal request body: %w", err)
 	}
-	reader := bytes.NewReader(payload)
 
-	// Build request
-	path := "/byoa/api/v1/data_asking/analyze"
-	fullURL := c.baseURL + ensureLeadingSlash(path)
-	if len(callOpts.query) > 0 {
-		delimiter := "?"
-		if strings.Contains(fullURL, "?") {
-			delimiter = "&"
+	resp, err := c.openAnalysisStream(ctx, payload, callOpts, "", "")
+	if err != nil {
+		if callOpts.longPollFallback && isSSEHandshakeError(ctx, err) {
+			return c.startAnalysisLongPoll(ctx, payload, opts...)
+		}
+		return nil, err
+	}
+	stream := &DataAnalysisStream{
+		Body:              resp.Body,
+		Header:            resp.Header.Clone(),
+		StatusCode:        resp.StatusCode,
+		initialBufferSize: callOpts.streamBufferSize,
+		readTimeout:       callOpts.streamReadTimeout,
+	}
+	if callOpts.reconnectRetries > 0 {
+		stream.reconnectCtx = ctx
+		stream.reconnectRetries = callOpts.reconnectRetries
+		stream.reconnectBackoff = callOpts.reconnectBackoff
+		stream.reconnect = func(ctx context.Context, lastEventID, requestID string) (*http.Response, error) {
+			return c.openAnalysisStream(ctx, payload, callOpts, lastEventID, requestID)
 		}
-		fullURL = fullURL + delimiter + callOpts.query.Encode()
 	}
+	return stream, nil
+}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, reader)
+// AnalyzeData performs data analysis and waits for it to complete.
+//
+// It reads the stream returned by AnalyzeDataStream to its end and consolidates its
+// events into a DataAnalysisResult, for callers that do not need to display partial
+// answers. If the analysis reports an error, the error is an *ErrorEvent. Use
+// WithAnalysisProgress to observe the events as they arrive.
+//
+// Example:
+//
+//	result, err := client.AnalyzeData(ctx, &sdk.DataAnalysisRequest{
+//		Question: "2024年收入下降的原因是什么？",
+//	}, sdk.WithAnalysisProgress(func(ev sdk.AnalysisEvent) {
+//		if step, ok := ev.(*sdk.SQLStepEvent); ok {
+//			log.Printf("%s: %s", step.StepName, step.SQL)
+//		}
+//	}))
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("[%s] %s\n", result.RequestID, result.Answer)
+func (c *RawClient) AnalyzeData(ctx context.Context, req *DataAnalysisRequest, opts ...CallOption) (*DataAnalysisResult, error) {
+	stream, err := c.AnalyzeDataStream(ctx, req, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		return nil, err
+	}
+	defer stream.Close()
+	return collectAnalysis(stream, newCallOptions(opts...).analysisProgress)
+}
+
+// collectAnalysis reads the stream to its end and consolidates its events. Every typed
+// event is passed to onEvent, if set, before it is consolidated.
+func collectAnalysis(stream *DataAnalysisStream, onEvent func(AnalysisEvent)) (*DataAnalysisResult, error) {
+	result := &DataAnalysisResult{}
+	var chunks strings.Builder
+	var final string
+	for event, err := range stream.All() {
+		if err != nil {
+			return nil, err
+		}
+		typed, err := event.Typed()
+		if err != nil {
+			return nil, err
+		}
+		if typed == nil {
+			continue
+		}
+		if onEvent != nil {
+			onEvent(typed)
+		}
+		switch ev := typed.(type) {
+		case *InitEvent:
+			result.RequestID = ev.RequestID
+			result.SessionTitle = ev.SessionTitle
+		case *ClassificationEvent:
+			result.Classification = ev
+		case *DecompositionEvent:
+			result.SubQuestions = ev.SubQuestions
+		case *StepCompleteEvent:
+			result.Steps = append(result.Steps, *ev)
+		case *AnswerChunkEvent:
+			chunks.WriteString(ev.Content)
+			if len(ev.Chunks) > 0 && string(ev.Chunks) != "null" {
+				result.Chunks = append(result.Chunks, ev.Chunks)
+			}
+		case *SQLStepEvent:
+			result.SQLSteps = append(result.SQLSteps, *ev)
+		case *CompleteEvent:
+			result.Summary = ev.Summary
+			if ev.Answer != "" {
+				final = ev.Answer
+			} else if chunks.Len() == 0 {
+				final = ev.Summary
+			}
+		case *ErrorEvent:
+			return nil, ev
+		}
 	}
+	result.Answer = final
+	if result.Answer == "" {
+		result.Answer = chunks.String()
+	}
+	if result.RequestID == "" {
+		result.RequestID = stream.requestID
+	}
+	return result, nil
+}
 
-	// Set headers
-	httpReq.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		httpReq.Header.Set(headerUserAgent, c.userAgent)
+// openAnalysisStream sends the analysis request and checks that the server answered
+// with an event stream. When resuming an interrupted stream, lastEventID and requestID
+// identify the analysis and the last event received.
+func (c *RawClient) openAnalysisStream(ctx context.Context, payload []byte, callOpts callOptions, lastEventID, requestID string) (*http.Response, error) {
+	reader := bytes.NewReader(payload)
+
+	// Build request
+	if requestID != "" {
+		query := make(url.Values, len(callOpts.query)+1)
+		for k, v := range callOpts.query {
+			query[k] = v
+		}
+		query.Set("request_id", requestID)
+		callOpts.query = query
 	}
-	mergeHeaders(httpReq.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		httpReq.Header.Set(headerRequestID, callOpts.requestID)
+	httpReq, err := c.buildRequest(ctx, http.MethodPost, "/byoa/api/v1/data_asking/analyze", reader, callOpts)
+	if err != nil {
+		return nil, err
 	}
-	mergeHeaders(httpReq.Header, callOpts.headers, true)
 	httpReq.Header.Set(headerContentType, mimeJSON)
 	httpReq.Header.Set(headerAccept, "text/event-stream")
+	if lastEventID != "" {
+		httpReq.Header.Set(headerLastEventID, lastEventID)
+	}
 
 	// Create a client with no timeout for streaming responses
 	// The stream can still be cancelled via context
@@ -365,7 +673,7 @@ func (c *RawClient) AnalyzeDataStream(ctx context.Context, req *DataAnalysisRequ
 	}
 
 	// Execute request
-	resp, err := streamClient.Do(httpReq)
+	resp, err := c.send(streamClient, httpReq, callOpts)
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
@@ -374,7 +682,7 @@ func (c *RawClient) AnalyzeDataStream(ctx context.Context, req *DataAnalysisRequ
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
 		data, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
+		return nil, newHTTPError(resp, data)
 	}
 
 	// Check content type
@@ -383,18 +691,165 @@ func (c *RawClient) AnalyzeDataStream(ctx context.Context, req *DataAnalysisRequ
 		// Not a streaming response, try to parse as error
 		data, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("unexpected content type: %s, body: %s", contentType, string(data))
+		return nil, &notEventStreamError{contentType: contentType, body: data}
+	}
+
+	return resp, nil
+}
+
+// notEventStreamError reports that the analysis endpoint did not answer with an event
+// stream, typically because a proxy buffers or rewrites text/event-stream responses.
+type notEventStreamError struct {
+	contentType string
+	body        []byte
+}
+
+func (e *notEventStreamError) Error() string {
+	return fmt.Sprintf("unexpected content type: %s, body: %s", e.contentType, string(e.body))
+}
+
+// isSSEHandshakeError reports whether err means that no event stream could be
+// established, as opposed to the server rejecting the request.
+func isSSEHandshakeError(ctx context.Context, err error) bool {
+	if ctx != nil && ctx.Err() != nil {
+		return false
 	}
+	var notStream *notEventStreamError
+	var urlErr *url.Error
+	return errors.As(err, &notStream) || errors.As(err, &urlErr)
+}
+
+const (
+	analysisLongPollWait     = 20 * time.Second // How long the server may hold a poll open
+	analysisLongPollInterval = time.Second      // Delay between polls that returned no events
+)
+
+// GetAnalysisEvents returns the events of a data analysis with a sequence number
+// greater than afterSeq.
+//
+// This is the long-polling counterpart of AnalyzeDataStream for clients behind proxies
+// that buffer or break text/event-stream responses: the server holds the request open
+// until new events are available or a wait time passes. Pass the NextSeq of the
+// previous response to continue, until Done is true.
+//
+// Example:
+//
+//	var seq int64
+//	for {
+//		resp, err := client.GetAnalysisEvents(ctx, requestID, seq)
+//		if err != nil {
+//			return err
+//		}
+//		for _, e := range resp.Events {
+//			fmt.Printf("%d: %s\n", e.Seq, e.Event)
+//		}
+//		if resp.Done {
+//			break
+//		}
+//		seq = resp.NextSeq
+//	}
+func (c *RawClient) GetAnalysisEvents(ctx context.Context, requestID string, afterSeq int64, opts ...CallOption) (*AnalysisEventsResponse, error) {
+	if strings.TrimSpace(requestID) == "" {
+		return nil, fmt.Errorf("request_id cannot be empty")
+	}
+	query := url.Values{}
+	query.Set("request_id", requestID)
+	query.Set("after_seq", strconv.FormatInt(afterSeq, 10))
+	query.Set("wait", strconv.Itoa(int(analysisLongPollWait/time.Second)))
 
+	resp := AnalysisEventsResponse{Events: []AnalysisEventRecord{}}
+	if err := c.getJSON(ctx, "/byoa/api/v1/data_asking/events?"+query.Encode(), &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Events == nil {
+		resp.Events = []AnalysisEventRecord{}
+	}
+	if resp.RequestID == "" {
+		resp.RequestID = requestID
+	}
+	if resp.NextSeq < afterSeq {
+		resp.NextSeq = afterSeq
+	}
+	for _, e := range resp.Events {
+		if e.Seq > resp.NextSeq {
+			resp.NextSeq = e.Seq
+		}
+	}
+	return &resp, nil
+}
+
+// startAnalysisLongPoll starts an analysis in polling mode and returns a stream that
+// reads its events with GetAnalysisEvents.
+func (c *RawClient) startAnalysisLongPoll(ctx context.Context, payload []byte, opts ...CallOption) (*DataAnalysisStream, error) {
+	var started struct {
+		RequestID string `json:"request_id"`
+	}
+	if err := c.postJSON(ctx, "/byoa/api/v1/data_asking/analyze?mode=poll", json.RawMessage(payload), &started, opts...); err != nil {
+		return nil, err
+	}
+	if started.RequestID == "" {
+		return nil, fmt.Errorf("long-poll analysis returned no request_id")
+	}
+	pollCtx, cancel := context.WithCancel(ctx)
 	return &DataAnalysisStream{
-		Body:              resp.Body,
-		Header:            resp.Header.Clone(),
-		StatusCode:        resp.StatusCode,
-		initialBufferSize: callOpts.streamBufferSize,
-		readTimeout:       callOpts.streamReadTimeout,
+		Body:       &analysisPollReader{ctx: pollCtx, cancel: cancel, client: c, requestID: started.RequestID, opts: opts},
+		Header:     make(http.Header),
+		StatusCode: http.StatusOK,
+		longPoll:   true,
+		requestID:  started.RequestID,
 	}, nil
 }
 
+// analysisPollReader polls the events of an analysis and renders them in SSE format,
+// so that DataAnalysisStream reads them like a live event stream.
+type analysisPollReader struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	client    *RawClient
+	requestID string
+	opts      []CallOption
+	seq       int64
+	done      bool
+	buf       bytes.Buffer
+}
+
+func (r *analysisPollReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		resp, err := r.client.GetAnalysisEvents(r.ctx, r.requestID, r.seq, r.opts...)
+		if err != nil {
+			return 0, err
+		}
+		for _, e := range resp.Events {
+			data := bytes.Buffer{}
+			if len(e.Data) == 0 || json.Compact(&data, e.Data) != nil {
+				data.Reset()
+				data.WriteString("{}")
+			}
+			fmt.Fprintf(&r.buf, "id: %d\n", e.Seq)
+			if e.Event != "" {
+				fmt.Fprintf(&r.buf, "event: %s\n", e.Event)
+			}
+			fmt.Fprintf(&r.buf, "data: %s\n\n", data.Bytes())
+		}
+		r.seq = resp.NextSeq
+		r.done = resp.Done
+		if len(resp.Events) == 0 && !r.done {
+			if err := sleepWithContext(r.ctx, analysisLongPollInterval); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return r.buf.Read(p)
+}
+
+func (r *analysisPollReader) Close() error {
+	r.cancel()
+	return nil
+}
+
 // CancelAnalyze cancels an ongoing data analysis request.
 //
 // This method sends a POST request to /byoa/api/v1/data_asking/cancel to cancel
@@ -429,3 +884,134 @@ func (c *RawClient) CancelAnalyze(ctx context.Context, req *CancelAnalyzeRequest
 	}
 	return &resp, nil
 }
+
+// ListAnalyzeRequests lists past data analysis requests, most recent first.
+//
+// Requests remain listed after their stream has closed, so that their questions,
+// status and final answers can be audited.
+//
+// Example:
+//
+//	resp, err := client.ListAnalyzeRequests(ctx, &sdk.AnalyzeRequestListRequest{
+//		Status:    sdk.AnalyzeRequestStatusCancelled,
+//		StartTime: time.Now().AddDate(0, 0, -7).Unix(),
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, r := range resp.Requests {
+//		fmt.Printf("%s %s: %s\n", r.RequestID, r.Status, r.Question)
+//	}
+func (c *RawClient) ListAnalyzeRequests(ctx context.Context, req *AnalyzeRequestListRequest, opts ...CallOption) (*AnalyzeRequestListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	c.applyPageDefaults(req)
+
+	query := url.Values{}
+	if req.SessionID != "" {
+		query.Set("session_id", req.SessionID)
+	}
+	if req.UserID != "" {
+		query.Set("user_id", req.UserID)
+	}
+	if req.Status != "" {
+		query.Set("status", string(req.Status))
+	}
+	if req.Keyword != "" {
+		query.Set("keyword", req.Keyword)
+	}
+	if req.StartTime > 0 {
+		query.Set("start_time", strconv.FormatInt(req.StartTime, 10))
+	}
+	if req.EndTime > 0 {
+		query.Set("end_time", strconv.FormatInt(req.EndTime, 10))
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	path := "/byoa/api/v1/data_asking/requests"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp := AnalyzeRequestListResponse{Requests: []AnalyzeRequestInfo{}}
+	if err := c.getJSON(ctx, path, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Requests == nil {
+		resp.Requests = []AnalyzeRequestInfo{}
+	}
+	return &resp, nil
+}
+
+// GetAnalyzeRequest returns a past data analysis request, including its status and
+// final answer.
+//
+// Example:
+//
+//	info, err := client.GetAnalyzeRequest(ctx, "request-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%s: %s\n", info.Status, info.Answer)
+func (c *RawClient) GetAnalyzeRequest(ctx context.Context, requestID string, opts ...CallOption) (*AnalyzeRequestInfo, error) {
+	if strings.TrimSpace(requestID) == "" {
+		return nil, fmt.Errorf("request_id cannot be empty")
+	}
+
+	var resp AnalyzeRequestInfo
+	if err := c.getJSON(ctx, "/byoa/api/v1/data_asking/requests/"+url.PathEscape(requestID), &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.RequestID == "" {
+		resp.RequestID = requestID
+	}
+	return &resp, nil
+}
+
+// SubmitAnalysisFeedback sends a user's rating of a data analysis answer, optionally
+// with a comment and the SQL that answers the question correctly.
+//
+// Feedback with CorrectSQL and SaveAsKnowledge set is also stored as an NL2SQL
+// knowledge entry, whose ID is returned.
+//
+// Example:
+//
+//	resp, err := client.SubmitAnalysisFeedback(ctx, &sdk.AnalysisFeedbackRequest{
+//		RequestID:       result.RequestID,
+//		Rating:          sdk.AnalysisRatingDown,
+//		Comment:         "revenue must exclude refunds",
+//		CorrectSQL:      "select sum(amount) from revenue where type <> 'refund'",
+//		SaveAsKnowledge: true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("feedback %s, knowledge %d\n", resp.FeedbackID, resp.KnowledgeID)
+func (c *RawClient) SubmitAnalysisFeedback(ctx context.Context, req *AnalysisFeedbackRequest, opts ...CallOption) (*AnalysisFeedbackResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if strings.TrimSpace(req.RequestID) == "" {
+		return nil, fmt.Errorf("request_id cannot be empty")
+	}
+	if req.Rating != AnalysisRatingUp && req.Rating != AnalysisRatingDown {
+		return nil, fmt.Errorf("invalid rating %q: must be %q or %q", req.Rating, AnalysisRatingUp, AnalysisRatingDown)
+	}
+	if req.SaveAsKnowledge && strings.TrimSpace(req.CorrectSQL) == "" {
+		return nil, fmt.Errorf("correct_sql is required to save feedback as knowledge")
+	}
+
+	var resp AnalysisFeedbackResponse
+	if err := c.postJSON(ctx, "/byoa/api/v1/data_asking/feedback", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.RequestID == "" {
+		resp.RequestID = req.RequestID
+	}
+	return &resp, nil
+}
@@ -0,0 +1,234 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DataAskingSessionOptions configures a DataAskingSession.
+type DataAskingSessionOptions struct {
+	// Name is sent as the session name with the first question of a new session.
+	Name string
+
+	// Source identifies the calling application.
+	Source string
+
+	// Config is sent with every question of the session.
+	Config *DataAnalysisConfig
+}
+
+// DataAskingSession is a data asking conversation. It generates the session ID,
+// sends it with every question so that the service answers follow-up questions in
+// context, and records the turns of the conversation.
+//
+// A DataAskingSession is safe for concurrent use; questions are asked one at a time.
+type DataAskingSession struct {
+	client *RawClient
+	id     string
+	opts   DataAskingSessionOptions
+
+	askMu sync.Mutex // Serializes Ask so that turns are recorded in order
+
+	mu    sync.Mutex
+	title string
+	turns []DataAskingTurn
+}
+
+// NewDataAskingSession starts a new data asking conversation. No request is sent until
+// the first question is asked.
+//
+// Example:
+//
+//	session := client.NewDataAskingSession(sdk.DataAskingSessionOptions{
+//		Name:   "revenue review",
+//		Config: &sdk.DataAnalysisConfig{DataSource: &sdk.DataSource{Type: "all"}},
+//	})
+//	turn, err := session.Ask(ctx, "2024年收入是多少？")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(turn.Answer)
+//	turn, err = session.Ask(ctx, "和2023年相比呢？")
+func (c *RawClient) NewDataAskingSession(opts DataAskingSessionOptions) *DataAskingSession {
+	return &DataAskingSession{client: c, id: newRequestID(), opts: opts}
+}
+
+// ResumeDataAskingSession continues a previous data asking conversation, loading its
+// turns with GetDataAskingSessionHistory.
+//
+// Example:
+//
+//	sessions, err := client.ListDataAskingSessions(ctx, &sdk.DataAskingSessionListRequest{})
+//	if err != nil {
+//		return err
+//	}
+//	session, err := client.ResumeDataAskingSession(ctx, sessions.Sessions[0].SessionID, sdk.DataAskingSessionOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	turn, err := session.Ask(ctx, "按地区拆分一下")
+func (c *RawClient) ResumeDataAskingSession(ctx context.Context, sessionID string, sessionOpts DataAskingSessionOptions, opts ...CallOption) (*DataAskingSession, error) {
+	history, err := c.GetDataAskingSessionHistory(ctx, sessionID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &DataAskingSession{
+		client: c,
+		id:     sessionID,
+		opts:   sessionOpts,
+		title:  history.Title,
+		turns:  history.Turns,
+	}, nil
+}
+
+// ID returns the session ID.
+func (s *DataAskingSession) ID() string {
+	return s.id
+}
+
+// Title returns the session title generated by the service, or "" before the first
+// answer.
+func (s *DataAskingSession) Title() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.title
+}
+
+// Turns returns the turns of the conversation, oldest first.
+func (s *DataAskingSession) Turns() []DataAskingTurn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]DataAskingTurn(nil), s.turns...)
+}
+
+// Ask asks a question in the session and waits for the analysis to complete.
+//
+// The streamed events are aggregated into the returned turn, which is also appended
+// to Turns. If the analysis reports an error, the error is an *ErrorEvent and no turn
+// is recorded. Call options are passed to AnalyzeData.
+func (s *DataAskingSession) Ask(ctx context.Context, question string, opts ...CallOption) (*DataAskingTurn, error) {
+	s.askMu.Lock()
+	defer s.askMu.Unlock()
+
+	req := &DataAnalysisRequest{
+		Question:  question,
+		SessionID: &s.id,
+		Config:    s.opts.Config,
+	}
+	if s.opts.Source != "" {
+		req.Source = &s.opts.Source
+	}
+	if s.opts.Name != "" && len(s.Turns()) == 0 {
+		req.SessionName = &s.opts.Name
+	}
+
+	result, err := s.client.AnalyzeData(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	turn := DataAskingTurn{
+		Question:  question,
+		RequestID: result.RequestID,
+		Answer:    result.Answer,
+		CreatedAt: time.Now().Unix(),
+	}
+	if result.Classification != nil {
+		classification := result.Classification.QuestionType
+		turn.Classification = &classification
+	}
+	for _, step := range result.SQLSteps {
+		if step.SQL != "" {
+			turn.SQL = append(turn.SQL, step.SQL)
+		}
+	}
+
+	s.mu.Lock()
+	if result.SessionTitle != "" {
+		s.title = result.SessionTitle
+	}
+	s.turns = append(s.turns, turn)
+	s.mu.Unlock()
+	return &turn, nil
+}
+
+// ListDataAskingSessions lists the data asking sessions of the current user, most
+// recently used first.
+//
+// Example:
+//
+//	resp, err := client.ListDataAskingSessions(ctx, &sdk.DataAskingSessionListRequest{
+//		Keyword:  "revenue",
+//		PageSize: 20,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, s := range resp.Sessions {
+//		fmt.Printf("%s: %s (%d questions)\n", s.SessionID, s.Title, s.TurnCount)
+//	}
+func (c *RawClient) ListDataAskingSessions(ctx context.Context, req *DataAskingSessionListRequest, opts ...CallOption) (*DataAskingSessionListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	c.applyPageDefaults(req)
+
+	query := url.Values{}
+	if req.Keyword != "" {
+		query.Set("keyword", req.Keyword)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	path := "/byoa/api/v1/data_asking/sessions"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp := DataAskingSessionListResponse{Sessions: []DataAskingSessionInfo{}}
+	if err := c.getJSON(ctx, path, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Sessions == nil {
+		resp.Sessions = []DataAskingSessionInfo{}
+	}
+	return &resp, nil
+}
+
+// GetDataAskingSessionHistory returns the questions asked in a data asking session
+// and their answers, oldest first.
+//
+// Example:
+//
+//	history, err := client.GetDataAskingSessionHistory(ctx, "session-123")
+//	if err != nil {
+//		return err
+//	}
+//	for _, turn := range history.Turns {
+//		fmt.Printf("Q: %s\nA: %s\n", turn.Question, turn.Answer)
+//	}
+func (c *RawClient) GetDataAskingSessionHistory(ctx context.Context, sessionID string, opts ...CallOption) (*DataAskingSessionHistoryResponse, error) {
+	if strings.TrimSpace(sessionID) == "" {
+		return nil, fmt.Errorf("sessionID cannot be empty")
+	}
+
+	resp := DataAskingSessionHistoryResponse{Turns: []DataAskingTurn{}}
+	path := "/byoa/api/v1/data_asking/sessions/" + url.PathEscape(sessionID) + "/history"
+	if err := c.getJSON(ctx, path, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Turns == nil {
+		resp.Turns = []DataAskingTurn{}
+	}
+	if resp.SessionID == "" {
+		resp.SessionID = sessionID
+	}
+	return &resp, nil
+}
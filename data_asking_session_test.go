@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataAskingSession_Ask(t *testing.T) {
+	t.Parallel()
+
+	var requests []DataAnalysisRequest
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var req DataAnalysisRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+		sse := "event: init\ndata: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-" + req.Question + "\",\"session_title\":\"收入\"}}\n\n" +
+			"event: classification\ndata: {\"type\":\"classification\",\"data\":{\"type\":\"query\",\"confidence\":0.8}}\n\n" +
+			"data: {\"source\":\"nl2sql\",\"step_type\":\"sql_generated\",\"sql\":\"select sum(amount) from revenue\"}\n\n" +
+			"data: {\"source\":\"rag\",\"data\":{\"content\":\"收入\"}}\n\n" +
+			"data: {\"source\":\"rag\",\"data\":{\"content\":\"增长\"}}\n\n" +
+			"event: complete\ndata: {\"type\":\"complete\",\"data\":{}}\n\n"
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+			Body:       io.NopCloser(strings.NewReader(sse)),
+		}, nil
+	})
+
+	session := client.NewDataAskingSession(DataAskingSessionOptions{Name: "revenue"})
+	require.NotEmpty(t, session.ID())
+
+	turn, err := session.Ask(context.Background(), "q1")
+	require.NoError(t, err)
+	require.Equal(t, "req-q1", turn.RequestID)
+	require.Equal(t, "收入增长", turn.Answer)
+	require.Equal(t, []string{"select sum(amount) from revenue"}, turn.SQL)
+	require.Equal(t, "query", turn.Classification.Type)
+	require.Equal(t, "收入", session.Title())
+
+	_, err = session.Ask(context.Background(), "q2")
+	require.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	require.Equal(t, session.ID(), *requests[0].SessionID)
+	require.Equal(t, session.ID(), *requests[1].SessionID)
+	require.Equal(t, "revenue", *requests[0].SessionName)
+	require.Nil(t, requests[1].SessionName)
+
+	turns := session.Turns()
+	require.Len(t, turns, 2)
+	require.Equal(t, "q1", turns[0].Question)
+	require.Equal(t, "q2", turns[1].Question)
+}
+
+func TestResumeDataAskingSession(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/byoa/api/v1/data_asking/sessions":
+			require.Equal(t, "page=1&page_size=20", r.URL.RawQuery)
+			return envelopeResponse(`{"sessions":[{"session_id":"s-1","title":"收入","turn_count":1}],"total":1}`), nil
+		case "/byoa/api/v1/data_asking/sessions/s-1/history":
+			return envelopeResponse(`{"session_id":"s-1","title":"收入","turns":[{"question":"q1","request_id":"req-1","answer":"a1"}]}`), nil
+		}
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL)
+		return nil, nil
+	})
+	ctx := context.Background()
+
+	list, err := client.ListDataAskingSessions(ctx, &DataAskingSessionListRequest{Page: 1, PageSize: 20})
+	require.NoError(t, err)
+	require.Len(t, list.Sessions, 1)
+
+	session, err := client.ResumeDataAskingSession(ctx, list.Sessions[0].SessionID, DataAskingSessionOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "s-1", session.ID())
+	require.Equal(t, "收入", session.Title())
+	require.Equal(t, []DataAskingTurn{{Question: "q1", RequestID: "req-1", Answer: "a1"}}, session.Turns())
+
+	_, err = client.GetDataAskingSessionHistory(ctx, " ")
+	require.Error(t, err)
+}
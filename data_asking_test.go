@@ -611,6 +611,309 @@ func TestDataAnalysisStream_ReadEvent_EmptyLines(t *testing.T) {
 	require.NoError(t, stream.Close())
 }
 
+func TestDataAnalysisStreamEvent_Typed(t *testing.T) {
+	t.Parallel()
+
+	sseData := "event: init\ndata: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-123\",\"session_title\":\"收入\"}}\n\n" +
+		"event: classification\ndata: {\"type\":\"classification\",\"data\":{\"type\":\"attribution\",\"confidence\":0.9}}\n\n" +
+		"data: {\"source\":\"rag\",\"data\":{\"content\":\"收入下降\"}}\n\n" +
+		"data: {\"source\":\"nl2sql\",\"step_type\":\"sql_generated\",\"step_name\":\"生成SQL\",\"sql\":\"select 1\"}\n\n" +
+		"event: error\ndata: {\"type\":\"error\",\"data\":{\"code\":\"ErrTimeout\",\"message\":\"llm timeout\"}}\n\n" +
+		"event: heartbeat\ndata: {}\n\n"
+	stream := &DataAnalysisStream{
+		Body:       io.NopCloser(strings.NewReader(sseData)),
+		Header:     make(http.Header),
+		StatusCode: 200,
+	}
+
+	var typed []AnalysisEvent
+	for {
+		event, err := stream.ReadEvent()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		ev, err := event.Typed()
+		require.NoError(t, err)
+		typed = append(typed, ev)
+	}
+	require.Len(t, typed, 6)
+	require.Equal(t, &InitEvent{RequestID: "req-123", SessionTitle: "收入"}, typed[0])
+	require.Equal(t, "attribution", typed[1].(*ClassificationEvent).Type)
+	require.Equal(t, &AnswerChunkEvent{Content: "收入下降"}, typed[2])
+	require.Equal(t, &SQLStepEvent{StepType: "sql_generated", StepName: "生成SQL", SQL: "select 1"}, typed[3])
+	require.EqualError(t, typed[4].(*ErrorEvent), "data analysis failed: ErrTimeout: llm timeout")
+	require.Nil(t, typed[5])
+
+	event := &DataAnalysisStreamEvent{Type: "complete", RawData: json.RawMessage(`{"type":"complete","data":{"answer":"done"}}`)}
+	var complete CompleteEvent
+	require.True(t, event.As(&complete))
+	require.Equal(t, "done", complete.Answer)
+	require.False(t, event.As(&ErrorEvent{}))
+}
+
+func TestDataAnalysisStream_EventsAndAll(t *testing.T) {
+	t.Parallel()
+
+	sseData := "event: init\ndata: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-123\"}}\n\n" +
+		"event: complete\ndata: {\"type\":\"complete\"}\n\n"
+	newStream := func(body io.ReadCloser) *DataAnalysisStream {
+		return &DataAnalysisStream{Body: body, Header: make(http.Header), StatusCode: 200}
+	}
+
+	stream := newStream(io.NopCloser(strings.NewReader(sseData)))
+	var kinds []string
+	for event := range stream.Events(context.Background()) {
+		kinds = append(kinds, event.Kind())
+	}
+	require.Equal(t, []string{"init", "complete"}, kinds)
+	require.NoError(t, stream.Err())
+
+	stream = newStream(io.NopCloser(strings.NewReader(sseData)))
+	kinds = nil
+	for event, err := range stream.All() {
+		require.NoError(t, err)
+		kinds = append(kinds, event.Kind())
+		break
+	}
+	require.Equal(t, []string{"init"}, kinds)
+
+	// Cancelling the context closes a stream that is waiting for data
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	stream = newStream(pr)
+	ctx, cancel := context.WithCancel(context.Background())
+	events := stream.Events(ctx)
+	cancel()
+	for range events {
+	}
+	require.ErrorIs(t, stream.Err(), context.Canceled)
+}
+
+// droppedStream returns data and then fails as if the connection dropped.
+type droppedStream struct {
+	data *strings.Reader
+}
+
+func (r *droppedStream) Read(p []byte) (int, error) {
+	if r.data.Len() == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return r.data.Read(p)
+}
+
+func (r *droppedStream) Close() error { return nil }
+
+func TestAnalyzeDataStream_AutoReconnect(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		header := http.Header{"Content-Type": []string{"text/event-stream"}}
+		if calls == 1 {
+			require.Empty(t, r.Header.Get("Last-Event-ID"))
+			body := &droppedStream{data: strings.NewReader("id: 1\nevent: init\ndata: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-123\"}}\n\nid: 2\ndata: {\"type\":")}
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: body}, nil
+		}
+		require.Equal(t, "1", r.Header.Get("Last-Event-ID"))
+		require.Equal(t, "req-123", r.URL.Query().Get("request_id"))
+		body := io.NopCloser(strings.NewReader("id: 2\nevent: complete\ndata: {\"type\":\"complete\"}\n\n"))
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: body}, nil
+	})
+
+	stream, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "why"},
+		WithStreamAutoReconnect(2, time.Millisecond))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	var kinds []string
+	for event, err := range stream.All() {
+		require.NoError(t, err)
+		kinds = append(kinds, event.Kind())
+	}
+	require.Equal(t, []string{"init", "complete"}, kinds)
+	require.Equal(t, 2, calls)
+
+	// Without the option the interruption is reported
+	calls = 0
+	stream, err = client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "why"})
+	require.NoError(t, err)
+	defer stream.Close()
+	_, err = stream.ReadEvent()
+	require.NoError(t, err)
+	_, err = stream.ReadEvent()
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+func TestAnalyzeDataStream_LongPollFallback(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Path == "/byoa/api/v1/data_asking/analyze" && r.URL.Query().Get("mode") == "":
+			// A proxy buffered the stream and rewrote the content type
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": []string{"text/html"}}, Body: io.NopCloser(strings.NewReader("<html>"))}, nil
+		case r.URL.Path == "/byoa/api/v1/data_asking/analyze":
+			require.Equal(t, "poll", r.URL.Query().Get("mode"))
+			return envelopeResponse(`{"request_id":"req-1"}`), nil
+		case r.URL.Path == "/byoa/api/v1/data_asking/events" && r.URL.Query().Get("after_seq") == "0":
+			require.Equal(t, "req-1", r.URL.Query().Get("request_id"))
+			return envelopeResponse(`{"events":[
+				{"seq":1,"event":"init","data":{"step_type":"init","data":{"request_id":"req-1"}}},
+				{"seq":2,"event":"classification","data":{"type":"classification"}}],"next_seq":2}`), nil
+		case r.URL.Path == "/byoa/api/v1/data_asking/events":
+			require.Equal(t, "2", r.URL.Query().Get("after_seq"))
+			return envelopeResponse(`{"events":[{"seq":3,"event":"complete","data":{"type":"complete"}}],"done":true}`), nil
+		}
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL)
+		return nil, nil
+	})
+
+	_, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "why"})
+	require.ErrorContains(t, err, "unexpected content type: text/html")
+
+	stream, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "why"}, WithLongPollFallback())
+	require.NoError(t, err)
+	defer stream.Close()
+	require.True(t, stream.LongPolling())
+
+	var kinds, ids []string
+	for event, err := range stream.All() {
+		require.NoError(t, err)
+		kinds = append(kinds, event.Kind())
+		ids = append(ids, event.ID)
+	}
+	require.Equal(t, []string{"init", "classification", "complete"}, kinds)
+	require.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func TestAnalyzeData_CollectsResult(t *testing.T) {
+	t.Parallel()
+
+	sse := "event: init\ndata: {\"step_type\":\"init\",\"data\":{\"request_id\":\"req-1\",\"session_title\":\"收入\"}}\n\n" +
+		"event: classification\ndata: {\"type\":\"classification\",\"data\":{\"type\":\"query\",\"confidence\":0.9}}\n\n" +
+		"data: {\"source\":\"nl2sql\",\"step_type\":\"sql_generated\",\"step_name\":\"generate\",\"sql\":\"select 1\"}\n\n" +
+		"data: {\"source\":\"rag\",\"data\":{\"content\":\"收入\",\"chunks\":[{\"id\":\"c1\"}]}}\n\n" +
+		"data: {\"source\":\"rag\",\"data\":{\"content\":\"增长\"}}\n\n" +
+		"event: complete\ndata: {\"type\":\"complete\",\"data\":{\"summary\":\"done\"}}\n\n"
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+			Body:       io.NopCloser(strings.NewReader(sse)),
+		}, nil
+	})
+
+	var kinds []string
+	result, err := client.AnalyzeData(context.Background(), &DataAnalysisRequest{Question: "why"},
+		WithAnalysisProgress(func(ev AnalysisEvent) {
+			kinds = append(kinds, ev.analysisEventKind())
+		}))
+	require.NoError(t, err)
+	require.Equal(t, "req-1", result.RequestID)
+	require.Equal(t, "收入", result.SessionTitle)
+	require.Equal(t, "query", result.Classification.Type)
+	require.Equal(t, "收入增长", result.Answer)
+	require.Equal(t, "done", result.Summary)
+	require.Len(t, result.SQLSteps, 1)
+	require.Equal(t, "select 1", result.SQLSteps[0].SQL)
+	require.Equal(t, "generate", result.SQLSteps[0].StepName)
+	require.Len(t, result.Chunks, 1)
+	require.JSONEq(t, `[{"id":"c1"}]`, string(result.Chunks[0]))
+	require.Equal(t, []string{"init", "classification", "sql_step", "answer_chunk", "answer_chunk", "complete"}, kinds)
+}
+
+func TestAnalyzeData_ErrorEvent(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		sse := "event: error\ndata: {\"type\":\"error\",\"data\":{\"code\":\"E1\",\"message\":\"no data\"}}\n\n"
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+			Body:       io.NopCloser(strings.NewReader(sse)),
+		}, nil
+	})
+
+	_, err := client.AnalyzeData(context.Background(), &DataAnalysisRequest{Question: "why"})
+	var evErr *ErrorEvent
+	require.ErrorAs(t, err, &evErr)
+	require.Equal(t, "E1", evErr.Code)
+}
+
+func TestListAndGetAnalyzeRequests(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case "/byoa/api/v1/data_asking/requests":
+			require.Equal(t, "page=2&page_size=10&start_time=1700000000&status=cancelled", r.URL.RawQuery)
+			return envelopeResponse(`{"requests":[{"request_id":"req-1","question":"why","status":"cancelled"}],"total":11}`), nil
+		case "/byoa/api/v1/data_asking/requests/req-2":
+			return envelopeResponse(`{"request_id":"req-2","status":"completed","answer":"because","sql":["select 1"]}`), nil
+		}
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL)
+		return nil, nil
+	})
+	ctx := context.Background()
+
+	list, err := client.ListAnalyzeRequests(ctx, &AnalyzeRequestListRequest{
+		Status:    AnalyzeRequestStatusCancelled,
+		StartTime: 1700000000,
+		Page:      2,
+		PageSize:  10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(11), list.Total)
+	require.Len(t, list.Requests, 1)
+	require.Equal(t, AnalyzeRequestStatusCancelled, list.Requests[0].Status)
+
+	info, err := client.GetAnalyzeRequest(ctx, "req-2")
+	require.NoError(t, err)
+	require.Equal(t, AnalyzeRequestStatusCompleted, info.Status)
+	require.Equal(t, "because", info.Answer)
+	require.Equal(t, []string{"select 1"}, info.SQL)
+
+	_, err = client.ListAnalyzeRequests(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.GetAnalyzeRequest(ctx, "")
+	require.Error(t, err)
+}
+
+func TestSubmitAnalysisFeedback(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/byoa/api/v1/data_asking/feedback", r.URL.Path)
+		var req AnalysisFeedbackRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "req-1", req.RequestID)
+		require.Equal(t, AnalysisRatingDown, req.Rating)
+		require.Equal(t, "select 2", req.CorrectSQL)
+		require.True(t, req.SaveAsKnowledge)
+		return envelopeResponse(`{"feedback_id":"fb-1","knowledge_id":7}`), nil
+	})
+	ctx := context.Background()
+
+	resp, err := client.SubmitAnalysisFeedback(ctx, &AnalysisFeedbackRequest{
+		RequestID:       "req-1",
+		Rating:          AnalysisRatingDown,
+		CorrectSQL:      "select 2",
+		SaveAsKnowledge: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "fb-1", resp.FeedbackID)
+	require.Equal(t, "req-1", resp.RequestID)
+	require.Equal(t, Nl2SqlKnowledgeID(7), resp.KnowledgeID)
+
+	_, err = client.SubmitAnalysisFeedback(ctx, &AnalysisFeedbackRequest{RequestID: "req-1", Rating: "meh"})
+	require.ErrorContains(t, err, "invalid rating")
+	_, err = client.SubmitAnalysisFeedback(ctx, &AnalysisFeedbackRequest{RequestID: "req-1", Rating: AnalysisRatingUp, SaveAsKnowledge: true})
+	require.ErrorContains(t, err, "correct_sql")
+}
+
 func TestWithStreamBufferSize_Option(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,149 @@
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// orgCodePattern is the format of the organizational codes listed by a DataScope.
+var orgCodePattern = regexp.MustCompile(`^[0-9A-Za-z_.-]+$`)
+
+// Validate reports whether the scope is well formed. The service treats a malformed
+// scope as matching no organization, so analysis questions asked with it silently
+// return empty results; AnalyzeDataStream and AnalyzeData check the scope with
+// Validate before sending the request. A nil scope is valid.
+func (s *DataScope) Validate() error {
+	if s == nil {
+		return nil
+	}
+	switch s.Type {
+	case DataScopeAll:
+		if len(s.CodeGroup) > 0 {
+			return fmt.Errorf("code_group must be empty when type is %q", DataScopeAll)
+		}
+		return nil
+	case DataScopeSpecified:
+	default:
+		return fmt.Errorf("type must be %q or %q, got %q", DataScopeAll, DataScopeSpecified, s.Type)
+	}
+
+	if s.CodeType == nil {
+		return fmt.Errorf("code_type is required when type is %q", DataScopeSpecified)
+	}
+	if codeType := DataScopeCodeType(*s.CodeType); codeType != CodeTypeCompany && codeType != CodeTypeBusinessUnit {
+		return fmt.Errorf("unknown code_type %d", *s.CodeType)
+	}
+	if len(s.CodeGroup) == 0 {
+		return fmt.Errorf("code_group cannot be empty when type is %q", DataScopeSpecified)
+	}
+	seen := make(map[string]bool)
+	for i, group := range s.CodeGroup {
+		if group.Code == "" && group.Name == "" {
+			return fmt.Errorf("code_group[%d]: code or name is required", i)
+		}
+		if group.Code != "" && !orgCodePattern.MatchString(group.Code) {
+			return fmt.Errorf("code_group[%d]: invalid code %q", i, group.Code)
+		}
+		if len(group.Values) == 0 {
+			return fmt.Errorf("code_group[%d]: values cannot be empty", i)
+		}
+		for _, value := range group.Values {
+			if !orgCodePattern.MatchString(value) {
+				return fmt.Errorf("code_group[%d]: invalid value %q", i, value)
+			}
+			if seen[value] {
+				return fmt.Errorf("code_group[%d]: duplicate value %q", i, value)
+			}
+			seen[value] = true
+		}
+	}
+	return nil
+}
+
+// DataScopeBuilder builds a DataScope limited to a set of organizations. Create one
+// with NewDataScope.
+type DataScopeBuilder struct {
+	codeType DataScopeCodeType
+	groups   []CodeGroup
+}
+
+// NewDataScope starts a scope listing organizations by codes of codeType.
+//
+// Example:
+//
+//	scope, err := sdk.NewDataScope(sdk.CodeTypeBusinessUnit).
+//		Group("1001", "East", "100101", "100102").
+//		Group("1002", "West", "1002").
+//		Build()
+//	if err != nil {
+//		return err
+//	}
+//	req.Config.DataScope = scope
+func NewDataScope(codeType DataScopeCodeType) *DataScopeBuilder {
+	return &DataScopeBuilder{codeType: codeType}
+}
+
+// Group adds the organizations values under the parent code, shown as name.
+func (b *DataScopeBuilder) Group(code, name string, values ...string) *DataScopeBuilder {
+	b.groups = append(b.groups, CodeGroup{Code: code, Name: name, Values: values})
+	return b
+}
+
+// Build returns the scope, or an error if it is malformed (see DataScope.Validate).
+func (b *DataScopeBuilder) Build() (*DataScope, error) {
+	codeType := int(b.codeType)
+	scope := &DataScope{
+		Type:      DataScopeSpecified,
+		CodeType:  &codeType,
+		CodeGroup: b.groups,
+	}
+	if err := scope.Validate(); err != nil {
+		return nil, err
+	}
+	return scope, nil
+}
+
+// DataScopeFromCodes builds a scope from a flat list of organizational codes, such as
+// the codes a user is entitled to.
+//
+// Codes are hierarchical: the code of a child organization starts with the code of its
+// parent. Each code that has no ancestor in the list starts a group, and the listed
+// descendants are added to the group of their topmost listed ancestor. Surrounding
+// whitespace is trimmed and duplicates are ignored.
+//
+// Example:
+//
+//	scope, err := sdk.DataScopeFromCodes(sdk.CodeTypeCompany, []string{"1001", "100101", "1002"})
+//	// Groups: 1001 [1001 100101], 1002 [1002]
+func DataScopeFromCodes(codeType DataScopeCodeType, codes []string) (*DataScope, error) {
+	unique := make(map[string]bool, len(codes))
+	sorted := make([]string, 0, len(codes))
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			return nil, fmt.Errorf("codes cannot contain empty values")
+		}
+		if !unique[code] {
+			unique[code] = true
+			sorted = append(sorted, code)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil, fmt.Errorf("codes cannot be empty")
+	}
+	// After sorting, every code follows its ancestors, and the descendants of a code
+	// come right after it.
+	sort.Strings(sorted)
+
+	builder := NewDataScope(codeType)
+	for _, code := range sorted {
+		if n := len(builder.groups); n > 0 && strings.HasPrefix(code, builder.groups[n-1].Code) {
+			builder.groups[n-1].Values = append(builder.groups[n-1].Values, code)
+			continue
+		}
+		builder.Group(code, code, code)
+	}
+	return builder.Build()
+}
@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataScopeValidate(t *testing.T) {
+	t.Parallel()
+
+	company, unknown := int(CodeTypeCompany), 7
+	group := []CodeGroup{{Name: "1001", Values: []string{"100101"}}}
+	tests := []struct {
+		name    string
+		scope   *DataScope
+		wantErr string
+	}{
+		{"Nil", nil, ""},
+		{"All", &DataScope{Type: DataScopeAll}, ""},
+		{"Specified", &DataScope{Type: DataScopeSpecified, CodeType: &company, CodeGroup: group}, ""},
+		{"MissingType", &DataScope{CodeType: &company, CodeGroup: group}, "type must be"},
+		{"AllWithGroups", &DataScope{Type: DataScopeAll, CodeGroup: group}, "code_group must be empty"},
+		{"MissingCodeType", &DataScope{Type: DataScopeSpecified, CodeGroup: group}, "code_type is required"},
+		{"UnknownCodeType", &DataScope{Type: DataScopeSpecified, CodeType: &unknown, CodeGroup: group}, "unknown code_type 7"},
+		{"NoGroups", &DataScope{Type: DataScopeSpecified, CodeType: &company}, "code_group cannot be empty"},
+		{"EmptyValues", &DataScope{Type: DataScopeSpecified, CodeType: &company,
+			CodeGroup: []CodeGroup{{Code: "1001"}}}, "values cannot be empty"},
+		{"InvalidValue", &DataScope{Type: DataScopeSpecified, CodeType: &company,
+			CodeGroup: []CodeGroup{{Code: "1001", Values: []string{"1001 01"}}}}, `invalid value "1001 01"`},
+		{"DuplicateValue", &DataScope{Type: DataScopeSpecified, CodeType: &company,
+			CodeGroup: []CodeGroup{{Code: "1", Values: []string{"10"}}, {Code: "2", Values: []string{"10"}}}}, "duplicate value"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.scope.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestDataScopeBuilders(t *testing.T) {
+	t.Parallel()
+
+	scope, err := NewDataScope(CodeTypeBusinessUnit).Group("1001", "East", "100101", "100102").Build()
+	require.NoError(t, err)
+	require.Equal(t, DataScopeSpecified, scope.Type)
+	require.Equal(t, int(CodeTypeBusinessUnit), *scope.CodeType)
+	require.Equal(t, []CodeGroup{{Code: "1001", Name: "East", Values: []string{"100101", "100102"}}}, scope.CodeGroup)
+
+	_, err = NewDataScope(CodeTypeCompany).Build()
+	require.ErrorContains(t, err, "code_group cannot be empty")
+
+	scope, err = DataScopeFromCodes(CodeTypeCompany, []string{"100201", "1001", " 100101 ", "1002", "2", "1001"})
+	require.NoError(t, err)
+	require.Equal(t, []CodeGroup{
+		{Code: "1001", Name: "1001", Values: []string{"1001", "100101"}},
+		{Code: "1002", Name: "1002", Values: []string{"1002", "100201"}},
+		{Code: "2", Name: "2", Values: []string{"2"}},
+	}, scope.CodeGroup)
+
+	_, err = DataScopeFromCodes(CodeTypeCompany, nil)
+	require.ErrorContains(t, err, "codes cannot be empty")
+	_, err = DataScopeFromCodes(CodeTypeCompany, []string{"1001", " "})
+	require.ErrorContains(t, err, "empty values")
+}
+
+func TestAnalyzeDataStream_RejectsInvalidDataScope(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	})
+	_, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{
+		Question: "q",
+		Config:   &DataAnalysisConfig{DataScope: &DataScope{Type: DataScopeSpecified}},
+	})
+	require.ErrorContains(t, err, "invalid data scope: code_type is required")
+}
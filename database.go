@@ -164,3 +164,27 @@ func (c *RawClient) GetDatabaseRefList(ctx context.Context, req *DatabaseRefList
 	}
 	return &resp, nil
 }
+
+// GetDatabaseStats retrieves the storage usage of a database.
+//
+// The response breaks the table figures down by table.
+//
+// Example:
+//
+//	stats, err := client.GetDatabaseStats(ctx, &sdk.DatabaseStatsRequest{DatabaseID: 456})
+//	if err != nil {
+//		return err
+//	}
+//	for _, table := range stats.Tables {
+//		fmt.Printf("%s: %d rows, %d bytes\n", table.Name, table.RowCount, table.SizeBytes)
+//	}
+func (c *RawClient) GetDatabaseStats(ctx context.Context, req *DatabaseStatsRequest, opts ...CallOption) (*DatabaseStatsResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp DatabaseStatsResponse
+	if err := c.postJSON(ctx, "/catalog/database/stats", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
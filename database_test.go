@@ -91,6 +91,7 @@ func TestDatabaseNilRequestErrors(t *testing.T) {
 		{"List", func() error { _, err := client.ListDatabases(ctx, nil); return err }},
 		{"Children", func() error { _, err := client.GetDatabaseChildren(ctx, nil); return err }},
 		{"RefList", func() error { _, err := client.GetDatabaseRefList(ctx, nil); return err }},
+		{"Stats", func() error { _, err := client.GetDatabaseStats(ctx, nil); return err }},
 	}
 
 	for _, tc := range tests {
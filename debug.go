@@ -0,0 +1,169 @@
+package sdk
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	maxRecentErrors       = 20
+	maxRecentErrorMessage = 256
+)
+
+// DebugInfo is the document served by DebugHandler.
+type DebugInfo struct {
+	Config         DebugConfig  `json:"config"`
+	ConnectionPool DebugPool    `json:"connection_pool"`
+	Stats          ClientStats  `json:"stats"`
+	RecentErrors   []DebugError `json:"recent_errors"` // Most recent first
+}
+
+// DebugConfig describes the configuration of a client. Secrets are redacted.
+type DebugConfig struct {
+	BaseURL         string              `json:"base_url"`
+	LLMProxyBaseURL string              `json:"llm_proxy_base_url,omitempty"`
+	APIKey          string              `json:"api_key"`
+	UserAgent       string              `json:"user_agent"`
+	Timeout         time.Duration       `json:"timeout"`
+	DefaultHeaders  map[string][]string `json:"default_headers,omitempty"`
+}
+
+// DebugPool describes the connection pool settings of the client's transport and the
+// number of requests waiting for a response. The pool settings are only known when
+// the transport is an *http.Transport.
+type DebugPool struct {
+	Transport           string        `json:"transport"`
+	InFlight            int64         `json:"in_flight"`
+	MaxIdleConns        int           `json:"max_idle_conns,omitempty"`
+	MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host,omitempty"`
+	MaxConnsPerHost     int           `json:"max_conns_per_host,omitempty"`
+	IdleConnTimeout     time.Duration `json:"idle_conn_timeout,omitempty"`
+}
+
+// DebugError describes a recent failed request.
+type DebugError struct {
+	Time       time.Time `json:"time"`
+	Endpoint   string    `json:"endpoint"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Code       string    `json:"code,omitempty"` // API error code, for error envelopes
+	Message    string    `json:"message"`
+	RequestID  string    `json:"request_id,omitempty"`
+}
+
+// DebugInfo returns the configuration, transport state, statistics and recent errors
+// of the client.
+func (c *RawClient) DebugInfo() DebugInfo {
+	info := DebugInfo{Stats: c.Stats(), RecentErrors: []DebugError{}}
+	if c == nil {
+		return info
+	}
+	info.Config = DebugConfig{
+		BaseURL:         c.baseURL,
+		LLMProxyBaseURL: c.llmProxyBaseURL,
+		APIKey:          redactSecret(c.currentAPIKey()),
+		UserAgent:       c.userAgent,
+		DefaultHeaders:  redactHeaders(c.defaultHeaders),
+	}
+	var transport http.RoundTripper
+	if c.httpClient != nil {
+		info.Config.Timeout = c.httpClient.Timeout
+		transport = c.httpClient.Transport
+	}
+	transport = baseTransport(transport)
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	info.ConnectionPool.Transport = fmt.Sprintf("%T", transport)
+	if t, ok := transport.(*http.Transport); ok {
+		info.ConnectionPool.MaxIdleConns = t.MaxIdleConns
+		info.ConnectionPool.MaxIdleConnsPerHost = t.MaxIdleConnsPerHost
+		info.ConnectionPool.MaxConnsPerHost = t.MaxConnsPerHost
+		info.ConnectionPool.IdleConnTimeout = t.IdleConnTimeout
+	}
+	if c.stats != nil {
+		info.ConnectionPool.InFlight = c.stats.inFlight.Load()
+		info.RecentErrors = c.stats.recentErrorsSnapshot()
+	}
+	return info
+}
+
+// DebugHandler returns an http.Handler that serves the DebugInfo of client as JSON.
+//
+// The handler is meant to be mounted on an internal debug endpoint of a service
+// embedding the SDK. The API key and sensitive default headers are redacted.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/debug/moi", sdk.DebugHandler(client))
+func DebugHandler(client *RawClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set(headerContentType, mimeJSON)
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(client.DebugInfo())
+	})
+}
+
+// DebugVar returns an expvar.Var that reports the DebugInfo of client, so that it can
+// be published next to the other variables served at /debug/vars.
+//
+// Example:
+//
+//	expvar.Publish("moi", sdk.DebugVar(client))
+func DebugVar(client *RawClient) expvar.Var {
+	return expvar.Func(func() interface{} { return client.DebugInfo() })
+}
+
+// redactSecret keeps only the last four characters of a secret.
+func redactSecret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	if len(secret) <= 8 {
+		return "****"
+	}
+	return "****" + secret[len(secret)-4:]
+}
+
+func redactHeaders(headers http.Header) map[string][]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		if !isSensitiveHeader(key) {
+			out[key] = append([]string(nil), values...)
+			continue
+		}
+		redacted := make([]string, len(values))
+		for i, v := range values {
+			redacted[i] = redactSecret(v)
+		}
+		out[key] = redacted
+	}
+	return out
+}
+
+// isSensitiveHeader reports whether a header may carry credentials.
+func isSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	if lower == "authorization" || lower == "proxy-authorization" || lower == "cookie" || lower == strings.ToLower(headerAPIKey) {
+		return true
+	}
+	for _, word := range []string{"key", "token", "secret", "password", "signature"} {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugHandler(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewRawClient("https://moi.test", "secret-api-key-1234",
+		WithDefaultHeader("X-Tenant", "acme"),
+		WithDefaultHeader("X-Auth-Token", "token-abcdefgh"),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Path == "/catalog/file/info" {
+				return errorEnvelopeResponse("ErrNotFound", "file not found"), nil
+			}
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Body: io.NopCloser(strings.NewReader(""))}, nil
+		})}),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+	_, _ = client.GetFile(ctx, &FileInfoRequest{FileID: "f1"})
+	_, _ = client.DeleteFile(ctx, &FileDeleteRequest{FileID: "f1"})
+
+	rec := httptest.NewRecorder()
+	DebugHandler(client).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/moi", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "secret-api-key")
+	require.NotContains(t, rec.Body.String(), "token-abcdefgh")
+
+	var info DebugInfo
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	require.Equal(t, "https://moi.test", info.Config.BaseURL)
+	require.Equal(t, "****1234", info.Config.APIKey)
+	require.Equal(t, []string{"acme"}, info.Config.DefaultHeaders["X-Tenant"])
+	require.Equal(t, []string{"****efgh"}, info.Config.DefaultHeaders["X-Auth-Token"])
+	require.Equal(t, "sdk.roundTripperFunc", info.ConnectionPool.Transport)
+	require.EqualValues(t, 2, info.Stats.Requests)
+
+	require.Len(t, info.RecentErrors, 2)
+	require.Equal(t, "/catalog/file/delete", info.RecentErrors[0].Endpoint)
+	require.Equal(t, http.StatusServiceUnavailable, info.RecentErrors[0].StatusCode)
+	require.Equal(t, "ErrNotFound", info.RecentErrors[1].Code)
+	require.Equal(t, "file not found", info.RecentErrors[1].Message)
+
+	rec = httptest.NewRecorder()
+	DebugHandler(client).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/moi", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestRecentErrorsRingBuffer(t *testing.T) {
+	t.Parallel()
+
+	stats := newClientStats("https://moi.test")
+	for i := 0; i < maxRecentErrors+5; i++ {
+		stats.recordRecentError(DebugError{Endpoint: "/e", StatusCode: i})
+	}
+	recent := stats.recentErrorsSnapshot()
+	require.Len(t, recent, maxRecentErrors)
+	require.Equal(t, maxRecentErrors+4, recent[0].StatusCode)
+	require.Equal(t, 5, recent[len(recent)-1].StatusCode)
+}
@@ -0,0 +1,50 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptionNilRequestErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Set", func() error { _, err := client.SetVolumeEncryption(ctx, nil); return err }},
+		{"Info", func() error { _, err := client.GetVolumeEncryption(ctx, nil); return err }},
+		{"Rotate", func() error { _, err := client.RotateVolumeEncryptionKey(ctx, nil); return err }},
+		{"RotationStatus", func() error { _, err := client.GetKeyRotationStatus(ctx, nil); return err }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, tc.call(), ErrNilRequest)
+		})
+	}
+}
+
+func TestGetVolumeEncryption(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/volume/encryption/info", r.URL.Path)
+		var req VolumeEncryptionInfoRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, VolumeID("v1"), req.VolumeID)
+		return envelopeResponse(`{"volume_id":"v1","mode":"customer_managed","key_ref":"key/1","status":"rotating","rotation_job_id":"job-1"}`), nil
+	})
+
+	info, err := client.GetVolumeEncryption(context.Background(), &VolumeEncryptionInfoRequest{VolumeID: "v1"})
+	require.NoError(t, err)
+	require.Equal(t, EncryptionModeCustomerManaged, info.Mode)
+	require.Equal(t, EncryptionStatusRotating, info.Status)
+	require.Equal(t, "job-1", info.RotationJobID)
+}
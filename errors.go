@@ -1,8 +1,13 @@
 package sdk
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 )
 
 var (
@@ -22,8 +27,157 @@ var (
 	// All API methods require a non-nil request parameter. If you need to pass
 	// an empty request, use an empty struct literal (e.g., &CatalogListRequest{}).
 	ErrNilRequest = errors.New("sdk: request payload cannot be nil")
+
+	// ErrWaitTimeout indicates that a Wait* helper gave up before the awaited
+	// operation reached a terminal state. The concrete error is a *WaitTimeoutError.
+	ErrWaitTimeout = errors.New("sdk: timed out waiting for operation")
+
+	// ErrMaxPagesExceeded indicates that an auto-paginating helper stopped because the
+	// result spans more pages than the limit set with WithMaxPages.
+	ErrMaxPagesExceeded = errors.New("sdk: maximum number of pages exceeded")
 )
 
+// Sentinel errors classifying common API failures.
+//
+// APIError and HTTPError values match these sentinels through errors.Is, so
+// callers can branch on the failure category without inspecting server codes
+// or messages:
+//
+//	_, err := client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: 123})
+//	if errors.Is(err, sdk.ErrNotFound) {
+//		// create it
+//	}
+var (
+	// ErrNotFound indicates that the requested resource does not exist.
+	ErrNotFound = errors.New("sdk: resource not found")
+
+	// ErrAlreadyExists indicates that a resource with the same identity or name already exists.
+	ErrAlreadyExists = errors.New("sdk: resource already exists")
+
+	// ErrPermissionDenied indicates that the caller lacks the privilege or credentials for the operation.
+	ErrPermissionDenied = errors.New("sdk: permission denied")
+
+	// ErrQuotaExceeded indicates that a quota or rate limit was exceeded.
+	ErrQuotaExceeded = errors.New("sdk: quota exceeded")
+
+	// ErrInvalidArgument indicates that the request was rejected because of invalid parameters.
+	ErrInvalidArgument = errors.New("sdk: invalid argument")
+
+	// ErrLegalHold indicates that the operation was blocked because the object
+	// (or one of its ancestors) is under legal hold.
+	ErrLegalHold = errors.New("sdk: object is under legal hold")
+
+	// ErrResourceChanged indicates that a conditional update was rejected because the
+	// resource was modified after the expected version was read.
+	ErrResourceChanged = errors.New("sdk: resource was modified concurrently")
+
+	// ErrJobFailed indicates that an awaited job finished unsuccessfully. The concrete
+	// error is a *GenAIJobError or a *TableJobError.
+	ErrJobFailed = errors.New("sdk: job failed")
+
+	// ErrNameConflict indicates that a create or rename failed because the name is
+	// already taken. Errors matching ErrAlreadyExists also match ErrNameConflict.
+	ErrNameConflict = errors.New("sdk: name conflict")
+
+	// ErrChecksumMismatch indicates that transferred content does not match its
+	// expected MD5 or SHA-256 digest.
+	ErrChecksumMismatch = errors.New("sdk: checksum mismatch")
+)
+
+// apiErrorCodeKinds maps normalized server error codes to sentinel errors.
+// Codes are normalized with normalizeErrorCode before lookup.
+var apiErrorCodeKinds = map[string]error{
+	"notfound":          ErrNotFound,
+	"notexist":          ErrNotFound,
+	"notexists":         ErrNotFound,
+	"resourcenotfound":  ErrNotFound,
+	"alreadyexists":     ErrAlreadyExists,
+	"alreadyexist":      ErrAlreadyExists,
+	"duplicate":         ErrAlreadyExists,
+	"duplicatename":     ErrAlreadyExists,
+	"nameexists":        ErrAlreadyExists,
+	"conflict":          ErrAlreadyExists,
+	"permissiondenied":  ErrPermissionDenied,
+	"forbidden":         ErrPermissionDenied,
+	"unauthorized":      ErrPermissionDenied,
+	"nopermission":      ErrPermissionDenied,
+	"noprivilege":       ErrPermissionDenied,
+	"quotaexceeded":     ErrQuotaExceeded,
+	"limitexceeded":     ErrQuotaExceeded,
+	"ratelimit":         ErrQuotaExceeded,
+	"ratelimited":       ErrQuotaExceeded,
+	"toomanyrequests":   ErrQuotaExceeded,
+	"invalidargument":   ErrInvalidArgument,
+	"invalidparam":      ErrInvalidArgument,
+	"invalidparams":     ErrInvalidArgument,
+	"invalidparameter":  ErrInvalidArgument,
+	"invalidparameters": ErrInvalidArgument,
+	"invalidrequest":    ErrInvalidArgument,
+	"badrequest":        ErrInvalidArgument,
+	"validation":        ErrInvalidArgument,
+	"legalhold":         ErrLegalHold,
+	"underlegalhold":    ErrLegalHold,
+	"objectlocked":      ErrLegalHold,
+	"immutable":         ErrLegalHold,
+	"versionmismatch":   ErrResourceChanged,
+	"versionconflict":   ErrResourceChanged,
+	"staleversion":      ErrResourceChanged,
+	"resourcechanged":   ErrResourceChanged,
+	"checksummismatch":  ErrChecksumMismatch,
+	"badchecksum":       ErrChecksumMismatch,
+	"baddigest":         ErrChecksumMismatch,
+}
+
+// normalizeErrorCode lowercases the code and strips the "Err" prefix and separators,
+// so "ErrNotFound", "NOT_FOUND" and "not-found" all normalize to "notfound".
+func normalizeErrorCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.TrimPrefix(code, "err")
+	return strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(code)
+}
+
+// errorKindFromStatus maps an HTTP status code to a sentinel error.
+func errorKindFromStatus(status int) error {
+	switch status {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrAlreadyExists
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrPermissionDenied
+	case http.StatusTooManyRequests:
+		return ErrQuotaExceeded
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrInvalidArgument
+	case http.StatusLocked:
+		return ErrLegalHold
+	case http.StatusPreconditionFailed:
+		return ErrResourceChanged
+	default:
+		return nil
+	}
+}
+
+// errorKindFromMessage classifies well-known server messages for services
+// that only return a generic error code.
+func errorKindFromMessage(msg string) error {
+	msg = strings.ToLower(msg)
+	switch {
+	case strings.Contains(msg, "legal hold"):
+		return ErrLegalHold
+	case strings.Contains(msg, "already exist") || strings.Contains(msg, "duplicate"):
+		return ErrAlreadyExists
+	case strings.Contains(msg, "not exist") || strings.Contains(msg, "not found"):
+		return ErrNotFound
+	case strings.Contains(msg, "permission denied") || strings.Contains(msg, "no privilege") || strings.Contains(msg, "forbidden"):
+		return ErrPermissionDenied
+	case strings.Contains(msg, "quota") || strings.Contains(msg, "rate limit"):
+		return ErrQuotaExceeded
+	default:
+		return nil
+	}
+}
+
 // APIError captures an application-level error returned by the catalog service envelope.
 //
 // APIError represents business logic errors returned by the server, such as
@@ -50,6 +204,86 @@ type APIError struct {
 
 	// HTTPStatus is the HTTP status code of the response.
 	HTTPStatus int
+
+	// LocalizedMessage is the user-facing message produced by the client's
+	// ErrorTranslator, if one is configured and knows the error.
+	LocalizedMessage string
+
+	// Details is the raw structured "details" object of the response, if the
+	// endpoint returned one. Use DecodeDetails to unmarshal endpoint-specific details.
+	Details json.RawMessage
+
+	// FieldErrors lists the request fields rejected by the server, extracted from
+	// Details (e.g. which column definition of a CreateTable request was invalid).
+	FieldErrors []FieldError
+}
+
+// FieldError describes a single invalid request field.
+type FieldError struct {
+	// Field is the path of the offending field, e.g. "columns[2].type".
+	Field string `json:"field"`
+
+	// Code is a machine-readable reason, if the server provides one (e.g. "unsupported_type").
+	Code string `json:"code,omitempty"`
+
+	// Message is the human-readable reason.
+	Message string `json:"message"`
+}
+
+// DecodeDetails unmarshals the structured error details into v.
+// It returns false when the error carries no details.
+func (e *APIError) DecodeDetails(v interface{}) (bool, error) {
+	if e == nil || len(e.Details) == 0 || string(e.Details) == "null" {
+		return false, nil
+	}
+	if err := json.Unmarshal(e.Details, v); err != nil {
+		return true, fmt.Errorf("decode error details: %w", err)
+	}
+	return true, nil
+}
+
+// FieldError returns the error reported for field, if any.
+func (e *APIError) FieldError(field string) (FieldError, bool) {
+	if e == nil {
+		return FieldError{}, false
+	}
+	for _, fe := range e.FieldErrors {
+		if fe.Field == field {
+			return fe, true
+		}
+	}
+	return FieldError{}, false
+}
+
+// parseFieldErrors extracts field errors from an error details object. Details may
+// be a list of field errors or an object carrying them under "field_errors" or
+// "errors"; anything else yields no field errors.
+func parseFieldErrors(details json.RawMessage) []FieldError {
+	if len(details) == 0 {
+		return nil
+	}
+	var list []FieldError
+	if json.Unmarshal(details, &list) == nil {
+		return compactFieldErrors(list)
+	}
+	var obj struct {
+		FieldErrors []FieldError `json:"field_errors"`
+		Errors      []FieldError `json:"errors"`
+	}
+	if json.Unmarshal(details, &obj) != nil {
+		return nil
+	}
+	return compactFieldErrors(append(obj.FieldErrors, obj.Errors...))
+}
+
+func compactFieldErrors(list []FieldError) []FieldError {
+	var out []FieldError
+	for _, fe := range list {
+		if fe.Field != "" || fe.Message != "" {
+			out = append(out, fe)
+		}
+	}
+	return out
 }
 
 func (e *APIError) Error() string {
@@ -59,6 +293,54 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("catalog service error: code=%s msg=%s request_id=%s status=%d", e.Code, e.Message, e.RequestID, e.HTTPStatus)
 }
 
+// UserMessage returns the localized message when one is available and the server
+// message otherwise.
+func (e *APIError) UserMessage() string {
+	if e == nil {
+		return ""
+	}
+	if e.LocalizedMessage != "" {
+		return e.LocalizedMessage
+	}
+	return e.Message
+}
+
+// Kind returns the sentinel error classifying this API error, or nil if the
+// error does not fall into a known category.
+//
+// The server code is consulted first, then the HTTP status, and finally
+// well-known message patterns for services that only return generic codes.
+func (e *APIError) Kind() error {
+	if e == nil {
+		return nil
+	}
+	if kind, ok := apiErrorCodeKinds[normalizeErrorCode(e.Code)]; ok {
+		return kind
+	}
+	// Legal hold violations are often reported as a generic 403; the message is more specific
+	if kind := errorKindFromMessage(e.Message); kind == ErrLegalHold {
+		return kind
+	}
+	if kind := errorKindFromStatus(e.HTTPStatus); kind != nil {
+		return kind
+	}
+	return errorKindFromMessage(e.Message)
+}
+
+// Is reports whether the API error belongs to the category of target,
+// enabling errors.Is(err, sdk.ErrNotFound) and similar checks.
+func (e *APIError) Is(target error) bool {
+	return kindMatches(e.Kind(), target)
+}
+
+// kindMatches reports whether an error of the given kind matches target.
+func kindMatches(kind, target error) bool {
+	if kind == nil {
+		return false
+	}
+	return kind == target || (target == ErrNameConflict && kind == ErrAlreadyExists)
+}
+
 // HTTPError represents a non-2xx HTTP response that occurred before the SDK could parse the envelope.
 //
 // HTTPError represents network-level errors or server errors that occur before
@@ -79,14 +361,243 @@ type HTTPError struct {
 
 	// Body contains the raw response body, if available.
 	Body []byte
+
+	// RequestID is the X-Request-ID of the failed request, if known.
+	RequestID string
 }
 
 func (e *HTTPError) Error() string {
 	if e == nil {
 		return "<nil>"
 	}
-	if len(e.Body) == 0 {
-		return fmt.Sprintf("http error: status=%d", e.StatusCode)
+	msg := fmt.Sprintf("http error: status=%d", e.StatusCode)
+	if e.RequestID != "" {
+		msg += " request_id=" + e.RequestID
+	}
+	if len(e.Body) > 0 {
+		msg += " body=" + string(e.Body)
+	}
+	return msg
+}
+
+// Kind returns the sentinel error classifying this HTTP error, or nil if it does
+// not map to a known category. When the body carries a standard response envelope,
+// its code and message are classified like an APIError; otherwise only the status
+// code is used.
+func (e *HTTPError) Kind() error {
+	if e == nil {
+		return nil
+	}
+	var envelope apiEnvelope
+	if len(e.Body) > 0 && json.Unmarshal(e.Body, &envelope) == nil && (envelope.Code != "" || envelope.Msg != "") {
+		return (&APIError{Code: envelope.Code, Message: envelope.Msg, HTTPStatus: e.StatusCode}).Kind()
+	}
+	return errorKindFromStatus(e.StatusCode)
+}
+
+// Is reports whether the HTTP error belongs to the category of target.
+func (e *HTTPError) Is(target error) bool {
+	return kindMatches(e.Kind(), target)
+}
+
+// NameConflictError is returned by rename helpers when the new name is already taken.
+// It matches ErrNameConflict and ErrAlreadyExists through errors.Is and unwraps to the
+// underlying *APIError.
+//
+// Example:
+//
+//	name := "report.pdf"
+//	for i := 1; ; i++ {
+//		_, err := client.RenameFileIfUnchanged(ctx, fileID, name, info.ETag())
+//		var conflict *sdk.NameConflictError
+//		if !errors.As(err, &conflict) {
+//			return err
+//		}
+//		name = fmt.Sprintf("report (%d).pdf", i)
+//	}
+type NameConflictError struct {
+	// Name is the name that is already taken.
+	Name string
+
+	// Err is the underlying server error.
+	Err error
+}
+
+func (e *NameConflictError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("name %q is already taken: %v", e.Name, e.Err)
+}
+
+// Unwrap returns the underlying server error.
+func (e *NameConflictError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// Is reports whether target is ErrNameConflict or ErrAlreadyExists.
+func (e *NameConflictError) Is(target error) bool {
+	return target == ErrNameConflict || target == ErrAlreadyExists
+}
+
+// WaitTimeoutError is returned by Wait* helpers when the timeout expires before the
+// awaited operation finishes. It matches ErrWaitTimeout and context.DeadlineExceeded
+// through errors.Is.
+//
+// Example:
+//
+//	job, err := client.WaitForWorkflowJob(ctx, jobID, sdk.WaitOptions{Timeout: time.Minute})
+//	var timeoutErr *sdk.WaitTimeoutError
+//	if errors.As(err, &timeoutErr) {
+//		fmt.Printf("job still %s after %s\n", timeoutErr.LastStatus, timeoutErr.Elapsed)
+//	}
+type WaitTimeoutError struct {
+	// Operation describes what was awaited, e.g. "workflow job job-123".
+	Operation string
+
+	// LastStatus is the last status observed before giving up, if any.
+	LastStatus string
+
+	// Elapsed is the time spent waiting.
+	Elapsed time.Duration
+
+	// LastErr is the error returned by the last poll, if it failed.
+	LastErr error
+}
+
+func (e *WaitTimeoutError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	msg := fmt.Sprintf("timed out after %s waiting for %s", e.Elapsed.Round(time.Millisecond), e.Operation)
+	if e.LastStatus != "" {
+		msg += fmt.Sprintf(" (last status: %s)", e.LastStatus)
+	}
+	if e.LastErr != nil {
+		msg += fmt.Sprintf(": last error: %v", e.LastErr)
+	}
+	return msg
+}
+
+// Is reports whether target is ErrWaitTimeout or context.DeadlineExceeded.
+func (e *WaitTimeoutError) Is(target error) bool {
+	return target == ErrWaitTimeout || target == context.DeadlineExceeded
+}
+
+// GenAIJobError is returned by WaitForGenAIJob when the job fails or is cancelled.
+// It matches ErrJobFailed through errors.Is.
+//
+// Example:
+//
+//	_, err := client.WaitForGenAIJob(ctx, jobID, sdk.GenAIJobWaitOptions{})
+//	var jobErr *sdk.GenAIJobError
+//	if errors.As(err, &jobErr) {
+//		for _, f := range jobErr.Job.FailedFiles() {
+//			fmt.Printf("%s: %s\n", f.FileName, f.ErrorMessage)
+//		}
+//	}
+type GenAIJobError struct {
+	// JobID is the ID of the failed job.
+	JobID string
+
+	// Status is the final status of the job, e.g. "failed" or "cancelled".
+	Status string
+
+	// Job is the final state of the job, including per-file errors.
+	Job *GenAIGetJobDetailResponse
+}
+
+func (e *GenAIJobError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	msg := fmt.Sprintf("genai job %s %s", e.JobID, e.Status)
+	if failed := e.Job.FailedFiles(); len(failed) > 0 {
+		msg += fmt.Sprintf(": %d file(s) failed, first: %s: %s", len(failed), failed[0].FileName, failed[0].ErrorMessage)
+	}
+	return msg
+}
+
+// Is reports whether target is ErrJobFailed.
+func (e *GenAIJobError) Is(target error) bool {
+	return target == ErrJobFailed
+}
+
+// TableJobError is returned by WaitForTableJob when the job fails or is cancelled.
+// It matches ErrJobFailed through errors.Is.
+type TableJobError struct {
+	// JobID is the ID of the failed job.
+	JobID string
+
+	// Status is the final status of the job.
+	Status TableJobStatus
+
+	// Job is the final state of the job, including the rows rejected before it failed.
+	Job *TableJob
+}
+
+func (e *TableJobError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	msg := fmt.Sprintf("table job %s %s", e.JobID, e.Status)
+	if e.Job != nil && e.Job.ErrorMessage != "" {
+		msg += ": " + e.Job.ErrorMessage
 	}
-	return fmt.Sprintf("http error: status=%d body=%s", e.StatusCode, string(e.Body))
+	return msg
+}
+
+// Is reports whether target is ErrJobFailed.
+func (e *TableJobError) Is(target error) bool {
+	return target == ErrJobFailed
+}
+
+// IsNotFound reports whether err indicates that a resource does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsAlreadyExists reports whether err indicates that a resource already exists.
+func IsAlreadyExists(err error) bool {
+	return errors.Is(err, ErrAlreadyExists)
+}
+
+// IsPermissionDenied reports whether err indicates missing privileges or credentials.
+func IsPermissionDenied(err error) bool {
+	return errors.Is(err, ErrPermissionDenied)
+}
+
+// IsQuotaExceeded reports whether err indicates that a quota or rate limit was exceeded.
+func IsQuotaExceeded(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded)
+}
+
+// IsInvalidArgument reports whether err indicates invalid request parameters.
+func IsInvalidArgument(err error) bool {
+	return errors.Is(err, ErrInvalidArgument)
+}
+
+// IsLegalHold reports whether err indicates that the object is under legal hold.
+func IsLegalHold(err error) bool {
+	return errors.Is(err, ErrLegalHold)
+}
+
+// IsResourceChanged reports whether err indicates that a conditional update lost a
+// race with a concurrent modification.
+func IsResourceChanged(err error) bool {
+	return errors.Is(err, ErrResourceChanged)
+}
+
+// IsChecksumMismatch reports whether err indicates that transferred content was
+// corrupted or does not match its expected digest.
+func IsChecksumMismatch(err error) bool {
+	return errors.Is(err, ErrChecksumMismatch)
+}
+
+// IsNameConflict reports whether err indicates that a name is already taken.
+func IsNameConflict(err error) bool {
+	return errors.Is(err, ErrNameConflict)
 }
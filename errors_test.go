@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError_Kind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  *APIError
+		want error
+	}{
+		{"CodeNotFound", &APIError{Code: "ErrNotFound", HTTPStatus: http.StatusOK}, ErrNotFound},
+		{"CodeUpperSnake", &APIError{Code: "ALREADY_EXISTS"}, ErrAlreadyExists},
+		{"CodePermission", &APIError{Code: "ErrPermissionDenied"}, ErrPermissionDenied},
+		{"CodeQuota", &APIError{Code: "quota-exceeded"}, ErrQuotaExceeded},
+		{"CodeInvalid", &APIError{Code: "ErrInvalidParam"}, ErrInvalidArgument},
+		{"CodeChecksum", &APIError{Code: "ErrChecksumMismatch"}, ErrChecksumMismatch},
+		{"StatusFallback", &APIError{Code: "ErrInternal", HTTPStatus: http.StatusNotFound}, ErrNotFound},
+		{"MessageFallback", &APIError{Code: "ErrInternal", Message: "catalog name already exists", HTTPStatus: http.StatusOK}, ErrAlreadyExists},
+		{"Unknown", &APIError{Code: "ErrInternal", Message: "boom", HTTPStatus: http.StatusOK}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.err.Kind())
+			if tc.want != nil {
+				require.ErrorIs(t, fmt.Errorf("wrapped: %w", tc.err), tc.want)
+			}
+		})
+	}
+}
+
+func TestHTTPError_Kind(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, IsNotFound(&HTTPError{StatusCode: http.StatusNotFound}))
+	require.True(t, IsPermissionDenied(&HTTPError{StatusCode: http.StatusUnauthorized}))
+	require.True(t, IsQuotaExceeded(&HTTPError{StatusCode: http.StatusTooManyRequests}))
+	require.True(t, IsAlreadyExists(&HTTPError{StatusCode: http.StatusConflict}))
+	require.True(t, IsInvalidArgument(&HTTPError{StatusCode: http.StatusBadRequest}))
+	require.False(t, IsNotFound(&HTTPError{StatusCode: http.StatusInternalServerError}))
+}
+
+func TestErrorHelpers_NonSDKErrors(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, IsNotFound(nil))
+	require.False(t, IsNotFound(errors.New("not found")))
+	require.False(t, IsAlreadyExists(ErrNilRequest))
+}
+
+func TestAPIError_Details(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		resp := envelopeResponse(`{}`)
+		resp.Body = io.NopCloser(strings.NewReader(`{"code":"ErrInvalidParam","msg":"invalid column definition","request_id":"req-1",
+			"details":{"table":"orders","field_errors":[{"field":"columns[1].type","code":"unsupported_type","message":"unknown type VARCHARR"}]}}`))
+		return resp, nil
+	})
+
+	_, err := client.CreateTable(context.Background(), &TableCreateRequest{Name: "orders"})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, []FieldError{{Field: "columns[1].type", Code: "unsupported_type", Message: "unknown type VARCHARR"}}, apiErr.FieldErrors)
+
+	fe, ok := apiErr.FieldError("columns[1].type")
+	require.True(t, ok)
+	require.Equal(t, "unsupported_type", fe.Code)
+	_, ok = apiErr.FieldError("name")
+	require.False(t, ok)
+
+	var details struct {
+		Table string `json:"table"`
+	}
+	ok, err = apiErr.DecodeDetails(&details)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "orders", details.Table)
+
+	ok, err = (&APIError{}).DecodeDetails(&details)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestParseFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []FieldError{{Field: "name", Message: "required"}}, parseFieldErrors([]byte(`[{"field":"name","message":"required"}]`)))
+	require.Equal(t, []FieldError{{Field: "name", Message: "required"}}, parseFieldErrors([]byte(`{"errors":[{"field":"name","message":"required"}]}`)))
+	require.Nil(t, parseFieldErrors([]byte(`"just a string"`)))
+	require.Nil(t, parseFieldErrors(nil))
+}
+
+func TestNameConflictAndResourceChanged(t *testing.T) {
+	t.Parallel()
+
+	// Every already-exists error is a name conflict
+	require.True(t, IsNameConflict(&APIError{Code: "ErrDuplicateName"}))
+	require.True(t, IsNameConflict(&HTTPError{StatusCode: http.StatusConflict}))
+	require.False(t, IsNameConflict(&APIError{Code: "ErrNotFound"}))
+
+	require.True(t, IsResourceChanged(&APIError{Code: "VERSION_MISMATCH"}))
+	require.True(t, IsResourceChanged(&HTTPError{StatusCode: http.StatusPreconditionFailed}))
+
+	err := fmt.Errorf("rename: %w", &NameConflictError{Name: "a.txt", Err: &APIError{Code: "ErrAlreadyExists"}})
+	require.True(t, IsNameConflict(err))
+	require.True(t, IsAlreadyExists(err))
+	require.Contains(t, err.Error(), `name "a.txt" is already taken`)
+}
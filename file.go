@@ -1,9 +1,54 @@
 package sdk
 
 import (
+	"bufio"
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
 )
 
+// FileContentUploadRequest describes a file whose content is streamed to a volume.
+type FileContentUploadRequest struct {
+	VolumeID VolumeID  // VolumeID is the target volume
+	ParentID FileID    // ParentID is the target folder, empty for the volume root
+	Name     string    // Name is the file name stored in the volume
+	Reader   io.Reader // Reader provides the file content
+
+	// ContentType overrides automatic detection. When empty, the type is derived
+	// from the file extension and, failing that, sniffed from the first 512 bytes.
+	ContentType string
+	// Size is the total content size in bytes, used only for progress reporting.
+	// Leave zero when unknown.
+	Size int64
+	// OnProgress, if set, is called after each chunk is sent with the number of
+	// bytes uploaded so far and the total size (zero when unknown).
+	OnProgress func(uploaded, total int64)
+	// SHA256, if set, is the expected hex-encoded SHA-256 digest of the content. The
+	// upload is aborted with an error matching ErrChecksumMismatch when the content
+	// read from Reader differs.
+	SHA256 string
+}
+
+// FileDownloadResult describes a completed DownloadFile transfer.
+type FileDownloadResult struct {
+	Size        int64  // Size is the number of bytes written
+	SHA256      string // SHA256 is the hex-encoded SHA-256 checksum of the content
+	MD5         string // MD5 is the hex-encoded MD5 checksum of the content
+	ContentType string // ContentType is the Content-Type reported by the storage server
+	Attempts    int    // Attempts is the number of HTTP transfers needed, including resumes
+}
+
 // CreateFile creates a new file in the specified volume.
 //
 // The file can be created in the root of the volume or within a folder.
@@ -15,7 +60,7 @@ import (
 //		VolumeID: "volume-id-123",
 //		ParentID: "folder-id-456", // optional, empty for root
 //		Size:     1024,
-//		ShowType: "normal",
+//		ShowType: sdk.FileShowTypeNormal,
 //	})
 //	if err != nil {
 //		return err
@@ -25,6 +70,9 @@ func (c *RawClient) CreateFile(ctx context.Context, req *FileCreateRequest, opts
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	if err := validateShowType(req.ShowType); err != nil {
+		return nil, err
+	}
 	var resp FileCreateResponse
 	if err := c.postJSON(ctx, "/catalog/file/create", req, &resp, opts...); err != nil {
 		return nil, err
@@ -53,6 +101,65 @@ func (c *RawClient) UpdateFile(ctx context.Context, req *FileUpdateRequest, opts
 	return &resp, nil
 }
 
+// SetFileShowType shows or hides a file or folder in the volume listings of the web console.
+//
+// Hidden files remain readable and are still returned by the API.
+//
+// Example:
+//
+//	_, err := client.SetFileShowType(ctx, &sdk.FileShowTypeUpdateRequest{
+//		FileID:   "file-id-123",
+//		ShowType: sdk.FileShowTypeHidden,
+//	})
+func (c *RawClient) SetFileShowType(ctx context.Context, req *FileShowTypeUpdateRequest, opts ...CallOption) (*FileShowTypeUpdateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.ShowType == "" {
+		return nil, fmt.Errorf("show_type is required")
+	}
+	if err := validateShowType(req.ShowType); err != nil {
+		return nil, err
+	}
+	var resp FileShowTypeUpdateResponse
+	if err := c.postJSON(ctx, "/catalog/file/update_show_type", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RenameFileIfUnchanged renames a file only if it has not been modified since version
+// was read.
+//
+// version is the ETag of a previous GetFile response. If the file changed in the
+// meantime, the error matches ErrResourceChanged; if the new name is taken, the error
+// is a *NameConflictError. Either way the file is left untouched.
+//
+// Example:
+//
+//	info, err := client.GetFile(ctx, &sdk.FileInfoRequest{FileID: "file-id-123"})
+//	if err != nil {
+//		return err
+//	}
+//	_, err = client.RenameFileIfUnchanged(ctx, info.ID, "final-report.pdf", info.ETag())
+//	switch {
+//	case sdk.IsResourceChanged(err):
+//		// reload and decide again
+//	case sdk.IsNameConflict(err):
+//		// pick another name
+//	}
+func (c *RawClient) RenameFileIfUnchanged(ctx context.Context, fileID FileID, newName, version string, opts ...CallOption) (*FileUpdateResponse, error) {
+	if err := validateConditionalRename(string(fileID), newName, version); err != nil {
+		return nil, err
+	}
+	req := &FileUpdateRequest{FileID: fileID, Name: newName, ExpectedVersion: version}
+	resp, err := c.UpdateFile(ctx, req, append(opts, WithHeader(headerIfMatch, version))...)
+	if err != nil {
+		return nil, asNameConflict(err, newName)
+	}
+	return resp, nil
+}
+
 // DeleteFile deletes the specified file.
 //
 // This operation permanently deletes the file.
@@ -95,7 +202,9 @@ func (c *RawClient) DeleteFileRef(ctx context.Context, req *FileDeleteRefRequest
 
 // GetFile retrieves detailed information about the specified file.
 //
-// The response includes file name, size, type, and metadata.
+// The response includes file name, size, type, and metadata. With IncludeDeleted,
+// a deleted file is returned as a tombstone with Deleted set instead of a not-found
+// error, which lets sync tools tell "was deleted" from "never existed".
 //
 // Example:
 //
@@ -120,6 +229,11 @@ func (c *RawClient) GetFile(ctx context.Context, req *FileInfoRequest, opts ...C
 // ListFiles lists files in a volume or folder with optional filtering.
 //
 // Supports filtering by volume ID, parent ID, file type, and other criteria.
+// Set IncludeDeleted to also list tombstones of deleted files.
+//
+// Pages are read from the live volume by default, so files added or removed while
+// paging can shift entries between pages. Set Snapshot on the first page and pass the
+// returned SnapshotToken with every later page to page through a consistent view.
 //
 // Example:
 //
@@ -174,6 +288,73 @@ func (c *RawClient) UploadFile(ctx context.Context, req *FileUploadRequest, opts
 	return &resp, nil
 }
 
+// GetFileUploadLink reserves a file and returns a presigned URL through which its
+// content can be uploaded directly to storage, for example from a browser, without
+// passing through the caller.
+//
+// Once the upload has finished, register it with CompleteFileUpload; until then the
+// file is not listed. Files that are never completed are removed when the URL
+// expires.
+//
+// Example:
+//
+//	link, err := client.GetFileUploadLink(ctx, &sdk.FileUploadLinkRequest{
+//		VolumeID:    "volume-id-123",
+//		Name:        "report.pdf",
+//		Size:        1048576,
+//		ContentType: "application/pdf",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	// Hand link.Url, link.Method and link.Headers to the frontend, then once it
+//	// reports success:
+//	info, err := client.CompleteFileUpload(ctx, &sdk.FileUploadCompleteRequest{FileID: link.FileID})
+func (c *RawClient) GetFileUploadLink(ctx context.Context, req *FileUploadLinkRequest, opts ...CallOption) (*FileUploadLinkResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.VolumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if req.Size < 0 || req.ExpiresIn < 0 {
+		return nil, fmt.Errorf("size and expires_in must not be negative")
+	}
+	var resp FileUploadLinkResponse
+	if err := c.postJSON(ctx, "/catalog/file/upload_link", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CompleteFileUpload registers content uploaded through a link from
+// GetFileUploadLink and returns the stored file.
+//
+// It fails with an error matching ErrNotFound when nothing was uploaded, and with
+// one matching ErrChecksumMismatch when SHA256 is set and the stored content differs.
+//
+// Example:
+//
+//	info, err := client.CompleteFileUpload(ctx, &sdk.FileUploadCompleteRequest{
+//		FileID: "file-id-123",
+//	})
+func (c *RawClient) CompleteFileUpload(ctx context.Context, req *FileUploadCompleteRequest, opts ...CallOption) (*FileInfoResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.FileID == "" {
+		return nil, fmt.Errorf("file_id is required")
+	}
+	var resp FileInfoResponse
+	if err := c.postJSON(ctx, "/catalog/file/upload_complete", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetFileDownloadLink retrieves a signed download link for the file.
 //
 // The link is a temporary URL that can be used to download the file.
@@ -245,3 +426,529 @@ func (c *RawClient) GetFilePreviewStream(ctx context.Context, req *FilePreviewSt
 	}
 	return &resp, nil
 }
+
+// MoveFile moves a file to another folder, optionally in another volume.
+//
+// ConflictPolicy controls what happens when the destination already has a file with
+// the same name; the default is NameConflictFail.
+//
+// Example:
+//
+//	resp, err := client.MoveFile(ctx, &sdk.FileMoveRequest{
+//		FileID:         "file-id-123",
+//		TargetVolumeID: "volume-id-456",
+//		TargetParentID: "folder-id-789",
+//		ConflictPolicy: sdk.NameConflictRename,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Moved as %s\n", resp.Name)
+func (c *RawClient) MoveFile(ctx context.Context, req *FileMoveRequest, opts ...CallOption) (*FileMoveResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp FileMoveResponse
+	if err := c.postJSON(ctx, "/catalog/file/move", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CopyFile copies a file to another folder, optionally in another volume.
+//
+// The copy gets a new file ID; the source file is left untouched.
+//
+// Example:
+//
+//	resp, err := client.CopyFile(ctx, &sdk.FileCopyRequest{
+//		FileID:         "file-id-123",
+//		TargetVolumeID: "volume-id-456",
+//		ConflictPolicy: sdk.NameConflictOverwrite,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Copy ID: %s\n", resp.FileID)
+func (c *RawClient) CopyFile(ctx context.Context, req *FileCopyRequest, opts ...CallOption) (*FileCopyResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp FileCopyResponse
+	if err := c.postJSON(ctx, "/catalog/file/copy", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UploadFileContent streams file content to a volume as a multipart upload.
+//
+// Unlike CreateFile, which only registers metadata, this sends the actual bytes
+// without buffering the whole file in memory. The MD5 and SHA-256 digests of the
+// content are computed while sending and returned in the response; when the server
+// reports different digests for the stored file, an error matching
+// ErrChecksumMismatch is returned.
+//
+// Example:
+//
+//	f, _ := os.Open("report.pdf")
+//	defer f.Close()
+//	stat, _ := f.Stat()
+//
+//	resp, err := client.UploadFileContent(ctx, &sdk.FileContentUploadRequest{
+//		VolumeID: "volume-id-123",
+//		Name:     "report.pdf",
+//		Reader:   f,
+//		Size:     stat.Size(),
+//		OnProgress: func(uploaded, total int64) {
+//			fmt.Printf("uploaded %d/%d bytes\n", uploaded, total)
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Uploaded file ID: %s\n", resp.FileID)
+func (c *RawClient) UploadFileContent(ctx context.Context, req *FileContentUploadRequest, opts ...CallOption) (*FileUploadResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.VolumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if req.Reader == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+
+	content := bufio.NewReader(req.Reader)
+	contentType := req.ContentType
+	if contentType == "" {
+		contentType = detectContentType(req.Name, content)
+	}
+	var body io.Reader = content
+	if req.OnProgress != nil {
+		body = &progressReader{r: content, total: req.Size, fn: req.OnProgress}
+	}
+
+	sha := sha256.New()
+	md5sum := md5.New()
+	body = io.TeeReader(body, io.MultiWriter(sha, md5sum))
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	formContentType := writer.FormDataContentType()
+
+	// sent is closed once the content has been written; it carries the digests
+	// when the whole content was read
+	var digests [2]string
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		fields := [][2]string{
+			{"name", req.Name},
+			{"volume_id", string(req.VolumeID)},
+			{"parent_id", string(req.ParentID)},
+		}
+		for _, field := range fields {
+			if err := writer.WriteField(field[0], field[1]); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, escapeQuotes(req.Name)))
+		header.Set(headerContentType, contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, body); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		sum := hex.EncodeToString(sha.Sum(nil))
+		if req.SHA256 != "" && !strings.EqualFold(req.SHA256, sum) {
+			pw.CloseWithError(fmt.Errorf("%w: content has sha256 %s, expected %s", ErrChecksumMismatch, sum, req.SHA256))
+			return
+		}
+		digests = [2]string{hex.EncodeToString(md5sum.Sum(nil)), sum}
+		// Sent after the content so the server can verify what it received
+		for _, field := range [][2]string{{"md5", digests[0]}, {"sha256", digests[1]}} {
+			if err := writer.WriteField(field[0], field[1]); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.CloseWithError(writer.Close())
+	}()
+
+	callOpts := newCallOptions(opts...)
+	resp, err := c.doRaw(ctx, http.MethodPost, "/catalog/file/upload", pr, callOpts, func(r *http.Request) {
+		r.Header.Set(headerContentType, formContentType)
+		r.Header.Set(headerAccept, mimeJSON)
+	})
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var uploadResp FileUploadResponse
+	err = c.decodeResponse(resp, &uploadResp)
+	pr.Close()
+	<-sent
+	if err != nil {
+		return nil, err
+	}
+	if digests[1] == "" {
+		// The server answered before reading the whole content
+		return &uploadResp, nil
+	}
+	if (uploadResp.SHA256 != "" && !strings.EqualFold(uploadResp.SHA256, digests[1])) ||
+		(uploadResp.MD5 != "" && !strings.EqualFold(uploadResp.MD5, digests[0])) {
+		return nil, fmt.Errorf("%w: file %s was stored with sha256 %s md5 %s, sent sha256 %s md5 %s",
+			ErrChecksumMismatch, uploadResp.FileID, uploadResp.SHA256, uploadResp.MD5, digests[1], digests[0])
+	}
+	uploadResp.MD5, uploadResp.SHA256 = digests[0], digests[1]
+	return &uploadResp, nil
+}
+
+// detectContentType derives the MIME type from the file extension, falling back
+// to sniffing the first bytes of content. Peeking does not consume the reader.
+func detectContentType(name string, content *bufio.Reader) string {
+	if ct := mime.TypeByExtension(filepath.Ext(name)); ct != "" {
+		return ct
+	}
+	head, _ := content.Peek(512)
+	return http.DetectContentType(head)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
+
+// progressReader reports the cumulative number of bytes read to a callback.
+type progressReader struct {
+	r     io.Reader
+	read  int64
+	total int64
+	fn    func(uploaded, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.read += int64(n)
+		p.fn(p.read, p.total)
+	}
+	return n, err
+}
+
+// DownloadFile downloads a file's content into w by following its signed download link.
+//
+// Interrupted transfers are resumed with HTTP Range requests from the last byte written,
+// and an expired link is fetched again transparently. Retries are controlled with
+// WithDownloadRetries. The returned result carries the size and checksums computed
+// over the written bytes. When the server reports the SHA-256 digest of the file and
+// the written bytes differ, the result is returned with an error matching
+// ErrChecksumMismatch. Errors returned by w are not retried.
+//
+// Example:
+//
+//	f, _ := os.Create("report.pdf")
+//	defer f.Close()
+//
+//	result, err := client.DownloadFile(ctx, &sdk.FileDownloadRequest{
+//		FileID:   "file-id-123",
+//		VolumeID: "volume-id-456",
+//	}, f)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Downloaded %d bytes, sha256=%s\n", result.Size, result.SHA256)
+func (c *RawClient) DownloadFile(ctx context.Context, req *FileDownloadRequest, w io.Writer, opts ...CallOption) (*FileDownloadResult, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if w == nil {
+		return nil, fmt.Errorf("writer is required")
+	}
+	callOpts := newCallOptions(opts...)
+	// Every attempt is sent with the request ID of the call
+	ctx = ContextWithRequestID(ctx, c.requestIDFor(ctx, callOpts))
+
+	sha := sha256.New()
+	md5sum := md5.New()
+	dst := &downloadWriter{w: io.MultiWriter(w, sha, md5sum)}
+	result := &FileDownloadResult{}
+
+	var expected string
+	getLink := func(ctx context.Context) (string, error) {
+		linkResp, err := c.GetFileDownloadLink(ctx, req, opts...)
+		if err != nil {
+			return "", err
+		}
+		expected = linkResp.SHA256
+		return linkResp.Url, nil
+	}
+	if err := c.downloadWithRetries(ctx, callOpts, getLink, dst, result, "file_id", req.FileID); err != nil {
+		return nil, fmt.Errorf("download file %s: %w", req.FileID, err)
+	}
+
+	result.Size = dst.written
+	result.SHA256 = hex.EncodeToString(sha.Sum(nil))
+	result.MD5 = hex.EncodeToString(md5sum.Sum(nil))
+	if expected != "" && !strings.EqualFold(expected, result.SHA256) {
+		return result, fmt.Errorf("download file %s: %w: received sha256 %s, expected %s", req.FileID, ErrChecksumMismatch, result.SHA256, expected)
+	}
+	return result, nil
+}
+
+// downloadWithRetries downloads the content behind the signed link returned by
+// getLink into dst, resuming interrupted transfers and fetching a new link when the
+// current one expires. logArgs identify the download in retry log records.
+func (c *RawClient) downloadWithRetries(ctx context.Context, callOpts callOptions, getLink func(context.Context) (string, error), dst *downloadWriter, result *FileDownloadResult, logArgs ...any) error {
+	var link string
+	for retries := 0; ; retries++ {
+		attemptCtx := ctx
+		if retries > 0 {
+			attemptCtx = withRetryAttempt(ctx)
+		}
+		var err error
+		if link == "" {
+			link, err = getLink(attemptCtx)
+			if err == nil && strings.HasPrefix(link, "/") {
+				link = c.baseURL + link
+			}
+		}
+		if err == nil {
+			result.Attempts++
+			err = c.downloadRange(callOpts.requestContext(attemptCtx), link, dst, result)
+		}
+		if err == nil {
+			return nil
+		}
+		if dst.err != nil || ctx.Err() != nil || !isRetryableDownloadError(err) || retries >= callOpts.downloadRetries {
+			return err
+		}
+		// Signed links expire; request a fresh one before retrying
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && (httpErr.StatusCode == http.StatusForbidden || httpErr.StatusCode == http.StatusUnauthorized) {
+			link = ""
+		}
+		c.log(ctx, LogLevelWarn, "retrying download", append(logArgs, "attempt", retries+1, "offset", dst.written, "error", err)...)
+		if err := sleepWithContext(ctx, downloadBackoff(retries)); err != nil {
+			return err
+		}
+	}
+}
+
+// downloadRange fetches link starting at dst.written and appends the content to dst.
+func (c *RawClient) downloadRange(ctx context.Context, link string, dst *downloadWriter, result *FileDownloadResult) error {
+	offset := dst.written
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		httpReq.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	// Use a client with no timeout for large files; the context still cancels the transfer
+	var transport http.RoundTripper
+	if c.httpClient != nil {
+		transport = c.httpClient.Transport
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0 {
+		// The previous attempt already received the whole file
+		return nil
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(resp.Body)
+		return newHTTPError(resp, data)
+	}
+	if ct := resp.Header.Get(headerContentType); ct != "" && result.ContentType == "" {
+		result.ContentType = ct
+	}
+
+	body := io.Reader(resp.Body)
+	want := resp.ContentLength
+	if offset > 0 && resp.StatusCode != http.StatusPartialContent {
+		// The server ignored the Range header; skip the bytes we already have
+		if _, err := io.CopyN(io.Discard, body, offset); err != nil {
+			return err
+		}
+		if want >= 0 {
+			want -= offset
+		}
+	}
+	n, err := io.Copy(dst, body)
+	if err != nil {
+		return err
+	}
+	if want >= 0 && n < want {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+// downloadWriter counts written bytes and remembers write failures so that they
+// can be told apart from network failures.
+type downloadWriter struct {
+	w       io.Writer
+	written int64
+	err     error
+}
+
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	n, err := d.w.Write(p)
+	d.written += int64(n)
+	if err != nil {
+		d.err = err
+	}
+	return n, err
+}
+
+func isRetryableDownloadError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch {
+		case httpErr.StatusCode >= http.StatusInternalServerError,
+			httpErr.StatusCode == http.StatusRequestTimeout,
+			httpErr.StatusCode == http.StatusTooManyRequests,
+			httpErr.StatusCode == http.StatusForbidden,
+			httpErr.StatusCode == http.StatusUnauthorized:
+			return true
+		}
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return false
+	}
+	// Network and truncated-body errors
+	return true
+}
+
+func downloadBackoff(retry int) time.Duration {
+	d := 200 * time.Millisecond << uint(retry)
+	if d > 5*time.Second {
+		d = 5 * time.Second
+	}
+	return d
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// DeleteFilesBatch deletes many files in one call.
+//
+// The server batch endpoint is used when available; otherwise the files are deleted
+// with individual requests, at most WithConcurrency at a time. Per-file failures are
+// reported in the BatchResult; the returned error is reserved for whole-batch failures.
+//
+// Example:
+//
+//	result, err := client.DeleteFilesBatch(ctx, []sdk.FileID{"file-1", "file-2"})
+//	if err != nil {
+//		return err
+//	}
+//	if err := result.Err(); err != nil {
+//		fmt.Printf("some files were not deleted: %v\n", err)
+//	}
+func (c *RawClient) DeleteFilesBatch(ctx context.Context, fileIDs []FileID, opts ...CallOption) (*BatchResult, error) {
+	if len(fileIDs) == 0 {
+		return &BatchResult{}, nil
+	}
+	var resp batchResponse
+	err := c.postJSON(ctx, "/catalog/file/batch_delete", map[string]interface{}{"ids": fileIDs}, &resp, opts...)
+	if err == nil {
+		result := resp.toBatchResult(len(fileIDs))
+		for i := range result.Items {
+			if result.Items[i].ID == "" {
+				result.Items[i].ID = string(fileIDs[i])
+			}
+		}
+		return result, nil
+	}
+	if !isBatchEndpointUnsupported(err) {
+		return nil, err
+	}
+
+	opts = withoutIdempotencyKey(opts)
+	callOpts := newCallOptions(opts...)
+	return runBatch(ctx, len(fileIDs), callOpts.concurrency, func(ctx context.Context, i int) (string, error) {
+		_, err := c.DeleteFile(ctx, &FileDeleteRequest{FileID: fileIDs[i]}, opts...)
+		return string(fileIDs[i]), err
+	}), nil
+}
+
+// CreateFilesBatch creates many file records in one call.
+//
+// The server batch endpoint is used when available; otherwise the files are created
+// with individual requests, at most WithConcurrency at a time. The ID of each successful
+// item is the new file ID.
+//
+// Example:
+//
+//	result, err := client.CreateFilesBatch(ctx, []sdk.FileCreateRequest{
+//		{Name: "a.txt", VolumeID: "volume-id-123", Size: 10},
+//		{Name: "b.txt", VolumeID: "volume-id-123", Size: 20},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, item := range result.Succeeded() {
+//		fmt.Printf("created file %s\n", item.ID)
+//	}
+func (c *RawClient) CreateFilesBatch(ctx context.Context, reqs []FileCreateRequest, opts ...CallOption) (*BatchResult, error) {
+	if len(reqs) == 0 {
+		return &BatchResult{}, nil
+	}
+	for i := range reqs {
+		if err := validateShowType(reqs[i].ShowType); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	var resp batchResponse
+	err := c.postJSON(ctx, "/catalog/file/batch_create", map[string]interface{}{"list": reqs}, &resp, opts...)
+	if err == nil {
+		return resp.toBatchResult(len(reqs)), nil
+	}
+	if !isBatchEndpointUnsupported(err) {
+		return nil, err
+	}
+
+	opts = withoutIdempotencyKey(opts)
+	callOpts := newCallOptions(opts...)
+	return runBatch(ctx, len(reqs), callOpts.concurrency, func(ctx context.Context, i int) (string, error) {
+		resp, err := c.CreateFile(ctx, &reqs[i], opts...)
+		if err != nil {
+			return "", err
+		}
+		return string(resp.FileID), nil
+	}), nil
+}
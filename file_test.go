@@ -1,7 +1,17 @@
 package sdk
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -100,6 +110,11 @@ func TestFileNilRequestErrors(t *testing.T) {
 		{"Download", func() error { _, err := client.GetFileDownloadLink(ctx, nil); return err }},
 		{"PreviewLink", func() error { _, err := client.GetFilePreviewLink(ctx, nil); return err }},
 		{"PreviewStream", func() error { _, err := client.GetFilePreviewStream(ctx, nil); return err }},
+		{"UploadContent", func() error { _, err := client.UploadFileContent(ctx, nil); return err }},
+		{"UploadLink", func() error { _, err := client.GetFileUploadLink(ctx, nil); return err }},
+		{"UploadComplete", func() error { _, err := client.CompleteFileUpload(ctx, nil); return err }},
+		{"Move", func() error { _, err := client.MoveFile(ctx, nil); return err }},
+		{"Copy", func() error { _, err := client.CopyFile(ctx, nil); return err }},
 	}
 
 	for _, tc := range tests {
@@ -109,6 +124,236 @@ func TestFileNilRequestErrors(t *testing.T) {
 	}
 }
 
+func TestUploadFileContentValidation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	_, err := client.UploadFileContent(ctx, &FileContentUploadRequest{Name: "a.txt", Reader: strings.NewReader("x")})
+	require.ErrorContains(t, err, "volume_id is required")
+	_, err = client.UploadFileContent(ctx, &FileContentUploadRequest{VolumeID: "1", Reader: strings.NewReader("x")})
+	require.ErrorContains(t, err, "name is required")
+	_, err = client.UploadFileContent(ctx, &FileContentUploadRequest{VolumeID: "1", Name: "a.txt"})
+	require.ErrorContains(t, err, "reader is required")
+}
+
+func TestDetectContentType(t *testing.T) {
+	t.Parallel()
+
+	content := bufio.NewReader(strings.NewReader("%PDF-1.4 test"))
+	require.Equal(t, "application/pdf", detectContentType("no-extension", content))
+	// Peeking must not consume the content
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.Equal(t, "%PDF-1.4 test", string(data))
+
+	require.Equal(t, "application/json", detectContentType("data.json", bufio.NewReader(strings.NewReader("{}"))))
+}
+
+func TestProgressReader(t *testing.T) {
+	t.Parallel()
+
+	var calls [][2]int64
+	r := &progressReader{
+		r:     strings.NewReader("hello world"),
+		total: 11,
+		fn:    func(uploaded, total int64) { calls = append(calls, [2]int64{uploaded, total}) },
+	}
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(data))
+	require.NotEmpty(t, calls)
+	require.Equal(t, [2]int64{11, 11}, calls[len(calls)-1])
+}
+
+// failingReader returns data and then fails, simulating a dropped connection.
+type failingReader struct{ data io.Reader }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.data.Read(p)
+	if err == io.EOF {
+		return n, io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func TestDownloadFile_ResumesAfterInterruption(t *testing.T) {
+	t.Parallel()
+	const content = "hello resumable world"
+
+	var ranges []string
+	linkCalls := 0
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/catalog/file/download" {
+			linkCalls++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"code":"OK","data":{"link":"https://storage.test/obj"}}`)),
+			}, nil
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		switch len(ranges) {
+		case 1:
+			// Drop the connection after the first 5 bytes
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				ContentLength: int64(len(content)),
+				Header:        http.Header{"Content-Type": []string{"text/plain"}},
+				Body:          io.NopCloser(&failingReader{data: strings.NewReader(content[:5])}),
+			}, nil
+		case 2:
+			// Signed link expired
+			return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader("expired"))}, nil
+		default:
+			return &http.Response{
+				StatusCode:    http.StatusPartialContent,
+				ContentLength: int64(len(content) - 5),
+				Body:          io.NopCloser(strings.NewReader(content[5:])),
+			}, nil
+		}
+	})
+	client := &RawClient{baseURL: "https://moi.test", httpClient: &http.Client{Transport: transport}}
+
+	var buf bytes.Buffer
+	result, err := client.DownloadFile(context.Background(), &FileDownloadRequest{FileID: "f1"}, &buf)
+	require.NoError(t, err)
+	require.Equal(t, content, buf.String())
+	require.Equal(t, int64(len(content)), result.Size)
+	require.Equal(t, 3, result.Attempts)
+	require.Equal(t, "text/plain", result.ContentType)
+	sum := sha256.Sum256([]byte(content))
+	require.Equal(t, hex.EncodeToString(sum[:]), result.SHA256)
+	require.Equal(t, []string{"", "bytes=5-", "bytes=5-"}, ranges)
+	require.Equal(t, 2, linkCalls)
+}
+
+func TestDownloadFile_ResumeWithServerIgnoringRange(t *testing.T) {
+	t.Parallel()
+	const content = "hello resumable world"
+
+	var ranges []string
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/catalog/file/download" {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"code":"OK","data":{"link":"https://storage.test/obj"}}`)),
+			}, nil
+		}
+		ranges = append(ranges, r.Header.Get("Range"))
+		body := io.Reader(strings.NewReader(content))
+		if len(ranges) == 1 {
+			body = &failingReader{data: strings.NewReader(content[:5])}
+		}
+		// The whole content is sent every time, whatever the Range header
+		return &http.Response{StatusCode: http.StatusOK, ContentLength: int64(len(content)), Body: io.NopCloser(body)}, nil
+	})
+	client := &RawClient{baseURL: "https://moi.test", httpClient: &http.Client{Transport: transport}}
+
+	var buf bytes.Buffer
+	result, err := client.DownloadFile(context.Background(), &FileDownloadRequest{FileID: "f1"}, &buf)
+	require.NoError(t, err)
+	require.Equal(t, content, buf.String())
+	require.Equal(t, 2, result.Attempts)
+	require.Equal(t, []string{"", "bytes=5-"}, ranges)
+}
+
+func TestDownloadFile_NoRetries(t *testing.T) {
+	t.Parallel()
+
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/catalog/file/download" {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"code":"OK","data":{"link":"/storage/obj"}}`)),
+			}, nil
+		}
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+	client := &RawClient{baseURL: "https://moi.test", httpClient: &http.Client{Transport: transport}}
+
+	_, err := client.DownloadFile(context.Background(), &FileDownloadRequest{FileID: "f1"}, io.Discard, WithDownloadRetries(0))
+	var httpErr *HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	require.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+
+	_, err = client.DownloadFile(context.Background(), nil, io.Discard)
+	require.ErrorIs(t, err, ErrNilRequest)
+}
+
+func TestDeleteFilesBatch_ServerEndpoint(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/file/batch_delete", r.URL.Path)
+		return envelopeResponse(`{"items":[{"index":0,"code":"OK"},{"index":1,"code":"ErrLegalHold","msg":"held"}]}`), nil
+	})
+
+	result, err := client.DeleteFilesBatch(context.Background(), []FileID{"f1", "f2"})
+	require.NoError(t, err)
+	require.Equal(t, "f1", result.Items[0].ID)
+	require.NoError(t, result.Items[0].Err)
+	require.Equal(t, "f2", result.Items[1].ID)
+	require.True(t, IsLegalHold(result.Items[1].Err))
+}
+
+func TestCreateFilesBatch_FallbackFanOut(t *testing.T) {
+	t.Parallel()
+
+	var created int32
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/file/batch_create":
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("404 page not found"))}, nil
+		case "/catalog/file/create":
+			var req FileCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Name == "bad.txt" {
+				return errorEnvelopeResponse("ErrDuplicate", "name exists"), nil
+			}
+			atomic.AddInt32(&created, 1)
+			return envelopeResponse(`{"id":"id-` + req.Name + `"}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	})
+
+	result, err := client.CreateFilesBatch(context.Background(), []FileCreateRequest{
+		{Name: "a.txt", VolumeID: "v"},
+		{Name: "bad.txt", VolumeID: "v"},
+		{Name: "c.txt", VolumeID: "v"},
+	}, WithConcurrency(2))
+	require.NoError(t, err)
+	require.Equal(t, int32(2), created)
+	require.Equal(t, "id-a.txt", result.Items[0].ID)
+	require.True(t, IsAlreadyExists(result.Items[1].Err))
+	require.Equal(t, "id-c.txt", result.Items[2].ID)
+
+	empty, err := client.CreateFilesBatch(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, empty.Items)
+}
+
+func TestMoveFile_ConflictPolicy(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/file/move", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "rename", body["conflict_policy"])
+		require.Equal(t, "v2", body["target_volume_id"])
+		return envelopeResponse(`{"id":"f1","name":"report (1).pdf"}`), nil
+	})
+
+	resp, err := client.MoveFile(context.Background(), &FileMoveRequest{
+		FileID:         "f1",
+		TargetVolumeID: "v2",
+		ConflictPolicy: NameConflictRename,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "report (1).pdf", resp.Name)
+}
+
 func TestFileVolumeIDNotExists(t *testing.T) {
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -910,3 +1155,208 @@ func TestFilePreviewStreamFormat(t *testing.T) {
 	require.Contains(t, previewStreamResp.Url, "Signature=")
 	t.Logf("Preview Stream URL format verified: %s", previewStreamResp.Url)
 }
+
+func TestRenameFileIfUnchanged(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/file/update", r.URL.Path)
+		var body FileUpdateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, body.ExpectedVersion, r.Header.Get("If-Match"))
+		switch {
+		case body.ExpectedVersion == "v1":
+			resp := errorEnvelopeResponse("ErrVersionMismatch", "file has been modified")
+			resp.StatusCode = http.StatusPreconditionFailed
+			return resp, nil
+		case body.Name == "taken.txt":
+			return errorEnvelopeResponse("ErrInternal", "file name already exists"), nil
+		}
+		return envelopeResponse(`{"id":"f1"}`), nil
+	})
+	ctx := context.Background()
+
+	resp, err := client.RenameFileIfUnchanged(ctx, "f1", "new.txt", "v2")
+	require.NoError(t, err)
+	require.Equal(t, FileID("f1"), resp.FileID)
+
+	_, err = client.RenameFileIfUnchanged(ctx, "f1", "new.txt", "v1")
+	require.True(t, IsResourceChanged(err))
+	require.False(t, IsNameConflict(err))
+
+	_, err = client.RenameFileIfUnchanged(ctx, "f1", "taken.txt", "v2")
+	var conflict *NameConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, "taken.txt", conflict.Name)
+	require.True(t, IsAlreadyExists(err))
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+
+	_, err = client.RenameFileIfUnchanged(ctx, "f1", "new.txt", "")
+	require.ErrorContains(t, err, "version cannot be empty")
+
+	require.Equal(t, "2024-01-01", (&FileInfoResponse{UpdatedAt: "2024-01-01"}).ETag())
+	require.Equal(t, "7", (&FileInfoResponse{UpdatedAt: "2024-01-01", Version: "7"}).ETag())
+}
+
+func TestGetFile_IncludeDeleted(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/catalog/file/info":
+			if body["include_deleted"] != true {
+				return errorEnvelopeResponse("ErrNotFound", "file not exist"), nil
+			}
+			return envelopeResponse(`{"id":"f1","name":"a.txt","deleted":true,"deleted_at":"2024-05-01 10:00:00"}`), nil
+		case "/catalog/file/list":
+			require.Equal(t, true, body["include_deleted"])
+			return envelopeResponse(`{"total":2,"list":[{"id":"f1","deleted":true},{"id":"f2"}]}`), nil
+		}
+		t.Fatalf("unexpected path %s", r.URL.Path)
+		return nil, nil
+	})
+	ctx := context.Background()
+
+	_, err := client.GetFile(ctx, &FileInfoRequest{FileID: "f1"})
+	require.True(t, IsNotFound(err))
+
+	info, err := client.GetFile(ctx, &FileInfoRequest{FileID: "f1", IncludeDeleted: true})
+	require.NoError(t, err)
+	require.True(t, info.Deleted)
+	require.Equal(t, "2024-05-01 10:00:00", info.DeletedAt)
+
+	list, err := client.ListFiles(ctx, &FileListRequest{IncludeDeleted: true})
+	require.NoError(t, err)
+	require.True(t, list.List[0].Deleted)
+	require.False(t, list.List[1].Deleted)
+
+	// The flag is omitted unless requested
+	payload, err := json.Marshal(&FileListRequest{})
+	require.NoError(t, err)
+	require.NotContains(t, string(payload), "include_deleted")
+}
+
+func TestFileShowType(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, FileShowType("").Valid())
+	require.True(t, FileShowTypeHidden.Valid())
+	require.False(t, FileShowType("invisible").Valid())
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/file/update_show_type", r.URL.Path)
+		var body FileShowTypeUpdateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, FileShowTypeHidden, body.ShowType)
+		return envelopeResponse(`{"id":"f1"}`), nil
+	})
+	ctx := context.Background()
+
+	resp, err := client.SetFileShowType(ctx, &FileShowTypeUpdateRequest{FileID: "f1", ShowType: FileShowTypeHidden})
+	require.NoError(t, err)
+	require.Equal(t, FileID("f1"), resp.FileID)
+
+	_, err = client.SetFileShowType(ctx, &FileShowTypeUpdateRequest{FileID: "f1"})
+	require.ErrorContains(t, err, "show_type is required")
+	_, err = client.CreateFile(ctx, &FileCreateRequest{Name: "a.txt", ShowType: "invisible"})
+	require.ErrorContains(t, err, `invalid show_type "invisible"`)
+	_, err = client.CreateFilesBatch(ctx, []FileCreateRequest{{Name: "a.txt"}, {Name: "b.txt", ShowType: "x"}})
+	require.ErrorContains(t, err, "item 1")
+}
+
+func TestUploadFileContent_Checksums(t *testing.T) {
+	t.Parallel()
+	const content = "checksummed content"
+	sha := sha256.Sum256([]byte(content))
+	md := md5.Sum([]byte(content))
+	shaHex, mdHex := hex.EncodeToString(sha[:]), hex.EncodeToString(md[:])
+
+	var fields []string
+	stored := shaHex
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/file/upload", r.URL.Path)
+		mr, err := r.MultipartReader()
+		require.NoError(t, err)
+		fields = nil
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err // The client aborted the upload
+			}
+			data, err := io.ReadAll(part)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, part.FormName()+"="+string(data))
+		}
+		return envelopeResponse(`{"id":"f1","sha256":"` + stored + `"}`), nil
+	})
+	ctx := context.Background()
+
+	resp, err := client.UploadFileContent(ctx, &FileContentUploadRequest{VolumeID: "v1", Name: "a.txt", Reader: strings.NewReader(content)})
+	require.NoError(t, err)
+	require.Equal(t, shaHex, resp.SHA256)
+	require.Equal(t, mdHex, resp.MD5)
+	require.Equal(t, []string{"name=a.txt", "volume_id=v1", "parent_id=", "file=" + content, "md5=" + mdHex, "sha256=" + shaHex}, fields)
+
+	stored = strings.Repeat("0", 64)
+	_, err = client.UploadFileContent(ctx, &FileContentUploadRequest{VolumeID: "v1", Name: "a.txt", Reader: strings.NewReader(content)})
+	require.True(t, IsChecksumMismatch(err))
+
+	_, err = client.UploadFileContent(ctx, &FileContentUploadRequest{VolumeID: "v1", Name: "a.txt", Reader: strings.NewReader(content), SHA256: stored})
+	require.True(t, IsChecksumMismatch(err))
+}
+
+func TestDownloadFile_ChecksumMismatch(t *testing.T) {
+	t.Parallel()
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/catalog/file/download" {
+			return envelopeResponse(`{"link":"/storage/obj","sha256":"` + strings.Repeat("0", 64) + `"}`), nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("corrupted"))}, nil
+	})
+
+	result, err := client.DownloadFile(context.Background(), &FileDownloadRequest{FileID: "f1"}, io.Discard)
+	require.True(t, IsChecksumMismatch(err))
+	require.Equal(t, int64(len("corrupted")), result.Size)
+}
+
+func TestFileUploadLinkFlow(t *testing.T) {
+	t.Parallel()
+	var linkReq map[string]any
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/file/upload_link":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&linkReq))
+			return envelopeResponse(`{"id":"f1","url":"https://storage.test/put/f1?sig=x","method":"PUT",
+				"headers":{"Content-Type":"application/pdf"},"expires_at":"2026-01-01T00:15:00Z"}`), nil
+		case "/catalog/file/upload_complete":
+			var req FileUploadCompleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, FileUploadCompleteRequest{FileID: "f1", SHA256: "abc"}, req)
+			return envelopeResponse(`{"id":"f1","name":"report.pdf","size":1024,"sha256":"abc"}`), nil
+		}
+		return nil, nil
+	})
+	ctx := context.Background()
+
+	link, err := client.GetFileUploadLink(ctx, &FileUploadLinkRequest{VolumeID: "v1", Name: "report.pdf", Size: 1024, ContentType: "application/pdf"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"volume_id": "v1", "name": "report.pdf", "size": float64(1024), "content_type": "application/pdf"}, linkReq)
+	require.Equal(t, FileID("f1"), link.FileID)
+	require.Equal(t, "PUT", link.Method)
+	require.Equal(t, "application/pdf", link.Headers["Content-Type"])
+
+	info, err := client.CompleteFileUpload(ctx, &FileUploadCompleteRequest{FileID: link.FileID, SHA256: "abc"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1024), info.Size)
+
+	_, err = client.GetFileUploadLink(ctx, &FileUploadLinkRequest{VolumeID: "v1"})
+	require.ErrorContains(t, err, "name is required")
+}
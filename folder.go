@@ -2,6 +2,9 @@ package sdk
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 )
 
 // CreateFolder creates a new folder in the specified volume.
@@ -52,6 +55,53 @@ func (c *RawClient) UpdateFolder(ctx context.Context, req *FolderUpdateRequest,
 	return &resp, nil
 }
 
+// RenameFolderIfUnchanged renames a folder only if it has not been modified since
+// version was read.
+//
+// version is the ETag of a previous GetFile response for the folder. If the folder
+// changed in the meantime, the error matches ErrResourceChanged; if the new name is
+// taken, the error is a *NameConflictError.
+//
+// Example:
+//
+//	info, err := client.GetFile(ctx, &sdk.FileInfoRequest{FileID: "folder-id-123"})
+//	if err != nil {
+//		return err
+//	}
+//	_, err = client.RenameFolderIfUnchanged(ctx, info.ID, "archive-2024", info.ETag())
+func (c *RawClient) RenameFolderIfUnchanged(ctx context.Context, folderID FileID, newName, version string, opts ...CallOption) (*FolderUpdateResponse, error) {
+	if err := validateConditionalRename(string(folderID), newName, version); err != nil {
+		return nil, err
+	}
+	req := &FolderUpdateRequest{FolderID: folderID, Name: newName, ExpectedVersion: version}
+	resp, err := c.UpdateFolder(ctx, req, append(opts, WithHeader(headerIfMatch, version))...)
+	if err != nil {
+		return nil, asNameConflict(err, newName)
+	}
+	return resp, nil
+}
+
+func validateConditionalRename(id, newName, version string) error {
+	if strings.TrimSpace(id) == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if strings.TrimSpace(newName) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if strings.TrimSpace(version) == "" {
+		return fmt.Errorf("version cannot be empty")
+	}
+	return nil
+}
+
+// asNameConflict wraps name collision errors into a *NameConflictError.
+func asNameConflict(err error, name string) error {
+	if errors.Is(err, ErrAlreadyExists) {
+		return &NameConflictError{Name: name, Err: err}
+	}
+	return err
+}
+
 // DeleteFolder deletes the specified folder.
 //
 // This operation will also delete all files and subfolders within the folder.
@@ -111,3 +161,29 @@ func (c *RawClient) GetFolderRefList(ctx context.Context, req *FolderRefListRequ
 	}
 	return &resp, nil
 }
+
+// CopyFolder recursively copies a folder and all of its contents to another location.
+//
+// ConflictPolicy applies to the top-level folder and to every entry copied beneath it.
+//
+// Example:
+//
+//	resp, err := client.CopyFolder(ctx, &sdk.FolderCopyRequest{
+//		FolderID:       "folder-id-123",
+//		TargetVolumeID: "volume-id-456",
+//		ConflictPolicy: sdk.NameConflictRename,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Copied %d files into folder %s\n", resp.FileCount, resp.FolderID)
+func (c *RawClient) CopyFolder(ctx context.Context, req *FolderCopyRequest, opts ...CallOption) (*FolderCopyResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp FolderCopyResponse
+	if err := c.postJSON(ctx, "/catalog/folder/copy", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -75,6 +75,7 @@ func TestFolderNilRequestErrors(t *testing.T) {
 		{"Delete", func() error { _, err := client.DeleteFolder(ctx, nil); return err }},
 		{"Clean", func() error { _, err := client.CleanFolder(ctx, nil); return err }},
 		{"RefList", func() error { _, err := client.GetFolderRefList(ctx, nil); return err }},
+		{"Copy", func() error { _, err := client.CopyFolder(ctx, nil); return err }},
 	}
 
 	for _, tc := range tests {
@@ -8,6 +8,8 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -192,6 +194,251 @@ func (c *RawClient) DownloadGenAIResult(ctx context.Context, fileID string, opts
 	}, nil
 }
 
+// ListGenAIJobResults lists the result files produced by a GenAI job.
+//
+// Each result carries the file ID accepted by DownloadGenAIResult.
+//
+// Example:
+//
+//	resp, err := client.ListGenAIJobResults(ctx, "job-id-123")
+//	if err != nil {
+//		return err
+//	}
+//	for _, f := range resp.Results {
+//		fmt.Printf("%s (%s, %d bytes)\n", f.FileName, f.FileType, f.FileSize)
+//	}
+func (c *RawClient) ListGenAIJobResults(ctx context.Context, jobID string, opts ...CallOption) (*GenAIJobResultsResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	resp := GenAIJobResultsResponse{Results: []GenAIJobResultFile{}}
+	path := fmt.Sprintf("/v1/genai/jobs/%s/results", url.PathEscape(jobID))
+	if err := c.getJSON(ctx, path, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Results == nil {
+		resp.Results = []GenAIJobResultFile{}
+	}
+	if resp.JobID == "" {
+		resp.JobID = jobID
+	}
+	return &resp, nil
+}
+
+// DownloadAllGenAIResults downloads every result file of a GenAI job into destDir.
+//
+// Files are downloaded in parallel, at most WithConcurrency at a time (default: 4), and
+// written under their result name; a name used by more than one result is prefixed with
+// the file ID. destDir is created if needed. Items of the returned BatchResult follow the
+// order of ListGenAIJobResults and carry the path of the written file as ID. The error is
+// only non-nil when the results cannot be listed; check result.Err() for download failures.
+//
+// Example:
+//
+//	result, err := client.DownloadAllGenAIResults(ctx, "job-id-123", "./out", sdk.WithConcurrency(8))
+//	if err != nil {
+//		return err
+//	}
+//	for _, item := range result.Failed() {
+//		fmt.Printf("failed to download %s: %v\n", item.ID, item.Err)
+//	}
+func (c *RawClient) DownloadAllGenAIResults(ctx context.Context, jobID, destDir string, opts ...CallOption) (*BatchResult, error) {
+	if strings.TrimSpace(destDir) == "" {
+		return nil, fmt.Errorf("destDir cannot be empty")
+	}
+	list, err := c.ListGenAIJobResults(ctx, jobID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, err
+	}
+
+	paths := genAIResultPaths(destDir, list.Results)
+	callOpts := newCallOptions(opts...)
+	return runBatch(ctx, len(list.Results), callOpts.concurrency, func(ctx context.Context, i int) (string, error) {
+		stream, err := c.DownloadGenAIResult(ctx, list.Results[i].FileID, opts...)
+		if err != nil {
+			return paths[i], err
+		}
+		defer stream.Close()
+		if _, err := stream.WriteToFile(paths[i]); err != nil {
+			os.Remove(paths[i])
+			return paths[i], err
+		}
+		return paths[i], nil
+	}), nil
+}
+
+// genAIResultPaths picks a local path in destDir for every result file. Names are
+// reduced to their base name, and names shared by several results are prefixed with
+// the file ID so that no download overwrites another.
+func genAIResultPaths(destDir string, results []GenAIJobResultFile) []string {
+	names := make([]string, len(results))
+	counts := make(map[string]int, len(results))
+	for i, f := range results {
+		name := filepath.Base(filepath.Clean("/" + f.FileName))
+		if name == "/" || name == "." {
+			name = f.FileID
+		}
+		names[i] = name
+		counts[name]++
+	}
+	paths := make([]string, len(results))
+	for i, name := range names {
+		if counts[name] > 1 && name != results[i].FileID {
+			name = results[i].FileID + "_" + name
+		}
+		paths[i] = filepath.Join(destDir, name)
+	}
+	return paths
+}
+
+// ListGenAIPipelines lists GenAI pipelines with optional filtering and pagination.
+//
+// Pipelines can be filtered by name (substring match) and by the status of their job.
+//
+// Example:
+//
+//	resp, err := client.ListGenAIPipelines(ctx, &sdk.GenAIPipelineListRequest{
+//		Status:   "failed",
+//		Page:     1,
+//		PageSize: 20,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, p := range resp.Pipelines {
+//		fmt.Printf("Pipeline: %s (job %s, %s)\n", p.ID, p.JobID, p.Status)
+//	}
+func (c *RawClient) ListGenAIPipelines(ctx context.Context, req *GenAIPipelineListRequest, opts ...CallOption) (*GenAIPipelineListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	c.applyPageDefaults(req)
+
+	query := url.Values{}
+	if req.Name != "" {
+		query.Set("name", req.Name)
+	}
+	if req.Status != "" {
+		query.Set("status", req.Status)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	path := "/v1/genai/pipeline"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp := GenAIPipelineListResponse{Pipelines: []GenAIPipeline{}}
+	if err := c.getJSON(ctx, path, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Pipelines == nil {
+		resp.Pipelines = []GenAIPipeline{}
+	}
+	return &resp, nil
+}
+
+// GetGenAIPipeline retrieves a GenAI pipeline by ID.
+//
+// Example:
+//
+//	p, err := client.GetGenAIPipeline(ctx, "pipeline-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Pipeline %s has %d steps\n", p.Name, len(p.Steps))
+func (c *RawClient) GetGenAIPipeline(ctx context.Context, pipelineID string, opts ...CallOption) (*GenAIPipeline, error) {
+	if strings.TrimSpace(pipelineID) == "" {
+		return nil, fmt.Errorf("pipelineID cannot be empty")
+	}
+	var resp GenAIPipeline
+	if err := c.getJSON(ctx, genAIPipelinePath(pipelineID), &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateGenAIPipeline updates the name or steps of a GenAI pipeline.
+//
+// Fields left empty in the request are not changed. Updated steps apply to future
+// runs; a job that is already running is not affected.
+//
+// Example:
+//
+//	p, err := client.UpdateGenAIPipeline(ctx, "pipeline-123", &sdk.GenAIPipelineUpdateRequest{
+//		Name: "invoices-v2",
+//	})
+func (c *RawClient) UpdateGenAIPipeline(ctx context.Context, pipelineID string, req *GenAIPipelineUpdateRequest, opts ...CallOption) (*GenAIPipeline, error) {
+	if strings.TrimSpace(pipelineID) == "" {
+		return nil, fmt.Errorf("pipelineID cannot be empty")
+	}
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp GenAIPipeline
+	if err := c.doJSON(ctx, http.MethodPut, genAIPipelinePath(pipelineID), req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteGenAIPipeline deletes a GenAI pipeline.
+//
+// Result files already produced by the pipeline can no longer be downloaded afterwards.
+//
+// Example:
+//
+//	if _, err := client.DeleteGenAIPipeline(ctx, "pipeline-123"); err != nil {
+//		return err
+//	}
+func (c *RawClient) DeleteGenAIPipeline(ctx context.Context, pipelineID string, opts ...CallOption) (*GenAIPipelineDeleteResponse, error) {
+	if strings.TrimSpace(pipelineID) == "" {
+		return nil, fmt.Errorf("pipelineID cannot be empty")
+	}
+	var resp GenAIPipelineDeleteResponse
+	if err := c.doJSON(ctx, http.MethodDelete, genAIPipelinePath(pipelineID), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CancelGenAIJob cancels a running GenAI job.
+//
+// Files that were already processed keep their results; the remaining files are skipped.
+//
+// Example:
+//
+//	resp, err := client.CancelGenAIJob(ctx, "job-id-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Job status: %s\n", resp.Status)
+func (c *RawClient) CancelGenAIJob(ctx context.Context, jobID string, opts ...CallOption) (*GenAICancelJobResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	var resp GenAICancelJobResponse
+	path := fmt.Sprintf("/v1/genai/jobs/%s/cancel", url.PathEscape(jobID))
+	if err := c.postJSON(ctx, path, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.JobID == "" {
+		resp.JobID = jobID
+	}
+	return &resp, nil
+}
+
+func genAIPipelinePath(pipelineID string) string {
+	return fmt.Sprintf("/v1/genai/pipeline/%s", url.PathEscape(pipelineID))
+}
+
 // CreateWorkflow creates a new workflow.
 //
 // This method creates a workflow using workflow metadata, which includes:
@@ -237,6 +484,16 @@ func (c *RawClient) CreateWorkflow(ctx context.Context, req *WorkflowMetadata, o
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	normalizeWorkflowMetadata(req)
+	var resp WorkflowCreateResponse
+	if err := c.postJSON(ctx, "/v1/genai/workflow", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// normalizeWorkflowMetadata initializes required fields so they are not serialized as null.
+func normalizeWorkflowMetadata(req *WorkflowMetadata) {
 	// Ensure required fields are initialized to avoid serializing them as null
 	// The server requires these fields to be present even if empty
 	if req.SourceVolumeNames == nil {
@@ -263,13 +520,207 @@ func (c *RawClient) CreateWorkflow(ctx context.Context, req *WorkflowMetadata, o
 			}
 		}
 	}
-	var resp WorkflowCreateResponse
-	if err := c.postJSON(ctx, "/v1/genai/workflow", req, &resp, opts...); err != nil {
+}
+
+// GetWorkflow retrieves a workflow by ID.
+//
+// The returned workflow has the same fields as the response of CreateWorkflow.
+//
+// Example:
+//
+//	wf, err := client.GetWorkflow(ctx, "workflow-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Workflow %s runs every %ds\n", wf.Name, wf.FlowInterval)
+func (c *RawClient) GetWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, fmt.Errorf("workflowID cannot be empty")
+	}
+	var resp WorkflowResponse
+	if err := c.getJSON(ctx, workflowPath(workflowID), &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
 
+// UpdateWorkflow replaces the definition of an existing workflow.
+//
+// The request uses the same metadata as CreateWorkflow and replaces the stored
+// definition as a whole, so fields left empty are cleared.
+//
+// Example:
+//
+//	wf.ProcessMode = &sdk.ProcessMode{Interval: 600}
+//	resp, err := client.UpdateWorkflow(ctx, "workflow-123", wf)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Updated workflow to version %s\n", resp.Version)
+func (c *RawClient) UpdateWorkflow(ctx context.Context, workflowID string, req *WorkflowMetadata, opts ...CallOption) (*WorkflowResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, fmt.Errorf("workflowID cannot be empty")
+	}
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	normalizeWorkflowMetadata(req)
+	var resp WorkflowResponse
+	if err := c.doJSON(ctx, http.MethodPut, workflowPath(workflowID), req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteWorkflow deletes a workflow.
+//
+// Files already processed by the workflow are kept in the target volume.
+//
+// Example:
+//
+//	if _, err := client.DeleteWorkflow(ctx, "workflow-123"); err != nil {
+//		return err
+//	}
+func (c *RawClient) DeleteWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowDeleteResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, fmt.Errorf("workflowID cannot be empty")
+	}
+	var resp WorkflowDeleteResponse
+	if err := c.doJSON(ctx, http.MethodDelete, workflowPath(workflowID), nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListWorkflows lists workflows with optional filtering and pagination.
+//
+// Workflows can be filtered by name (substring match) and by source volume.
+//
+// Example:
+//
+//	resp, err := client.ListWorkflows(ctx, &sdk.WorkflowListRequest{
+//		SourceVolumeID: "vol-123",
+//		Page:           1,
+//		PageSize:       20,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, wf := range resp.Workflows {
+//		fmt.Printf("Workflow: %s (%s)\n", wf.Name, wf.ID)
+//	}
+func (c *RawClient) ListWorkflows(ctx context.Context, req *WorkflowListRequest, opts ...CallOption) (*WorkflowListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	c.applyPageDefaults(req)
+
+	query := url.Values{}
+	if req.Name != "" {
+		query.Set("name", req.Name)
+	}
+	if req.SourceVolumeID != "" {
+		query.Set("source_volume_id", req.SourceVolumeID)
+	}
+	if req.Page > 0 {
+		query.Set("page", strconv.Itoa(req.Page))
+	}
+	if req.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(req.PageSize))
+	}
+	path := "/v1/genai/workflow"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	resp := WorkflowListResponse{Workflows: []WorkflowResponse{}}
+	if err := c.getJSON(ctx, path, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Workflows == nil {
+		resp.Workflows = []WorkflowResponse{}
+	}
+	return &resp, nil
+}
+
+// StartWorkflow starts a stopped workflow.
+//
+// Once started, a workflow with an interval ProcessMode processes new files on its schedule.
+//
+// Example:
+//
+//	resp, err := client.StartWorkflow(ctx, "workflow-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Workflow state: %s\n", resp.State)
+func (c *RawClient) StartWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowStateResponse, error) {
+	return c.changeWorkflowState(ctx, workflowID, "start", opts...)
+}
+
+// PauseWorkflow pauses a running workflow.
+//
+// Scheduled runs are skipped while the workflow is paused; jobs already running are not interrupted.
+//
+// Example:
+//
+//	if _, err := client.PauseWorkflow(ctx, "workflow-123"); err != nil {
+//		return err
+//	}
+func (c *RawClient) PauseWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowStateResponse, error) {
+	return c.changeWorkflowState(ctx, workflowID, "pause", opts...)
+}
+
+// ResumeWorkflow resumes a paused workflow.
+//
+// Files that arrived while the workflow was paused are picked up by the next scheduled run.
+//
+// Example:
+//
+//	if _, err := client.ResumeWorkflow(ctx, "workflow-123"); err != nil {
+//		return err
+//	}
+func (c *RawClient) ResumeWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowStateResponse, error) {
+	return c.changeWorkflowState(ctx, workflowID, "resume", opts...)
+}
+
+// TriggerWorkflowRun starts a workflow run immediately, outside of its schedule.
+//
+// The returned job ID can be used to follow the run with ListWorkflowJobs.
+//
+// Example:
+//
+//	resp, err := client.TriggerWorkflowRun(ctx, "workflow-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Started job %s\n", resp.JobID)
+func (c *RawClient) TriggerWorkflowRun(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowTriggerResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, fmt.Errorf("workflowID cannot be empty")
+	}
+	var resp WorkflowTriggerResponse
+	if err := c.postJSON(ctx, workflowPath(workflowID)+"/trigger", nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *RawClient) changeWorkflowState(ctx context.Context, workflowID, action string, opts ...CallOption) (*WorkflowStateResponse, error) {
+	if strings.TrimSpace(workflowID) == "" {
+		return nil, fmt.Errorf("workflowID cannot be empty")
+	}
+	var resp WorkflowStateResponse
+	if err := c.postJSON(ctx, workflowPath(workflowID)+"/"+action, nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func workflowPath(workflowID string) string {
+	return fmt.Sprintf("/v1/genai/workflow/%s", url.PathEscape(workflowID))
+}
+
 // ListWorkflowJobs lists workflow jobs with optional filtering and pagination.
 //
 // This method calls the workflow-be API endpoint /byoa/api/v1/workflow_job to retrieve
@@ -301,6 +752,7 @@ func (c *RawClient) ListWorkflowJobs(ctx context.Context, req *WorkflowJobListRe
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	c.applyPageDefaults(req)
 
 	// Build query parameters
 	query := url.Values{}
@@ -341,28 +793,7 @@ func (c *RawClient) ListWorkflowJobs(ctx context.Context, req *WorkflowJobListRe
 	// Convert raw jobs to WorkflowJob format
 	jobs := make([]WorkflowJob, len(rawResp.Jobs))
 	for i, rawJob := range rawResp.Jobs {
-		jobs[i] = WorkflowJob{
-			JobID:        rawJob.ID,
-			WorkflowID:   rawJob.WorkflowID,
-			SourceFileID: req.SourceFileID,                 // Populate from request filter
-			Status:       WorkflowJobStatus(rawJob.Status), // Convert int to WorkflowJobStatus
-			StartTime:    rawJob.StartTime,
-		}
-		// Handle end_time (can be null)
-		if rawJob.EndTime != nil {
-			jobs[i].EndTime = *rawJob.EndTime
-		}
-		// Try to extract source_file_id from description if available
-		if jobs[i].SourceFileID == "" && rawJob.Description != nil {
-			if triggerTaskID, ok := rawJob.Description["triggerTaskID"]; ok {
-				// Convert to string if it's a number
-				if idStr, ok := triggerTaskID.(string); ok {
-					jobs[i].SourceFileID = idStr
-				} else if idNum, ok := triggerTaskID.(float64); ok {
-					jobs[i].SourceFileID = strconv.FormatFloat(idNum, 'f', -1, 64)
-				}
-			}
-		}
+		jobs[i] = rawJob.toWorkflowJob(req.SourceFileID) // Populate source file from request filter
 	}
 
 	resp := WorkflowJobListResponse{
@@ -375,3 +806,107 @@ func (c *RawClient) ListWorkflowJobs(ctx context.Context, req *WorkflowJobListRe
 	}
 	return &resp, nil
 }
+
+// toWorkflowJob converts a raw job to WorkflowJob. sourceFileID is used when the
+// job description does not carry the ID of the file that triggered the job.
+func (r workflowJobRaw) toWorkflowJob(sourceFileID string) WorkflowJob {
+	job := WorkflowJob{
+		JobID:        r.ID,
+		WorkflowID:   r.WorkflowID,
+		SourceFileID: sourceFileID,
+		Status:       WorkflowJobStatus(r.Status), // Convert int to WorkflowJobStatus
+		StartTime:    r.StartTime,
+	}
+	// Handle end_time (can be null)
+	if r.EndTime != nil {
+		job.EndTime = *r.EndTime
+	}
+	// Try to extract source_file_id from description if available
+	if job.SourceFileID == "" && r.Description != nil {
+		if triggerTaskID, ok := r.Description["triggerTaskID"]; ok {
+			// Convert to string if it's a number
+			if idStr, ok := triggerTaskID.(string); ok {
+				job.SourceFileID = idStr
+			} else if idNum, ok := triggerTaskID.(float64); ok {
+				job.SourceFileID = strconv.FormatFloat(idNum, 'f', -1, 64)
+			}
+		}
+	}
+	return job
+}
+
+// SimulateWorkflow runs a workflow definition synchronously against a single sample file.
+//
+// Nothing is persisted: the workflow is not created and the sample is not stored in a
+// volume. The response contains the output of every node (parsed text, chunks, embedding
+// statistics), which makes it possible to debug a pipeline before deploying it.
+//
+// Example:
+//
+//	f, _ := os.Open("sample.pdf")
+//	defer f.Close()
+//
+//	resp, err := client.SimulateWorkflow(ctx, workflow, sdk.PipelineFile{FileName: "sample.pdf", Reader: f})
+//	if err != nil {
+//		return err
+//	}
+//	for _, node := range resp.Nodes {
+//		fmt.Printf("%s (%s): %s, %d chunks\n", node.NodeID, node.NodeType, node.Status, len(node.Chunks))
+//	}
+func (c *RawClient) SimulateWorkflow(ctx context.Context, req *WorkflowMetadata, sample PipelineFile, opts ...CallOption) (*WorkflowSimulationResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if sample.Reader == nil {
+		return nil, fmt.Errorf("sample file reader is nil")
+	}
+	normalizeWorkflowMetadata(req)
+
+	filename := sample.FileName
+	if strings.TrimSpace(filename) == "" {
+		filename = "sample"
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	contentType := writer.FormDataContentType()
+
+	go func() {
+		payload, err := json.Marshal(req)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if err := writer.WriteField("payload", string(payload)); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		part, err := writer.CreateFormFile("file", filename)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, sample.Reader); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(writer.Close())
+	}()
+
+	callOpts := newCallOptions(opts...)
+	resp, err := c.doRaw(ctx, http.MethodPost, "/v1/genai/workflow/simulate", pr, callOpts, func(r *http.Request) {
+		r.Header.Set(headerContentType, contentType)
+		r.Header.Set(headerAccept, mimeJSON)
+	})
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var simResp WorkflowSimulationResponse
+	if err := c.decodeResponse(resp, &simResp); err != nil {
+		return nil, err
+	}
+	return &simResp, nil
+}
@@ -2,6 +2,12 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -17,6 +23,31 @@ func TestCreateWorkflow_NilRequest(t *testing.T) {
 	require.ErrorIs(t, err, ErrNilRequest)
 }
 
+func TestSimulateWorkflow_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	_, err := client.SimulateWorkflow(ctx, nil, PipelineFile{FileName: "a.txt", Reader: strings.NewReader("x")})
+	require.ErrorIs(t, err, ErrNilRequest)
+
+	_, err = client.SimulateWorkflow(ctx, &WorkflowMetadata{}, PipelineFile{FileName: "a.txt"})
+	require.ErrorContains(t, err, "sample file reader is nil")
+}
+
+func TestWorkflowSimulationResponse_Node(t *testing.T) {
+	t.Parallel()
+
+	resp := &WorkflowSimulationResponse{Nodes: []WorkflowNodeSimulationResult{
+		{NodeID: "ChunkNode_2", NodeType: "ChunkNode", Chunks: []string{"a", "b"}},
+	}}
+	require.Len(t, resp.Node("ChunkNode_2").Chunks, 2)
+	require.Nil(t, resp.Node("missing"))
+
+	var nilResp *WorkflowSimulationResponse
+	require.Nil(t, nilResp.Node("ChunkNode_2"))
+}
+
 func TestCreateWorkflow_Basic(t *testing.T) {
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -877,3 +908,215 @@ func TestListWorkflowJobs_WithCombinedFilters(t *testing.T) {
 		t.Logf("No jobs found, skipping combined filter test")
 	}
 }
+
+func TestWorkflowCRUD_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	_, err := client.GetWorkflow(ctx, " ")
+	require.ErrorContains(t, err, "workflowID cannot be empty")
+	_, err = client.UpdateWorkflow(ctx, "", &WorkflowMetadata{})
+	require.ErrorContains(t, err, "workflowID cannot be empty")
+	_, err = client.UpdateWorkflow(ctx, "wf-1", nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.DeleteWorkflow(ctx, "")
+	require.ErrorContains(t, err, "workflowID cannot be empty")
+	_, err = client.ListWorkflows(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+}
+
+func TestWorkflowCRUD_Requests(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Path == "/v1/genai/workflow" {
+				require.Equal(t, "docs", r.URL.Query().Get("name"))
+				require.Equal(t, "vol-1", r.URL.Query().Get("source_volume_id"))
+				require.Equal(t, "2", r.URL.Query().Get("page"))
+				return envelopeResponse(`{"total":1,"workflows":[{"id":"wf-1","name":"docs"}]}`), nil
+			}
+			require.Equal(t, "/v1/genai/workflow/wf-1", r.URL.Path)
+			return envelopeResponse(`{"id":"wf-1","name":"docs","flow_interval":60}`), nil
+		case http.MethodPut:
+			require.Equal(t, "/v1/genai/workflow/wf-1", r.URL.Path)
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			// Required fields are filled in the same way as for CreateWorkflow
+			require.Equal(t, []interface{}{}, body["source_volume_ids"])
+			require.NotNil(t, body["process_mode"])
+			return envelopeResponse(`{"id":"wf-1","version":"2"}`), nil
+		case http.MethodDelete:
+			require.Equal(t, "/v1/genai/workflow/wf-1", r.URL.Path)
+			return envelopeResponse(`{"id":"wf-1"}`), nil
+		}
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL)
+		return nil, nil
+	})
+
+	list, err := client.ListWorkflows(ctx, &WorkflowListRequest{Name: "docs", SourceVolumeID: "vol-1", Page: 2})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Total)
+	require.Equal(t, "wf-1", list.Workflows[0].ID)
+
+	wf, err := client.GetWorkflow(ctx, "wf-1")
+	require.NoError(t, err)
+	require.Equal(t, 60, wf.FlowInterval)
+
+	updated, err := client.UpdateWorkflow(ctx, "wf-1", &WorkflowMetadata{Name: "docs"})
+	require.NoError(t, err)
+	require.Equal(t, "2", updated.Version)
+
+	deleted, err := client.DeleteWorkflow(ctx, "wf-1")
+	require.NoError(t, err)
+	require.Equal(t, "wf-1", deleted.ID)
+}
+
+func TestWorkflowControl(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var paths []string
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodPost, r.Method)
+		paths = append(paths, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/trigger") {
+			return envelopeResponse(`{"job_id":"job-1"}`), nil
+		}
+		return envelopeResponse(`{"id":"wf-1","state":"paused"}`), nil
+	})
+
+	_, err := client.StartWorkflow(ctx, "wf-1")
+	require.NoError(t, err)
+	state, err := client.PauseWorkflow(ctx, "wf-1")
+	require.NoError(t, err)
+	require.Equal(t, WorkflowStatePaused, state.State)
+	_, err = client.ResumeWorkflow(ctx, "wf-1")
+	require.NoError(t, err)
+	run, err := client.TriggerWorkflowRun(ctx, "wf-1")
+	require.NoError(t, err)
+	require.Equal(t, "job-1", run.JobID)
+
+	require.Equal(t, []string{
+		"/v1/genai/workflow/wf-1/start",
+		"/v1/genai/workflow/wf-1/pause",
+		"/v1/genai/workflow/wf-1/resume",
+		"/v1/genai/workflow/wf-1/trigger",
+	}, paths)
+
+	_, err = client.PauseWorkflow(ctx, "")
+	require.ErrorContains(t, err, "workflowID cannot be empty")
+	_, err = client.TriggerWorkflowRun(ctx, "")
+	require.ErrorContains(t, err, "workflowID cannot be empty")
+}
+
+func TestGenAIPipelineLifecycle_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	_, err := client.ListGenAIPipelines(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.GetGenAIPipeline(ctx, " ")
+	require.ErrorContains(t, err, "pipelineID cannot be empty")
+	_, err = client.UpdateGenAIPipeline(ctx, "p-1", nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.DeleteGenAIPipeline(ctx, "")
+	require.ErrorContains(t, err, "pipelineID cannot be empty")
+	_, err = client.CancelGenAIJob(ctx, "")
+	require.ErrorContains(t, err, "jobID cannot be empty")
+}
+
+func TestGenAIPipelineLifecycle_Requests(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /v1/genai/pipeline":
+			require.Equal(t, "failed", r.URL.Query().Get("status"))
+			require.Equal(t, "50", r.URL.Query().Get("page_size"))
+			return envelopeResponse(`{"total":1,"pipelines":[{"id":"p-1","job_id":"job-1","status":"failed"}]}`), nil
+		case "GET /v1/genai/pipeline/p-1":
+			return envelopeResponse(`{"id":"p-1","name":"invoices","steps":[{"node":"ParseNode"}]}`), nil
+		case "PUT /v1/genai/pipeline/p-1":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			require.Equal(t, map[string]interface{}{"name": "invoices-v2"}, body)
+			return envelopeResponse(`{"id":"p-1","name":"invoices-v2"}`), nil
+		case "DELETE /v1/genai/pipeline/p-1":
+			return envelopeResponse(`{"id":"p-1"}`), nil
+		case "POST /v1/genai/jobs/job-1/cancel":
+			return envelopeResponse(`{"status":"cancelled"}`), nil
+		}
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL)
+		return nil, nil
+	})
+
+	list, err := client.ListGenAIPipelines(ctx, &GenAIPipelineListRequest{Status: "failed", PageSize: 50})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Total)
+	require.Equal(t, "job-1", list.Pipelines[0].JobID)
+
+	p, err := client.GetGenAIPipeline(ctx, "p-1")
+	require.NoError(t, err)
+	require.Equal(t, "ParseNode", p.Steps[0].Node)
+
+	updated, err := client.UpdateGenAIPipeline(ctx, "p-1", &GenAIPipelineUpdateRequest{Name: "invoices-v2"})
+	require.NoError(t, err)
+	require.Equal(t, "invoices-v2", updated.Name)
+
+	deleted, err := client.DeleteGenAIPipeline(ctx, "p-1")
+	require.NoError(t, err)
+	require.Equal(t, "p-1", deleted.ID)
+
+	cancelled, err := client.CancelGenAIJob(ctx, "job-1")
+	require.NoError(t, err)
+	require.Equal(t, &GenAICancelJobResponse{JobID: "job-1", Status: "cancelled"}, cancelled)
+}
+
+func TestDownloadAllGenAIResults(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/v1/genai/jobs/job-1/results":
+			return envelopeResponse(`{"results":[
+				{"file_id":"r1","file_name":"a.md","file_size":5},
+				{"file_id":"r2","file_name":"../b.json","file_size":2},
+				{"file_id":"r3","file_name":"a.md","file_size":5},
+				{"file_id":"r4","file_name":"missing.md"}]}`), nil
+		case "/v1/genai/results/file/r4":
+			resp := errorEnvelopeResponse("ErrNotFound", "result not exist")
+			resp.StatusCode = http.StatusNotFound
+			return resp, nil
+		}
+		fileID := strings.TrimPrefix(r.URL.Path, "/v1/genai/results/file/")
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("data-" + fileID))}, nil
+	})
+
+	list, err := client.ListGenAIJobResults(ctx, "job-1")
+	require.NoError(t, err)
+	require.Equal(t, "job-1", list.JobID)
+	require.Len(t, list.Results, 4)
+	require.EqualValues(t, 5, list.Results[0].FileSize)
+
+	dir := filepath.Join(t.TempDir(), "out")
+	result, err := client.DownloadAllGenAIResults(ctx, "job-1", dir, WithConcurrency(2))
+	require.NoError(t, err)
+	require.Len(t, result.Succeeded(), 3)
+	require.Len(t, result.Failed(), 1)
+	require.Equal(t, filepath.Join(dir, "missing.md"), result.Items[3].ID)
+
+	for path, want := range map[string]string{"r1_a.md": "data-r1", "b.json": "data-r2", "r3_a.md": "data-r3"} {
+		got, err := os.ReadFile(filepath.Join(dir, path))
+		require.NoError(t, err)
+		require.Equal(t, want, string(got))
+	}
+	_, err = os.Stat(filepath.Join(dir, "missing.md"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"net/http"
+	"strings"
+)
+
+// WithIdempotencyKey sends key in the Idempotency-Key header of the call, so that the
+// service applies a mutating operation only once however many times it is sent with
+// the same key.
+//
+// Use the same key when repeating a call whose outcome is unknown, e.g. after a
+// network error, to create the object at most once. A call with a key can also be
+// replayed by the client after a credential refresh (see WithTokenRefresher).
+//
+// A key identifies a single request, so calls that send several mutating requests
+// ignore it rather than send it with each of them, which would make the service
+// treat every request after the first as a replay: the SDKClient helpers such as
+// EnsureFolderPath, CopyFolder, MoveFolder, SyncDirToVolume, Apply and
+// Provisioner.Provision, the individual requests of Batch and of the *Batch methods
+// when the service has no batch endpoint, and Batch with more operations than fit
+// in one request. Use WithAutoIdempotencyKeys to protect each of their requests.
+//
+// Example:
+//
+//	key := "create-catalog-" + jobID
+//	resp, err := client.CreateCatalog(ctx, req, sdk.WithIdempotencyKey(key))
+//	if err != nil && !sdk.IsPermissionDenied(err) {
+//		// safe to repeat: the catalog is created once
+//		resp, err = client.CreateCatalog(ctx, req, sdk.WithIdempotencyKey(key))
+//	}
+func WithIdempotencyKey(key string) CallOption {
+	return func(co *callOptions) {
+		if key = strings.TrimSpace(key); key != "" {
+			co.headers.Set(headerIdempotencyKey, key)
+		}
+	}
+}
+
+// WithAutoIdempotencyKeys makes the client send a random Idempotency-Key with every
+// create, delete, copy and upload request that does not set one with
+// WithIdempotencyKey.
+//
+// The key is generated per call and kept for the sends of that call, such as the
+// replay after a credential refresh or the retries of a retrying transport set with
+// WithHTTPClient, so that they cannot create or delete twice. Repeating the call
+// itself generates a new key; pass WithIdempotencyKey for that.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithTokenRefresher(refresh),
+//		sdk.WithAutoIdempotencyKeys())
+func WithAutoIdempotencyKeys() ClientOption {
+	return func(o *clientOptions) {
+		o.autoIdempotency = true
+	}
+}
+
+// setIdempotencyKey adds an automatic idempotency key to req, a request to the
+// endpoint at path, if the client generates them and the endpoint creates or
+// deletes objects.
+func (c *RawClient) setIdempotencyKey(req *http.Request, path string) {
+	if !c.autoIdempotency || req.Method != http.MethodPost || req.Header.Get(headerIdempotencyKey) != "" {
+		return
+	}
+	if isCreateOrDelete(path) {
+		req.Header.Set(headerIdempotencyKey, newRequestID())
+	}
+}
+
+// isCreateOrDelete reports whether the endpoint at path creates or deletes objects,
+// judging by the last segment of the path, e.g. /catalog/create, /role/batch_delete
+// or /catalog/file/upload.
+func isCreateOrDelete(path string) bool {
+	path, _, _ = strings.Cut(path, "?")
+	op := path[strings.LastIndex(path, "/")+1:]
+	for _, verb := range []string{"create", "delete", "copy", "upload"} {
+		if op == verb || strings.HasPrefix(op, verb+"_") || strings.HasSuffix(op, "_"+verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutIdempotencyKey returns opts followed by an option that removes the key set
+// with WithIdempotencyKey. Calls that send several mutating requests use it, as one
+// key cannot identify them all; automatic keys are still generated per request.
+func withoutIdempotencyKey(opts []CallOption) []CallOption {
+	return append(opts[:len(opts):len(opts)], func(co *callOptions) {
+		co.headers.Del(headerIdempotencyKey)
+	})
+}
@@ -0,0 +1,108 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCreateOrDelete(t *testing.T) {
+	t.Parallel()
+	cases := map[string]bool{
+		"/catalog/create":               true,
+		"/catalog/delete":               true,
+		"/role/batch_create":            true,
+		"/catalog/file/copy":            true,
+		"/catalog/file/upload":          true,
+		"/catalog/delete_ref?x=1":       true,
+		"/catalog/info":                 false,
+		"/catalog/file/upload_link":     true,
+		"/catalog/table/truncate":       false,
+		"/catalog/volume/snapshot/list": false,
+	}
+	for path, want := range cases {
+		require.Equal(t, want, isCreateOrDelete(path), path)
+	}
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	t.Parallel()
+	var keys []string
+	client, err := NewRawClient("https://moi.test", "key",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			keys = append(keys, r.Header.Get(headerIdempotencyKey))
+			return envelopeResponse(`{"id":1}`), nil
+		})}),
+		WithAutoIdempotencyKeys())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: "c"}, WithIdempotencyKey("create-c"))
+	require.NoError(t, err)
+	_, err = client.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: "c"})
+	require.NoError(t, err)
+	_, err = client.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: "c"})
+	require.NoError(t, err)
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+
+	require.Len(t, keys, 4)
+	require.Equal(t, "create-c", keys[0])
+	require.Len(t, keys[1], 36)
+	require.NotEqual(t, keys[1], keys[2])
+	require.Empty(t, keys[3])
+
+	// Without the client option only explicit keys are sent
+	keys = nil
+	plain := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		keys = append(keys, r.Header.Get(headerIdempotencyKey))
+		return envelopeResponse(`{"id":1}`), nil
+	})
+	_, err = plain.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: "c"})
+	require.NoError(t, err)
+	require.Equal(t, []string{""}, keys)
+}
+
+func TestIdempotencyKeys_FanOut(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	keys := map[string][]string{}
+	client, err := NewRawClient("https://moi.test", "key",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			keys[r.URL.Path] = append(keys[r.URL.Path], r.Header.Get(headerIdempotencyKey))
+			switch r.URL.Path {
+			case "/catalog/file/list":
+				return envelopeResponse(`{"total":0,"list":[]}`), nil
+			case "/catalog/file/batch_delete":
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("not found"))}, nil
+			}
+			return envelopeResponse(`{"id":"d1"}`), nil
+		})}),
+		WithAutoIdempotencyKeys())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Each folder created gets a key of its own instead of the key of the call
+	_, created, err := NewSDKClient(client).EnsureFolderPath(ctx, "v1", "a/b", WithIdempotencyKey("mkdir"))
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	folderKeys := keys["/catalog/folder/create"]
+	require.Len(t, folderKeys, 2)
+	require.NotContains(t, folderKeys, "mkdir")
+	require.NotEqual(t, folderKeys[0], folderKeys[1])
+
+	// So do the individual requests that replace a missing batch endpoint, while the
+	// batch request itself carries the key
+	_, err = client.DeleteFilesBatch(ctx, []FileID{"f1", "f2"}, WithIdempotencyKey("cleanup"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"cleanup"}, keys["/catalog/file/batch_delete"])
+	require.Len(t, keys["/catalog/file/delete"], 2)
+	require.NotContains(t, keys["/catalog/file/delete"], "cleanup")
+}
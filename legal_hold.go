@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+)
+
+// SetLegalHold places or releases a legal hold on a file, folder or volume.
+//
+// While a hold is in place, delete, truncate and clean operations on the object and
+// everything it contains fail with an error matching ErrLegalHold.
+//
+// Example:
+//
+//	_, err := client.SetLegalHold(ctx, &sdk.LegalHoldSetRequest{
+//		ObjectType: sdk.LegalHoldObjectVolume,
+//		ObjectID:   "volume-id-123",
+//		Enabled:    true,
+//		Reason:     "contract records, case 2024-17",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//
+//	_, err = client.DeleteVolume(ctx, &sdk.VolumeDeleteRequest{VolumeID: "volume-id-123"})
+//	if sdk.IsLegalHold(err) {
+//		fmt.Println("volume is under legal hold")
+//	}
+func (c *RawClient) SetLegalHold(ctx context.Context, req *LegalHoldSetRequest, opts ...CallOption) (*LegalHoldSetResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp LegalHoldSetResponse
+	if err := c.postJSON(ctx, "/catalog/legal_hold/set", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetLegalHold retrieves the legal hold state of a file, folder or volume.
+//
+// The response reports whether the hold is set on the object itself or inherited from an ancestor.
+//
+// Example:
+//
+//	info, err := client.GetLegalHold(ctx, &sdk.LegalHoldInfoRequest{
+//		ObjectType: sdk.LegalHoldObjectFile,
+//		ObjectID:   "file-id-123",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("held: %v (inherited: %v)\n", info.Enabled, info.Inherited)
+func (c *RawClient) GetLegalHold(ctx context.Context, req *LegalHoldInfoRequest, opts ...CallOption) (*LegalHoldInfoResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp LegalHoldInfoResponse
+	if err := c.postJSON(ctx, "/catalog/legal_hold/info", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
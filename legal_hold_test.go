@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegalHoldNilRequestErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	_, err := client.SetLegalHold(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.GetLegalHold(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+}
+
+func TestLegalHoldErrorClassification(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		resp := errorEnvelopeResponse("ErrInternal", "file is under legal hold")
+		resp.StatusCode = http.StatusForbidden
+		return resp, nil
+	})
+	_, err := client.DeleteFile(context.Background(), &FileDeleteRequest{FileID: "f1"})
+	require.True(t, IsLegalHold(err))
+	require.False(t, IsPermissionDenied(err))
+
+	require.True(t, IsLegalHold(&APIError{Code: "ErrLegalHold"}))
+	require.True(t, IsLegalHold(&HTTPError{StatusCode: http.StatusLocked}))
+}
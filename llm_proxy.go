@@ -29,53 +29,16 @@ func (c *RawClient) doLLMJSON(ctx context.Context, method, path string, body int
 		reader = bytes.NewReader(payload)
 	}
 
-	// Determine base URL and path
-	var baseURL string
-	var fullPath string
-
-	if callOpts.useDirectLLMProxy && c.llmProxyBaseURL != "" {
-		// Direct connection to LLM Proxy (no prefix)
-		baseURL = c.llmProxyBaseURL
-		fullPath = ensureLeadingSlash(path)
-	} else {
-		// Default: through MOI SDK gateway with /llm-proxy prefix
-		baseURL = c.baseURL
-		fullPath = "/llm-proxy" + ensureLeadingSlash(path)
-	}
-
-	// Build full URL
-	fullURL := baseURL + fullPath
-	if len(callOpts.query) > 0 {
-		delimiter := "?"
-		if strings.Contains(fullURL, "?") {
-			delimiter = "&"
-		}
-		fullURL = fullURL + delimiter + callOpts.query.Encode()
-	}
-
-	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, reader)
+	req, err := c.buildLLMRequest(ctx, method, path, reader, callOpts)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		req.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		req.Header.Set(headerRequestID, callOpts.requestID)
+		return err
 	}
-	mergeHeaders(req.Header, callOpts.headers, true)
 	req.Header.Set(headerAccept, mimeJSON)
 	if body != nil {
 		req.Header.Set(headerContentType, mimeJSON)
 	}
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(c.httpClient, req, callOpts)
 	if err != nil {
 		return err
 	}
@@ -104,7 +67,7 @@ func (c *RawClient) doLLMJSON(ctx context.Context, method, path string, body int
 			}
 		}
 		// If not in error format, return HTTP error
-		return &HTTPError{StatusCode: resp.StatusCode, Body: data}
+		return newHTTPError(resp, data)
 	}
 
 	// Parse successful response
@@ -116,6 +79,16 @@ func (c *RawClient) doLLMJSON(ctx context.Context, method, path string, body int
 	return nil
 }
 
+// buildLLMRequest builds a request to path of the LLM Proxy API, sent through the
+// MOI gateway under /llm-proxy, or directly to the LLM Proxy with WithDirectLLMProxy
+// when its base URL is configured.
+func (c *RawClient) buildLLMRequest(ctx context.Context, method, path string, body io.Reader, callOpts callOptions) (*http.Request, error) {
+	if callOpts.useDirectLLMProxy && c.llmProxyBaseURL != "" {
+		return c.buildRequestTo(ctx, method, c.llmProxyBaseURL, path, body, callOpts)
+	}
+	return c.buildRequestTo(ctx, method, c.baseURL, "/llm-proxy"+ensureLeadingSlash(path), body, callOpts)
+}
+
 // ============ Session Management APIs ============
 
 // CreateLLMSession creates a new session in LLM Proxy.
@@ -163,6 +136,7 @@ func (c *RawClient) ListLLMSessions(ctx context.Context, req *LLMSessionListRequ
 	if req == nil {
 		return nil, ErrNilRequest
 	}
+	c.applyPageDefaults(req)
 
 	// Build query parameters
 	query := url.Values{}
@@ -368,51 +342,15 @@ func (c *RawClient) ModifyLLMSessionMessageResponse(ctx context.Context, session
 	}
 	callOpts := newCallOptions(opts...)
 
-	// Determine base URL and path
-	var baseURL string
-	var fullPath string
-
-	if callOpts.useDirectLLMProxy && c.llmProxyBaseURL != "" {
-		// Direct connection to LLM Proxy (no prefix)
-		baseURL = c.llmProxyBaseURL
-		fullPath = ensureLeadingSlash(fmt.Sprintf("/api/sessions/%d/messages/%d/modify-response", sessionID, messageID))
-	} else {
-		// Default: through MOI SDK gateway with /llm-proxy prefix
-		baseURL = c.baseURL
-		fullPath = "/llm-proxy" + ensureLeadingSlash(fmt.Sprintf("/api/sessions/%d/messages/%d/modify-response", sessionID, messageID))
-	}
-
-	// Build full URL
-	fullURL := baseURL + fullPath
-	if len(callOpts.query) > 0 {
-		delimiter := "?"
-		if strings.Contains(fullURL, "?") {
-			delimiter = "&"
-		}
-		fullURL = fullURL + delimiter + callOpts.query.Encode()
-	}
-
-	// Create request with plain text body
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, fullURL, strings.NewReader(modifiedResponse))
+	path := fmt.Sprintf("/api/sessions/%d/messages/%d/modify-response", sessionID, messageID)
+	req, err := c.buildLLMRequest(ctx, http.MethodPut, path, strings.NewReader(modifiedResponse), callOpts)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		req.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		req.Header.Set(headerRequestID, callOpts.requestID)
+		return nil, err
 	}
-	mergeHeaders(req.Header, callOpts.headers, true)
 	req.Header.Set(headerAccept, mimeJSON)
 	req.Header.Set(headerContentType, "text/plain")
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(c.httpClient, req, callOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -441,7 +379,7 @@ func (c *RawClient) ModifyLLMSessionMessageResponse(ctx context.Context, session
 			}
 		}
 		// If not in error format, return HTTP error
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
+		return nil, newHTTPError(resp, data)
 	}
 
 	// Parse successful response
@@ -472,51 +410,15 @@ func (c *RawClient) AppendLLMSessionMessageModifiedResponse(ctx context.Context,
 	}
 	callOpts := newCallOptions(opts...)
 
-	// Determine base URL and path
-	var baseURL string
-	var fullPath string
-
-	if callOpts.useDirectLLMProxy && c.llmProxyBaseURL != "" {
-		// Direct connection to LLM Proxy (no prefix)
-		baseURL = c.llmProxyBaseURL
-		fullPath = ensureLeadingSlash(fmt.Sprintf("/api/sessions/%d/messages/%d/append-modified-response", sessionID, messageID))
-	} else {
-		// Default: through MOI SDK gateway with /llm-proxy prefix
-		baseURL = c.baseURL
-		fullPath = "/llm-proxy" + ensureLeadingSlash(fmt.Sprintf("/api/sessions/%d/messages/%d/append-modified-response", sessionID, messageID))
-	}
-
-	// Build full URL
-	fullURL := baseURL + fullPath
-	if len(callOpts.query) > 0 {
-		delimiter := "?"
-		if strings.Contains(fullURL, "?") {
-			delimiter = "&"
-		}
-		fullURL = fullURL + delimiter + callOpts.query.Encode()
-	}
-
-	// Create request with plain text body
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, strings.NewReader(appendContent))
+	path := fmt.Sprintf("/api/sessions/%d/messages/%d/append-modified-response", sessionID, messageID)
+	req, err := c.buildLLMRequest(ctx, http.MethodPost, path, strings.NewReader(appendContent), callOpts)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	// Set headers
-	req.Header.Set(headerAPIKey, c.apiKey)
-	if c.userAgent != "" {
-		req.Header.Set(headerUserAgent, c.userAgent)
-	}
-	mergeHeaders(req.Header, c.defaultHeaders, false)
-	if callOpts.requestID != "" {
-		req.Header.Set(headerRequestID, callOpts.requestID)
+		return nil, err
 	}
-	mergeHeaders(req.Header, callOpts.headers, true)
 	req.Header.Set(headerAccept, mimeJSON)
 	req.Header.Set(headerContentType, "text/plain")
 
-	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(c.httpClient, req, callOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -545,7 +447,7 @@ func (c *RawClient) AppendLLMSessionMessageModifiedResponse(ctx context.Context,
 			}
 		}
 		// If not in error format, return HTTP error
-		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: data}
+		return nil, newHTTPError(resp, data)
 	}
 
 	// Parse successful response
@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const headerAcceptLanguage = "Accept-Language"
+
+// ErrorTranslator maps API errors to localized, user-facing messages.
+//
+// languages lists the language tags of the request's Accept-Language header in order
+// of preference (e.g. ["zh-CN", "zh", "en"]); it is empty when no language was
+// requested. Translate returns false when it has no message for the error.
+type ErrorTranslator interface {
+	Translate(err *APIError, languages []string) (string, bool)
+}
+
+// ErrorTranslatorFunc adapts a function into an ErrorTranslator.
+type ErrorTranslatorFunc func(err *APIError, languages []string) (string, bool)
+
+// Translate calls f.
+func (f ErrorTranslatorFunc) Translate(err *APIError, languages []string) (string, bool) {
+	return f(err, languages)
+}
+
+// MessageCatalog is an ErrorTranslator backed by a table of messages, keyed by error
+// code and then by language tag.
+//
+// Codes are matched after normalization, so "ErrNotFound", "NOT_FOUND" and "notfound"
+// are the same key. When the code itself is not in the catalog, the error category
+// is looked up instead under "notfound", "alreadyexists", "permissiondenied",
+// "quotaexceeded", "invalidargument", "legalhold" or "resourcechanged". Language tags are matched exactly
+// first and then by primary language ("zh-CN" matches "zh"); English is used when none
+// of the requested languages is available.
+//
+// Example:
+//
+//	catalog := sdk.MessageCatalog{
+//		"ErrTableNameInvalid": {"en": "Table names may only contain letters, digits and _", "zh": "表名只能包含字母、数字和下划线"},
+//	}
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithErrorTranslator(catalog.Merge(sdk.DefaultErrorMessages)))
+type MessageCatalog map[string]map[string]string
+
+// DefaultErrorMessages provides English and Chinese messages for every error category.
+var DefaultErrorMessages = MessageCatalog{
+	"notfound":         {"en": "The requested resource does not exist.", "zh": "请求的资源不存在。"},
+	"alreadyexists":    {"en": "A resource with the same name already exists.", "zh": "同名资源已存在。"},
+	"permissiondenied": {"en": "You do not have permission to perform this operation.", "zh": "您没有执行此操作的权限。"},
+	"quotaexceeded":    {"en": "The quota or rate limit has been exceeded. Please try again later.", "zh": "已超出配额或频率限制，请稍后再试。"},
+	"invalidargument":  {"en": "The request contains invalid parameters.", "zh": "请求参数无效。"},
+	"legalhold":        {"en": "The object is under legal hold and cannot be modified.", "zh": "对象处于法律保留状态，无法修改。"},
+	"resourcechanged":  {"en": "The resource was modified by someone else. Please reload and try again.", "zh": "资源已被他人修改，请刷新后重试。"},
+}
+
+// errorKindKeys maps error categories to their MessageCatalog key.
+var errorKindKeys = map[error]string{
+	ErrNotFound:         "notfound",
+	ErrAlreadyExists:    "alreadyexists",
+	ErrPermissionDenied: "permissiondenied",
+	ErrQuotaExceeded:    "quotaexceeded",
+	ErrInvalidArgument:  "invalidargument",
+	ErrLegalHold:        "legalhold",
+	ErrResourceChanged:  "resourcechanged",
+}
+
+// Translate implements ErrorTranslator.
+func (m MessageCatalog) Translate(err *APIError, languages []string) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	messages, ok := m.lookup(normalizeErrorCode(err.Code))
+	if !ok {
+		if key, known := errorKindKeys[err.Kind()]; known {
+			messages, ok = m.lookup(key)
+		}
+	}
+	if !ok {
+		return "", false
+	}
+	return pickLanguage(messages, languages)
+}
+
+// Merge returns a new catalog with the entries of m, completed by those of fallback
+// for codes and languages that m does not define.
+func (m MessageCatalog) Merge(fallback MessageCatalog) MessageCatalog {
+	out := make(MessageCatalog, len(m)+len(fallback))
+	for _, src := range []MessageCatalog{fallback, m} {
+		for code, messages := range src {
+			key := normalizeErrorCode(code)
+			if out[key] == nil {
+				out[key] = make(map[string]string, len(messages))
+			}
+			for lang, msg := range messages {
+				out[key][lang] = msg
+			}
+		}
+	}
+	return out
+}
+
+func (m MessageCatalog) lookup(key string) (map[string]string, bool) {
+	if messages, ok := m[key]; ok {
+		return messages, true
+	}
+	for code, messages := range m {
+		if normalizeErrorCode(code) == key {
+			return messages, true
+		}
+	}
+	return nil, false
+}
+
+func pickLanguage(messages map[string]string, languages []string) (string, bool) {
+	tags := make([]string, 0, len(messages))
+	for tag := range messages {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	for _, lang := range languages {
+		for _, tag := range tags {
+			if strings.EqualFold(tag, lang) {
+				return messages[tag], true
+			}
+		}
+		primary, _, _ := strings.Cut(lang, "-")
+		for _, tag := range tags {
+			if strings.EqualFold(tag, primary) {
+				return messages[tag], true
+			}
+		}
+		for _, tag := range tags {
+			if tagPrimary, _, _ := strings.Cut(tag, "-"); strings.EqualFold(tagPrimary, primary) {
+				return messages[tag], true
+			}
+		}
+	}
+	msg, ok := messages["en"]
+	return msg, ok
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header in the
+// order they appear, dropping quality values and the "*" wildcard.
+func parseAcceptLanguage(header string) []string {
+	var langs []string
+	for _, part := range strings.Split(header, ",") {
+		tag, _, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag != "" && tag != "*" {
+			langs = append(langs, tag)
+		}
+	}
+	return langs
+}
+
+// localizeAPIError sets the localized message of apiErr using the client's translator
+// and the languages requested by req.
+func (c *RawClient) localizeAPIError(req *http.Request, apiErr *APIError) {
+	if c == nil || c.errorTranslator == nil || apiErr == nil {
+		return
+	}
+	var languages []string
+	if req != nil {
+		languages = parseAcceptLanguage(req.Header.Get(headerAcceptLanguage))
+	}
+	if msg, ok := c.errorTranslator.Translate(apiErr, languages); ok {
+		apiErr.LocalizedMessage = msg
+	}
+}
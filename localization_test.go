@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageCatalog_Translate(t *testing.T) {
+	t.Parallel()
+
+	catalog := MessageCatalog{
+		"ErrTableNameInvalid": {"en": "Invalid table name", "zh-CN": "表名无效"},
+	}.Merge(DefaultErrorMessages)
+
+	tests := []struct {
+		name      string
+		err       *APIError
+		languages []string
+		want      string
+		ok        bool
+	}{
+		{"code exact language", &APIError{Code: "ERR_TABLE_NAME_INVALID"}, []string{"zh-CN"}, "表名无效", true},
+		{"code primary language", &APIError{Code: "ErrTableNameInvalid"}, []string{"zh"}, "表名无效", true},
+		{"english fallback", &APIError{Code: "ErrTableNameInvalid"}, []string{"fr"}, "Invalid table name", true},
+		{"category by code", &APIError{Code: "ErrNotFound"}, []string{"zh-TW", "en"}, "请求的资源不存在。", true},
+		{"category by status", &APIError{Code: "ErrInternal", HTTPStatus: http.StatusForbidden}, nil, "You do not have permission to perform this operation.", true},
+		{"unknown", &APIError{Code: "ErrInternal"}, []string{"zh"}, "", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := catalog.Translate(tc.err, tc.languages)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"zh-CN", "zh", "en"}, parseAcceptLanguage("zh-CN, zh;q=0.9, en;q=0.8, *;q=0.1"))
+	require.Empty(t, parseAcceptLanguage(""))
+}
+
+func TestWithErrorTranslator(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewRawClient("https://moi.test", "key",
+		WithErrorTranslator(DefaultErrorMessages),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return errorEnvelopeResponse("ErrNotFound", "catalog 123 not exist"), nil
+		})}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 123}, WithAcceptLanguage("zh-CN", "en"))
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, "请求的资源不存在。", apiErr.UserMessage())
+	require.Equal(t, "catalog 123 not exist", apiErr.Message)
+
+	// Without a translator the server message is used
+	require.Equal(t, "boom", (&APIError{Message: "boom"}).UserMessage())
+}
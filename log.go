@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"fmt"
 )
 
 // ListUserLogs lists user operation logs with optional filtering and pagination.
@@ -75,3 +76,37 @@ func (c *RawClient) ListRoleLogs(ctx context.Context, req *LogLogListRequest, op
 	}
 	return &resp, nil
 }
+
+// ListObjectLogs lists operations on catalogs, databases, tables, volumes, folders
+// and files, newest first.
+//
+// Use it to answer questions such as who deleted a volume and when. Entries are kept
+// after the object itself is deleted.
+//
+// Example:
+//
+//	resp, err := client.ListObjectLogs(ctx, &sdk.ObjectLogRequest{
+//		ObjectType: sdk.CatalogObjectVolume,
+//		ObjectID:   "volume-id-123",
+//		Action:     "delete",
+//		TimeRange:  sdk.TimeRange{Start: time.Now().AddDate(0, 0, -30).Unix()},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, entry := range resp.List {
+//		fmt.Printf("%s %s by %s\n", entry.Action, entry.ObjectName, entry.UserName)
+//	}
+func (c *RawClient) ListObjectLogs(ctx context.Context, req *ObjectLogRequest, opts ...CallOption) (*ObjectLogResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.TimeRange.Start > 0 && req.TimeRange.End > 0 && req.TimeRange.End <= req.TimeRange.Start {
+		return nil, fmt.Errorf("time range end must be after start")
+	}
+	var resp ObjectLogResponse
+	if err := c.postJSON(ctx, "/log/object", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,38 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListObjectLogs(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/log/object", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "volume", body["object_type"])
+		require.Equal(t, map[string]interface{}{"start_time": float64(100)}, body["time_range"])
+		return envelopeResponse(`{"total":1,"log_list":[{"object_type":"volume","object_id":"v1","action":"delete","user_name":"bob","created_at":150}]}`), nil
+	})
+
+	resp, err := client.ListObjectLogs(context.Background(), &ObjectLogRequest{
+		ObjectType: CatalogObjectVolume,
+		ObjectID:   "v1",
+		TimeRange:  TimeRange{Start: 100},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.List, 1)
+	require.Equal(t, "bob", resp.List[0].UserName)
+	require.Equal(t, int64(150), resp.List[0].CreatedAt)
+
+	_, err = client.ListObjectLogs(context.Background(), nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.ListObjectLogs(context.Background(), &ObjectLogRequest{TimeRange: TimeRange{Start: 200, End: 100}})
+	require.ErrorContains(t, err, "end must be after start")
+}
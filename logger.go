@@ -0,0 +1,330 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogLevel is the severity of a message passed to a Logger.
+type LogLevel int
+
+const (
+	LogLevelDebug LogLevel = iota // Every request and its outcome
+	LogLevelInfo
+	LogLevelWarn  // Failed requests and retries
+	LogLevelError // Requests that could not be sent
+)
+
+// String returns the lowercase name of the level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	default:
+		return fmt.Sprintf("level(%d)", int(l))
+	}
+}
+
+// Logger receives the log messages of the SDK.
+//
+// keysAndValues holds alternating string keys and arbitrary values, in the style of
+// slog and zap's SugaredLogger. Implementations must be safe for concurrent use.
+// Adapters are provided for slog (NewSlogLogger), zap (NewZapLogger) and logrus
+// (NewLogrusLogger); any other logger can be plugged in with LoggerFunc.
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{})
+}
+
+// LoggerFunc adapts a function into a Logger.
+type LoggerFunc func(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{})
+
+// Log calls f.
+func (f LoggerFunc) Log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	f(ctx, level, msg, keysAndValues...)
+}
+
+// NewSlogLogger returns a Logger that writes to l.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithLogger(sdk.NewSlogLogger(slog.Default())))
+func NewSlogLogger(l *slog.Logger) Logger {
+	return LoggerFunc(func(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		l.Log(ctx, slogLevel(level), msg, keysAndValues...)
+	})
+}
+
+func slogLevel(level LogLevel) slog.Level {
+	switch level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// ZapSugaredLogger is the subset of *zap.SugaredLogger used by NewZapLogger. It is
+// declared here so that the SDK does not depend on zap.
+type ZapSugaredLogger interface {
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// NewZapLogger returns a Logger that writes structured entries to a zap SugaredLogger.
+//
+// Example:
+//
+//	zl, _ := zap.NewProduction()
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithLogger(sdk.NewZapLogger(zl.Sugar())))
+func NewZapLogger(l ZapSugaredLogger) Logger {
+	return LoggerFunc(func(_ context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+		switch level {
+		case LogLevelDebug:
+			l.Debugw(msg, keysAndValues...)
+		case LogLevelWarn:
+			l.Warnw(msg, keysAndValues...)
+		case LogLevelError:
+			l.Errorw(msg, keysAndValues...)
+		default:
+			l.Infow(msg, keysAndValues...)
+		}
+	})
+}
+
+// LogrusLogger is the subset of logrus.FieldLogger used by NewLogrusLogger; both
+// *logrus.Logger and *logrus.Entry implement it. It is declared here so that the
+// SDK does not depend on logrus.
+type LogrusLogger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// NewLogrusLogger returns a Logger that writes to a logrus logger or entry. The
+// key/value pairs are appended to the message as key=value.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithLogger(sdk.NewLogrusLogger(logrus.WithField("component", "moi"))))
+func NewLogrusLogger(l LogrusLogger) Logger {
+	return LoggerFunc(func(_ context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+		line := formatLogLine(msg, keysAndValues)
+		switch level {
+		case LogLevelDebug:
+			l.Debugf("%s", line)
+		case LogLevelWarn:
+			l.Warnf("%s", line)
+		case LogLevelError:
+			l.Errorf("%s", line)
+		default:
+			l.Infof("%s", line)
+		}
+	})
+}
+
+// formatLogLine renders msg followed by key=value pairs. Values containing spaces
+// are quoted; a trailing key without a value is reported as such.
+func formatLogLine(msg string, keysAndValues []interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		if i+1 >= len(keysAndValues) {
+			fmt.Fprintf(&b, " %s=<missing>", key)
+			break
+		}
+		value := fmt.Sprint(keysAndValues[i+1])
+		if strings.ContainsAny(value, " \t\n\"") {
+			value = fmt.Sprintf("%q", value)
+		}
+		fmt.Fprintf(&b, " %s=%s", key, value)
+	}
+	return b.String()
+}
+
+// log writes to the client's logger, if one is configured.
+func (c *RawClient) log(ctx context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	if c == nil || c.logger == nil {
+		return
+	}
+	c.logger.Log(ctx, level, msg, keysAndValues...)
+}
+
+// loggingTransport logs every request that passes through the client's transport.
+// Only the host and path are logged, so that signatures in query strings of signed
+// URLs do not end up in logs. With a positive bodyBytes, the redacted headers and
+// bodies are logged as well (see WithLogBodies).
+type loggingTransport struct {
+	base      http.RoundTripper
+	logger    Logger
+	bodyBytes int
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	var requestBody string
+	if t.bodyBytes > 0 {
+		requestBody = t.requestBody(req)
+	}
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	kv := []interface{}{
+		"method", req.Method,
+		"host", req.URL.Host,
+		"path", req.URL.Path,
+		"latency", time.Since(start),
+	}
+	if id := req.Header.Get(headerRequestID); id != "" {
+		kv = append(kv, "request_id", id)
+	}
+	if t.bodyBytes > 0 {
+		kv = append(kv, "request_headers", redactHeaders(req.Header))
+		if requestBody != "" {
+			kv = append(kv, "request_body", requestBody)
+		}
+		if err == nil {
+			if body := t.responseBody(resp); body != "" {
+				kv = append(kv, "response_body", body)
+			}
+		}
+	}
+	switch {
+	case err != nil:
+		t.logger.Log(req.Context(), LogLevelError, "moi request failed", append(kv, "error", err)...)
+	case resp.StatusCode >= http.StatusBadRequest:
+		t.logger.Log(req.Context(), LogLevelWarn, "moi request returned error status", append(kv, "status", resp.StatusCode)...)
+	default:
+		t.logger.Log(req.Context(), LogLevelDebug, "moi request", append(kv, "status", resp.StatusCode)...)
+	}
+	return resp, err
+}
+
+func (t *loggingTransport) unwrap() http.RoundTripper { return t.base }
+
+// requestBody returns the loggable part of the body of req, read from a copy so that
+// the body sent is left untouched.
+func (t *loggingTransport) requestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	contentType := req.Header.Get(headerContentType)
+	if !isLoggableContentType(contentType) {
+		return fmt.Sprintf("<%s body>", contentTypeOrUnknown(contentType))
+	}
+	if req.GetBody == nil {
+		return "<body not replayable>"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, int64(t.bodyBytes)+1))
+	if err != nil {
+		return ""
+	}
+	return redactBody(data, t.bodyBytes)
+}
+
+// responseBody returns the loggable part of the body of resp. The bytes read are put
+// back in front of the remaining body for the caller.
+func (t *loggingTransport) responseBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+	contentType := resp.Header.Get(headerContentType)
+	if !isLoggableContentType(contentType) {
+		return fmt.Sprintf("<%s body>", contentTypeOrUnknown(contentType))
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(t.bodyBytes)+1))
+	resp.Body = &replayedBody{Reader: io.MultiReader(bytes.NewReader(data), resp.Body), body: resp.Body}
+	if err != nil && len(data) == 0 {
+		return ""
+	}
+	return redactBody(data, t.bodyBytes)
+}
+
+// replayedBody serves the bytes already read from body before the rest of it.
+type replayedBody struct {
+	io.Reader
+	body io.ReadCloser
+}
+
+func (b *replayedBody) Close() error { return b.body.Close() }
+
+// isLoggableContentType reports whether bodies of contentType are text that can be
+// logged. Event streams are excluded: reading ahead would block until the service
+// sends enough events.
+func isLoggableContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	switch {
+	case mediaType == "" || mediaType == "text/event-stream":
+		return false
+	case mediaType == "application/x-www-form-urlencoded":
+		return true
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	default:
+		return mediaType == mimeJSON || strings.HasSuffix(mediaType, "+json")
+	}
+}
+
+func contentTypeOrUnknown(contentType string) string {
+	if contentType == "" {
+		return "unknown"
+	}
+	return contentType
+}
+
+// sensitiveBodyField matches a JSON string member, possibly cut at the end of a
+// truncated body, whose name denotes a credential.
+var sensitiveBodyField = regexp.MustCompile(`(?i)("(?:key|[^"]*(?:password|passwd|secret|credential)[^"]*|[^"]*(?:token|signature|authorization|api_?key|access_?key|private_?key|moi-key))"\s*:\s*)"(?:[^"\\]|\\.)*(?:"|\\?$)`)
+
+// sensitiveFormField matches a form field whose name denotes a credential.
+var sensitiveFormField = regexp.MustCompile(`(?i)((?:^|&)(?:key|[^=&]*(?:password|passwd|secret|credential)[^=&]*|[^=&]*(?:token|signature|api_?key|access_?key|private_?key))=)[^&]*`)
+
+// redactBody truncates data to limit bytes and masks the credentials it contains.
+func redactBody(data []byte, limit int) string {
+	truncated := len(data) > limit
+	if truncated {
+		data = data[:limit]
+	}
+	text := sensitiveBodyField.ReplaceAllString(string(data), `$1"****"`)
+	if !strings.ContainsAny(text, "{[\"") {
+		text = sensitiveFormField.ReplaceAllString(text, "${1}****")
+	}
+	if truncated {
+		text += "...(truncated)"
+	}
+	return text
+}
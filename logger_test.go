@@ -0,0 +1,158 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordedLog struct {
+	level LogLevel
+	msg   string
+	kv    []interface{}
+}
+
+type recordingLogger struct {
+	mu   sync.Mutex
+	logs []recordedLog
+}
+
+func (l *recordingLogger) Log(_ context.Context, level LogLevel, msg string, keysAndValues ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logs = append(l.logs, recordedLog{level: level, msg: msg, kv: keysAndValues})
+}
+
+// fakeSugared records calls the way *zap.SugaredLogger would receive them.
+type fakeSugared struct{ calls []string }
+
+func (f *fakeSugared) Debugw(msg string, kv ...interface{}) { f.add("debug", msg, kv) }
+func (f *fakeSugared) Infow(msg string, kv ...interface{})  { f.add("info", msg, kv) }
+func (f *fakeSugared) Warnw(msg string, kv ...interface{})  { f.add("warn", msg, kv) }
+func (f *fakeSugared) Errorw(msg string, kv ...interface{}) { f.add("error", msg, kv) }
+func (f *fakeSugared) add(level, msg string, kv []interface{}) {
+	f.calls = append(f.calls, fmt.Sprintf("%s %s %v", level, msg, kv))
+}
+
+// fakeLogrus records calls the way *logrus.Entry would receive them.
+type fakeLogrus struct{ calls []string }
+
+func (f *fakeLogrus) Debugf(format string, args ...interface{}) { f.add("debug", format, args) }
+func (f *fakeLogrus) Infof(format string, args ...interface{})  { f.add("info", format, args) }
+func (f *fakeLogrus) Warnf(format string, args ...interface{})  { f.add("warn", format, args) }
+func (f *fakeLogrus) Errorf(format string, args ...interface{}) { f.add("error", format, args) }
+func (f *fakeLogrus) add(level, format string, args []interface{}) {
+	f.calls = append(f.calls, level+" "+fmt.Sprintf(format, args...))
+}
+
+func TestLoggerAdapters(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	slogLogger := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	slogLogger.Log(ctx, LogLevelWarn, "retrying", "attempt", 2)
+	require.Contains(t, buf.String(), "level=WARN msg=retrying attempt=2")
+
+	zap := &fakeSugared{}
+	NewZapLogger(zap).Log(ctx, LogLevelError, "failed", "path", "/catalog/create")
+	require.Equal(t, []string{"error failed [path /catalog/create]"}, zap.calls)
+
+	logrus := &fakeLogrus{}
+	NewLogrusLogger(logrus).Log(ctx, LogLevelDebug, "moi request", "path", "/a", "error", "dial failed", "odd")
+	require.Equal(t, []string{`debug moi request path=/a error="dial failed" odd=<missing>`}, logrus.calls)
+}
+
+func TestWithLogger_LogsRequests(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+	client, err := NewRawClient("https://moi.test", "key",
+		WithLogger(logger),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if strings.HasSuffix(r.URL.Path, "/delete") {
+				resp := errorEnvelopeResponse("ErrInternal", "boom")
+				resp.StatusCode = http.StatusInternalServerError
+				return resp, nil
+			}
+			return envelopeResponse(`{}`), nil
+		})}),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.GetFile(ctx, &FileInfoRequest{FileID: "f1"}, WithRequestID("req-1"))
+	require.NoError(t, err)
+	_, err = client.DeleteFile(ctx, &FileDeleteRequest{FileID: "f1"})
+	require.Error(t, err)
+
+	require.Len(t, logger.logs, 2)
+	require.Equal(t, LogLevelDebug, logger.logs[0].level)
+	require.Contains(t, logger.logs[0].kv, "/catalog/file/info")
+	require.Contains(t, logger.logs[0].kv, "req-1")
+	require.Equal(t, LogLevelWarn, logger.logs[1].level)
+	require.Contains(t, logger.logs[1].kv, http.StatusInternalServerError)
+
+	// Stats still see requests and the debug view still reports the user transport
+	require.EqualValues(t, 2, client.Stats().Requests)
+	require.Equal(t, "sdk.roundTripperFunc", client.DebugInfo().ConnectionPool.Transport)
+}
+
+func TestRedactBody(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		body  string
+		limit int
+		want  string
+	}{
+		{`{"name":"u1","password":"p@ss\"word","api_key":"k1"}`, 100, `{"name":"u1","password":"****","api_key":"****"}`},
+		{`{"key": "abc", "key_id": 7, "max_tokens": 10, "client_secret_value":"s"}`, 100, `{"key": "****", "key_id": 7, "max_tokens": 10, "client_secret_value":"****"}`},
+		{`{"name":"u1","password":"secret-value"}`, 30, `{"name":"u1","password":"****"...(truncated)`},
+		{`grant_type=password&password=p1&client_id=c`, 100, `grant_type=password&password=****&client_id=c`},
+		{`plain text`, 5, `plain...(truncated)`},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, redactBody([]byte(tc.body), tc.limit), tc.body)
+	}
+}
+
+func TestWithLogBodies(t *testing.T) {
+	t.Parallel()
+
+	logger := &recordingLogger{}
+	var sent string
+	client, err := NewRawClient("https://moi.test", "secret-api-key",
+		WithLogger(logger),
+		WithLogBodies(0),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			data, _ := io.ReadAll(r.Body)
+			sent = string(data)
+			return envelopeResponse(`{"id":1,"api_key":"new-user-key"}`), nil
+		})}),
+	)
+	require.NoError(t, err)
+
+	resp, err := client.CreateUser(context.Background(), &UserCreateRequest{UserName: "u1", Password: "p1", GetApiKey: true})
+	require.NoError(t, err)
+	require.Equal(t, "new-user-key", resp.ApiKey)
+	require.Contains(t, sent, `"password":"p1"`)
+
+	require.Len(t, logger.logs, 1)
+	kv := map[string]interface{}{}
+	for i := 0; i+1 < len(logger.logs[0].kv); i += 2 {
+		kv[logger.logs[0].kv[i].(string)] = logger.logs[0].kv[i+1]
+	}
+	require.Contains(t, kv["request_body"], `"name":"u1","password":"****"`)
+	require.Contains(t, kv["response_body"], `"api_key":"****"`)
+	require.Equal(t, "****-key", http.Header(kv["request_headers"].(map[string][]string)).Get(headerAPIKey))
+	require.NotContains(t, fmt.Sprint(logger.logs[0].kv), "p1")
+	require.NotContains(t, fmt.Sprint(logger.logs[0].kv), "new-user-key")
+}
@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// MetricsRecorder receives the measurements of every request of a client, to feed a
+// metrics system. The sdkmetrics package provides a Prometheus implementation.
+//
+// Endpoints are identified like in ClientStats: by API path, or by host for signed
+// URLs. Implementations must be safe for concurrent use.
+type MetricsRecorder interface {
+	// RequestStarted is called when a request is sent.
+	RequestStarted(endpoint string)
+	// RequestFinished is called when the response headers of a request arrive, or
+	// when it fails without a response, in which case status is 0.
+	RequestFinished(endpoint, method string, status int, duration time.Duration)
+	// RequestFailed is called for each failed request with the code of the failure:
+	// the API error code of an error envelope, the HTTP status of an error response,
+	// or "timeout", "canceled" or "transport" for a request without a response.
+	RequestFailed(endpoint, code string)
+}
+
+// WithMetricsRecorder sends the measurements of every request of the client to
+// recorder. Clients derived with WithSpecialUser report to the same recorder.
+//
+// Example:
+//
+//	metrics, err := sdkmetrics.New(prometheus.DefaultRegisterer, sdkmetrics.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithMetricsRecorder(metrics))
+func WithMetricsRecorder(recorder MetricsRecorder) ClientOption {
+	return func(o *clientOptions) {
+		o.metrics = recorder
+	}
+}
+
+// metricsTransport reports every request that passes through the client's transport
+// to a MetricsRecorder.
+type metricsTransport struct {
+	base     http.RoundTripper
+	stats    *clientStats // Resolves endpoint names
+	recorder MetricsRecorder
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	endpoint := t.stats.endpointKey(req.URL)
+	t.recorder.RequestStarted(endpoint)
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		t.recorder.RequestFinished(endpoint, req.Method, 0, time.Since(start))
+		t.recorder.RequestFailed(endpoint, transportErrorCode(err))
+		return nil, err
+	}
+	t.recorder.RequestFinished(endpoint, req.Method, resp.StatusCode, time.Since(start))
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.recorder.RequestFailed(endpoint, strconv.Itoa(resp.StatusCode))
+	}
+	return resp, nil
+}
+
+func (t *metricsTransport) unwrap() http.RoundTripper { return t.base }
+
+// transportErrorCode classifies an error of a request that got no response.
+func transportErrorCode(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "transport"
+	}
+}
+
+// recordAPIErrorMetric reports an error envelope to the client's MetricsRecorder.
+func (c *RawClient) recordAPIErrorMetric(req *http.Request, apiErr *APIError) {
+	if c.metrics == nil || req == nil {
+		return
+	}
+	c.metrics.RequestFailed(c.stats.endpointKey(req.URL), apiErr.Code)
+}
@@ -0,0 +1,153 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func TestCatalogServiceMock(t *testing.T) {
+	t.Parallel()
+	catalogs := &CatalogService{
+		GetCatalogFunc: func(ctx context.Context, req *sdk.CatalogInfoRequest, opts ...sdk.CallOption) (*sdk.CatalogInfoResponse, error) {
+			return &sdk.CatalogInfoResponse{CatalogID: req.CatalogID, CatalogName: "sales"}, nil
+		},
+	}
+	var service sdk.CatalogService = catalogs
+
+	resp, err := service.GetCatalog(context.Background(), &sdk.CatalogInfoRequest{CatalogID: 7})
+	require.NoError(t, err)
+	require.Equal(t, "sales", resp.CatalogName)
+	_, err = service.DeleteCatalog(context.Background(), &sdk.CatalogDeleteRequest{CatalogID: 7})
+	require.ErrorIs(t, err, ErrNotStubbed)
+
+	require.Equal(t, 1, catalogs.CallCount("GetCatalog"))
+	require.Equal(t, []Call{
+		{Method: "GetCatalog", Args: []interface{}{&sdk.CatalogInfoRequest{CatalogID: 7}}},
+		{Method: "DeleteCatalog", Args: []interface{}{&sdk.CatalogDeleteRequest{CatalogID: 7}}},
+	}, catalogs.Calls())
+}
+
+func TestServer_Catalogs(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client, err := server.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	created, err := client.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "sales", Comment: "q1"})
+	require.NoError(t, err)
+	_, err = client.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "sales"})
+	require.ErrorIs(t, err, sdk.ErrAlreadyExists)
+	_, err = client.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "hr"})
+	require.NoError(t, err)
+
+	_, err = client.UpdateCatalog(ctx, &sdk.CatalogUpdateRequest{CatalogID: created.CatalogID, CatalogName: "sales-eu", Comment: "q2"})
+	require.NoError(t, err)
+	info, err := client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: created.CatalogID})
+	require.NoError(t, err)
+	require.Equal(t, "sales-eu", info.CatalogName)
+	require.Equal(t, "q2", info.Comment)
+
+	list, err := client.ListCatalogs(ctx)
+	require.NoError(t, err)
+	require.Len(t, list.List, 2)
+	filtered, err := client.ListCatalogsFiltered(ctx, &sdk.CatalogListRequest{Keyword: "SALES"})
+	require.NoError(t, err)
+	require.Equal(t, 1, filtered.Total)
+
+	_, err = client.DeleteCatalog(ctx, &sdk.CatalogDeleteRequest{CatalogID: created.CatalogID})
+	require.NoError(t, err)
+	_, err = client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: created.CatalogID})
+	require.True(t, sdk.IsNotFound(err), "got %v", err)
+
+	// Requests without credentials are rejected
+	anonymous, err := sdk.NewRawClient(server.URL, "", sdk.WithAuthProvider(sdk.StaticAPIKey("")))
+	require.NoError(t, err)
+	_, err = anonymous.ListCatalogs(ctx)
+	require.Error(t, err)
+}
+
+func TestServer_FilesAndFolders(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client, err := server.SDKClient()
+	require.NoError(t, err)
+	raw, err := server.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	folder, err := raw.CreateFolder(ctx, &sdk.FolderCreateRequest{Name: "docs", VolumeID: "v1"})
+	require.NoError(t, err)
+	file, err := raw.CreateFile(ctx, &sdk.FileCreateRequest{Name: "a.pdf", VolumeID: "v1", ParentID: folder.FolderID, Size: 10})
+	require.NoError(t, err)
+	_, err = raw.CreateFile(ctx, &sdk.FileCreateRequest{Name: "a.pdf", VolumeID: "v1", ParentID: folder.FolderID})
+	require.ErrorIs(t, err, sdk.ErrAlreadyExists)
+	_, err = raw.CreateFile(ctx, &sdk.FileCreateRequest{Name: "b.txt", VolumeID: "v1"})
+	require.NoError(t, err)
+
+	var paths []string
+	require.NoError(t, client.WalkVolume(ctx, "v1", func(entry sdk.VolumeChildrenResponse, path string) error {
+		paths = append(paths, path)
+		return nil
+	}))
+	require.ElementsMatch(t, []string{"docs", "docs/a.pdf", "b.txt"}, paths)
+
+	info, err := raw.GetFile(ctx, &sdk.FileInfoRequest{FileID: file.FileID})
+	require.NoError(t, err)
+	require.Equal(t, "pdf", info.FileExt)
+	_, err = raw.RenameFileIfUnchanged(ctx, file.FileID, "c.pdf", info.ETag())
+	require.NoError(t, err)
+	_, err = raw.RenameFileIfUnchanged(ctx, file.FileID, "d.pdf", info.ETag())
+	require.ErrorIs(t, err, sdk.ErrResourceChanged)
+
+	_, err = raw.DeleteFolder(ctx, &sdk.FolderDeleteRequest{FolderID: folder.FolderID})
+	require.NoError(t, err)
+	_, err = raw.GetFile(ctx, &sdk.FileInfoRequest{FileID: file.FileID})
+	require.ErrorIs(t, err, sdk.ErrNotFound)
+	files, err := raw.ListFiles(ctx, &sdk.FileListRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, files.Total)
+}
+
+func TestServer_Workflows(t *testing.T) {
+	t.Parallel()
+	server := NewServer()
+	defer server.Close()
+	client, err := server.Client()
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	created, err := client.CreateWorkflow(ctx, &sdk.WorkflowMetadata{Name: "parse", SourceVolumeIDs: []string{"v1"}})
+	require.NoError(t, err)
+	_, err = client.CreateWorkflow(ctx, &sdk.WorkflowMetadata{Name: "embed", SourceVolumeIDs: []string{"v2"}})
+	require.NoError(t, err)
+
+	updated, err := client.UpdateWorkflow(ctx, created.ID, &sdk.WorkflowMetadata{Name: "parse-v2", SourceVolumeIDs: []string{"v1"}})
+	require.NoError(t, err)
+	require.Equal(t, "2", updated.Version)
+	list, err := client.ListWorkflows(ctx, &sdk.WorkflowListRequest{SourceVolumeID: "v1"})
+	require.NoError(t, err)
+	require.Equal(t, 1, list.Total)
+	require.Equal(t, "parse-v2", list.Workflows[0].Name)
+
+	_, err = client.PauseWorkflow(ctx, created.ID)
+	require.ErrorIs(t, err, sdk.ErrInvalidArgument)
+	state, err := client.StartWorkflow(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, sdk.WorkflowStateRunning, state.State)
+	state, err = client.PauseWorkflow(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, sdk.WorkflowStatePaused, state.State)
+
+	_, err = client.DeleteWorkflow(ctx, created.ID)
+	require.NoError(t, err)
+	_, err = client.GetWorkflow(ctx, created.ID)
+	require.True(t, errors.Is(err, sdk.ErrNotFound), "got %v", err)
+}
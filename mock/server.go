@@ -0,0 +1,700 @@
+// Package mock helps unit testing code built on the SDK without a MOI deployment or
+// credentials.
+//
+// It offers two levels of fakes. The CatalogService, FileService and WorkflowService
+// mocks implement the service interfaces of the sdk package with stubbed functions,
+// for code that depends on those interfaces. Server is an in-memory fake of the
+// catalog, file, folder and workflow endpoints, for code that uses an *sdk.RawClient
+// or an *sdk.SDKClient:
+//
+//	server := mock.NewServer()
+//	defer server.Close()
+//	client, err := server.Client()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	resp, err := client.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "sales"})
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// APIKey is the API key used by the clients returned by Server.Client. The server
+// accepts any non-empty key.
+const APIKey = "mock-api-key"
+
+// Error codes returned by Server, which the SDK maps to its sentinel errors.
+const (
+	codeNotFound        = "ErrNotFound"        // sdk.ErrNotFound
+	codeAlreadyExists   = "ErrAlreadyExists"   // sdk.ErrAlreadyExists
+	codeInvalidArgument = "ErrInvalidArgument" // sdk.ErrInvalidArgument
+	codeResourceChanged = "ErrResourceChanged" // sdk.ErrResourceChanged
+)
+
+// Server is an httptest server that implements the catalog, file, folder and workflow
+// endpoints of the MOI API with in-memory state. Other endpoints answer 404.
+//
+// The state starts empty and is shared by all clients of the server. Files have no
+// content: only their metadata is stored. Server is safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, to pass to sdk.NewRawClient.
+	URL string
+
+	srv       *httptest.Server
+	mu        sync.Mutex
+	lastID    int64
+	catalogs  map[sdk.CatalogID]*sdk.CatalogResponse
+	files     map[sdk.FileID]*sdk.FileInfoResponse
+	workflows map[string]*workflow
+}
+
+type workflow struct {
+	resp  sdk.WorkflowResponse
+	state sdk.WorkflowState
+}
+
+// apiError is an error returned in the response envelope.
+type apiError struct {
+	code string
+	msg  string
+}
+
+func (e *apiError) Error() string { return e.msg }
+
+func errorf(code, format string, args ...interface{}) *apiError {
+	return &apiError{code: code, msg: fmt.Sprintf(format, args...)}
+}
+
+// NewServer starts a fake server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		catalogs:  make(map[sdk.CatalogID]*sdk.CatalogResponse),
+		files:     make(map[sdk.FileID]*sdk.FileInfoResponse),
+		workflows: make(map[string]*workflow),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a client of the server, authenticated with APIKey.
+func (s *Server) Client(opts ...sdk.ClientOption) (*sdk.RawClient, error) {
+	return sdk.NewRawClient(s.URL, APIKey, opts...)
+}
+
+// SDKClient returns a high-level client of the server, authenticated with APIKey.
+func (s *Server) SDKClient(opts ...sdk.ClientOption) (*sdk.SDKClient, error) {
+	raw, err := s.Client(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.NewSDKClient(raw), nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("moi-key") == "" && r.Header.Get("Authorization") == "" {
+		http.Error(w, "missing API key", http.StatusUnauthorized)
+		return
+	}
+	handler, params := s.route(r)
+	if handler == nil {
+		http.Error(w, "mock: endpoint not implemented: "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+		return
+	}
+
+	s.mu.Lock()
+	data, err := handler(r, params)
+	s.mu.Unlock()
+
+	envelope := map[string]interface{}{"code": "OK", "msg": "OK", "data": data}
+	if err != nil {
+		apiErr, ok := err.(*apiError)
+		if !ok {
+			apiErr = errorf(codeInvalidArgument, "%v", err)
+		}
+		envelope = map[string]interface{}{"code": apiErr.code, "msg": apiErr.msg}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(envelope)
+}
+
+type handlerFunc func(r *http.Request, params string) (interface{}, error)
+
+// route returns the handler of r and, for workflow endpoints, the workflow ID and
+// action in the path.
+func (s *Server) route(r *http.Request) (handlerFunc, string) {
+	if r.Method == http.MethodPost {
+		if h, ok := s.postHandlers()[r.URL.Path]; ok {
+			return h, ""
+		}
+	}
+	const workflowPrefix = "/v1/genai/workflow"
+	if r.URL.Path == workflowPrefix {
+		switch r.Method {
+		case http.MethodPost:
+			return s.createWorkflow, ""
+		case http.MethodGet:
+			return s.listWorkflows, ""
+		}
+		return nil, ""
+	}
+	rest, ok := strings.CutPrefix(r.URL.Path, workflowPrefix+"/")
+	if !ok || rest == "" {
+		return nil, ""
+	}
+	id, action, _ := strings.Cut(rest, "/")
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		return s.getWorkflow, id
+	case action == "" && r.Method == http.MethodPut:
+		return s.updateWorkflow, id
+	case action == "" && r.Method == http.MethodDelete:
+		return s.deleteWorkflow, id
+	case r.Method == http.MethodPost && workflowTransitions[action] != [2]sdk.WorkflowState{}:
+		return s.changeWorkflowState, rest
+	}
+	return nil, ""
+}
+
+func (s *Server) postHandlers() map[string]handlerFunc {
+	return map[string]handlerFunc{
+		"/catalog/create":        s.createCatalog,
+		"/catalog/delete":        s.deleteCatalog,
+		"/catalog/update":        s.updateCatalog,
+		"/catalog/info":          s.getCatalog,
+		"/catalog/list":          s.listCatalogs,
+		"/catalog/file/create":   s.createFile,
+		"/catalog/file/update":   s.updateFile,
+		"/catalog/file/delete":   s.deleteFile,
+		"/catalog/file/info":     s.getFile,
+		"/catalog/file/list":     s.listFiles,
+		"/catalog/folder/create": s.createFolder,
+		"/catalog/folder/update": s.updateFolder,
+		"/catalog/folder/delete": s.deleteFile,
+	}
+}
+
+func decode(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errorf(codeInvalidArgument, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func (s *Server) nextID() int64 {
+	s.lastID++
+	return s.lastID
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// page returns the items of the requested page, counted from 1; a pageSize of zero
+// returns every item.
+func page[T any](items []T, pageNum, pageSize int) []T {
+	if pageSize <= 0 {
+		return items
+	}
+	if pageNum < 1 {
+		pageNum = 1
+	}
+	start := (pageNum - 1) * pageSize
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + pageSize
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// ============ Catalogs ============
+
+func (s *Server) createCatalog(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.CatalogCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.CatalogName == "" {
+		return nil, errorf(codeInvalidArgument, "catalog name is required")
+	}
+	for _, c := range s.catalogs {
+		if c.CatalogName == req.CatalogName {
+			return nil, errorf(codeAlreadyExists, "catalog %q already exists", req.CatalogName)
+		}
+	}
+	ts := now()
+	c := &sdk.CatalogResponse{
+		CatalogID:   sdk.CatalogID(s.nextID()),
+		CatalogName: req.CatalogName,
+		Comment:     req.Comment,
+		CreatedAt:   ts,
+		CreatedBy:   "mock",
+		UpdatedAt:   ts,
+		UpdatedBy:   "mock",
+	}
+	s.catalogs[c.CatalogID] = c
+	return sdk.CatalogCreateResponse{CatalogID: c.CatalogID}, nil
+}
+
+func (s *Server) catalog(id sdk.CatalogID) (*sdk.CatalogResponse, error) {
+	c, ok := s.catalogs[id]
+	if !ok {
+		return nil, errorf(codeNotFound, "catalog %d not found", id)
+	}
+	return c, nil
+}
+
+func (s *Server) deleteCatalog(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.CatalogDeleteRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if _, err := s.catalog(req.CatalogID); err != nil {
+		return nil, err
+	}
+	delete(s.catalogs, req.CatalogID)
+	return sdk.CatalogDeleteResponse{CatalogID: req.CatalogID}, nil
+}
+
+func (s *Server) updateCatalog(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.CatalogUpdateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	c, err := s.catalog(req.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+	if req.CatalogName != "" && req.CatalogName != c.CatalogName {
+		for _, other := range s.catalogs {
+			if other.CatalogName == req.CatalogName {
+				return nil, errorf(codeAlreadyExists, "catalog %q already exists", req.CatalogName)
+			}
+		}
+		c.CatalogName = req.CatalogName
+	}
+	c.Comment = req.Comment
+	c.UpdatedAt = now()
+	return sdk.CatalogUpdateResponse{CatalogID: c.CatalogID}, nil
+}
+
+func (s *Server) getCatalog(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.CatalogInfoRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	c, err := s.catalog(req.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.CatalogInfoResponse{CatalogID: c.CatalogID, CatalogName: c.CatalogName, Comment: c.Comment}, nil
+}
+
+func (s *Server) listCatalogs(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.CatalogListRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	list := []sdk.CatalogResponse{}
+	for _, c := range s.catalogs {
+		if req.Keyword == "" || containsFold(c.CatalogName, req.Keyword) || containsFold(c.Comment, req.Keyword) {
+			list = append(list, *c)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CatalogID < list[j].CatalogID })
+	return sdk.CatalogListResponse{List: page(list, req.Page, req.PageSize), Total: len(list)}, nil
+}
+
+// ============ Files and folders ============
+
+// addFile stores a file or folder, failing if its parent already holds the name.
+func (s *Server) addFile(f *sdk.FileInfoResponse) error {
+	if f.Name == "" {
+		return errorf(codeInvalidArgument, "name is required")
+	}
+	if f.VolumeID == "" {
+		return errorf(codeInvalidArgument, "volume_id is required")
+	}
+	if err := s.checkName(f.VolumeID, f.ParentID, f.Name, ""); err != nil {
+		return err
+	}
+	f.ID = sdk.FileID(strconv.FormatInt(s.nextID(), 10))
+	f.CreatedAt = now()
+	f.UpdatedAt = f.CreatedAt
+	f.Version = "1"
+	s.files[f.ID] = f
+	return nil
+}
+
+// checkName fails if another entry than self in the parent folder is named name.
+func (s *Server) checkName(volumeID, parentID, name string, self sdk.FileID) error {
+	if parentID != "" {
+		if parent, ok := s.files[sdk.FileID(parentID)]; !ok || !isFolder(parent) {
+			return errorf(codeNotFound, "folder %s not found", parentID)
+		}
+	}
+	for _, other := range s.files {
+		if other.ID != self && other.VolumeID == volumeID && other.ParentID == parentID && other.Name == name {
+			return errorf(codeAlreadyExists, "%q already exists", name)
+		}
+	}
+	return nil
+}
+
+func isFolder(f *sdk.FileInfoResponse) bool {
+	return f.FileType == strconv.Itoa(int(sdk.FileTypeDir))
+}
+
+func (s *Server) file(id sdk.FileID) (*sdk.FileInfoResponse, error) {
+	f, ok := s.files[id]
+	if !ok {
+		return nil, errorf(codeNotFound, "file %s not found", id)
+	}
+	return f, nil
+}
+
+// rename renames f, honouring the expected version of conditional updates.
+func (s *Server) rename(f *sdk.FileInfoResponse, name, expectedVersion string) error {
+	if expectedVersion != "" && expectedVersion != f.ETag() {
+		return errorf(codeResourceChanged, "%s was modified since version %s", f.ID, expectedVersion)
+	}
+	if name != "" && name != f.Name {
+		if err := s.checkName(f.VolumeID, f.ParentID, name, f.ID); err != nil {
+			return err
+		}
+		f.Name = name
+	}
+	version, _ := strconv.Atoi(f.Version)
+	f.Version = strconv.Itoa(version + 1)
+	f.UpdatedAt = now()
+	return nil
+}
+
+func (s *Server) createFile(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.FileCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	showType := req.ShowType
+	if showType == "" {
+		showType = sdk.FileShowTypeNormal
+	}
+	f := &sdk.FileInfoResponse{
+		Name:          req.Name,
+		VolumeID:      string(req.VolumeID),
+		ParentID:      string(req.ParentID),
+		Size:          req.Size,
+		ShowType:      showType,
+		OriginFileExt: req.OriginFileExt,
+		RefFileID:     req.RefFileID,
+	}
+	if i := strings.LastIndex(req.Name, "."); i > 0 {
+		f.FileExt = req.Name[i+1:]
+	}
+	if err := s.addFile(f); err != nil {
+		return nil, err
+	}
+	return sdk.FileCreateResponse{FileID: f.ID, Name: f.Name}, nil
+}
+
+func (s *Server) updateFile(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.FileUpdateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	f, err := s.file(req.FileID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rename(f, req.Name, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	return sdk.FileUpdateResponse{FileID: f.ID}, nil
+}
+
+// deleteFile deletes a file, or a folder with its content.
+func (s *Server) deleteFile(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.FileDeleteRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if _, err := s.file(req.FileID); err != nil {
+		return nil, err
+	}
+	s.deleteTree(req.FileID)
+	return sdk.FileDeleteResponse{FileID: req.FileID}, nil
+}
+
+func (s *Server) deleteTree(id sdk.FileID) {
+	for _, child := range s.files {
+		if child.ParentID == string(id) {
+			s.deleteTree(child.ID)
+		}
+	}
+	delete(s.files, id)
+}
+
+func (s *Server) getFile(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.FileInfoRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	f, err := s.file(req.FileID)
+	if err != nil {
+		return nil, err
+	}
+	return *f, nil
+}
+
+// listFiles lists files filtered by the volume_id, parent_id and name filters, and by
+// keyword on the name.
+func (s *Server) listFiles(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.FileListRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	list := []sdk.VolumeChildrenResponse{}
+	for _, f := range s.files {
+		if matchFilters(f, req.Filters) && (req.Keyword == "" || containsFold(f.Name, req.Keyword)) {
+			list = append(list, sdk.VolumeChildrenResponse{
+				ID:            string(f.ID),
+				Name:          f.Name,
+				FileType:      f.FileType,
+				ShowType:      f.ShowType,
+				FileExt:       f.FileExt,
+				OriginFileExt: f.OriginFileExt,
+				RefFileID:     f.RefFileID,
+				Size:          f.Size,
+				VolumeID:      f.VolumeID,
+				ParentID:      f.ParentID,
+				CreatedAt:     f.CreatedAt,
+				UpdatedAt:     f.UpdatedAt,
+			})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, _ := strconv.ParseInt(list[i].ID, 10, 64)
+		b, _ := strconv.ParseInt(list[j].ID, 10, 64)
+		return a < b
+	})
+	return sdk.FileListResponse{List: page(list, req.Page, req.PageSize), Total: len(list)}, nil
+}
+
+func matchFilters(f *sdk.FileInfoResponse, filters []sdk.CommonFilter) bool {
+	for _, filter := range filters {
+		var value string
+		switch filter.Name {
+		case "volume_id":
+			value = f.VolumeID
+		case "parent_id":
+			value = f.ParentID
+		case "name":
+			value = f.Name
+		default:
+			continue
+		}
+		matched := len(filter.Values) == 0
+		for _, want := range filter.Values {
+			if value == want || (filter.Fuzzy && containsFold(value, want)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) createFolder(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.FolderCreateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	f := &sdk.FileInfoResponse{
+		Name:     req.Name,
+		VolumeID: string(req.VolumeID),
+		ParentID: string(req.ParentID),
+		FileType: strconv.Itoa(int(sdk.FileTypeDir)),
+		ShowType: sdk.FileShowTypeNormal,
+	}
+	if err := s.addFile(f); err != nil {
+		return nil, err
+	}
+	return sdk.FolderCreateResponse{FolderID: f.ID, Name: f.Name}, nil
+}
+
+func (s *Server) updateFolder(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.FolderUpdateRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	f, err := s.file(req.FolderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rename(f, req.Name, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	return sdk.FolderUpdateResponse{FolderID: f.ID}, nil
+}
+
+// ============ Workflows ============
+
+func (s *Server) createWorkflow(r *http.Request, _ string) (interface{}, error) {
+	var req sdk.WorkflowMetadata
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		return nil, errorf(codeInvalidArgument, "workflow name is required")
+	}
+	for _, wf := range s.workflows {
+		if wf.resp.Name == req.Name {
+			return nil, errorf(codeAlreadyExists, "workflow %q already exists", req.Name)
+		}
+	}
+	ts := now()
+	wf := &workflow{
+		resp: sdk.WorkflowResponse{
+			ID:        "wf-" + strconv.FormatInt(s.nextID(), 10),
+			CreatedAt: ts,
+			Creator:   "mock",
+		},
+		state: sdk.WorkflowStateStopped,
+	}
+	setWorkflowMetadata(&wf.resp, &req, ts)
+	s.workflows[wf.resp.ID] = wf
+	return wf.resp, nil
+}
+
+// setWorkflowMetadata stores the definition req in resp, in the string encoding of
+// the service.
+func setWorkflowMetadata(resp *sdk.WorkflowResponse, req *sdk.WorkflowMetadata, ts string) {
+	encode := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	version, _ := strconv.Atoi(resp.Version)
+	resp.Name = req.Name
+	resp.SourceVolumeIDs = encode(req.SourceVolumeIDs)
+	resp.SourceVolumeNames = encode(req.SourceVolumeNames)
+	resp.TargetVolumeID = req.TargetVolumeID
+	resp.TargetVolumeName = req.TargetVolumeName
+	resp.FileTypes = encode(req.FileTypes)
+	resp.Content = encode(req.Workflow)
+	resp.Version = strconv.Itoa(version + 1)
+	resp.UpdatedAt = ts
+	resp.Modifier = "mock"
+}
+
+func (s *Server) workflow(id string) (*workflow, error) {
+	wf, ok := s.workflows[id]
+	if !ok {
+		return nil, errorf(codeNotFound, "workflow %s not found", id)
+	}
+	return wf, nil
+}
+
+func (s *Server) getWorkflow(_ *http.Request, id string) (interface{}, error) {
+	wf, err := s.workflow(id)
+	if err != nil {
+		return nil, err
+	}
+	return wf.resp, nil
+}
+
+func (s *Server) updateWorkflow(r *http.Request, id string) (interface{}, error) {
+	wf, err := s.workflow(id)
+	if err != nil {
+		return nil, err
+	}
+	var req sdk.WorkflowMetadata
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		req.Name = wf.resp.Name
+	}
+	setWorkflowMetadata(&wf.resp, &req, now())
+	return wf.resp, nil
+}
+
+func (s *Server) deleteWorkflow(_ *http.Request, id string) (interface{}, error) {
+	if _, err := s.workflow(id); err != nil {
+		return nil, err
+	}
+	delete(s.workflows, id)
+	return sdk.WorkflowDeleteResponse{ID: id}, nil
+}
+
+func (s *Server) listWorkflows(r *http.Request, _ string) (interface{}, error) {
+	query := r.URL.Query()
+	name := query.Get("name")
+	sourceVolumeID := query.Get("source_volume_id")
+	list := []sdk.WorkflowResponse{}
+	for _, wf := range s.workflows {
+		if name != "" && !containsFold(wf.resp.Name, name) {
+			continue
+		}
+		if sourceVolumeID != "" && !strings.Contains(wf.resp.SourceVolumeIDs, strconv.Quote(sourceVolumeID)) {
+			continue
+		}
+		list = append(list, wf.resp)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt+list[i].ID < list[j].CreatedAt+list[j].ID })
+	pageNum, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("page_size"))
+	return sdk.WorkflowListResponse{Workflows: page(list, pageNum, pageSize), Total: len(list)}, nil
+}
+
+// workflowTransitions maps the state actions to the state they apply to and the
+// state they lead to.
+var workflowTransitions = map[string][2]sdk.WorkflowState{
+	"start":  {sdk.WorkflowStateStopped, sdk.WorkflowStateRunning},
+	"pause":  {sdk.WorkflowStateRunning, sdk.WorkflowStatePaused},
+	"resume": {sdk.WorkflowStatePaused, sdk.WorkflowStateRunning},
+}
+
+// changeWorkflowState starts, pauses or resumes a workflow; params is "<id>/<action>".
+func (s *Server) changeWorkflowState(_ *http.Request, params string) (interface{}, error) {
+	id, action, _ := strings.Cut(params, "/")
+	wf, err := s.workflow(id)
+	if err != nil {
+		return nil, err
+	}
+	transition, ok := workflowTransitions[action]
+	if !ok {
+		return nil, errorf(codeInvalidArgument, "unknown workflow action %q", action)
+	}
+	from, to := transition[0], transition[1]
+	if wf.state != from {
+		return nil, errorf(codeInvalidArgument, "cannot %s workflow %s in state %s", action, id, wf.state)
+	}
+	wf.state = to
+	return sdk.WorkflowStateResponse{ID: id, State: to}, nil
+}
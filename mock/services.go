@@ -0,0 +1,312 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+// ErrNotStubbed is returned by the methods of a mock whose function field is nil.
+var ErrNotStubbed = errors.New("mock: method not stubbed")
+
+// Call records a call made to a mock: the method name and its arguments, without
+// the context and call options.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// calls records the calls made to a mock. It is safe for concurrent use.
+type calls struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (c *calls) record(method string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made to the mock, in order.
+func (c *calls) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Call(nil), c.calls...)
+}
+
+// CallCount returns the number of calls made to method.
+func (c *calls) CallCount(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, call := range c.calls {
+		if call.Method == method {
+			n++
+		}
+	}
+	return n
+}
+
+func notStubbed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotStubbed, method)
+}
+
+// CatalogService is a mock of sdk.CatalogService. Each method calls the function
+// field of the same name and records the call; methods whose field is nil return
+// ErrNotStubbed.
+//
+// Example:
+//
+//	catalogs := &mock.CatalogService{
+//		GetCatalogFunc: func(ctx context.Context, req *sdk.CatalogInfoRequest, opts ...sdk.CallOption) (*sdk.CatalogInfoResponse, error) {
+//			return &sdk.CatalogInfoResponse{CatalogID: req.CatalogID, CatalogName: "sales"}, nil
+//		},
+//	}
+//	report, err := buildReport(ctx, catalogs) // buildReport takes an sdk.CatalogService
+//	require.Equal(t, 1, catalogs.CallCount("GetCatalog"))
+type CatalogService struct {
+	calls
+
+	CreateCatalogFunc        func(ctx context.Context, req *sdk.CatalogCreateRequest, opts ...sdk.CallOption) (*sdk.CatalogCreateResponse, error)
+	DeleteCatalogFunc        func(ctx context.Context, req *sdk.CatalogDeleteRequest, opts ...sdk.CallOption) (*sdk.CatalogDeleteResponse, error)
+	UpdateCatalogFunc        func(ctx context.Context, req *sdk.CatalogUpdateRequest, opts ...sdk.CallOption) (*sdk.CatalogUpdateResponse, error)
+	GetCatalogFunc           func(ctx context.Context, req *sdk.CatalogInfoRequest, opts ...sdk.CallOption) (*sdk.CatalogInfoResponse, error)
+	ListCatalogsFunc         func(ctx context.Context, opts ...sdk.CallOption) (*sdk.CatalogListResponse, error)
+	ListCatalogsFilteredFunc func(ctx context.Context, req *sdk.CatalogListRequest, opts ...sdk.CallOption) (*sdk.CatalogListResponse, error)
+}
+
+var _ sdk.CatalogService = (*CatalogService)(nil)
+
+// CreateCatalog calls CreateCatalogFunc.
+func (m *CatalogService) CreateCatalog(ctx context.Context, req *sdk.CatalogCreateRequest, opts ...sdk.CallOption) (*sdk.CatalogCreateResponse, error) {
+	m.record("CreateCatalog", req)
+	if m.CreateCatalogFunc == nil {
+		return nil, notStubbed("CreateCatalog")
+	}
+	return m.CreateCatalogFunc(ctx, req, opts...)
+}
+
+// DeleteCatalog calls DeleteCatalogFunc.
+func (m *CatalogService) DeleteCatalog(ctx context.Context, req *sdk.CatalogDeleteRequest, opts ...sdk.CallOption) (*sdk.CatalogDeleteResponse, error) {
+	m.record("DeleteCatalog", req)
+	if m.DeleteCatalogFunc == nil {
+		return nil, notStubbed("DeleteCatalog")
+	}
+	return m.DeleteCatalogFunc(ctx, req, opts...)
+}
+
+// UpdateCatalog calls UpdateCatalogFunc.
+func (m *CatalogService) UpdateCatalog(ctx context.Context, req *sdk.CatalogUpdateRequest, opts ...sdk.CallOption) (*sdk.CatalogUpdateResponse, error) {
+	m.record("UpdateCatalog", req)
+	if m.UpdateCatalogFunc == nil {
+		return nil, notStubbed("UpdateCatalog")
+	}
+	return m.UpdateCatalogFunc(ctx, req, opts...)
+}
+
+// GetCatalog calls GetCatalogFunc.
+func (m *CatalogService) GetCatalog(ctx context.Context, req *sdk.CatalogInfoRequest, opts ...sdk.CallOption) (*sdk.CatalogInfoResponse, error) {
+	m.record("GetCatalog", req)
+	if m.GetCatalogFunc == nil {
+		return nil, notStubbed("GetCatalog")
+	}
+	return m.GetCatalogFunc(ctx, req, opts...)
+}
+
+// ListCatalogs calls ListCatalogsFunc.
+func (m *CatalogService) ListCatalogs(ctx context.Context, opts ...sdk.CallOption) (*sdk.CatalogListResponse, error) {
+	m.record("ListCatalogs")
+	if m.ListCatalogsFunc == nil {
+		return nil, notStubbed("ListCatalogs")
+	}
+	return m.ListCatalogsFunc(ctx, opts...)
+}
+
+// ListCatalogsFiltered calls ListCatalogsFilteredFunc.
+func (m *CatalogService) ListCatalogsFiltered(ctx context.Context, req *sdk.CatalogListRequest, opts ...sdk.CallOption) (*sdk.CatalogListResponse, error) {
+	m.record("ListCatalogsFiltered", req)
+	if m.ListCatalogsFilteredFunc == nil {
+		return nil, notStubbed("ListCatalogsFiltered")
+	}
+	return m.ListCatalogsFilteredFunc(ctx, req, opts...)
+}
+
+// FileService is a mock of sdk.FileService, used like CatalogService.
+type FileService struct {
+	calls
+
+	CreateFileFunc   func(ctx context.Context, req *sdk.FileCreateRequest, opts ...sdk.CallOption) (*sdk.FileCreateResponse, error)
+	UpdateFileFunc   func(ctx context.Context, req *sdk.FileUpdateRequest, opts ...sdk.CallOption) (*sdk.FileUpdateResponse, error)
+	DeleteFileFunc   func(ctx context.Context, req *sdk.FileDeleteRequest, opts ...sdk.CallOption) (*sdk.FileDeleteResponse, error)
+	GetFileFunc      func(ctx context.Context, req *sdk.FileInfoRequest, opts ...sdk.CallOption) (*sdk.FileInfoResponse, error)
+	ListFilesFunc    func(ctx context.Context, req *sdk.FileListRequest, opts ...sdk.CallOption) (*sdk.FileListResponse, error)
+	CreateFolderFunc func(ctx context.Context, req *sdk.FolderCreateRequest, opts ...sdk.CallOption) (*sdk.FolderCreateResponse, error)
+	UpdateFolderFunc func(ctx context.Context, req *sdk.FolderUpdateRequest, opts ...sdk.CallOption) (*sdk.FolderUpdateResponse, error)
+	DeleteFolderFunc func(ctx context.Context, req *sdk.FolderDeleteRequest, opts ...sdk.CallOption) (*sdk.FolderDeleteResponse, error)
+}
+
+var _ sdk.FileService = (*FileService)(nil)
+
+// CreateFile calls CreateFileFunc.
+func (m *FileService) CreateFile(ctx context.Context, req *sdk.FileCreateRequest, opts ...sdk.CallOption) (*sdk.FileCreateResponse, error) {
+	m.record("CreateFile", req)
+	if m.CreateFileFunc == nil {
+		return nil, notStubbed("CreateFile")
+	}
+	return m.CreateFileFunc(ctx, req, opts...)
+}
+
+// UpdateFile calls UpdateFileFunc.
+func (m *FileService) UpdateFile(ctx context.Context, req *sdk.FileUpdateRequest, opts ...sdk.CallOption) (*sdk.FileUpdateResponse, error) {
+	m.record("UpdateFile", req)
+	if m.UpdateFileFunc == nil {
+		return nil, notStubbed("UpdateFile")
+	}
+	return m.UpdateFileFunc(ctx, req, opts...)
+}
+
+// DeleteFile calls DeleteFileFunc.
+func (m *FileService) DeleteFile(ctx context.Context, req *sdk.FileDeleteRequest, opts ...sdk.CallOption) (*sdk.FileDeleteResponse, error) {
+	m.record("DeleteFile", req)
+	if m.DeleteFileFunc == nil {
+		return nil, notStubbed("DeleteFile")
+	}
+	return m.DeleteFileFunc(ctx, req, opts...)
+}
+
+// GetFile calls GetFileFunc.
+func (m *FileService) GetFile(ctx context.Context, req *sdk.FileInfoRequest, opts ...sdk.CallOption) (*sdk.FileInfoResponse, error) {
+	m.record("GetFile", req)
+	if m.GetFileFunc == nil {
+		return nil, notStubbed("GetFile")
+	}
+	return m.GetFileFunc(ctx, req, opts...)
+}
+
+// ListFiles calls ListFilesFunc.
+func (m *FileService) ListFiles(ctx context.Context, req *sdk.FileListRequest, opts ...sdk.CallOption) (*sdk.FileListResponse, error) {
+	m.record("ListFiles", req)
+	if m.ListFilesFunc == nil {
+		return nil, notStubbed("ListFiles")
+	}
+	return m.ListFilesFunc(ctx, req, opts...)
+}
+
+// CreateFolder calls CreateFolderFunc.
+func (m *FileService) CreateFolder(ctx context.Context, req *sdk.FolderCreateRequest, opts ...sdk.CallOption) (*sdk.FolderCreateResponse, error) {
+	m.record("CreateFolder", req)
+	if m.CreateFolderFunc == nil {
+		return nil, notStubbed("CreateFolder")
+	}
+	return m.CreateFolderFunc(ctx, req, opts...)
+}
+
+// UpdateFolder calls UpdateFolderFunc.
+func (m *FileService) UpdateFolder(ctx context.Context, req *sdk.FolderUpdateRequest, opts ...sdk.CallOption) (*sdk.FolderUpdateResponse, error) {
+	m.record("UpdateFolder", req)
+	if m.UpdateFolderFunc == nil {
+		return nil, notStubbed("UpdateFolder")
+	}
+	return m.UpdateFolderFunc(ctx, req, opts...)
+}
+
+// DeleteFolder calls DeleteFolderFunc.
+func (m *FileService) DeleteFolder(ctx context.Context, req *sdk.FolderDeleteRequest, opts ...sdk.CallOption) (*sdk.FolderDeleteResponse, error) {
+	m.record("DeleteFolder", req)
+	if m.DeleteFolderFunc == nil {
+		return nil, notStubbed("DeleteFolder")
+	}
+	return m.DeleteFolderFunc(ctx, req, opts...)
+}
+
+// WorkflowService is a mock of sdk.WorkflowService, used like CatalogService.
+type WorkflowService struct {
+	calls
+
+	CreateWorkflowFunc func(ctx context.Context, req *sdk.WorkflowMetadata, opts ...sdk.CallOption) (*sdk.WorkflowCreateResponse, error)
+	GetWorkflowFunc    func(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowResponse, error)
+	UpdateWorkflowFunc func(ctx context.Context, workflowID string, req *sdk.WorkflowMetadata, opts ...sdk.CallOption) (*sdk.WorkflowResponse, error)
+	DeleteWorkflowFunc func(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowDeleteResponse, error)
+	ListWorkflowsFunc  func(ctx context.Context, req *sdk.WorkflowListRequest, opts ...sdk.CallOption) (*sdk.WorkflowListResponse, error)
+	StartWorkflowFunc  func(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowStateResponse, error)
+	PauseWorkflowFunc  func(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowStateResponse, error)
+	ResumeWorkflowFunc func(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowStateResponse, error)
+}
+
+var _ sdk.WorkflowService = (*WorkflowService)(nil)
+
+// CreateWorkflow calls CreateWorkflowFunc.
+func (m *WorkflowService) CreateWorkflow(ctx context.Context, req *sdk.WorkflowMetadata, opts ...sdk.CallOption) (*sdk.WorkflowCreateResponse, error) {
+	m.record("CreateWorkflow", req)
+	if m.CreateWorkflowFunc == nil {
+		return nil, notStubbed("CreateWorkflow")
+	}
+	return m.CreateWorkflowFunc(ctx, req, opts...)
+}
+
+// GetWorkflow calls GetWorkflowFunc.
+func (m *WorkflowService) GetWorkflow(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowResponse, error) {
+	m.record("GetWorkflow", workflowID)
+	if m.GetWorkflowFunc == nil {
+		return nil, notStubbed("GetWorkflow")
+	}
+	return m.GetWorkflowFunc(ctx, workflowID, opts...)
+}
+
+// UpdateWorkflow calls UpdateWorkflowFunc.
+func (m *WorkflowService) UpdateWorkflow(ctx context.Context, workflowID string, req *sdk.WorkflowMetadata, opts ...sdk.CallOption) (*sdk.WorkflowResponse, error) {
+	m.record("UpdateWorkflow", workflowID, req)
+	if m.UpdateWorkflowFunc == nil {
+		return nil, notStubbed("UpdateWorkflow")
+	}
+	return m.UpdateWorkflowFunc(ctx, workflowID, req, opts...)
+}
+
+// DeleteWorkflow calls DeleteWorkflowFunc.
+func (m *WorkflowService) DeleteWorkflow(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowDeleteResponse, error) {
+	m.record("DeleteWorkflow", workflowID)
+	if m.DeleteWorkflowFunc == nil {
+		return nil, notStubbed("DeleteWorkflow")
+	}
+	return m.DeleteWorkflowFunc(ctx, workflowID, opts...)
+}
+
+// ListWorkflows calls ListWorkflowsFunc.
+func (m *WorkflowService) ListWorkflows(ctx context.Context, req *sdk.WorkflowListRequest, opts ...sdk.CallOption) (*sdk.WorkflowListResponse, error) {
+	m.record("ListWorkflows", req)
+	if m.ListWorkflowsFunc == nil {
+		return nil, notStubbed("ListWorkflows")
+	}
+	return m.ListWorkflowsFunc(ctx, req, opts...)
+}
+
+// StartWorkflow calls StartWorkflowFunc.
+func (m *WorkflowService) StartWorkflow(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowStateResponse, error) {
+	m.record("StartWorkflow", workflowID)
+	if m.StartWorkflowFunc == nil {
+		return nil, notStubbed("StartWorkflow")
+	}
+	return m.StartWorkflowFunc(ctx, workflowID, opts...)
+}
+
+// PauseWorkflow calls PauseWorkflowFunc.
+func (m *WorkflowService) PauseWorkflow(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowStateResponse, error) {
+	m.record("PauseWorkflow", workflowID)
+	if m.PauseWorkflowFunc == nil {
+		return nil, notStubbed("PauseWorkflow")
+	}
+	return m.PauseWorkflowFunc(ctx, workflowID, opts...)
+}
+
+// ResumeWorkflow calls ResumeWorkflowFunc.
+func (m *WorkflowService) ResumeWorkflow(ctx context.Context, workflowID string, opts ...sdk.CallOption) (*sdk.WorkflowStateResponse, error) {
+	m.record("ResumeWorkflow", workflowID)
+	if m.ResumeWorkflowFunc == nil {
+		return nil, notStubbed("ResumeWorkflow")
+	}
+	return m.ResumeWorkflowFunc(ctx, workflowID, opts...)
+}
@@ -1,8 +1,11 @@
 package sdk
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // This file contains all type definitions copied from catalog_service dependency.
@@ -47,6 +50,19 @@ const UserIDNotFound UserID = 4294967295              // math.MaxUint
 
 var TableIDInSubDatabase TableID = -1 //订阅库下的表都没有表id,用一个特殊值
 
+// CatalogObjectType identifies a kind of object of the catalog hierarchy, as used
+// by operation logs and search.
+type CatalogObjectType string
+
+const (
+	CatalogObjectCatalog  CatalogObjectType = "catalog"
+	CatalogObjectDatabase CatalogObjectType = "database"
+	CatalogObjectTable    CatalogObjectType = "table"
+	CatalogObjectVolume   CatalogObjectType = "volume"
+	CatalogObjectFolder   CatalogObjectType = "folder"
+	CatalogObjectFile     CatalogObjectType = "file"
+)
+
 type FullPath struct {
 	IDList   []string `json:"id_list"`
 	NameList []string `json:"name_list"`
@@ -94,6 +110,35 @@ const (
 	FileTypeIMAGE    FileType = 3
 )
 
+// FileShowType controls whether a file is shown in volume listings of the web console.
+type FileShowType string
+
+const (
+	// FileShowTypeNormal shows the file in listings. It is the default for new files.
+	FileShowTypeNormal FileShowType = "normal"
+	// FileShowTypeHidden hides the file from listings of the web console; the file can
+	// still be read and listed through the API.
+	FileShowTypeHidden FileShowType = "hidden"
+)
+
+// Valid reports whether t is a known show type. The empty value is valid and lets
+// the server apply its default.
+func (t FileShowType) Valid() bool {
+	switch t {
+	case "", FileShowTypeNormal, FileShowTypeHidden:
+		return true
+	}
+	return false
+}
+
+// validateShowType returns an error for unknown show types.
+func validateShowType(t FileShowType) error {
+	if !t.Valid() {
+		return fmt.Errorf("invalid show_type %q: must be %q or %q", string(t), FileShowTypeNormal, FileShowTypeHidden)
+	}
+	return nil
+}
+
 // ============ Models: Priv types ============
 
 const (
@@ -521,6 +566,9 @@ type TreeNode struct {
 	Reserved             bool        `json:"reserved"`
 	HasWorkflowTargetRef bool        `json:"has_workflow_target_ref"`
 	NodeList             []*TreeNode `json:"node_list"`
+	// ChildrenCount is the number of children of the node, also when NodeList was
+	// left empty because of a depth limit; load them with GetCatalogTreeFiltered.
+	ChildrenCount int `json:"children_count,omitempty"`
 }
 
 // ============ Models: Database types ============
@@ -563,24 +611,37 @@ type VolumeRefResp struct {
 }
 
 type VolumeChildrenResponse struct {
-	ID             string `json:"id"`
-	Name           string `json:"name"`
-	FileType       string `json:"file_type"`
-	ShowType       string `json:"show_type"`
-	FileExt        string `json:"file_ext"`
-	OriginFileExt  string `json:"origin_file_ext"`
-	RefFileID      string `json:"ref_file_id"`
-	Size           int64  `json:"size"`
-	VolumeID       string `json:"volume_id"`
-	VolumeName     string `json:"volume_name"`
-	VolumeReserved bool   `json:"volume_reserved"`
-	RefWorkFlowID  string `json:"ref_workflow_id"`
-	ParentID       string `json:"parent_id"`
-	ShowPath       string `json:"show_path"`
-	SavePath       string `json:"save_path"`
-	CreatedAt      string `json:"created_at"`
-	CreatedBy      string `json:"created_by"`
-	UpdatedAt      string `json:"updated_at"`
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	FileType       string       `json:"file_type"`
+	ShowType       FileShowType `json:"show_type"`
+	FileExt        string       `json:"file_ext"`
+	OriginFileExt  string       `json:"origin_file_ext"`
+	RefFileID      string       `json:"ref_file_id"`
+	Size           int64        `json:"size"`
+	Hash           string       `json:"hash"`
+	LegalHold      bool         `json:"legal_hold"`
+	VolumeID       string       `json:"volume_id"`
+	VolumeName     string       `json:"volume_name"`
+	VolumeReserved bool         `json:"volume_reserved"`
+	RefWorkFlowID  string       `json:"ref_workflow_id"`
+	ParentID       string       `json:"parent_id"`
+	ShowPath       string       `json:"show_path"`
+	SavePath       string       `json:"save_path"`
+	CreatedAt      string       `json:"created_at"`
+	CreatedBy      string       `json:"created_by"`
+	UpdatedAt      string       `json:"updated_at"`
+	Deleted        bool         `json:"deleted,omitempty"`    // Set on tombstones listed with IncludeDeleted
+	DeletedAt      string       `json:"deleted_at,omitempty"` // When the entry was deleted
+}
+
+// IsFolder reports whether the entry is a folder rather than a file.
+func (r VolumeChildrenResponse) IsFolder() bool {
+	switch strings.ToLower(r.FileType) {
+	case strconv.Itoa(int(FileTypeDir)), "dir", "folder":
+		return true
+	}
+	return false
 }
 
 // ============ Models: Table types ============
@@ -758,12 +819,42 @@ type CatalogInfoResponse struct {
 	Comment     string    `json:"description"`
 }
 
+// CatalogTreeDepth limits how many levels GetCatalogTreeFiltered returns.
+type CatalogTreeDepth int
+
+const (
+	CatalogTreeDepthAll       CatalogTreeDepth = 0 // The whole hierarchy
+	CatalogTreeDepthCatalogs  CatalogTreeDepth = 1 // Catalogs only
+	CatalogTreeDepthDatabases CatalogTreeDepth = 2 // Catalogs and databases
+	CatalogTreeDepthObjects   CatalogTreeDepth = 3 // Down to tables and volumes
+)
+
+// CatalogTreeRequest selects part of the catalog tree. Set ParentType and ParentID
+// to load the children of a single catalog or database, e.g. when a node is expanded
+// in a UI; Depth is then counted from the children.
+type CatalogTreeRequest struct {
+	Keyword    string           `json:"keyword,omitempty"` // Fuzzy match on the names of the returned top-level nodes
+	Depth      CatalogTreeDepth `json:"depth,omitempty"`
+	ParentType string           `json:"parent_type,omitempty"` // "catalog" or "database"
+	ParentID   string           `json:"parent_id,omitempty"`
+	Page       int              `json:"page,omitempty"`      // Paginates the top-level nodes (starts from 1)
+	PageSize   int              `json:"page_size,omitempty"` // Zero returns every top-level node, unless the client has a default page size
+}
+
 type CatalogTreeResponse struct {
-	Tree []*TreeNode `json:"tree"`
+	Tree  []*TreeNode `json:"tree"`
+	Total int         `json:"total,omitempty"` // Number of top-level nodes matching the request
+}
+
+// CatalogListRequest filters and paginates ListCatalogsFiltered.
+type CatalogListRequest struct {
+	CommonCondition
+	Keyword string `json:"keyword,omitempty"` // Fuzzy match on catalog name and description
 }
 
 type CatalogListResponse struct {
-	List []CatalogResponse `json:"list"`
+	List  []CatalogResponse `json:"list"`
+	Total int               `json:"total,omitempty"` // Set by ListCatalogsFiltered
 }
 
 type CatalogRefListRequest struct {
@@ -774,6 +865,31 @@ type CatalogRefListResponse struct {
 	List []*VolumeRefResp `json:"list"`
 }
 
+// UsageStats aggregates the storage usage of a catalog or database.
+type UsageStats struct {
+	StorageBytes   int64  `json:"storage_bytes"` // Table data and volume files together
+	TableBytes     int64  `json:"table_bytes"`   // Table data only
+	FileBytes      int64  `json:"file_bytes"`    // Volume files only
+	TableCount     int    `json:"table_count"`
+	TableRowCount  int64  `json:"table_row_count"`
+	VolumeCount    int    `json:"volume_count"`
+	FileCount      int64  `json:"file_count"`
+	FolderCount    int64  `json:"folder_count"`
+	LastModifiedAt string `json:"last_modified_at"` // Latest change to any object below, empty if there is none
+}
+
+type CatalogStatsRequest struct {
+	CatalogID CatalogID `json:"id"`
+}
+
+// CatalogStatsResponse reports the usage of a catalog and of each of its databases.
+type CatalogStatsResponse struct {
+	CatalogID     CatalogID `json:"id"`
+	DatabaseCount int       `json:"database_count"`
+	UsageStats
+	Databases []DatabaseStatsResponse `json:"database_list"`
+}
+
 // ============ Handler: Database types ============
 
 type DatabaseCreateRequest struct {
@@ -840,6 +956,27 @@ type DatabaseRefListResponse struct {
 	List []*VolumeRefResp `json:"list"`
 }
 
+type DatabaseStatsRequest struct {
+	DatabaseID DatabaseID `json:"id"`
+}
+
+// DatabaseStatsResponse reports the usage of a database and of each of its tables.
+type DatabaseStatsResponse struct {
+	DatabaseID   DatabaseID `json:"id"`
+	DatabaseName string     `json:"name"`
+	UsageStats
+	Tables []TableUsage `json:"table_list,omitempty"`
+}
+
+// TableUsage is the storage usage of a single table.
+type TableUsage struct {
+	TableID        TableID `json:"id"`
+	Name           string  `json:"name"`
+	RowCount       int64   `json:"row_count"`
+	SizeBytes      int64   `json:"size_bytes"`
+	LastModifiedAt string  `json:"last_modified_at"`
+}
+
 // ============ Handler: Table types ============
 
 type TableCreateRequest struct {
@@ -883,6 +1020,30 @@ type MultiTableInfoResponse struct {
 	InfoMap map[string]TableInfoResponse `json:"info_map" binding:"required"`
 }
 
+// TableListRequest lists the tables of a database, one page at a time.
+type TableListRequest struct {
+	CommonCondition
+	DatabaseID DatabaseID `json:"database_id"`
+	NameFilter string     `json:"name_filter,omitempty"` // Fuzzy match on table name
+}
+
+type TableListResponse struct {
+	List  []TableListItem `json:"list"`
+	Total int             `json:"total"` // Number of matching tables across all pages
+}
+
+// TableListItem is a table in a TableListResponse.
+type TableListItem struct {
+	TableID   TableID `json:"id"`
+	Name      string  `json:"name"`
+	Comment   string  `json:"comment"`
+	RowCount  int64   `json:"row_count"`
+	SizeBytes int64   `json:"size_bytes"`
+	CreatedAt string  `json:"created_at"`
+	CreatedBy string  `json:"created_by"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
 type TableOverview struct {
 	DbName    string   `json:"db_name"`
 	TableName string   `json:"table_name"`
@@ -894,6 +1055,33 @@ type TableExistRequest struct {
 	Name       string     `json:"name"`
 }
 
+type TableStatsRequest struct {
+	TableID TableID `json:"id"`
+}
+
+// TableStatsResponse profiles the data of a table. Column figures are estimates
+// computed from table statistics and may lag behind recent writes.
+type TableStatsResponse struct {
+	TableID        TableID         `json:"id"`
+	Name           string          `json:"name"`
+	RowCount       int64           `json:"row_count"`
+	SizeBytes      int64           `json:"size_bytes"`
+	LastLoadedAt   string          `json:"last_loaded_at,omitempty"` // End of the last load job; empty if the table was never loaded
+	LastModifiedAt string          `json:"last_modified_at"`
+	Columns        []ColumnProfile `json:"columns"`
+}
+
+// ColumnProfile is the profile of a single column in a TableStatsResponse.
+type ColumnProfile struct {
+	Name             string  `json:"name"`
+	Type             string  `json:"type"`
+	MinValue         string  `json:"min_value,omitempty"` // Empty when the column holds only NULLs
+	MaxValue         string  `json:"max_value,omitempty"`
+	NullCount        int64   `json:"null_count"`
+	NullRatio        float64 `json:"null_ratio"`        // Fraction of rows that are NULL, between 0 and 1
+	DistinctEstimate int64   `json:"distinct_estimate"` // Approximate number of distinct non-NULL values
+}
+
 type TablePreviewRequest struct {
 	TableID TableID `json:"id"`
 	Lines   int     `json:"lines"`
@@ -920,6 +1108,30 @@ type GetTableDataResponse struct {
 	PageSize  int             `json:"page_size"`
 }
 
+// TableOrderBy sorts the rows returned by QueryTable on one column.
+type TableOrderBy struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// TableQueryRequest reads rows of a table without writing SQL.
+type TableQueryRequest struct {
+	TableID TableID        `json:"id"`
+	Columns []string       `json:"columns,omitempty"`  // Columns to return, in order; all columns when empty
+	Filter  string         `json:"filter,omitempty"`   // SQL condition selecting rows, such as "amount > 100"
+	OrderBy []TableOrderBy `json:"order_by,omitempty"` // Sort keys, applied in order
+	Limit   int            `json:"limit,omitempty"`    // Maximum number of rows; the service default when zero
+	Offset  int            `json:"offset,omitempty"`   // Number of matching rows to skip
+}
+
+// TableQueryResponse holds the rows selected by QueryTable. Each row has one value
+// per column, in the order of Columns.
+type TableQueryResponse struct {
+	Columns []Column        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+	Total   int64           `json:"total"` // Number of rows matching the filter, ignoring Limit and Offset
+}
+
 type TableLoadRequest struct {
 	TableID     TableID     `json:"id"`
 	FileOption  FileOption  `json:"file_option"`
@@ -954,6 +1166,70 @@ type TableLoadResponse struct {
 	Lines int64 `json:"lines"`
 }
 
+// TableStreamLoadResponse is the response from LoadTableFromReader.
+type TableStreamLoadResponse struct {
+	RowsProcessed int64           `json:"rows_processed"` // Rows read from the data
+	RowsLoaded    int64           `json:"rows_loaded"`    // Rows written to the table
+	ErrorRows     int64           `json:"error_rows"`     // Rows rejected
+	Errors        []TableRowError `json:"errors,omitempty"`
+}
+
+// TableJobType is the kind of operation run by a table job.
+type TableJobType string
+
+const (
+	TableJobLoad     TableJobType = "load"
+	TableJobTruncate TableJobType = "truncate"
+)
+
+// TableJobStatus is the status of a table job.
+type TableJobStatus string
+
+const (
+	TableJobStatusPending   TableJobStatus = "pending"
+	TableJobStatusRunning   TableJobStatus = "running"
+	TableJobStatusSucceeded TableJobStatus = "succeeded" // Finished; some rows may still have been rejected, see ErrorRows
+	TableJobStatusFailed    TableJobStatus = "failed"
+	TableJobStatusCancelled TableJobStatus = "cancelled"
+)
+
+// IsTerminal reports whether the job has finished.
+func (s TableJobStatus) IsTerminal() bool {
+	return s == TableJobStatusSucceeded || s == TableJobStatusFailed || s == TableJobStatusCancelled
+}
+
+// TableJobStartResponse is the response from starting a table job.
+type TableJobStartResponse struct {
+	JobID string `json:"job_id"`
+}
+
+type TableJobInfoRequest struct {
+	JobID string `json:"job_id"`
+}
+
+// TableRowError describes a row rejected by a load job.
+type TableRowError struct {
+	Row     int64  `json:"row"`              // Row number in the source file, starting from 1
+	Column  string `json:"column,omitempty"` // Column that could not be converted, if known
+	Message string `json:"message"`
+}
+
+// TableJob reports the progress of a LoadTableAsync or TruncateTableAsync job.
+type TableJob struct {
+	JobID         string          `json:"job_id"`
+	TableID       TableID         `json:"table_id"`
+	Type          TableJobType    `json:"type"`
+	Status        TableJobStatus  `json:"status"`
+	RowsProcessed int64           `json:"rows_processed"` // Rows read from the source so far
+	RowsTotal     int64           `json:"rows_total"`     // Rows in the source; 0 while unknown
+	RowsLoaded    int64           `json:"rows_loaded"`    // Rows written to the table
+	ErrorRows     int64           `json:"error_rows"`     // Rows rejected so far
+	Errors        []TableRowError `json:"errors,omitempty"`
+	ErrorMessage  string          `json:"error_message,omitempty"` // Why the job failed
+	CreatedAt     string          `json:"created_at"`
+	FinishedAt    string          `json:"finished_at,omitempty"`
+}
+
 type TableDownloadRequest struct {
 	TableID TableID `json:"id"`
 }
@@ -962,6 +1238,15 @@ type TableDownloadResponse struct {
 	Url string `json:"url"`
 }
 
+// TableExportRequest asks for a signed link to a table's data in a given format,
+// optionally restricted to some columns and rows.
+type TableExportRequest struct {
+	TableID TableID    `json:"id"`
+	Format  LoadFormat `json:"format"`
+	Columns []string   `json:"columns,omitempty"` // Columns to export, in order; all columns when empty
+	Filter  string     `json:"filter,omitempty"`  // SQL condition selecting the exported rows, such as "amount > 100"
+}
+
 type TableDownloadDataRequest struct {
 	ID int64 `json:"id"`
 }
@@ -978,6 +1263,31 @@ type TableDeleteRequest struct {
 
 type TableDeleteResponse struct{}
 
+// TableAlterRequest changes the schema of a table. Changes are applied by the
+// service in one statement: drops, then modifications, then additions, then the rename.
+type TableAlterRequest struct {
+	TableID       TableID  `json:"id"`
+	AddColumns    []Column `json:"add_columns,omitempty"`
+	DropColumns   []string `json:"drop_columns,omitempty"`
+	ModifyColumns []Column `json:"modify_columns,omitempty"` // Matched by name; the new type, default and comment replace the old ones
+	RenameTo      string   `json:"rename_to,omitempty"`
+}
+
+type TableAlterResponse struct {
+	TableID TableID `json:"id"`
+}
+
+// TableInsertRequest appends rows to a table. Each row maps column names to
+// values; columns missing from a row take their default value.
+type TableInsertRequest struct {
+	TableID TableID          `json:"id"`
+	Rows    []map[string]any `json:"rows"`
+}
+
+type TableInsertResponse struct {
+	Inserted int64 `json:"inserted"` // Number of rows written
+}
+
 type TableFullPathRequest struct {
 	TableIDList []TableID `json:"table_id_list"`
 }
@@ -1073,6 +1383,121 @@ type VolumeRemoveRefWorkflowResponse struct {
 	VolumeID VolumeID `json:"id"`
 }
 
+// VolumeListRequest lists the volumes of a database, one page at a time. Set
+// OrderBy of the embedded CommonCondition to "name", "size" or "created_at", and
+// Order to "asc" or "desc", to sort the list.
+type VolumeListRequest struct {
+	CommonCondition
+	DatabaseID DatabaseID `json:"database_id"`
+	NameFilter string     `json:"name_filter,omitempty"` // Fuzzy match on volume name
+}
+
+type VolumeListResponse struct {
+	List  []VolumeListItem `json:"list"`
+	Total int              `json:"total"` // Number of matching volumes across all pages
+}
+
+// VolumeListItem is a volume in a VolumeListResponse.
+type VolumeListItem struct {
+	VolumeID  VolumeID `json:"id"`
+	Name      string   `json:"name"`
+	Comment   string   `json:"description"`
+	Size      int64    `json:"size"`
+	Ref       bool     `json:"ref"` // Whether a workflow references the volume
+	CreatedAt string   `json:"created_at"`
+	CreatedBy string   `json:"created_by"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type VolumeUsageRequest struct {
+	VolumeID VolumeID `json:"id"`
+	TopFiles int      `json:"top_files,omitempty"` // Number of largest files to return; the service default when zero
+}
+
+// VolumeUsageResponse reports the storage used by a volume.
+type VolumeUsageResponse struct {
+	VolumeID     VolumeID          `json:"id"`
+	UsedBytes    int64             `json:"used_bytes"`
+	FileCount    int64             `json:"file_count"`
+	FolderCount  int64             `json:"folder_count"`
+	LargestFiles []VolumeFileUsage `json:"largest_files"` // Largest files first
+}
+
+// VolumeFileUsage is a file listed in a VolumeUsageResponse.
+type VolumeFileUsage struct {
+	FileID    FileID `json:"id"`
+	Name      string `json:"name"`
+	ParentID  FileID `json:"parent_id"` // Empty for files in the volume root
+	Size      int64  `json:"size"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// VolumeQuota limits the content of a volume. A zero limit means unlimited.
+type VolumeQuota struct {
+	MaxBytes int64 `json:"max_bytes"`
+	MaxFiles int64 `json:"max_files"`
+}
+
+type VolumeQuotaRequest struct {
+	VolumeID VolumeID `json:"id"`
+}
+
+// VolumeQuotaResponse reports the quota of a volume next to its current usage.
+type VolumeQuotaResponse struct {
+	VolumeID VolumeID `json:"id"`
+	VolumeQuota
+	UsedBytes int64 `json:"used_bytes"`
+	FileCount int64 `json:"file_count"`
+}
+
+type VolumeQuotaSetRequest struct {
+	VolumeID VolumeID `json:"id"`
+	VolumeQuota
+}
+
+type VolumeQuotaSetResponse struct {
+	VolumeID VolumeID `json:"id"`
+}
+
+// ExternalStorageProvider identifies the object storage service behind an external volume.
+type ExternalStorageProvider string
+
+const (
+	ExternalStorageS3    ExternalStorageProvider = "s3"    // Amazon S3 and S3-compatible storage
+	ExternalStorageOSS   ExternalStorageProvider = "oss"   // Alibaba Cloud OSS
+	ExternalStorageCOS   ExternalStorageProvider = "cos"   // Tencent Cloud COS
+	ExternalStorageMinIO ExternalStorageProvider = "minio" // Self-hosted MinIO
+)
+
+// ExternalStorageCredentials are the access keys used to read an external bucket.
+type ExternalStorageCredentials struct {
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	SessionToken    string `json:"session_token,omitempty"` // For temporary credentials
+}
+
+// ExternalVolumeRequest registers an existing bucket as a read-only volume.
+//
+// Exactly one of Credentials and ConnectorID must be set; ConnectorID reuses the
+// credentials of an existing connector.
+type ExternalVolumeRequest struct {
+	DatabaseID  DatabaseID                  `json:"database_id"`
+	Name        string                      `json:"name"`
+	Comment     string                      `json:"description,omitempty"`
+	Provider    ExternalStorageProvider     `json:"provider"`
+	Bucket      string                      `json:"bucket"`
+	Prefix      string                      `json:"prefix,omitempty"`   // Only objects under this prefix are visible
+	Region      string                      `json:"region,omitempty"`   // e.g. "cn-hangzhou"
+	Endpoint    string                      `json:"endpoint,omitempty"` // Custom endpoint for S3-compatible storage
+	Credentials *ExternalStorageCredentials `json:"credentials,omitempty"`
+	ConnectorID uint64                      `json:"connector_id,omitempty"`
+}
+
+type ExternalVolumeResponse struct {
+	VolumeID VolumeID `json:"id"`
+	ReadOnly bool     `json:"read_only"`
+}
+
 // ============ Handler: File types ============
 
 type FileCreateRequest struct {
@@ -1080,7 +1505,7 @@ type FileCreateRequest struct {
 	VolumeID      VolumeID     `json:"volume_id"`
 	ParentID      FileID       `json:"parent_id"`
 	Size          int64        `json:"size"`
-	ShowType      string       `json:"show_type"`
+	ShowType      FileShowType `json:"show_type"`
 	OriginFileExt string       `json:"origin_file_ext"`
 	RefFileID     string       `json:"ref_file_id"`
 	SavePath      string       `json:"save_path"`
@@ -1088,6 +1513,17 @@ type FileCreateRequest struct {
 	Dedup         *DedupConfig `json:"dedup,omitempty"`
 }
 
+// FileShowTypeUpdateRequest represents a request to change the show type of a file or folder.
+type FileShowTypeUpdateRequest struct {
+	FileID   FileID       `json:"id"`
+	ShowType FileShowType `json:"show_type"`
+}
+
+// FileShowTypeUpdateResponse represents the response from changing the show type of a file.
+type FileShowTypeUpdateResponse struct {
+	FileID FileID `json:"id"`
+}
+
 type FileCreateResponse struct {
 	FileID FileID `json:"id"`
 	Name   string `json:"name"`
@@ -1096,6 +1532,9 @@ type FileCreateResponse struct {
 type FileUpdateRequest struct {
 	FileID FileID `json:"id"`
 	Name   string `json:"name"`
+	// ExpectedVersion, if set, makes the update fail with ErrResourceChanged when the
+	// file was modified since this version was read (see FileInfoResponse.ETag)
+	ExpectedVersion string `json:"expected_version,omitempty"`
 }
 
 type FileUpdateResponse struct {
@@ -1120,31 +1559,65 @@ type FileDeleteRefResponse struct {
 
 type FileInfoRequest struct {
 	FileID FileID `json:"id"`
+	// IncludeDeleted returns the tombstone of a deleted file instead of a not-found
+	// error, if the server still keeps it
+	IncludeDeleted bool `json:"include_deleted,omitempty"`
 }
 
 type FileInfoResponse struct {
-	ID            FileID `json:"id"`
-	Name          string `json:"name"`
-	FileType      string `json:"file_type"`
-	ShowType      string `json:"show_type"`
-	FileExt       string `json:"file_ext"`
-	OriginFileExt string `json:"origin_file_ext"`
-	RefFileID     string `json:"ref_file_id"`
-	Size          int64  `json:"size"`
-	ParentID      string `json:"parent_id"`
-	VolumeID      string `json:"volume_id"`
-	CreatedAt     string `json:"created_at"`
-	UpdatedAt     string `json:"updated_at"`
+	ID            FileID       `json:"id"`
+	Name          string       `json:"name"`
+	FileType      string       `json:"file_type"`
+	ShowType      FileShowType `json:"show_type"`
+	FileExt       string       `json:"file_ext"`
+	OriginFileExt string       `json:"origin_file_ext"`
+	RefFileID     string       `json:"ref_file_id"`
+	Size          int64        `json:"size"`
+	ParentID      string       `json:"parent_id"`
+	VolumeID      string       `json:"volume_id"`
+	LegalHold     bool         `json:"legal_hold"`
+	CreatedAt     string       `json:"created_at"`
+	UpdatedAt     string       `json:"updated_at"`
+	Version       string       `json:"version,omitempty"`    // Changes on every modification
+	Deleted       bool         `json:"deleted,omitempty"`    // Set on tombstones returned with IncludeDeleted
+	DeletedAt     string       `json:"deleted_at,omitempty"` // When the file was deleted
+	MD5           string       `json:"md5,omitempty"`        // Hex-encoded MD5 digest of the content, if the server computed it
+	SHA256        string       `json:"sha256,omitempty"`     // Hex-encoded SHA-256 digest of the content, if the server computed it
+}
+
+// ETag returns the version token to pass to conditional updates such as
+// RenameFileIfUnchanged. Servers that do not report a version use the update time.
+func (f *FileInfoResponse) ETag() string {
+	if f == nil {
+		return ""
+	}
+	if f.Version != "" {
+		return f.Version
+	}
+	return f.UpdatedAt
 }
 
 type FileListRequest struct {
 	CommonCondition
 	Keyword string `json:"keyword"`
+	// IncludeDeleted also lists tombstones of deleted files, marked with Deleted
+	IncludeDeleted bool `json:"include_deleted,omitempty"`
+	// Snapshot pins the listing to the files present when this page is served, so that
+	// later pages requested with the returned SnapshotToken neither miss nor repeat
+	// files while the volume changes
+	Snapshot bool `json:"snapshot,omitempty"`
+	// SnapshotToken continues a snapshot listing started with Snapshot; it takes
+	// precedence over Snapshot
+	SnapshotToken string `json:"snapshot_token,omitempty"`
 }
 
 type FileListResponse struct {
 	Total int                      `json:"total"`
 	List  []VolumeChildrenResponse `json:"list"`
+	// SnapshotToken identifies the snapshot this page was read from; empty unless the
+	// request set Snapshot or SnapshotToken. Pass it with the next page, or save it to
+	// resume the listing later
+	SnapshotToken string `json:"snapshot_token,omitempty"`
 }
 
 type FileUploadRequest struct {
@@ -1155,6 +1628,44 @@ type FileUploadRequest struct {
 
 type FileUploadResponse struct {
 	FileID FileID `json:"id"`
+	// MD5 and SHA256 are the hex-encoded digests of the uploaded content. They are
+	// computed by the SDK while sending and checked against the server's when it
+	// reports them.
+	MD5    string `json:"md5,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// FileUploadLinkRequest asks for a presigned URL through which a client, such as a
+// browser, uploads the content of a new file directly to storage.
+type FileUploadLinkRequest struct {
+	VolumeID    VolumeID `json:"volume_id"`
+	ParentID    FileID   `json:"parent_id,omitempty"` // Empty for the volume root
+	Name        string   `json:"name"`
+	Size        int64    `json:"size"`                   // Exact content size in bytes; the storage rejects other sizes
+	ContentType string   `json:"content_type,omitempty"` // Content-Type the uploader must send
+	// ExpiresIn is how long the URL stays valid, in seconds; the service default when zero.
+	ExpiresIn int `json:"expires_in,omitempty"`
+}
+
+// FileUploadLinkResponse describes how to upload the content of a file reserved by
+// GetFileUploadLink.
+//
+// For Method "PUT", send the content as the request body with Headers. For Method
+// "POST", send a multipart form with Fields followed by the content in a part named
+// "file".
+type FileUploadLinkResponse struct {
+	FileID    FileID            `json:"id"` // The file to pass to CompleteFileUpload
+	Url       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	ExpiresAt string            `json:"expires_at"`
+}
+
+// FileUploadCompleteRequest registers content uploaded through a presigned URL.
+type FileUploadCompleteRequest struct {
+	FileID FileID `json:"id"`
+	SHA256 string `json:"sha256,omitempty"` // Expected hex-encoded SHA-256 digest of the content, checked by the service if set
 }
 
 type FileDownloadRequest struct {
@@ -1163,7 +1674,8 @@ type FileDownloadRequest struct {
 }
 
 type FileDownloadResponse struct {
-	Url string `json:"link"`
+	Url    string `json:"link"`
+	SHA256 string `json:"sha256,omitempty"` // Hex-encoded SHA-256 digest of the content, if the server knows it
 }
 
 type FilePreviewLinkRequest struct {
@@ -1179,6 +1691,40 @@ type FilePreviewStreamRequest struct {
 	FileID FileID `json:"file_id"`
 }
 
+// NameConflictPolicy decides what happens when the destination of a move or copy
+// already contains an entry with the same name.
+type NameConflictPolicy string
+
+const (
+	NameConflictFail      NameConflictPolicy = "fail"      // Reject the operation (default)
+	NameConflictRename    NameConflictPolicy = "rename"    // Keep both, giving the new entry a unique name
+	NameConflictOverwrite NameConflictPolicy = "overwrite" // Replace the existing entry
+)
+
+type FileMoveRequest struct {
+	FileID         FileID             `json:"id"`
+	TargetVolumeID VolumeID           `json:"target_volume_id"`
+	TargetParentID FileID             `json:"target_parent_id"` // Empty for the volume root
+	ConflictPolicy NameConflictPolicy `json:"conflict_policy,omitempty"`
+}
+
+type FileMoveResponse struct {
+	FileID FileID `json:"id"`
+	Name   string `json:"name"` // Final name, which differs from the original under NameConflictRename
+}
+
+type FileCopyRequest struct {
+	FileID         FileID             `json:"id"`
+	TargetVolumeID VolumeID           `json:"target_volume_id"`
+	TargetParentID FileID             `json:"target_parent_id"` // Empty for the volume root
+	ConflictPolicy NameConflictPolicy `json:"conflict_policy,omitempty"`
+}
+
+type FileCopyResponse struct {
+	FileID FileID `json:"id"` // ID of the new copy
+	Name   string `json:"name"`
+}
+
 // ============ Handler: Folder types ============
 
 type FolderCreateRequest struct {
@@ -1195,6 +1741,9 @@ type FolderCreateResponse struct {
 type FolderUpdateRequest struct {
 	FolderID FileID `json:"id"`
 	Name     string `json:"name"`
+	// ExpectedVersion, if set, makes the update fail with ErrResourceChanged when the
+	// folder was modified since this version was read (see FileInfoResponse.ETag)
+	ExpectedVersion string `json:"expected_version,omitempty"`
 }
 
 type FolderUpdateResponse struct {
@@ -1225,107 +1774,391 @@ type FolderRefListResponse struct {
 	List []*VolumeRefResp `json:"list"`
 }
 
-// ============ Handler: Role types ============
-
-type RoleCreateRequest struct {
-	RoleName    string            `json:"name"`
-	PrivList    []string          `json:"authority_code_list"`
-	ObjPrivList []ObjPrivResponse `json:"obj_authority_code_list"`
-	Comment     string            `json:"description"`
+type FolderCopyRequest struct {
+	FolderID       FileID             `json:"id"`
+	TargetVolumeID VolumeID           `json:"target_volume_id"`
+	TargetParentID FileID             `json:"target_parent_id"` // Empty for the volume root
+	ConflictPolicy NameConflictPolicy `json:"conflict_policy,omitempty"`
 }
 
-type RoleCreateResponse struct {
-	RoleID RoleID `json:"id"`
+type FolderCopyResponse struct {
+	FolderID  FileID `json:"id"` // ID of the new folder
+	Name      string `json:"name"`
+	FileCount int    `json:"file_count"` // Number of files copied
 }
 
-type RoleDeleteRequest struct {
-	RoleID RoleID `json:"id"`
-}
+// ArchiveFormat is the container format of a folder archive.
+type ArchiveFormat string
 
-type RoleDeleteResponse struct {
-	RoleID RoleID `json:"id"`
-}
+const (
+	ArchiveZip   ArchiveFormat = "zip"
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+)
 
-type RoleInfoRequest struct {
-	RoleID RoleID `json:"id"`
+// FolderArchiveRequest asks for a signed link to an archive of a folder's content.
+type FolderArchiveRequest struct {
+	FolderID          FileID        `json:"id"`
+	Format            ArchiveFormat `json:"format"`
+	IncludeSubfolders bool          `json:"include_subfolders"`
 }
 
-type RoleInfoResponse struct {
-	RoleID           RoleID             `json:"id"`
-	RoleName         string             `json:"name"`
-	Status           string             `json:"status"`
-	Reserved         bool               `json:"reserved"`
-	Comment          string             `json:"description"`
-	AuthorityList    []*PrivResponse    `json:"authority_list"`
-	ObjAuthorityList []*ObjPrivResponse `json:"obj_authority_list"`
-	CreatedAt        string             `json:"created_at"`
-	UpdatedAt        string             `json:"updated_at"`
+type FolderArchiveResponse struct {
+	Url string `json:"link"`
 }
 
-type PrivResponse struct {
-	PrivCode string `json:"code"`
-	PrivName string `json:"name"`
-	Comment  string `json:"description"`
-	ObjType  string `json:"category"`
-}
+// ============ Handler: Encryption types ============
 
-type RoleListRequest struct {
-	CommonCondition
-	Keyword string `json:"keyword"`
+// EncryptionMode selects who manages the key used to encrypt a volume at rest.
+type EncryptionMode string
+
+const (
+	EncryptionModePlatformManaged EncryptionMode = "platform_managed" // Key managed by the platform
+	EncryptionModeCustomerManaged EncryptionMode = "customer_managed" // Bring-your-own-key from an external KMS
+)
+
+// EncryptionStatus is the state of a volume's encryption configuration.
+type EncryptionStatus string
+
+const (
+	EncryptionStatusEnabled  EncryptionStatus = "enabled"
+	EncryptionStatusRotating EncryptionStatus = "rotating" // A key rotation is re-encrypting data
+	EncryptionStatusError    EncryptionStatus = "error"    // The key is unreachable or was revoked
+)
+
+type VolumeEncryptionSetRequest struct {
+	VolumeID    VolumeID       `json:"volume_id"`
+	Mode        EncryptionMode `json:"mode"`
+	KeyProvider string         `json:"key_provider,omitempty"` // KMS provider, e.g. "aliyun_kms", "aws_kms"; customer-managed only
+	KeyRef      string         `json:"key_ref,omitempty"`      // Key reference (ARN/URI) in the KMS; customer-managed only
 }
 
-type RoleListResponse struct {
-	Total int                `json:"total"`
-	List  []RoleInfoResponse `json:"role_list"`
+type VolumeEncryptionSetResponse struct {
+	VolumeID VolumeID `json:"volume_id"`
 }
 
-type RoleUpdateInfoRequest struct {
-	RoleID      RoleID            `json:"id"`
-	PrivList    []string          `json:"authority_code_list"`
-	ObjPrivList []ObjPrivResponse `json:"obj_authority_code_list"`
-	Comment     string            `json:"description"`
+type VolumeEncryptionInfoRequest struct {
+	VolumeID VolumeID `json:"volume_id"`
 }
 
-type RoleUpdateInfoResponse struct {
-	RoleID RoleID `json:"id"`
+// VolumeEncryptionInfo describes how a volume is encrypted.
+type VolumeEncryptionInfo struct {
+	VolumeID      VolumeID         `json:"volume_id"`
+	Mode          EncryptionMode   `json:"mode"`
+	KeyProvider   string           `json:"key_provider"`
+	KeyRef        string           `json:"key_ref"`
+	KeyVersion    string           `json:"key_version"`
+	Status        EncryptionStatus `json:"status"`
+	StatusMessage string           `json:"status_message"`
+	RotationJobID string           `json:"rotation_job_id"` // Set while Status is "rotating"
+	LastRotatedAt string           `json:"last_rotated_at"`
 }
 
-type RoleUpdateStatusRequest struct {
-	RoleID RoleID `json:"id"`
-	Action string `json:"action"`
+type VolumeKeyRotateRequest struct {
+	VolumeID  VolumeID `json:"volume_id"`
+	NewKeyRef string   `json:"new_key_ref,omitempty"` // Switch to a different key; empty rotates to a new version of the current key
 }
 
-type RoleUpdateStatusResponse struct {
-	RoleID RoleID `json:"id"`
+type VolumeKeyRotateResponse struct {
+	RotationJobID string `json:"rotation_job_id"`
 }
 
-type RoleUpdateCodeListRequest struct {
-	RoleID   RoleID   `json:"role_id"`
-	ObjType  string   `json:"category"`
-	ObjID    string   `json:"id"`
-	CodeList []string `json:"code_list"`
+type KeyRotationStatusRequest struct {
+	RotationJobID string `json:"rotation_job_id"`
 }
 
-type RoleUpdateCodeListResponse struct {
-	RoleID RoleID `json:"role_id"`
+// KeyRotationStatus reports the progress of a key rotation job.
+type KeyRotationStatus struct {
+	RotationJobID string  `json:"rotation_job_id"`
+	VolumeID      string  `json:"volume_id"`
+	Status        string  `json:"status"`   // "running", "completed" or "failed"
+	Progress      float64 `json:"progress"` // Fraction of data re-encrypted, between 0 and 1
+	Error         string  `json:"error"`
+	StartedAt     string  `json:"started_at"`
+	FinishedAt    string  `json:"finished_at"`
 }
 
-type RoleUpdateRolesByObjectRequest struct {
-	ObjID      string   `json:"id"`
-	Code       string   `json:"code"`
-	RoleIdList []RoleID `json:"role_id_list"`
+// ============ Handler: Legal hold types ============
+
+// LegalHoldObjectType identifies the kind of object a legal hold is placed on.
+type LegalHoldObjectType string
+
+const (
+	LegalHoldObjectFile   LegalHoldObjectType = "file"
+	LegalHoldObjectFolder LegalHoldObjectType = "folder"
+	LegalHoldObjectVolume LegalHoldObjectType = "volume"
+)
+
+type LegalHoldSetRequest struct {
+	ObjectType LegalHoldObjectType `json:"object_type"`
+	ObjectID   string              `json:"object_id"`
+	Enabled    bool                `json:"enabled"`          // true places the hold, false releases it
+	Reason     string              `json:"reason,omitempty"` // Recorded in the audit log
 }
 
-type RoleUpdateRolesByObjectResponse struct{}
+type LegalHoldSetResponse struct {
+	ObjectID string `json:"object_id"`
+}
 
-type RoleListByCategoryAndObjectRequest struct {
-	ObjType string `json:"category"`
-	ObjID   string `json:"id"`
+type LegalHoldInfoRequest struct {
+	ObjectType LegalHoldObjectType `json:"object_type"`
+	ObjectID   string              `json:"object_id"`
 }
 
-type RoleListByCategoryAndObjectResponse struct {
-	Total int                `json:"total"`
-	List  []*PrivAndRoleList `json:"list"`
+// LegalHoldInfoResponse describes the legal hold state of an object. Inherited is true
+// when the hold is placed on an ancestor folder or volume rather than the object itself.
+type LegalHoldInfoResponse struct {
+	ObjectType LegalHoldObjectType `json:"object_type"`
+	ObjectID   string              `json:"object_id"`
+	Enabled    bool                `json:"enabled"`
+	Inherited  bool                `json:"inherited"`
+	Reason     string              `json:"reason"`
+	SetBy      string              `json:"set_by"`
+	SetAt      string              `json:"set_at"`
+}
+
+// ============ Handler: Retention types ============
+
+// RetentionTargetType identifies the kind of object a retention policy applies to.
+type RetentionTargetType string
+
+const (
+	RetentionTargetVolume   RetentionTargetType = "volume"   // Files in a volume
+	RetentionTargetFolder   RetentionTargetType = "folder"   // Files in a folder
+	RetentionTargetWorkflow RetentionTargetType = "workflow" // Job records of a workflow
+)
+
+// RetentionPolicy describes which files or workflow job records are deleted automatically.
+type RetentionPolicy struct {
+	ID           string              `json:"id"`
+	Name         string              `json:"name"`
+	TargetType   RetentionTargetType `json:"target_type"`
+	TargetID     string              `json:"target_id"`
+	MaxAgeDays   int                 `json:"max_age_days"`   // Delete files older than this many days (0 disables)
+	KeepLastJobs int                 `json:"keep_last_jobs"` // Keep only the most recent job records (0 disables)
+	Enabled      bool                `json:"enabled"`
+	CreatedAt    string              `json:"created_at"`
+	CreatedBy    string              `json:"created_by"`
+	UpdatedAt    string              `json:"updated_at"`
+	UpdatedBy    string              `json:"updated_by"`
+}
+
+type RetentionPolicyCreateRequest struct {
+	Name         string              `json:"name"`
+	TargetType   RetentionTargetType `json:"target_type"`
+	TargetID     string              `json:"target_id"`
+	MaxAgeDays   int                 `json:"max_age_days,omitempty"`
+	KeepLastJobs int                 `json:"keep_last_jobs,omitempty"`
+	Enabled      bool                `json:"enabled"`
+}
+
+type RetentionPolicyCreateResponse struct {
+	ID string `json:"id"`
+}
+
+type RetentionPolicyUpdateRequest struct {
+	ID           string `json:"id"`
+	Name         string `json:"name,omitempty"`
+	MaxAgeDays   *int   `json:"max_age_days,omitempty"`
+	KeepLastJobs *int   `json:"keep_last_jobs,omitempty"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+}
+
+type RetentionPolicyUpdateResponse struct {
+	ID string `json:"id"`
+}
+
+type RetentionPolicyDeleteRequest struct {
+	ID string `json:"id"`
+}
+
+type RetentionPolicyDeleteResponse struct {
+	ID string `json:"id"`
+}
+
+type RetentionPolicyInfoRequest struct {
+	ID string `json:"id"`
+}
+
+type RetentionPolicyListRequest struct {
+	CommonCondition
+	TargetType RetentionTargetType `json:"target_type,omitempty"`
+	TargetID   string              `json:"target_id,omitempty"`
+}
+
+type RetentionPolicyListResponse struct {
+	Total int               `json:"total"`
+	List  []RetentionPolicy `json:"list"`
+}
+
+// RetentionPreviewRequest previews either a saved policy (ID) or an unsaved one (Policy).
+type RetentionPreviewRequest struct {
+	ID     string                        `json:"id,omitempty"`
+	Policy *RetentionPolicyCreateRequest `json:"policy,omitempty"`
+}
+
+// RetentionPreviewResponse lists what a policy would delete if it ran now.
+type RetentionPreviewResponse struct {
+	Files     []VolumeChildrenResponse `json:"files"`
+	Jobs      []WorkflowJob            `json:"jobs"`
+	TotalSize int64                    `json:"total_size"` // Total size in bytes of Files
+}
+
+// ============ Handler: Volume snapshot types ============
+
+// VolumeSnapshot is a point-in-time copy of the folders and files of a volume.
+type VolumeSnapshot struct {
+	SnapshotID  string   `json:"id"`
+	VolumeID    VolumeID `json:"volume_id"`
+	Name        string   `json:"name"`
+	Comment     string   `json:"description"`
+	FileCount   int64    `json:"file_count"`
+	FolderCount int64    `json:"folder_count"`
+	Size        int64    `json:"size"` // Total size in bytes of the files in the snapshot
+	CreatedAt   string   `json:"created_at"`
+	CreatedBy   string   `json:"created_by"`
+}
+
+type VolumeSnapshotCreateRequest struct {
+	VolumeID VolumeID `json:"volume_id"`
+	Name     string   `json:"name"`
+	Comment  string   `json:"description,omitempty"`
+}
+
+type VolumeSnapshotCreateResponse struct {
+	SnapshotID string `json:"id"`
+}
+
+type VolumeSnapshotListRequest struct {
+	CommonCondition
+	VolumeID VolumeID `json:"volume_id"`
+}
+
+type VolumeSnapshotListResponse struct {
+	Total int              `json:"total"`
+	List  []VolumeSnapshot `json:"list"` // Newest first
+}
+
+// VolumeSnapshotRestoreRequest restores a snapshot, either over the volume it was
+// taken from or, when TargetName is set, into a new volume of the same database.
+type VolumeSnapshotRestoreRequest struct {
+	SnapshotID string `json:"id"`
+	TargetName string `json:"target_name,omitempty"`
+}
+
+type VolumeSnapshotRestoreResponse struct {
+	VolumeID      VolumeID `json:"volume_id"` // The restored volume: the original one, or the new one named TargetName
+	FilesRestored int64    `json:"files_restored"`
+}
+
+type VolumeSnapshotDeleteRequest struct {
+	SnapshotID string `json:"id"`
+}
+
+type VolumeSnapshotDeleteResponse struct {
+	SnapshotID string `json:"id"`
+}
+
+// ============ Handler: Role types ============
+
+type RoleCreateRequest struct {
+	RoleName    string            `json:"name"`
+	PrivList    []string          `json:"authority_code_list"`
+	ObjPrivList []ObjPrivResponse `json:"obj_authority_code_list"`
+	Comment     string            `json:"description"`
+}
+
+type RoleCreateResponse struct {
+	RoleID RoleID `json:"id"`
+}
+
+type RoleDeleteRequest struct {
+	RoleID RoleID `json:"id"`
+}
+
+type RoleDeleteResponse struct {
+	RoleID RoleID `json:"id"`
+}
+
+type RoleInfoRequest struct {
+	RoleID RoleID `json:"id"`
+}
+
+type RoleInfoResponse struct {
+	RoleID           RoleID             `json:"id"`
+	RoleName         string             `json:"name"`
+	Status           string             `json:"status"`
+	Reserved         bool               `json:"reserved"`
+	Comment          string             `json:"description"`
+	AuthorityList    []*PrivResponse    `json:"authority_list"`
+	ObjAuthorityList []*ObjPrivResponse `json:"obj_authority_list"`
+	CreatedAt        string             `json:"created_at"`
+	UpdatedAt        string             `json:"updated_at"`
+}
+
+type PrivResponse struct {
+	PrivCode string `json:"code"`
+	PrivName string `json:"name"`
+	Comment  string `json:"description"`
+	ObjType  string `json:"category"`
+}
+
+type RoleListRequest struct {
+	CommonCondition
+	Keyword string `json:"keyword"`
+}
+
+type RoleListResponse struct {
+	Total int                `json:"total"`
+	List  []RoleInfoResponse `json:"role_list"`
+}
+
+type RoleUpdateInfoRequest struct {
+	RoleID      RoleID            `json:"id"`
+	PrivList    []string          `json:"authority_code_list"`
+	ObjPrivList []ObjPrivResponse `json:"obj_authority_code_list"`
+	Comment     string            `json:"description"`
+}
+
+type RoleUpdateInfoResponse struct {
+	RoleID RoleID `json:"id"`
+}
+
+type RoleUpdateStatusRequest struct {
+	RoleID RoleID `json:"id"`
+	Action string `json:"action"`
+}
+
+type RoleUpdateStatusResponse struct {
+	RoleID RoleID `json:"id"`
+}
+
+type RoleUpdateCodeListRequest struct {
+	RoleID   RoleID   `json:"role_id"`
+	ObjType  string   `json:"category"`
+	ObjID    string   `json:"id"`
+	CodeList []string `json:"code_list"`
+}
+
+type RoleUpdateCodeListResponse struct {
+	RoleID RoleID `json:"role_id"`
+}
+
+type RoleUpdateRolesByObjectRequest struct {
+	ObjID      string   `json:"id"`
+	Code       string   `json:"code"`
+	RoleIdList []RoleID `json:"role_id_list"`
+}
+
+type RoleUpdateRolesByObjectResponse struct{}
+
+type RoleListByCategoryAndObjectRequest struct {
+	ObjType string `json:"category"`
+	ObjID   string `json:"id"`
+}
+
+type RoleListByCategoryAndObjectResponse struct {
+	Total int                `json:"total"`
+	List  []*PrivAndRoleList `json:"list"`
 }
 
 type PrivAndRoleList struct {
@@ -1455,6 +2288,118 @@ type UserApiKeyResponse struct {
 
 type UserApiKeyRefreshResonse struct{}
 
+// ============ Handler: Access policy types ============
+
+// APIKeyIPAllowlistSetRequest replaces the IP allowlist of a user's API key.
+// An empty CIDRs list removes the restriction.
+type APIKeyIPAllowlistSetRequest struct {
+	UserID UserID   `json:"user_id"`
+	CIDRs  []string `json:"cidr_list"` // IPv4/IPv6 addresses or CIDR blocks, e.g. "10.0.0.0/8"
+}
+
+type APIKeyIPAllowlistSetResponse struct {
+	UserID UserID `json:"user_id"`
+}
+
+type APIKeyIPAllowlistInfoRequest struct {
+	UserID UserID `json:"user_id"`
+}
+
+type APIKeyIPAllowlistInfoResponse struct {
+	UserID    UserID   `json:"user_id"`
+	CIDRs     []string `json:"cidr_list"`
+	UpdatedAt string   `json:"updated_at"`
+	UpdatedBy string   `json:"updated_by"`
+}
+
+// SessionPolicy limits the lifetime and number of concurrent login sessions.
+// A zero value for a limit means no limit.
+type SessionPolicy struct {
+	MaxAgeSeconds         int `json:"max_age_seconds"`         // Sessions expire this many seconds after login
+	IdleTimeoutSeconds    int `json:"idle_timeout_seconds"`    // Sessions expire after this many seconds without activity
+	MaxConcurrentSessions int `json:"max_concurrent_sessions"` // Oldest sessions are revoked when the limit is exceeded
+}
+
+// SessionPolicySetRequest sets the session policy of a user, or the account-wide
+// default when UserID is zero.
+type SessionPolicySetRequest struct {
+	UserID UserID `json:"user_id,omitempty"`
+	SessionPolicy
+}
+
+type SessionPolicySetResponse struct {
+	UserID UserID `json:"user_id"`
+}
+
+type SessionPolicyInfoRequest struct {
+	UserID UserID `json:"user_id,omitempty"`
+}
+
+// SessionPolicyInfoResponse describes the effective session policy. Inherited is true
+// when the user has no policy of its own and the account-wide default applies.
+type SessionPolicyInfoResponse struct {
+	UserID UserID `json:"user_id"`
+	SessionPolicy
+	Inherited bool   `json:"inherited"`
+	UpdatedAt string `json:"updated_at"`
+	UpdatedBy string `json:"updated_by"`
+}
+
+// ============ Handler: API key types ============
+
+// APIKeyID identifies one of a user's API keys.
+type APIKeyID string
+
+// APIKeyCreateRequest creates an additional API key for a user. The key never
+// expires when ExpiresAt is empty, and carries all of the user's privileges when
+// Scopes is empty.
+type APIKeyCreateRequest struct {
+	UserID      UserID   `json:"user_id"`
+	Description string   `json:"description,omitempty"`
+	ExpiresAt   string   `json:"expires_at,omitempty"` // RFC 3339 timestamp
+	Scopes      []string `json:"scope_list,omitempty"` // Privilege codes the key is limited to, e.g. "DT8"
+}
+
+// APIKeyCreateResponse carries the secret of a new key. The secret is only
+// returned once; ListUserAPIKeys reports the key without it.
+type APIKeyCreateResponse struct {
+	APIKeyInfo
+	Key string `json:"key"`
+}
+
+type APIKeyRevokeRequest struct {
+	UserID UserID   `json:"user_id"`
+	KeyID  APIKeyID `json:"key_id"`
+}
+
+type APIKeyRevokeResponse struct {
+	KeyID APIKeyID `json:"key_id"`
+}
+
+type APIKeyListRequest struct {
+	UserID UserID `json:"user_id"`
+	// IncludeRevoked also returns revoked and expired keys
+	IncludeRevoked bool `json:"include_revoked,omitempty"`
+}
+
+type APIKeyListResponse struct {
+	List []APIKeyInfo `json:"key_list"`
+}
+
+// APIKeyInfo describes an API key without its secret.
+type APIKeyInfo struct {
+	KeyID       APIKeyID `json:"key_id"`
+	UserID      UserID   `json:"user_id"`
+	Prefix      string   `json:"prefix"` // First characters of the key, to recognize it
+	Description string   `json:"description"`
+	Scopes      []string `json:"scope_list"`
+	ExpiresAt   string   `json:"expires_at"`
+	LastUsedAt  string   `json:"last_used_at"`
+	Revoked     bool     `json:"revoked"`
+	CreatedAt   string   `json:"created_at"`
+	CreatedBy   string   `json:"created_by"`
+}
+
 // ============ Handler: Priv types ============
 
 type PrivGetAuthorizedObjectsRequest struct {
@@ -1543,10 +2488,137 @@ type GenAIGetJobDetailResponse struct {
 	Files  []GenAIWorkflowJobFileResponse `json:"files"`
 }
 
+// Status values of GenAI jobs and of the files they process.
+const (
+	GenAIJobStatusPending   = "pending"
+	GenAIJobStatusRunning   = "running"
+	GenAIJobStatusCompleted = "completed"
+	GenAIJobStatusFailed    = "failed"
+	GenAIJobStatusCancelled = "cancelled"
+)
+
+// genAIStatusKind classifies a GenAI status, accepting the spellings used by
+// different service versions.
+func genAIStatusKind(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "completed", "complete", "succeeded", "success", "finished", "done":
+		return GenAIJobStatusCompleted
+	case "failed", "failure", "error":
+		return GenAIJobStatusFailed
+	case "cancelled", "canceled":
+		return GenAIJobStatusCancelled
+	case "running", "processing", "in_progress":
+		return GenAIJobStatusRunning
+	default:
+		return GenAIJobStatusPending
+	}
+}
+
+// IsTerminal reports whether the job has finished, successfully or not.
+func (r *GenAIGetJobDetailResponse) IsTerminal() bool {
+	if r == nil {
+		return false
+	}
+	switch genAIStatusKind(r.Status) {
+	case GenAIJobStatusCompleted, GenAIJobStatusFailed, GenAIJobStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// FailedFiles returns the files whose processing failed.
+func (r *GenAIGetJobDetailResponse) FailedFiles() []GenAIWorkflowJobFileResponse {
+	if r == nil {
+		return nil
+	}
+	var failed []GenAIWorkflowJobFileResponse
+	for _, f := range r.Files {
+		if genAIStatusKind(f.FileStatus) == GenAIJobStatusFailed {
+			failed = append(failed, f)
+		}
+	}
+	return failed
+}
+
+// FinishedFiles returns how many files have finished processing, successfully or not.
+func (r *GenAIGetJobDetailResponse) FinishedFiles() int {
+	if r == nil {
+		return 0
+	}
+	n := 0
+	for _, f := range r.Files {
+		switch genAIStatusKind(f.FileStatus) {
+		case GenAIJobStatusCompleted, GenAIJobStatusFailed, GenAIJobStatusCancelled:
+			n++
+		}
+	}
+	return n
+}
+
 type GenAIDownloadFileResultRequest struct {
 	FileID string `uri:"file_id"`
 }
 
+// GenAIJobResultFile describes a result file produced by a GenAI job.
+type GenAIJobResultFile struct {
+	FileID       string `json:"file_id"` // ID accepted by DownloadGenAIResult
+	FileName     string `json:"file_name"`
+	FileSize     int64  `json:"file_size"`
+	FileType     string `json:"file_type"`      // Result format, e.g. "markdown" or "json"
+	SourceFileID string `json:"source_file_id"` // Input file the result was produced from
+}
+
+// GenAIJobResultsResponse lists the result files of a GenAI job.
+type GenAIJobResultsResponse struct {
+	JobID   string               `json:"job_id"`
+	Results []GenAIJobResultFile `json:"results"`
+}
+
+// GenAIPipeline represents a stored GenAI pipeline.
+type GenAIPipeline struct {
+	ID        string              `json:"id"`
+	Name      string              `json:"name"`
+	JobID     string              `json:"job_id"` // Job created for the pipeline run
+	Status    string              `json:"status"` // Status of the pipeline job
+	Steps     []GenAIWorkflowStep `json:"steps"`
+	FileCount int                 `json:"file_count"`
+	Creator   string              `json:"creator"`
+	CreatedAt string              `json:"created_at"`
+	UpdatedAt string              `json:"updated_at"`
+}
+
+// GenAIPipelineListRequest represents a request to list GenAI pipelines.
+type GenAIPipelineListRequest struct {
+	Name     string `json:"name,omitempty"`      // Filter by pipeline name (substring match)
+	Status   string `json:"status,omitempty"`    // Filter by job status
+	Page     int    `json:"page,omitempty"`      // Page number (starts from 1, default 1)
+	PageSize int    `json:"page_size,omitempty"` // Page size (default 20)
+}
+
+// GenAIPipelineListResponse represents the response from listing GenAI pipelines.
+type GenAIPipelineListResponse struct {
+	Pipelines []GenAIPipeline `json:"pipelines"`
+	Total     int             `json:"total"` // Total number of pipelines matching the filters
+}
+
+// GenAIPipelineUpdateRequest represents a request to update a GenAI pipeline.
+// Empty fields are left unchanged.
+type GenAIPipelineUpdateRequest struct {
+	Name  string              `json:"name,omitempty"`
+	Steps []GenAIWorkflowStep `json:"steps,omitempty"`
+}
+
+// GenAIPipelineDeleteResponse represents the response from deleting a GenAI pipeline.
+type GenAIPipelineDeleteResponse struct {
+	ID string `json:"id"`
+}
+
+// GenAICancelJobResponse represents the response from cancelling a GenAI job.
+type GenAICancelJobResponse struct {
+	JobID  string `json:"job_id"`
+	Status string `json:"status"`
+}
+
 // ============ Handler: Workflow types ============
 
 // ProcessMode represents the processing mode for workflows.
@@ -1564,6 +2636,7 @@ const (
 	WorkflowJobStatusRunning   WorkflowJobStatus = 1 // Job is running
 	WorkflowJobStatusCompleted WorkflowJobStatus = 2 // Job completed successfully
 	WorkflowJobStatusFailed    WorkflowJobStatus = 3 // Job failed
+	WorkflowJobStatusCancelled WorkflowJobStatus = 4 // Job was cancelled
 )
 
 // String returns the string representation of the workflow job status.
@@ -1575,6 +2648,8 @@ func (s WorkflowJobStatus) String() string {
 		return "completed"
 	case WorkflowJobStatusFailed:
 		return "failed"
+	case WorkflowJobStatusCancelled:
+		return "cancelled"
 	case WorkflowJobStatusUnknown:
 		return "unknown"
 	default:
@@ -1582,6 +2657,11 @@ func (s WorkflowJobStatus) String() string {
 	}
 }
 
+// IsTerminal reports whether the job has finished, successfully or not.
+func (s WorkflowJobStatus) IsTerminal() bool {
+	return s == WorkflowJobStatusCompleted || s == WorkflowJobStatusFailed || s == WorkflowJobStatusCancelled
+}
+
 // WorkflowMetadata represents workflow metadata for creating a workflow.
 // This is used by the CreateWorkflow API endpoint.
 type WorkflowMetadata struct {
@@ -1640,6 +2720,125 @@ type WorkflowCreateResponse struct {
 	Files             string `json:"files"`
 }
 
+// WorkflowResponse represents a stored workflow, as returned by GetWorkflow, UpdateWorkflow
+// and ListWorkflows. It has the same fields as the response from creating a workflow.
+type WorkflowResponse = WorkflowCreateResponse
+
+// WorkflowDeleteResponse represents the response from deleting a workflow.
+type WorkflowDeleteResponse struct {
+	ID string `json:"id"`
+}
+
+// WorkflowListRequest represents a request to list workflows.
+type WorkflowListRequest struct {
+	Name           string `json:"name,omitempty"`             // Filter by workflow name (substring match)
+	SourceVolumeID string `json:"source_volume_id,omitempty"` // Filter by source volume ID
+	Page           int    `json:"page,omitempty"`             // Page number (starts from 1, default 1)
+	PageSize       int    `json:"page_size,omitempty"`        // Page size (default 20)
+}
+
+// WorkflowListResponse represents the response from listing workflows.
+type WorkflowListResponse struct {
+	Workflows []WorkflowResponse `json:"workflows"`
+	Total     int                `json:"total"` // Total number of workflows matching the filters
+}
+
+// WorkflowNodeTypeListResponse represents the response from listing workflow node types.
+type WorkflowNodeTypeListResponse struct {
+	NodeTypes []WorkflowNodeType `json:"node_types"`
+}
+
+// WorkflowNodeType describes a node type that can be used in a workflow.
+type WorkflowNodeType struct {
+	Type        string                  `json:"type"`         // Value of CatalogWorkflowNode.Type, e.g. "ChunkNode"
+	Name        string                  `json:"name"`         // Display name
+	Description string                  `json:"description"`  // What the node does
+	Category    string                  `json:"category"`     // e.g. "parse", "chunk", "embed", "write"
+	InputPorts  []string                `json:"input_ports"`  // Ports accepted as receiver_port
+	OutputPorts []string                `json:"output_ports"` // Ports accepted as sender_port
+	Components  []WorkflowNodeComponent `json:"components"`   // Parameter groups, keys of CatalogWorkflowNode.InitParameters
+}
+
+// WorkflowNodeComponent is a named group of init parameters of a node type.
+type WorkflowNodeComponent struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Parameters  []WorkflowNodeParameter `json:"parameters"`
+}
+
+// WorkflowNodeParameter describes a single init parameter of a node component.
+type WorkflowNodeParameter struct {
+	Name        string        `json:"name"`
+	Type        string        `json:"type"` // "string", "integer", "number", "boolean", "array" or "object"
+	Description string        `json:"description"`
+	Required    bool          `json:"required"`
+	Default     interface{}   `json:"default,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`    // Allowed values, if restricted
+	Minimum     *float64      `json:"minimum,omitempty"` // Lower bound for numeric parameters
+	Maximum     *float64      `json:"maximum,omitempty"` // Upper bound for numeric parameters
+}
+
+// WorkflowState represents the scheduling state of a workflow.
+type WorkflowState string
+
+const (
+	WorkflowStateRunning WorkflowState = "running" // Scheduled runs are executed
+	WorkflowStatePaused  WorkflowState = "paused"  // Scheduled runs are skipped until resumed
+	WorkflowStateStopped WorkflowState = "stopped" // The workflow has not been started
+)
+
+// WorkflowStateResponse represents the response from starting, pausing or resuming a workflow.
+type WorkflowStateResponse struct {
+	ID    string        `json:"id"`
+	State WorkflowState `json:"state"`
+}
+
+// WorkflowTriggerResponse represents the response from triggering a workflow run.
+type WorkflowTriggerResponse struct {
+	JobID string `json:"job_id"`
+}
+
+// WorkflowSimulationResponse represents the result of running a workflow against a sample file.
+type WorkflowSimulationResponse struct {
+	Status     string                         `json:"status"`      // Overall status: "completed" or "failed"
+	Error      string                         `json:"error"`       // Error message when the simulation failed
+	DurationMs int64                          `json:"duration_ms"` // Total execution time in milliseconds
+	Nodes      []WorkflowNodeSimulationResult `json:"nodes"`       // Per-node outputs in execution order
+}
+
+// Node returns the simulation result for the given node ID, or nil if the node did not run.
+func (r *WorkflowSimulationResponse) Node(nodeID string) *WorkflowNodeSimulationResult {
+	if r == nil {
+		return nil
+	}
+	for i := range r.Nodes {
+		if r.Nodes[i].NodeID == nodeID {
+			return &r.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// WorkflowNodeSimulationResult represents the output of a single node during a simulation.
+type WorkflowNodeSimulationResult struct {
+	NodeID         string                  `json:"node_id"`
+	NodeType       string                  `json:"node_type"`
+	Status         string                  `json:"status"`                    // "completed", "failed" or "skipped"
+	Error          string                  `json:"error,omitempty"`           // Error message when the node failed
+	DurationMs     int64                   `json:"duration_ms"`               // Node execution time in milliseconds
+	Text           string                  `json:"text,omitempty"`            // Parsed text (parse nodes)
+	Chunks         []string                `json:"chunks,omitempty"`          // Produced chunks (chunk nodes)
+	EmbeddingStats *WorkflowEmbeddingStats `json:"embedding_stats,omitempty"` // Embedding statistics (embed nodes)
+	Output         map[string]interface{}  `json:"output,omitempty"`          // Any other node-specific output
+}
+
+// WorkflowEmbeddingStats summarizes the embeddings produced by an embed node.
+type WorkflowEmbeddingStats struct {
+	Count     int    `json:"count"`     // Number of vectors produced
+	Dimension int    `json:"dimension"` // Vector dimension
+	Model     string `json:"model"`     // Embedding model used
+}
+
 // WorkflowJobListRequest represents a request to list workflow jobs.
 type WorkflowJobListRequest struct {
 	WorkflowID   string `json:"workflow_id,omitempty"`    // Filter by workflow ID
@@ -1672,6 +2871,72 @@ type workflowJobRaw struct {
 	Description map[string]interface{} `json:"description,omitempty"` // May contain triggerTaskID
 }
 
+// WorkflowJobDetail represents a workflow job with node-level execution detail.
+type WorkflowJobDetail struct {
+	WorkflowJob
+	ErrorMessage string                  `json:"error_message,omitempty"` // Why the job failed, if it did
+	Nodes        []WorkflowJobNodeResult `json:"nodes"`                   // Node executions in execution order
+}
+
+// FailedNodes returns the nodes whose execution failed.
+func (d *WorkflowJobDetail) FailedNodes() []WorkflowJobNodeResult {
+	if d == nil {
+		return nil
+	}
+	var failed []WorkflowJobNodeResult
+	for _, n := range d.Nodes {
+		if n.Status == "failed" {
+			failed = append(failed, n)
+		}
+	}
+	return failed
+}
+
+// WorkflowJobNodeResult describes the execution of a single node in a workflow job.
+type WorkflowJobNodeResult struct {
+	NodeID      string `json:"node_id"`
+	NodeType    string `json:"node_type"`
+	Status      string `json:"status"` // "pending", "running", "completed", "failed" or "skipped"
+	StartTime   string `json:"start_time"`
+	EndTime     string `json:"end_time"`
+	DurationMs  int64  `json:"duration_ms"`
+	InputCount  int    `json:"input_count"`  // Number of documents received
+	OutputCount int    `json:"output_count"` // Number of documents produced
+	Error       string `json:"error,omitempty"`
+}
+
+// workflowJobDetailRaw represents the raw API response structure for a job detail.
+type workflowJobDetailRaw struct {
+	workflowJobRaw
+	ErrorMessage string                  `json:"error_message"`
+	Nodes        []WorkflowJobNodeResult `json:"nodes"`
+}
+
+// WorkflowJobLogEntry represents a single log line of a workflow job.
+type WorkflowJobLogEntry struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"` // "debug", "info", "warn" or "error"
+	NodeID  string `json:"node_id,omitempty"`
+	Message string `json:"message"`
+}
+
+// WorkflowJobLogsResponse represents the response from retrieving workflow job logs.
+type WorkflowJobLogsResponse struct {
+	Logs []WorkflowJobLogEntry `json:"logs"`
+}
+
+// WorkflowJobRetryResponse represents the response from retrying a workflow job.
+type WorkflowJobRetryResponse struct {
+	JobID       string `json:"job_id"`       // ID of the new job
+	RetriedFrom string `json:"retried_from"` // ID of the job that was retried
+}
+
+// WorkflowJobCancelResponse represents the response from cancelling a workflow job.
+type WorkflowJobCancelResponse struct {
+	JobID  string            `json:"job_id"`
+	Status WorkflowJobStatus `json:"status"`
+}
+
 // WorkflowJobListResponse represents the response from listing workflow jobs.
 // This matches the API response structure: {"code":"ok","msg":"ok","data":{"total":1,"jobs":[...]}}
 type WorkflowJobListResponse struct {
@@ -1707,19 +2972,55 @@ type NL2SQLResult struct {
 // NL2SQLRow represents one row in an NL2SQL result set.
 type NL2SQLRow []string
 
+// NL2SQLExplainRequest asks for the plan of a statement without running it. Set either
+// Statement, or Question to explain the SQL generated for a natural language question.
+type NL2SQLExplainRequest struct {
+	Statement  string            `json:"statement,omitempty"`
+	Question   string            `json:"question,omitempty"`
+	DbNames    []string          `json:"db_names,omitempty"`
+	TableNames []DbAndTablesInfo `json:"table_names,omitempty"`
+}
+
+// NL2SQLPlanStep is an operator of a query plan.
+type NL2SQLPlanStep struct {
+	Operator      string  `json:"operator"`         // e.g. "Table Scan", "Join", "Aggregate"
+	Object        string  `json:"object,omitempty"` // Table or index the operator reads, if any
+	EstimatedRows int64   `json:"estimated_rows"`
+	EstimatedCost float64 `json:"estimated_cost"`
+	Depth         int     `json:"depth"` // Nesting level in the plan tree, 0 for the root
+}
+
+// NL2SQLExplainResponse is the plan of a statement returned by ExplainNL2SQL.
+type NL2SQLExplainResponse struct {
+	SQL           string           `json:"sql"`            // Statement explained, generated when the request set Question
+	Plan          string           `json:"plan"`           // Plan as printed by EXPLAIN
+	Steps         []NL2SQLPlanStep `json:"steps"`          // Plan operators in pre-order
+	EstimatedRows int64            `json:"estimated_rows"` // Rows the statement is expected to return
+	EstimatedCost float64          `json:"estimated_cost"` // Total estimated cost, in the planner's units
+	Warnings      []string         `json:"warnings,omitempty"`
+}
+
+// NL2SQLColumn describes a column of a result streamed by RunNL2SQLStream.
+type NL2SQLColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`     // Database type name, e.g. "BIGINT", "VARCHAR", "DECIMAL(10,2)"
+	Nullable bool   `json:"nullable"` // Whether the column may contain NULL
+}
+
 // ============ Models: NL2SQL Knowledge types ============
 
 type Nl2SqlKnowledgeID int64
 
 type Nl2SqlKnowledgeResponse struct {
-	ID        Nl2SqlKnowledgeID      `json:"id"`
-	Type      string                 `json:"type"`
-	Key       string                 `json:"key"`
-	Value     []string               `json:"value"`
-	Embedding []float64              `json:"embedding,omitempty"`
-	Meta      map[string]interface{} `json:"meta,omitempty"`
-	CreatedAt string                 `json:"created_at"`
-	UpdatedAt string                 `json:"updated_at"`
+	ID              Nl2SqlKnowledgeID      `json:"id"`
+	Type            string                 `json:"type"`
+	Key             string                 `json:"key"`
+	Value           []string               `json:"value"`
+	Embedding       []float64              `json:"embedding,omitempty"`
+	AssociateTables []string               `json:"associate_tables,omitempty"`
+	Meta            map[string]interface{} `json:"meta,omitempty"`
+	CreatedAt       string                 `json:"created_at"`
+	UpdatedAt       string                 `json:"updated_at"`
 }
 
 type Nl2SqlOperationType string
@@ -1801,6 +3102,39 @@ type NL2SQLKnowledgeSearchResponse struct {
 	Total int64                      `json:"total"`
 }
 
+// NL2SQLKnowledgeValidateRequest selects the knowledge entry checked by
+// ValidateKnowledge: either a stored entry by ID, or an entry given inline, e.g. one
+// not created yet.
+type NL2SQLKnowledgeValidateRequest struct {
+	ID    Nl2SqlKnowledgeID             `json:"id,omitempty"`
+	Entry *NL2SQLKnowledgeCreateRequest `json:"entry,omitempty"`
+}
+
+// KnowledgeIssueKind classifies a problem found by ValidateKnowledge.
+type KnowledgeIssueKind string
+
+const (
+	KnowledgeIssueMissingTable  KnowledgeIssueKind = "missing_table"  // A referenced table does not exist
+	KnowledgeIssueMissingColumn KnowledgeIssueKind = "missing_column" // A referenced column does not exist
+	KnowledgeIssueSyntax        KnowledgeIssueKind = "syntax_error"   // The SQL cannot be parsed
+	KnowledgeIssueExecution     KnowledgeIssueKind = "execution_error"
+)
+
+// KnowledgeIssue is a problem found in one of the SQL statements of a knowledge entry.
+type KnowledgeIssue struct {
+	Kind       KnowledgeIssueKind `json:"kind"`
+	ValueIndex int                `json:"value_index"`      // Index of the statement in the entry's Value
+	Object     string             `json:"object,omitempty"` // Missing table (db.table) or column (db.table.column)
+	Message    string             `json:"message"`
+}
+
+// NL2SQLKnowledgeValidateResponse reports the outcome of ValidateKnowledge.
+type NL2SQLKnowledgeValidateResponse struct {
+	ID     Nl2SqlKnowledgeID `json:"id,omitempty"`
+	Valid  bool              `json:"valid"`
+	Issues []KnowledgeIssue  `json:"issues,omitempty"`
+}
+
 // ============ Handler: Log types ============
 
 type LogLogResponse struct {
@@ -1822,6 +3156,80 @@ type LogLogListResponse struct {
 	List  []LogLogResponse `json:"role_list"`
 }
 
+// TimeRange limits a query to [Start, End). A zero bound leaves that side open.
+type TimeRange struct {
+	Start int64 `json:"start_time,omitempty"` // Unix timestamp in seconds, inclusive
+	End   int64 `json:"end_time,omitempty"`   // Unix timestamp in seconds, exclusive
+}
+
+// ObjectLogRequest lists operations on catalog objects. Leave ObjectType empty to
+// cover every kind of object, and ObjectID empty to cover every object of the type.
+type ObjectLogRequest struct {
+	ObjectType CatalogObjectType `json:"object_type,omitempty"`
+	ObjectID   string            `json:"object_id,omitempty"`
+	Action     string            `json:"action,omitempty"`  // e.g. "create", "update", "delete", "move"
+	UserID     UserID            `json:"user_id,omitempty"` // Only operations by this user
+	TimeRange  TimeRange         `json:"time_range"`
+	Page       int               `json:"page,omitempty"`      // Page number (starts from 1, default 1)
+	PageSize   int               `json:"page_size,omitempty"` // Page size (default 20, max 100)
+}
+
+// ObjectLogEntry is a single operation on a catalog object.
+type ObjectLogEntry struct {
+	ObjectType CatalogObjectType `json:"object_type"`
+	ObjectID   string            `json:"object_id"`
+	ObjectName string            `json:"object_name"` // Name at the time of the operation
+	Action     string            `json:"action"`
+	UserID     UserID            `json:"user_id"`
+	UserName   string            `json:"user_name"`
+	Status     string            `json:"status"` // "success" or "failure"
+	Detail     string            `json:"detail"`
+	RequestID  string            `json:"request_id"`
+	CreatedAt  int64             `json:"created_at"` // Unix timestamp in seconds
+}
+
+type ObjectLogResponse struct {
+	Total int              `json:"total"`
+	List  []ObjectLogEntry `json:"log_list"`
+}
+
+// ============ Handler: Search types ============
+
+// SearchScope restricts a search to the objects below a catalog, database or
+// volume. Leave it zero to search everything the caller can see.
+type SearchScope struct {
+	CatalogID  CatalogID  `json:"catalog_id,omitempty"`
+	DatabaseID DatabaseID `json:"database_id,omitempty"`
+	VolumeID   VolumeID   `json:"volume_id,omitempty"`
+}
+
+// SearchRequest searches catalog objects by name and description.
+type SearchRequest struct {
+	Query    string              `json:"query"`
+	Types    []CatalogObjectType `json:"type_list,omitempty"` // Empty searches every type
+	Scope    SearchScope         `json:"scope"`
+	Page     int                 `json:"page,omitempty"`      // Page number (starts from 1, default 1)
+	PageSize int                 `json:"page_size,omitempty"` // Page size (default 20, max 100)
+}
+
+// SearchHit is an object matching a search, with its location in the hierarchy.
+type SearchHit struct {
+	Type        CatalogObjectType `json:"type"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Path        FullPath          `json:"full_path"` // From the catalog down to the object itself
+	Score       float64           `json:"score"`     // Relevance; higher is better
+	Highlight   string            `json:"highlight"` // Matched text with matches wrapped in <em></em>
+	UpdatedAt   string            `json:"updated_at"`
+}
+
+// SearchResponse lists the hits of a search by decreasing relevance.
+type SearchResponse struct {
+	Total int         `json:"total"`
+	List  []SearchHit `json:"list"`
+}
+
 // ============ Models: LLM Proxy types ============
 
 // LLMTag represents a tag used in LLM Proxy (sessions and messages).
@@ -2046,12 +3454,27 @@ type CodeGroup struct {
 }
 
 // DataScope represents data scope configuration.
+// Use NewDataScope or DataScopeFromCodes to build a validated scope.
 type DataScope struct {
-	Type      string      `json:"type"`                // "all", "specified"
-	CodeType  *int        `json:"code_type,omitempty"` // 0-company, 1-business unit
+	Type      string      `json:"type"`                // DataScopeAll or DataScopeSpecified
+	CodeType  *int        `json:"code_type,omitempty"` // A DataScopeCodeType: 0-company, 1-business unit
 	CodeGroup []CodeGroup `json:"code_group,omitempty"`
 }
 
+// Data scope types for DataScope.Type.
+const (
+	DataScopeAll       = "all"       // All data the caller may access
+	DataScopeSpecified = "specified" // Only the organizations listed in CodeGroup
+)
+
+// DataScopeCodeType is the kind of organizational code listed by a DataScope.
+type DataScopeCodeType int
+
+const (
+	CodeTypeCompany      DataScopeCodeType = 0 // Company codes
+	CodeTypeBusinessUnit DataScopeCodeType = 1 // Business unit codes
+)
+
 // DataSource represents data source configuration.
 type DataSource struct {
 	Type   string                 `json:"type"` // "all", "specified"
@@ -2103,6 +3526,8 @@ type DataAnalysisStreamEvent struct {
 	StepName string `json:"step_name,omitempty"`
 	// Raw JSON data for flexible parsing
 	RawData json.RawMessage `json:"-"`
+	// ID is the SSE event ID, if the server sent one
+	ID string `json:"-"`
 }
 
 // InitEventData represents the data field in an init event.
@@ -2158,6 +3583,248 @@ func (e *DataAnalysisStreamEvent) GetInitEventData() *InitEventData {
 	return nil
 }
 
+// Kinds of data analysis stream events, as returned by DataAnalysisStreamEvent.Kind.
+const (
+	DataAnalysisEventInit           = "init"
+	DataAnalysisEventClassification = "classification"
+	DataAnalysisEventDecomposition  = "decomposition"
+	DataAnalysisEventStepStart      = "step_start"
+	DataAnalysisEventStepComplete   = "step_complete"
+	DataAnalysisEventAnswerChunk    = "answer_chunk"
+	DataAnalysisEventSQLStep        = "sql_step"
+	DataAnalysisEventComplete       = "complete"
+	DataAnalysisEventError          = "error"
+)
+
+// AnalysisEvent is a typed data analysis stream event. It is implemented by *InitEvent,
+// *ClassificationEvent, *DecompositionEvent, *StepStartEvent, *StepCompleteEvent,
+// *AnswerChunkEvent, *SQLStepEvent, *CompleteEvent and *ErrorEvent.
+type AnalysisEvent interface {
+	analysisEventKind() string
+}
+
+// InitEvent is the first event of an analysis.
+type InitEvent struct {
+	RequestID    string `json:"request_id"` // ID accepted by CancelAnalyze
+	SessionTitle string `json:"session_title"`
+}
+
+// ClassificationEvent reports how the question was classified.
+type ClassificationEvent struct {
+	QuestionType
+	Category string `json:"category,omitempty"`
+}
+
+// DecompositionEvent lists the sub-questions an attribution question was split into.
+type DecompositionEvent struct {
+	SubQuestions []string `json:"sub_questions"`
+	Reason       string   `json:"reason,omitempty"`
+}
+
+// StepStartEvent marks the start of an attribution step.
+type StepStartEvent struct {
+	StepID   string `json:"step_id"`
+	StepName string `json:"step_name"`
+	Question string `json:"question,omitempty"`
+}
+
+// StepCompleteEvent marks the end of an attribution step.
+type StepCompleteEvent struct {
+	StepID   string          `json:"step_id"`
+	StepName string          `json:"step_name"`
+	Summary  string          `json:"summary,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"` // Step output; its shape depends on the step
+}
+
+// AnswerChunkEvent carries a piece of an answer produced by RAG.
+type AnswerChunkEvent struct {
+	Content string          `json:"content"`
+	Chunks  json.RawMessage `json:"chunks,omitempty"` // Retrieved chunks the answer is based on
+}
+
+// SQLStepEvent reports the progress of NL2SQL.
+type SQLStepEvent struct {
+	StepType string          `json:"step_type"` // e.g. "sql_generated"
+	StepName string          `json:"step_name"`
+	SQL      string          `json:"sql,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+}
+
+// CompleteEvent is the last event of a successful analysis.
+type CompleteEvent struct {
+	Answer  string `json:"answer,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// ErrorEvent reports that the analysis failed. It implements error.
+type ErrorEvent struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+func (*InitEvent) analysisEventKind() string           { return DataAnalysisEventInit }
+func (*ClassificationEvent) analysisEventKind() string { return DataAnalysisEventClassification }
+func (*DecompositionEvent) analysisEventKind() string  { return DataAnalysisEventDecomposition }
+func (*StepStartEvent) analysisEventKind() string      { return DataAnalysisEventStepStart }
+func (*StepCompleteEvent) analysisEventKind() string   { return DataAnalysisEventStepComplete }
+func (*AnswerChunkEvent) analysisEventKind() string    { return DataAnalysisEventAnswerChunk }
+func (*SQLStepEvent) analysisEventKind() string        { return DataAnalysisEventSQLStep }
+func (*CompleteEvent) analysisEventKind() string       { return DataAnalysisEventComplete }
+func (*ErrorEvent) analysisEventKind() string          { return DataAnalysisEventError }
+
+// Error implements error.
+func (e *ErrorEvent) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("data analysis failed: %s: %s", e.Code, e.Message)
+	}
+	return "data analysis failed: " + e.Message
+}
+
+// Kind classifies the event as one of the DataAnalysisEvent* kinds.
+//
+// The kind is derived from the SSE event name and the type, source and step_type
+// fields, which differ between the RAG and NL2SQL parts of the service. Events of
+// unknown kinds return their type unchanged.
+func (e *DataAnalysisStreamEvent) Kind() string {
+	if e == nil {
+		return ""
+	}
+	switch {
+	case e.StepType == "init" || e.Type == "init":
+		return DataAnalysisEventInit
+	case e.Type == "chunks" || e.Type == DataAnalysisEventAnswerChunk:
+		return DataAnalysisEventAnswerChunk
+	case e.Type == DataAnalysisEventClassification, e.Type == DataAnalysisEventDecomposition,
+		e.Type == DataAnalysisEventStepStart, e.Type == DataAnalysisEventStepComplete,
+		e.Type == DataAnalysisEventSQLStep, e.Type == DataAnalysisEventComplete,
+		e.Type == DataAnalysisEventError:
+		return e.Type
+	case e.Source == "rag":
+		return DataAnalysisEventAnswerChunk
+	case e.Source == "nl2sql" || e.StepType != "":
+		return DataAnalysisEventSQLStep
+	}
+	return e.Type
+}
+
+// Typed decodes the event into its typed form, ready for a type switch. It returns
+// nil and no error for events of unknown kinds.
+//
+// Example:
+//
+//	typed, err := event.Typed()
+//	if err != nil {
+//		return err
+//	}
+//	switch ev := typed.(type) {
+//	case *sdk.InitEvent:
+//		fmt.Printf("request %s\n", ev.RequestID)
+//	case *sdk.AnswerChunkEvent:
+//		fmt.Print(ev.Content)
+//	case *sdk.ErrorEvent:
+//		return ev
+//	}
+func (e *DataAnalysisStreamEvent) Typed() (AnalysisEvent, error) {
+	var typed AnalysisEvent
+	switch e.Kind() {
+	case DataAnalysisEventInit:
+		typed = &InitEvent{}
+	case DataAnalysisEventClassification:
+		typed = &ClassificationEvent{}
+	case DataAnalysisEventDecomposition:
+		typed = &DecompositionEvent{}
+	case DataAnalysisEventStepStart:
+		typed = &StepStartEvent{}
+	case DataAnalysisEventStepComplete:
+		typed = &StepCompleteEvent{}
+	case DataAnalysisEventAnswerChunk:
+		typed = &AnswerChunkEvent{}
+	case DataAnalysisEventSQLStep:
+		typed = &SQLStepEvent{}
+	case DataAnalysisEventComplete:
+		typed = &CompleteEvent{}
+	case DataAnalysisEventError:
+		typed = &ErrorEvent{}
+	default:
+		return nil, nil
+	}
+	if err := e.decode(typed); err != nil {
+		return nil, err
+	}
+	return typed, nil
+}
+
+// As decodes the event into target when the event is of the kind of target, and
+// reports whether it did.
+//
+// Example:
+//
+//	var chunk sdk.AnswerChunkEvent
+//	if event.As(&chunk) {
+//		fmt.Print(chunk.Content)
+//	}
+func (e *DataAnalysisStreamEvent) As(target AnalysisEvent) bool {
+	if e == nil || target == nil || target.analysisEventKind() != e.Kind() {
+		return false
+	}
+	return e.decode(target) == nil
+}
+
+// decode fills target from the event's data object, or from the whole event when it
+// carries its fields at the top level.
+func (e *DataAnalysisStreamEvent) decode(target AnalysisEvent) error {
+	payload := []byte(e.RawData)
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(e.RawData, &envelope) == nil && bytes.HasPrefix(bytes.TrimSpace(envelope.Data), []byte("{")) {
+		payload = envelope.Data
+	}
+	if len(bytes.TrimSpace(payload)) > 0 {
+		if err := json.Unmarshal(payload, target); err != nil {
+			return fmt.Errorf("decode %s event: %w", e.Kind(), err)
+		}
+	}
+	if step, ok := target.(*SQLStepEvent); ok {
+		if step.StepType == "" {
+			step.StepType = e.StepType
+		}
+		if step.StepName == "" {
+			step.StepName = e.StepName
+		}
+	}
+	return nil
+}
+
+// DataAnalysisResult is the outcome of a data analysis, consolidated by AnalyzeData
+// from the events of the stream.
+type DataAnalysisResult struct {
+	RequestID      string               `json:"request_id"`
+	SessionTitle   string               `json:"session_title,omitempty"`
+	Classification *ClassificationEvent `json:"classification,omitempty"`
+	Answer         string               `json:"answer"`                  // Final answer, or the concatenated answer chunks
+	Summary        string               `json:"summary,omitempty"`       // Summary sent with the complete event
+	SubQuestions   []string             `json:"sub_questions,omitempty"` // Sub-questions of an attribution question
+	Steps          []StepCompleteEvent  `json:"steps,omitempty"`         // Completed attribution steps, in order
+	SQLSteps       []SQLStepEvent       `json:"sql_steps,omitempty"`     // NL2SQL steps, in order
+	Chunks         []json.RawMessage    `json:"chunks,omitempty"`        // Chunks the answer is based on, as sent with each answer chunk
+}
+
+// AnalysisEventRecord is an event of a data analysis returned by GetAnalysisEvents.
+type AnalysisEventRecord struct {
+	Seq   int64           `json:"seq"`   // Sequence number, increasing within the analysis
+	Event string          `json:"event"` // SSE event name, e.g. "classification"
+	Data  json.RawMessage `json:"data"`  // Event payload, as sent in the SSE data field
+}
+
+// AnalysisEventsResponse is the response of GetAnalysisEvents.
+type AnalysisEventsResponse struct {
+	RequestID string                `json:"request_id"`
+	Events    []AnalysisEventRecord `json:"events"`
+	NextSeq   int64                 `json:"next_seq"` // Pass as afterSeq to continue
+	Done      bool                  `json:"done"`     // True once the analysis has ended
+}
+
 // CancelAnalyzeRequest represents a request to cancel a data analysis request.
 type CancelAnalyzeRequest struct {
 	RequestID string `json:"request_id"` // Required: The request ID of the analysis to cancel
@@ -2171,6 +3838,115 @@ type CancelAnalyzeResponse struct {
 	UserName  string `json:"user_name"`  // User name who cancelled the request
 }
 
+// AnalyzeRequestStatus is the status of a data analysis request.
+type AnalyzeRequestStatus string
+
+const (
+	AnalyzeRequestStatusRunning   AnalyzeRequestStatus = "running"
+	AnalyzeRequestStatusCompleted AnalyzeRequestStatus = "completed"
+	AnalyzeRequestStatusCancelled AnalyzeRequestStatus = "cancelled"
+	AnalyzeRequestStatusFailed    AnalyzeRequestStatus = "failed"
+)
+
+// AnalyzeRequestInfo describes a past data analysis request and its outcome.
+type AnalyzeRequestInfo struct {
+	RequestID      string               `json:"request_id"`
+	SessionID      string               `json:"session_id"`
+	Question       string               `json:"question"`
+	Status         AnalyzeRequestStatus `json:"status"`
+	UserID         string               `json:"user_id"`
+	UserName       string               `json:"user_name"`
+	Classification *QuestionType        `json:"classification,omitempty"`
+	Answer         string               `json:"answer"`                  // Final answer; empty unless completed
+	SQL            []string             `json:"sql,omitempty"`           // SQL statements generated by NL2SQL, in order
+	ErrorMessage   string               `json:"error_message,omitempty"` // Set when the request failed
+	CreatedAt      int64                `json:"created_at"`              // Unix timestamp in seconds
+	FinishedAt     int64                `json:"finished_at,omitempty"`   // Unix timestamp in seconds; 0 while running
+}
+
+// AnalyzeRequestListRequest represents a request to list past data analysis requests.
+type AnalyzeRequestListRequest struct {
+	SessionID string               `json:"session_id,omitempty"` // Only requests of this session
+	UserID    string               `json:"user_id,omitempty"`    // Only requests of this user
+	Status    AnalyzeRequestStatus `json:"status,omitempty"`
+	Keyword   string               `json:"keyword,omitempty"`    // Keyword search in questions
+	StartTime int64                `json:"start_time,omitempty"` // Created at or after (Unix timestamp in seconds)
+	EndTime   int64                `json:"end_time,omitempty"`   // Created before (Unix timestamp in seconds)
+	Page      int                  `json:"page,omitempty"`       // Page number (starts from 1, default 1)
+	PageSize  int                  `json:"page_size,omitempty"`  // Page size (default 20, max 100)
+}
+
+// AnalyzeRequestListResponse represents a response from listing data analysis requests.
+type AnalyzeRequestListResponse struct {
+	Requests []AnalyzeRequestInfo `json:"requests"`
+	Total    int64                `json:"total"`
+}
+
+// AnalysisRating is a user's rating of a data analysis answer.
+type AnalysisRating string
+
+const (
+	AnalysisRatingUp   AnalysisRating = "up"   // The answer was helpful
+	AnalysisRatingDown AnalysisRating = "down" // The answer was wrong or unhelpful
+)
+
+// AnalysisFeedbackRequest sends a user's feedback on a data analysis answer.
+type AnalysisFeedbackRequest struct {
+	RequestID  string         `json:"request_id"`            // Required: ID of the analysis request
+	Rating     AnalysisRating `json:"rating"`                // Required
+	Comment    string         `json:"comment,omitempty"`     // Free-form explanation
+	CorrectSQL string         `json:"correct_sql,omitempty"` // SQL that answers the question correctly
+	// SaveAsKnowledge asks the service to store Question and CorrectSQL as an NL2SQL
+	// knowledge entry, so that similar questions are answered with the corrected SQL.
+	SaveAsKnowledge bool `json:"save_as_knowledge,omitempty"`
+}
+
+// AnalysisFeedbackResponse represents the response from submitting analysis feedback.
+type AnalysisFeedbackResponse struct {
+	FeedbackID  string            `json:"feedback_id"`
+	RequestID   string            `json:"request_id"`
+	KnowledgeID Nl2SqlKnowledgeID `json:"knowledge_id,omitempty"` // Set when a knowledge entry was created
+}
+
+// DataAskingSessionInfo describes a data asking conversation.
+type DataAskingSessionInfo struct {
+	SessionID   string `json:"session_id"`
+	SessionName string `json:"session_name"`
+	Title       string `json:"title"`      // Title generated from the first question
+	TurnCount   int    `json:"turn_count"` // Questions asked in the session
+	CreatedAt   int64  `json:"created_at"` // Creation time (Unix timestamp in seconds)
+	UpdatedAt   int64  `json:"updated_at"` // Time of the last question (Unix timestamp in seconds)
+}
+
+// DataAskingSessionListRequest represents a request to list data asking sessions.
+type DataAskingSessionListRequest struct {
+	Keyword  string `json:"keyword,omitempty"`   // Keyword search (name and title)
+	Page     int    `json:"page,omitempty"`      // Page number (starts from 1, default 1)
+	PageSize int    `json:"page_size,omitempty"` // Page size (default 20, max 100)
+}
+
+// DataAskingSessionListResponse represents a response from listing data asking sessions.
+type DataAskingSessionListResponse struct {
+	Sessions []DataAskingSessionInfo `json:"sessions"`
+	Total    int64                   `json:"total"`
+}
+
+// DataAskingTurn is a question asked in a data asking session and its outcome.
+type DataAskingTurn struct {
+	Question       string        `json:"question"`
+	RequestID      string        `json:"request_id"`
+	Classification *QuestionType `json:"classification,omitempty"`
+	Answer         string        `json:"answer"`        // Final answer, or the concatenated answer chunks
+	SQL            []string      `json:"sql,omitempty"` // SQL statements generated by NL2SQL, in order
+	CreatedAt      int64         `json:"created_at,omitempty"`
+}
+
+// DataAskingSessionHistoryResponse represents the turns of a data asking session.
+type DataAskingSessionHistoryResponse struct {
+	DataAskingSessionInfo
+	Turns []DataAskingTurn `json:"turns"`
+}
+
 // ============ Handler: Task types ============
 
 type TaskID int64
@@ -1,7 +1,16 @@
 package sdk
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
 )
 
 // RunNL2SQL executes a natural language to SQL query.
@@ -30,3 +39,271 @@ func (c *RawClient) RunNL2SQL(ctx context.Context, req *NL2SQLRunSQLRequest, opt
 	}
 	return &resp, nil
 }
+
+// ExplainNL2SQL returns the query plan and estimated cost of a statement without
+// running it.
+//
+// Set Statement to explain SQL you provide, or Question to explain the SQL that
+// would be generated for a natural language question; the explained SQL is returned
+// in the response. Automated pipelines can use the estimates to reject expensive
+// queries before executing them.
+//
+// Example:
+//
+//	plan, err := client.ExplainNL2SQL(ctx, &sdk.NL2SQLExplainRequest{
+//		Statement: "select * from sales.orders where amount > 100",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if plan.EstimatedCost > maxCost {
+//		return fmt.Errorf("query too expensive (cost %.0f):\n%s", plan.EstimatedCost, plan.Plan)
+//	}
+func (c *RawClient) ExplainNL2SQL(ctx context.Context, req *NL2SQLExplainRequest, opts ...CallOption) (*NL2SQLExplainResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	hasStatement := strings.TrimSpace(req.Statement) != ""
+	hasQuestion := strings.TrimSpace(req.Question) != ""
+	if hasStatement == hasQuestion {
+		return nil, fmt.Errorf("exactly one of statement or question is required")
+	}
+	var resp NL2SQLExplainResponse
+	if err := c.postJSON(ctx, "/catalog/nl2sql/explain", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// NL2SQLRows is a cursor over the rows of a statement run by RunNL2SQLStream.
+//
+// Rows are decoded one at a time as they arrive, so result sets of any size can be
+// read with constant memory. Call Next to advance to each row and Scan to read it;
+// after Next returns false, Err reports whether the result was read completely.
+// NL2SQLRows is not safe for concurrent use.
+type NL2SQLRows struct {
+	body    io.ReadCloser
+	dec     *json.Decoder
+	columns []NL2SQLColumn
+	row     []*string
+	count   int64
+	done    bool
+	err     error
+}
+
+// nl2sqlStreamLine is a line of the newline-delimited JSON response of
+// /catalog/nl2sql/run_sql_stream. The first line carries the columns, then one line
+// per row, and a last line with done set or an error.
+type nl2sqlStreamLine struct {
+	Columns  []NL2SQLColumn `json:"columns,omitempty"`
+	Row      []*string      `json:"row,omitempty"`
+	Done     bool           `json:"done,omitempty"`
+	RowCount int64          `json:"row_count,omitempty"`
+	Code     string         `json:"code,omitempty"`
+	Msg      string         `json:"msg,omitempty"`
+}
+
+// RunNL2SQLStream executes a statement like RunNL2SQL, but streams the rows of the
+// result instead of materializing them in memory.
+//
+// The returned cursor must be closed. Values are scanned like database/sql does:
+// destinations may be *string, *[]byte, *int, *int64, *float64, *bool, *any or an
+// sql.Scanner such as *sql.NullString, which is required for nullable columns.
+//
+// Example:
+//
+//	rows, err := client.RunNL2SQLStream(ctx, &sdk.NL2SQLRunSQLRequest{
+//		Operation: sdk.RunSQL,
+//		Statement: "select id, amount from orders",
+//		DbNames:   []string{"sales"},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer rows.Close()
+//
+//	for rows.Next() {
+//		var id int64
+//		var amount float64
+//		if err := rows.Scan(&id, &amount); err != nil {
+//			return err
+//		}
+//		total += amount
+//	}
+//	if err := rows.Err(); err != nil {
+//		return err
+//	}
+func (c *RawClient) RunNL2SQLStream(ctx context.Context, req *NL2SQLRunSQLRequest, opts ...CallOption) (*NL2SQLRows, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	callOpts := newCallOptions(opts...)
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request body: %w", err)
+	}
+	httpReq, err := c.buildRequest(ctx, http.MethodPost, "/catalog/nl2sql/run_sql_stream", bytes.NewReader(payload), callOpts)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set(headerContentType, mimeJSON)
+	httpReq.Header.Set(headerAccept, mimeNDJSON)
+
+	// Create a client with no timeout for reading large results
+	// The stream can still be cancelled via context
+	streamClient := &http.Client{
+		Timeout:   0,
+		Transport: c.httpClient.Transport,
+	}
+	resp, err := c.send(streamClient, httpReq, callOpts)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, newHTTPError(resp, data)
+	}
+
+	rows := &NL2SQLRows{body: resp.Body, dec: json.NewDecoder(bufio.NewReader(resp.Body))}
+	var first nl2sqlStreamLine
+	if err := rows.dec.Decode(&first); err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("decode result header: %w", err)
+	}
+	if first.Code != "" {
+		resp.Body.Close()
+		return nil, &APIError{Code: first.Code, Message: first.Msg, RequestID: responseRequestID(resp), HTTPStatus: resp.StatusCode}
+	}
+	rows.columns = first.Columns
+	return rows, nil
+}
+
+// Columns returns the columns of the result.
+func (r *NL2SQLRows) Columns() []NL2SQLColumn {
+	return r.columns
+}
+
+// Next advances to the next row and reports whether there is one. It returns false
+// at the end of the result or on error; check Err to tell them apart.
+func (r *NL2SQLRows) Next() bool {
+	if r.done || r.err != nil {
+		return false
+	}
+	var line nl2sqlStreamLine
+	if err := r.dec.Decode(&line); err != nil {
+		if err == io.EOF {
+			err = fmt.Errorf("result stream ended after %d rows: %w", r.count, io.ErrUnexpectedEOF)
+		}
+		r.fail(err)
+		return false
+	}
+	switch {
+	case line.Code != "":
+		r.fail(&APIError{Code: line.Code, Message: line.Msg})
+		return false
+	case line.Done:
+		r.done = true
+		r.row = nil
+		r.Close()
+		if line.RowCount > 0 && line.RowCount != r.count {
+			r.err = fmt.Errorf("result stream ended after %d of %d rows", r.count, line.RowCount)
+		}
+		return false
+	}
+	r.row = line.Row
+	r.count++
+	return true
+}
+
+func (r *NL2SQLRows) fail(err error) {
+	r.err = err
+	r.row = nil
+	r.Close()
+}
+
+// Row returns the values of the current row; NULL values are nil.
+func (r *NL2SQLRows) Row() []*string {
+	return r.row
+}
+
+// RowsRead returns the number of rows read so far.
+func (r *NL2SQLRows) RowsRead() int64 {
+	return r.count
+}
+
+// Scan copies the values of the current row into dest, one destination per column.
+func (r *NL2SQLRows) Scan(dest ...interface{}) error {
+	if r.row == nil {
+		return fmt.Errorf("scan called without a current row")
+	}
+	if len(dest) != len(r.row) {
+		return fmt.Errorf("expected %d destination arguments in Scan, got %d", len(r.row), len(dest))
+	}
+	for i, value := range r.row {
+		if err := scanNL2SQLValue(dest[i], value); err != nil {
+			name := strconv.Itoa(i)
+			if i < len(r.columns) {
+				name = r.columns[i].Name
+			}
+			return fmt.Errorf("scan column %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Err returns the error that ended the iteration, if any.
+func (r *NL2SQLRows) Err() error {
+	return r.err
+}
+
+// Close releases the underlying response body. It is safe to call Close multiple times.
+func (r *NL2SQLRows) Close() error {
+	if r == nil || r.body == nil {
+		return nil
+	}
+	body := r.body
+	r.body = nil
+	return body.Close()
+}
+
+// scanNL2SQLValue converts a value of a result row into dest.
+func scanNL2SQLValue(dest interface{}, value *string) error {
+	if scanner, ok := dest.(sql.Scanner); ok {
+		if value == nil {
+			return scanner.Scan(nil)
+		}
+		return scanner.Scan(*value)
+	}
+	if d, ok := dest.(*interface{}); ok {
+		if value == nil {
+			*d = nil
+		} else {
+			*d = *value
+		}
+		return nil
+	}
+	if value == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dest)
+	}
+	v := *value
+	var err error
+	switch d := dest.(type) {
+	case *string:
+		*d = v
+	case *[]byte:
+		*d = []byte(v)
+	case *int:
+		*d, err = strconv.Atoi(v)
+	case *int64:
+		*d, err = strconv.ParseInt(v, 10, 64)
+	case *float64:
+		*d, err = strconv.ParseFloat(v, 64)
+	case *bool:
+		*d, err = strconv.ParseBool(v)
+	default:
+		return fmt.Errorf("unsupported destination type %T", dest)
+	}
+	return err
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"fmt"
 )
 
 // CreateKnowledge creates a new NL2SQL knowledge entry.
@@ -156,3 +157,37 @@ func (c *RawClient) SearchKnowledge(ctx context.Context, req *NL2SQLKnowledgeSea
 	}
 	return &resp, nil
 }
+
+// ValidateKnowledge checks the SQL of a knowledge entry against the current schema of
+// the target database without changing any data.
+//
+// Each statement of the entry is planned in a read-only, dry-run mode, and the
+// response lists the tables and columns it references that no longer exist, along
+// with statements that fail to parse or plan. Set ID to check a stored entry, or
+// Entry to check one before creating it. Running it over every entry in CI detects
+// knowledge made stale by schema changes.
+//
+// Example:
+//
+//	resp, err := client.ValidateKnowledge(ctx, &sdk.NL2SQLKnowledgeValidateRequest{
+//		ID: 456,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, issue := range resp.Issues {
+//		fmt.Printf("%s %s: %s\n", issue.Kind, issue.Object, issue.Message)
+//	}
+func (c *RawClient) ValidateKnowledge(ctx context.Context, req *NL2SQLKnowledgeValidateRequest, opts ...CallOption) (*NL2SQLKnowledgeValidateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if (req.ID == 0) == (req.Entry == nil) {
+		return nil, fmt.Errorf("exactly one of id or entry is required")
+	}
+	var resp NL2SQLKnowledgeValidateResponse
+	if err := c.postJSON(ctx, "/catalog/nl2sql_knowledge/validate", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,44 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKnowledge(t *testing.T) {
+	t.Parallel()
+
+	var got NL2SQLKnowledgeValidateRequest
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/nl2sql_knowledge/validate", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		return envelopeResponse(`{"id":7,"valid":false,"issues":[
+			{"kind":"missing_column","value_index":1,"object":"sales.orders.discount","message":"column discount does not exist"}
+		]}`), nil
+	})
+	ctx := context.Background()
+
+	resp, err := client.ValidateKnowledge(ctx, &NL2SQLKnowledgeValidateRequest{ID: 7})
+	require.NoError(t, err)
+	require.Equal(t, Nl2SqlKnowledgeID(7), got.ID)
+	require.Nil(t, got.Entry)
+	require.False(t, resp.Valid)
+	require.Len(t, resp.Issues, 1)
+	require.Equal(t, KnowledgeIssueMissingColumn, resp.Issues[0].Kind)
+	require.Equal(t, 1, resp.Issues[0].ValueIndex)
+
+	_, err = client.ValidateKnowledge(ctx, &NL2SQLKnowledgeValidateRequest{
+		Entry: &NL2SQLKnowledgeCreateRequest{Type: "sql", Key: "q", Value: []string{"select 1"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "q", got.Entry.Key)
+
+	_, err = client.ValidateKnowledge(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.ValidateKnowledge(ctx, &NL2SQLKnowledgeValidateRequest{})
+	require.ErrorContains(t, err, "exactly one of id or entry")
+}
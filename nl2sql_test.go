@@ -2,7 +2,12 @@ package sdk
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,6 +19,119 @@ func TestNL2SQLRunSQL_NilRequest(t *testing.T) {
 	require.ErrorIs(t, err, ErrNilRequest)
 }
 
+func ndjsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{mimeNDJSON}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestRunNL2SQLStream(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/nl2sql/run_sql_stream", r.URL.Path)
+		require.Equal(t, mimeNDJSON, r.Header.Get(headerAccept))
+		return ndjsonResponse(`{"columns":[{"name":"id","type":"BIGINT"},{"name":"amount","type":"DOUBLE"},{"name":"note","type":"VARCHAR","nullable":true}]}
+{"row":["1","9.5","first"]}
+{"row":["2","0.5",null]}
+{"done":true,"row_count":2}
+`), nil
+	})
+
+	rows, err := client.RunNL2SQLStream(context.Background(), &NL2SQLRunSQLRequest{Operation: RunSQL, Statement: "select 1"})
+	require.NoError(t, err)
+	defer rows.Close()
+	require.Equal(t, "BIGINT", rows.Columns()[0].Type)
+
+	var ids []int64
+	var total float64
+	var notes []sql.NullString
+	for rows.Next() {
+		var id int64
+		var amount float64
+		var note sql.NullString
+		require.NoError(t, rows.Scan(&id, &amount, &note))
+		ids = append(ids, id)
+		total += amount
+		notes = append(notes, note)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []int64{1, 2}, ids)
+	require.Equal(t, 10.0, total)
+	require.Equal(t, sql.NullString{String: "first", Valid: true}, notes[0])
+	require.False(t, notes[1].Valid)
+	require.Equal(t, int64(2), rows.RowsRead())
+}
+
+func TestRunNL2SQLStream_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return ndjsonResponse(`{"columns":[{"name":"note","type":"VARCHAR"}]}
+{"row":[null]}
+{"code":"ErrInternal","msg":"query killed"}
+`), nil
+	})
+
+	rows, err := client.RunNL2SQLStream(context.Background(), &NL2SQLRunSQLRequest{Operation: RunSQL, Statement: "select 1"})
+	require.NoError(t, err)
+	defer rows.Close()
+
+	require.True(t, rows.Next())
+	var note string
+	require.ErrorContains(t, rows.Scan(&note), "cannot scan NULL")
+	require.ErrorContains(t, rows.Scan(&note, &note), "expected 1 destination")
+
+	require.False(t, rows.Next())
+	var apiErr *APIError
+	require.ErrorAs(t, rows.Err(), &apiErr)
+	require.Equal(t, "query killed", apiErr.Message)
+
+	truncated := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return ndjsonResponse(`{"columns":[{"name":"id","type":"INT"}]}` + "\n" + `{"row":["1"]}` + "\n"), nil
+	})
+	rows, err = truncated.RunNL2SQLStream(context.Background(), &NL2SQLRunSQLRequest{Operation: RunSQL, Statement: "select 1"})
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	require.False(t, rows.Next())
+	require.ErrorIs(t, rows.Err(), io.ErrUnexpectedEOF)
+}
+
+func TestExplainNL2SQL(t *testing.T) {
+	t.Parallel()
+
+	var got NL2SQLExplainRequest
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/nl2sql/explain", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		return envelopeResponse(`{"sql":"select * from sales.orders","plan":"Project\n  Table Scan on sales.orders",
+			"steps":[{"operator":"Project","estimated_rows":5000,"estimated_cost":5200,"depth":0},
+				{"operator":"Table Scan","object":"sales.orders","estimated_rows":5000,"estimated_cost":5000,"depth":1}],
+			"estimated_rows":5000,"estimated_cost":5200.5,"warnings":["full table scan on sales.orders"]}`), nil
+	})
+	ctx := context.Background()
+
+	plan, err := client.ExplainNL2SQL(ctx, &NL2SQLExplainRequest{Question: "all orders", DbNames: []string{"sales"}})
+	require.NoError(t, err)
+	require.Equal(t, "all orders", got.Question)
+	require.Empty(t, got.Statement)
+	require.Equal(t, "select * from sales.orders", plan.SQL)
+	require.Equal(t, 5200.5, plan.EstimatedCost)
+	require.Len(t, plan.Steps, 2)
+	require.Equal(t, "sales.orders", plan.Steps[1].Object)
+	require.Equal(t, 1, plan.Steps[1].Depth)
+	require.Len(t, plan.Warnings, 1)
+
+	_, err = client.ExplainNL2SQL(ctx, nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.ExplainNL2SQL(ctx, &NL2SQLExplainRequest{})
+	require.ErrorContains(t, err, "exactly one of statement or question")
+	_, err = client.ExplainNL2SQL(ctx, &NL2SQLExplainRequest{Statement: "select 1", Question: "one"})
+	require.ErrorContains(t, err, "exactly one of statement or question")
+}
+
 func TestNL2SQLRunSQL_ShowOperations(t *testing.T) {
 	client := newTestClient(t)
 	ctx := context.Background()
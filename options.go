@@ -1,6 +1,9 @@
 package sdk
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"net/http"
 	"net/url"
 	"strings"
@@ -8,16 +11,43 @@ import (
 )
 
 const (
-	defaultUserAgent        = "matrixflow-sdk-go/0.1.0"
-	defaultHTTPTimeout      = 30 * time.Second
+	defaultUserAgent         = "matrixflow-sdk-go/" + sdkVersion
+	defaultHTTPTimeout       = 30 * time.Second
 	defaultStreamReadTimeout = 30 * time.Second // Default timeout between messages in streaming responses
+	defaultDownloadRetries   = 3
+	defaultConcurrency       = 4
+	defaultInsertBatchSize   = 1000
+	defaultReconnectBackoff  = time.Second
+	defaultLogBodyBytes      = 4 << 10
 )
 
 type clientOptions struct {
-	httpClient      *http.Client
-	userAgent       string
-	defaultHeaders  http.Header
-	llmProxyBaseURL string // Optional: direct LLM Proxy base URL for direct connection
+	httpClient         *http.Client
+	userAgent          string
+	defaultHeaders     http.Header
+	llmProxyBaseURL    string // Optional: direct LLM Proxy base URL for direct connection
+	logger             Logger
+	logBodies          int // Bytes of request and response bodies logged; 0 logs none
+	metrics            MetricsRecorder
+	errorTranslator    ErrorTranslator
+	defaultPageSize    int
+	maxPages           int
+	appInfo            string // Product token of the calling application, prepended to the User-Agent
+	requestIDFunc      func(context.Context) string
+	tokenRefresher     TokenRefresher
+	authProvider       AuthProvider
+	requestSigner      RequestSigner
+	timeoutPolicy      TimeoutPolicy
+	autoIdempotency    bool
+	workspace          string
+	cacheTTL           time.Duration
+	transportOptions   *TransportOptions // Connection pool tuning; nil leaves the transport unchanged
+	tlsConfig          *tls.Config
+	rootCAs            *x509.CertPool
+	clientCertFile     string
+	clientKeyFile      string
+	rateLimit          *rateLimit // Client-wide request budget; nil means unlimited
+	endpointRateLimits map[EndpointGroup]rateLimit
 }
 
 // ClientOption customizes the SDK client during construction.
@@ -83,6 +113,25 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithAppInfo identifies the calling application in the User-Agent header.
+//
+// The User-Agent becomes the application token followed by the SDK token and the Go
+// runtime and platform, e.g. "billing-sync/1.2 matrixflow-sdk-go/0.1.0 (go1.22; linux/amd64)",
+// so that support can tell which application generated a request. The SDK token is
+// the one set with WithUserAgent, if any.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithAppInfo("billing-sync", "1.2"))
+func WithAppInfo(name, version string) ClientOption {
+	return func(o *clientOptions) {
+		if token := productToken(name, version); token != "" {
+			o.appInfo = token
+		}
+	}
+}
+
 // WithDefaultHeader adds a header that will be included on every request.
 //
 // Headers added via WithDefaultHeader are sent with all API calls made by the client.
@@ -156,6 +205,171 @@ func WithLLMProxyBaseURL(baseURL string) ClientOption {
 	}
 }
 
+// WithLogger sets the logger that receives the SDK's log messages.
+//
+// Every request is logged at debug level with its method, host, path, status and
+// latency; failed requests and retries are logged at warn or error level. By default
+// nothing is logged. Use NewSlogLogger, NewZapLogger or NewLogrusLogger to adapt an
+// existing logger.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithLogger(sdk.NewSlogLogger(slog.Default())))
+func WithLogger(logger Logger) ClientOption {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithLogBodies makes the logger set with WithLogger also log the request headers
+// and the first maxBytes bytes of the request and response bodies, e.g. for support
+// cases that need the exchanged payloads. A maxBytes of zero or less logs up to 4 KiB.
+//
+// Credentials are redacted: the values of sensitive headers such as moi-key and
+// Authorization, and the JSON string fields whose names denote a password, secret,
+// token, signature or key. Only JSON, text and form bodies are logged; binary
+// payloads, multipart uploads and event streams are reported by content type.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithLogger(sdk.NewSlogLogger(slog.Default())),
+//		sdk.WithLogBodies(8<<10))
+func WithLogBodies(maxBytes int) ClientOption {
+	return func(o *clientOptions) {
+		if maxBytes <= 0 {
+			maxBytes = defaultLogBodyBytes
+		}
+		o.logBodies = maxBytes
+	}
+}
+
+// WithErrorTranslator sets the translator used to fill APIError.LocalizedMessage.
+//
+// The translator receives the languages of the request's Accept-Language header,
+// which can be set per client with WithDefaultHeader or per call with
+// WithAcceptLanguage. MessageCatalog provides a table-based implementation.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithDefaultHeader("Accept-Language", "zh-CN"),
+//		sdk.WithErrorTranslator(sdk.DefaultErrorMessages))
+//
+//	_, err = client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: 123})
+//	var apiErr *sdk.APIError
+//	if errors.As(err, &apiErr) {
+//		fmt.Println(apiErr.UserMessage()) // 请求的资源不存在。
+//	}
+func WithErrorTranslator(translator ErrorTranslator) ClientOption {
+	return func(o *clientOptions) {
+		o.errorTranslator = translator
+	}
+}
+
+// WithDefaultPageSize sets the page size used by list requests that leave PageSize zero.
+//
+// Such requests also start at page 1 when Page is zero. Requests that set PageSize
+// explicitly are sent unchanged. Without this option, the server default applies.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithDefaultPageSize(50))
+//
+//	// Sent with page=1 and page_size=50
+//	resp, err := client.ListRoles(ctx, &sdk.RoleListRequest{})
+func WithDefaultPageSize(size int) ClientOption {
+	return func(o *clientOptions) {
+		if size > 0 {
+			o.defaultPageSize = size
+		}
+	}
+}
+
+// WithMaxPages limits how many pages auto-paginating helpers may fetch.
+//
+// Helpers that follow pagination to collect a complete result, such as
+// SDKClient.GetVolumeTree or SDKClient.CreateTableRole, return an error matching
+// ErrMaxPagesExceeded instead of fetching more than n pages. This guards production
+// code paths against accidentally walking lists with millions of rows. Zero means no limit.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithMaxPages(100))
+//	sdkClient := sdk.NewSDKClient(client)
+//
+//	tree, err := sdkClient.GetVolumeTree(ctx, volumeID)
+//	if errors.Is(err, sdk.ErrMaxPagesExceeded) {
+//		// the volume is too large to load at once
+//	}
+func WithMaxPages(n int) ClientOption {
+	return func(o *clientOptions) {
+		if n >= 0 {
+			o.maxPages = n
+		}
+	}
+}
+
+// WithRequestIDFunc derives the X-Request-ID of calls that do not set one from their
+// context, typically from the trace ID of the incoming request being served.
+//
+// The function is consulted after WithRequestID and ContextWithRequestID; when it
+// returns "", a random UUID is used.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithRequestIDFunc(func(ctx context.Context) string {
+//			return trace.SpanContextFromContext(ctx).TraceID().String()
+//		}))
+func WithRequestIDFunc(fn func(ctx context.Context) string) ClientOption {
+	return func(o *clientOptions) {
+		o.requestIDFunc = fn
+	}
+}
+
+// WithTokenRefresher makes the client obtain a new API key from refresh when the
+// service rejects the current one with 401 Unauthorized, e.g. after a key rotation.
+//
+// The key is refreshed once per rejected key, even when many requests fail at the
+// same time, and later requests use the new key. The rejected request is sent once
+// more with the new key if that is safe: its body must be replayable, and POST and
+// PATCH requests must either only read, like GetCatalog or ListFiles, or carry an
+// Idempotency-Key header. Otherwise its 401 error is returned. A failed refresh is logged and the 401 error is returned.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithTokenRefresher(func(ctx context.Context) (string, error) {
+//			return secrets.Get(ctx, "moi/api-key")
+//		}))
+func WithTokenRefresher(refresh TokenRefresher) ClientOption {
+	return func(o *clientOptions) {
+		o.tokenRefresher = refresh
+	}
+}
+
+// WithAuthProvider makes the client authenticate every request, including streams,
+// with the tokens of provider instead of a static API key.
+//
+// The apiKey argument of NewRawClient may then be empty, and WithTokenRefresher is
+// ignored. Clients derived with WithSpecialUser use their own API key instead.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, "",
+//		sdk.WithAuthProvider(sdk.OAuth2ClientCredentials(sdk.OAuth2Config{
+//			TokenURL:     "https://auth.example.com/oauth2/token",
+//			ClientID:     clientID,
+//			ClientSecret: clientSecret,
+//		})))
+func WithAuthProvider(provider AuthProvider) ClientOption {
+	return func(o *clientOptions) {
+		o.authProvider = provider
+	}
+}
+
 // CallOption customizes individual SDK operations.
 //
 // CallOption functions are used with individual API method calls to customize
@@ -169,32 +383,53 @@ func WithLLMProxyBaseURL(baseURL string) ClientOption {
 type CallOption func(*callOptions)
 
 type callOptions struct {
-	headers            http.Header
-	query              url.Values
-	requestID          string
-	useDirectLLMProxy  bool          // Whether to use direct LLM Proxy connection
-	streamBufferSize   int           // Buffer size for stream scanner (in bytes)
-	streamReadTimeout  time.Duration // Timeout between messages in streaming responses (0 means use default)
+	headers           http.Header
+	query             url.Values
+	requestID         string
+	useDirectLLMProxy bool                // Whether to use direct LLM Proxy connection
+	streamBufferSize  int                 // Buffer size for stream scanner (in bytes)
+	streamReadTimeout time.Duration       // Timeout between messages in streaming responses (0 means use default)
+	downloadRetries   int                 // Maximum number of retries for resumable downloads
+	concurrency       int                 // Maximum number of parallel requests for fan-out helpers
+	insertBatchSize   int                 // Maximum number of rows sent per insert request
+	consistencyWait   *WaitOptions        // Visibility wait applied by Ensure* helpers after creating objects
+	reconnectRetries  int                 // Maximum number of consecutive reconnects of an interrupted stream
+	reconnectBackoff  time.Duration       // Delay before the first reconnect, growing with each attempt
+	autoRequestID     string              // Request ID generated for calls without WithRequestID
+	longPollFallback  bool                // Whether streams fall back to long polling
+	analysisProgress  func(AnalysisEvent) // Called by AnalyzeData with every typed event
+	responseMetadata  *ResponseMetadata
+	timeoutPolicy     TimeoutPolicy // Timeouts of the call, overriding those of the client
+	noCache           bool          // Set by WithNoCache
 }
 
 func newCallOptions(opts ...CallOption) callOptions {
 	co := callOptions{
 		headers:           make(http.Header),
 		query:             make(url.Values),
-		streamBufferSize:  0,                     // 0 means use default
+		streamBufferSize:  0,                        // 0 means use default
 		streamReadTimeout: defaultStreamReadTimeout, // Default timeout between messages
+		downloadRetries:   defaultDownloadRetries,
+		concurrency:       defaultConcurrency,
+		insertBatchSize:   defaultInsertBatchSize,
 	}
 	for _, opt := range opts {
 		if opt != nil {
 			opt(&co)
 		}
 	}
+	if co.requestID == "" {
+		co.autoRequestID = newRequestID()
+	}
 	return co
 }
 
 // WithRequestID sets the X-Request-ID header on the outgoing request.
 //
 // The request ID is useful for tracking and debugging requests on the server side.
+// Without this option, the ID comes from ContextWithRequestID or WithRequestIDFunc,
+// or a random UUID is generated for the call; it is kept across the retries of the
+// call and reported in APIError.RequestID, HTTPError.RequestID and ResponseMetadata.
 //
 // Example:
 //
@@ -206,6 +441,20 @@ func WithRequestID(id string) CallOption {
 	}
 }
 
+// WithResponseMetadata fills md with the request ID, status code and headers of the
+// response once the call returns.
+//
+// Example:
+//
+//	var md sdk.ResponseMetadata
+//	resp, err := client.CreateCatalog(ctx, req, sdk.WithResponseMetadata(&md))
+//	log.Printf("create catalog: request_id=%s status=%d", md.RequestID, md.StatusCode)
+func WithResponseMetadata(md *ResponseMetadata) CallOption {
+	return func(co *callOptions) {
+		co.responseMetadata = md
+	}
+}
+
 // WithHeader sets or overrides a header on the outgoing request.
 //
 // Headers set via WithHeader will override default headers and any headers
@@ -227,6 +476,24 @@ func WithHeader(key, value string) CallOption {
 	}
 }
 
+// WithAcceptLanguage sets the Accept-Language header on the outgoing request.
+//
+// Services that support it return error messages in the requested language, and
+// the client's ErrorTranslator uses it to pick the localized message.
+//
+// Example:
+//
+//	resp, err := client.CreateCatalog(ctx, req,
+//		sdk.WithAcceptLanguage("zh-CN"))
+func WithAcceptLanguage(languages ...string) CallOption {
+	return func(co *callOptions) {
+		if len(languages) == 0 {
+			return
+		}
+		WithHeader(headerAcceptLanguage, strings.Join(languages, ", "))(co)
+	}
+}
+
 // WithHeaders merges headers into the outgoing request.
 //
 // This is useful when you need to add multiple headers to a single request.
@@ -358,6 +625,135 @@ func WithStreamReadTimeout(timeout time.Duration) CallOption {
 	}
 }
 
+// WithStreamAutoReconnect makes analysis streams reconnect when the connection drops.
+//
+// When reading a stream fails before it ends, ReadEvent closes the connection, waits
+// and sends the analysis request again with the Last-Event-ID header and the request
+// ID of the analysis, so that the server resumes event delivery after the last event
+// received. Up to maxRetries consecutive reconnects are made; the wait is backoff times
+// the attempt number (default backoff: 1 second). A clean end of stream is never retried.
+//
+// Example:
+//
+//	stream, err := client.AnalyzeDataStream(ctx, req,
+//		sdk.WithStreamAutoReconnect(5, 2*time.Second))
+func WithStreamAutoReconnect(maxRetries int, backoff time.Duration) CallOption {
+	return func(co *callOptions) {
+		if maxRetries <= 0 {
+			return
+		}
+		if backoff <= 0 {
+			backoff = defaultReconnectBackoff
+		}
+		co.reconnectRetries = maxRetries
+		co.reconnectBackoff = backoff
+	}
+}
+
+// WithLongPollFallback makes AnalyzeDataStream and StreamWorkflowJobLogs fall back to
+// long polling when the stream cannot be established, e.g. behind a proxy that
+// buffers or breaks text/event-stream responses.
+//
+// An analysis is then started in polling mode and its events are fetched with
+// GetAnalysisEvents; the returned stream is read the same way and reports
+// LongPolling() == true. Job logs are polled with GetWorkflowJobLogs until the job
+// has finished. Requests rejected by the server are not retried.
+//
+// Example:
+//
+//	stream, err := client.AnalyzeDataStream(ctx, req, sdk.WithLongPollFallback())
+func WithLongPollFallback() CallOption {
+	return func(co *callOptions) {
+		co.longPollFallback = true
+	}
+}
+
+// WithAnalysisProgress makes AnalyzeData call fn with every typed event of the
+// analysis as it arrives, e.g. to display progress while waiting for the result.
+// Events of unknown kinds are skipped.
+//
+// Example:
+//
+//	result, err := client.AnalyzeData(ctx, req,
+//		sdk.WithAnalysisProgress(func(ev sdk.AnalysisEvent) {
+//			if chunk, ok := ev.(*sdk.AnswerChunkEvent); ok {
+//				fmt.Print(chunk.Content)
+//			}
+//		}))
+func WithAnalysisProgress(fn func(AnalysisEvent)) CallOption {
+	return func(co *callOptions) {
+		co.analysisProgress = fn
+	}
+}
+
+// WithDownloadRetries sets how many times DownloadFile retries after a failed or
+// interrupted transfer. Each retry resumes from the last received byte.
+//
+// If not set, the default is 3 retries. Set to 0 to disable retries.
+//
+// Example:
+//
+//	result, err := client.DownloadFile(ctx, req, f,
+//		sdk.WithDownloadRetries(5))
+func WithDownloadRetries(retries int) CallOption {
+	return func(co *callOptions) {
+		if retries >= 0 {
+			co.downloadRetries = retries
+		}
+	}
+}
+
+// WithConcurrency limits how many requests helpers that fan out over many objects,
+// such as GetVolumeTree and WalkVolume, issue in parallel.
+//
+// If not set, the default is 4. Values below 1 are ignored.
+//
+// Example:
+//
+//	tree, err := sdkClient.GetVolumeTree(ctx, volumeID,
+//		sdk.WithConcurrency(8))
+func WithConcurrency(n int) CallOption {
+	return func(co *callOptions) {
+		if n >= 1 {
+			co.concurrency = n
+		}
+	}
+}
+
+// WithInsertBatchSize sets how many rows InsertRows and InsertStructs send per
+// request. Larger inputs are split into consecutive requests of at most n rows.
+//
+// If not set, the default is 1000. Values below 1 are ignored.
+//
+// Example:
+//
+//	resp, err := client.InsertRows(ctx, req,
+//		sdk.WithInsertBatchSize(200))
+func WithInsertBatchSize(n int) CallOption {
+	return func(co *callOptions) {
+		if n >= 1 {
+			co.insertBatchSize = n
+		}
+	}
+}
+
+// WithConsistencyWait makes Ensure* helpers wait, after creating an object, until the
+// object is visible in list calls, polling as described by waitOpts.
+//
+// Newly created objects are sometimes not immediately returned by list and tree calls;
+// this option lets automation rely on them right away instead of sleeping. Zero fields
+// of waitOpts use the defaults of WaitUntilVisible.
+//
+// Example:
+//
+//	kb, err := sdkClient.EnsureKnowledgeBase(ctx, "tenant-a", "product-docs",
+//		sdk.WithConsistencyWait(sdk.WaitOptions{Timeout: 10 * time.Second}))
+func WithConsistencyWait(waitOpts WaitOptions) CallOption {
+	return func(co *callOptions) {
+		co.consistencyWait = &waitOpts
+	}
+}
+
 func cloneHeader(src http.Header) http.Header {
 	if len(src) == 0 {
 		return make(http.Header)
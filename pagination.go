@@ -0,0 +1,63 @@
+package sdk
+
+import "fmt"
+
+// paginated is implemented by list requests with page-based pagination. It exposes
+// the Page and PageSize fields so client-wide defaults can be filled in.
+type paginated interface {
+	pagination() (page, pageSize *int)
+}
+
+func (c *CommonCondition) pagination() (*int, *int) { return &c.Page, &c.PageSize }
+
+func (r *GetTableDataRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+func (r *GenAIPipelineListRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+func (r *WorkflowListRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+func (r *WorkflowJobListRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+func (r *NL2SQLKnowledgeListRequest) pagination() (*int, *int) { return &r.PageNumber, &r.PageSize }
+
+func (r *NL2SQLKnowledgeSearchRequest) pagination() (*int, *int) { return &r.PageNumber, &r.PageSize }
+
+func (r *LLMSessionListRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+func (r *DataAskingSessionListRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+func (r *AnalyzeRequestListRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+func (r *CatalogTreeRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+func (r *SearchRequest) pagination() (*int, *int) { return &r.Page, &r.PageSize }
+
+// applyPageDefaults fills in the client's default page size, and page 1, when req is a
+// paginated request that leaves PageSize zero. Like normalizeWorkflowMetadata, it
+// updates the request in place.
+func (c *RawClient) applyPageDefaults(req interface{}) {
+	if c == nil || c.defaultPageSize <= 0 {
+		return
+	}
+	p, ok := req.(paginated)
+	if !ok {
+		return
+	}
+	page, pageSize := p.pagination()
+	if *pageSize != 0 {
+		return
+	}
+	*pageSize = c.defaultPageSize
+	if *page == 0 {
+		*page = 1
+	}
+}
+
+// checkPageLimit returns an error matching ErrMaxPagesExceeded when page, counted from
+// 1, is beyond the limit set with WithMaxPages.
+func (c *RawClient) checkPageLimit(page int) error {
+	if c == nil || c.maxPages <= 0 || page <= c.maxPages {
+		return nil
+	}
+	return fmt.Errorf("%w: stopped after %d pages", ErrMaxPagesExceeded, c.maxPages)
+}
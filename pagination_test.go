@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDefaultPageSize(t *testing.T) {
+	t.Parallel()
+
+	var bodies []RoleListRequest
+	var queries []string
+	client, err := NewRawClient("https://moi.test", "key",
+		WithDefaultPageSize(50),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method == http.MethodGet {
+				queries = append(queries, r.URL.RawQuery)
+				return envelopeResponse(`{"workflows":[]}`), nil
+			}
+			var req RoleListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			bodies = append(bodies, req)
+			return envelopeResponse(`{"total":0,"list":[]}`), nil
+		})}),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.ListRoles(ctx, &RoleListRequest{})
+	require.NoError(t, err)
+	_, err = client.ListRoles(ctx, &RoleListRequest{CommonCondition: CommonCondition{Page: 3, PageSize: 10}})
+	require.NoError(t, err)
+	_, err = client.ListWorkflows(ctx, &WorkflowListRequest{Page: 2})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, bodies[0].Page)
+	require.Equal(t, 50, bodies[0].PageSize)
+	require.Equal(t, 3, bodies[1].Page)
+	require.Equal(t, 10, bodies[1].PageSize)
+	require.Equal(t, []string{"page=2&page_size=50"}, queries)
+}
+
+func TestWithMaxPages(t *testing.T) {
+	t.Parallel()
+
+	var calls int
+	raw, err := NewRawClient("https://moi.test", "key",
+		WithMaxPages(2),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			list := make([]VolumeChildrenResponse, listAllFilesPageSize)
+			data, err := json.Marshal(FileListResponse{Total: 1000, List: list})
+			require.NoError(t, err)
+			return envelopeResponse(string(data)), nil
+		})}),
+	)
+	require.NoError(t, err)
+
+	_, err = NewSDKClient(raw).listAllFiles(context.Background(), nil)
+	require.True(t, errors.Is(err, ErrMaxPagesExceeded))
+	require.Equal(t, 2, calls)
+
+	// The limit is kept by clients for other users
+	require.Equal(t, 2, raw.WithSpecialUser("other").maxPages)
+}
+
+func TestListAllFiles_UsesSnapshot(t *testing.T) {
+	t.Parallel()
+
+	var bodies []FileListRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var req FileListRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		bodies = append(bodies, req)
+		list := make([]VolumeChildrenResponse, listAllFilesPageSize)
+		if req.Page == 2 {
+			list = list[:1]
+		}
+		data, err := json.Marshal(FileListResponse{Total: listAllFilesPageSize + 1, List: list, SnapshotToken: "snap-1"})
+		require.NoError(t, err)
+		return envelopeResponse(string(data)), nil
+	}))
+
+	files, err := client.listAllFiles(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, files, listAllFilesPageSize+1)
+	require.Len(t, bodies, 2)
+	require.True(t, bodies[0].Snapshot)
+	require.Empty(t, bodies[0].SnapshotToken)
+	require.Equal(t, "snap-1", bodies[1].SnapshotToken)
+}
@@ -0,0 +1,222 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointGroup identifies a family of service endpoints that can be given its own
+// request budget with WithEndpointRateLimit.
+type EndpointGroup string
+
+const (
+	// EndpointGroupCatalog covers the catalog, file and table endpoints under /catalog.
+	EndpointGroupCatalog EndpointGroup = "catalog"
+	// EndpointGroupGenAI covers the GenAI pipeline endpoints under /v1/genai.
+	EndpointGroupGenAI EndpointGroup = "genai"
+	// EndpointGroupDataAsking covers the data asking endpoints, including AnalyzeData.
+	EndpointGroupDataAsking EndpointGroup = "data_asking"
+)
+
+// endpointGroupOf returns the group of the endpoint at path, or "" if it belongs to none.
+func endpointGroupOf(path string) EndpointGroup {
+	switch {
+	case strings.HasPrefix(path, "/catalog/"):
+		return EndpointGroupCatalog
+	case strings.HasPrefix(path, "/v1/genai/"):
+		return EndpointGroupGenAI
+	case strings.Contains(path, "/data_asking/"):
+		return EndpointGroupDataAsking
+	default:
+		return ""
+	}
+}
+
+// rateLimit is a request budget of rps requests per second with bursts of up to
+// burst requests.
+type rateLimit struct {
+	rps   float64
+	burst int
+}
+
+// WithRateLimit limits the requests the client sends to the service to rps per
+// second, allowing bursts of up to burst requests.
+//
+// Requests over the budget wait for their turn instead of being sent and rejected
+// with 429 Too Many Requests, so batch tools stay below the server-side throttling.
+// The budget is a token bucket with the semantics of golang.org/x/time/rate: it
+// starts full, refills at rps tokens per second, and each request takes one token.
+// A request whose context would expire before its turn fails at once with an error
+// matching context.DeadlineExceeded. Requests to presigned storage links, such as
+// file downloads, are not limited.
+//
+// The budget is shared by all calls of the client, including clients derived with
+// WithSpecialUser. A burst below 1 is treated as 1; a non-positive rps disables the
+// limit.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithRateLimit(20, 40))
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(o *clientOptions) {
+		if rps <= 0 {
+			o.rateLimit = nil
+			return
+		}
+		o.rateLimit = &rateLimit{rps: rps, burst: burst}
+	}
+}
+
+// WithEndpointRateLimit gives a group of endpoints its own budget of rps requests
+// per second with bursts of up to burst requests, with the same semantics as
+// WithRateLimit.
+//
+// Requests of the group must fit both its budget and the client-wide budget of
+// WithRateLimit, if any. This keeps, for example, a flood of catalog calls from
+// using up the budget of the slower GenAI endpoints.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithRateLimit(50, 100),
+//		sdk.WithEndpointRateLimit(sdk.EndpointGroupGenAI, 5, 10),
+//		sdk.WithEndpointRateLimit(sdk.EndpointGroupDataAsking, 2, 2))
+func WithEndpointRateLimit(group EndpointGroup, rps float64, burst int) ClientOption {
+	return func(o *clientOptions) {
+		if rps <= 0 {
+			delete(o.endpointRateLimits, group)
+			return
+		}
+		if o.endpointRateLimits == nil {
+			o.endpointRateLimits = make(map[EndpointGroup]rateLimit)
+		}
+		o.endpointRateLimits[group] = rateLimit{rps: rps, burst: burst}
+	}
+}
+
+// rateLimiter is a token bucket.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rps    float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(l rateLimit) *rateLimiter {
+	burst := float64(l.burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rps: l.rps, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rps
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rps * float64(time.Second))
+}
+
+// cancel returns a token taken by reserve that was not used.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	now := time.Now()
+	delay := l.reserve(now)
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(now.Add(delay)) {
+		l.cancel()
+		return fmt.Errorf("rate limit wait of %s would exceed context deadline: %w", delay, context.DeadlineExceeded)
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	}
+}
+
+// rateLimitTransport delays the requests to the service hosts so that they fit the
+// configured budgets.
+type rateLimitTransport struct {
+	base   http.RoundTripper
+	hosts  map[string]bool
+	global *rateLimiter
+	groups map[EndpointGroup]*rateLimiter
+}
+
+// newRateLimitTransport returns base limited for requests to hosts, or base itself
+// when no budget is configured.
+func newRateLimitTransport(base http.RoundTripper, hosts map[string]bool, global *rateLimit, groups map[EndpointGroup]rateLimit) http.RoundTripper {
+	if global == nil && len(groups) == 0 {
+		return base
+	}
+	t := &rateLimitTransport{base: base, hosts: hosts, groups: make(map[EndpointGroup]*rateLimiter, len(groups))}
+	if global != nil {
+		t.global = newRateLimiter(*global)
+	}
+	for group, l := range groups {
+		t.groups[group] = newRateLimiter(l)
+	}
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.hosts[req.URL.Host] {
+		if err := t.wait(req); err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, err
+		}
+	}
+	return base.RoundTrip(req)
+}
+
+func (t *rateLimitTransport) wait(req *http.Request) error {
+	if l := t.groups[endpointGroupOf(req.URL.Path)]; l != nil {
+		if err := l.wait(req.Context()); err != nil {
+			return err
+		}
+	}
+	if t.global != nil {
+		return t.global.wait(req.Context())
+	}
+	return nil
+}
+
+func (t *rateLimitTransport) unwrap() http.RoundTripper { return t.base }
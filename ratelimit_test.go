@@ -0,0 +1,78 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointGroupOf(t *testing.T) {
+	t.Parallel()
+	cases := map[string]EndpointGroup{
+		"/catalog/file/info":                    EndpointGroupCatalog,
+		"/v1/genai/pipeline":                    EndpointGroupGenAI,
+		"/byoa/api/v1/data_asking/analyze":      EndpointGroupDataAsking,
+		"/user/me/info":                         "",
+		"/catalogue":                            "",
+		"/byoa/api/v1/data_asking_sessions/new": "",
+	}
+	for path, want := range cases {
+		require.Equal(t, want, endpointGroupOf(path), path)
+	}
+}
+
+func TestRateLimiter_BurstThenRefill(t *testing.T) {
+	t.Parallel()
+	l := newRateLimiter(rateLimit{rps: 10, burst: 2})
+	now := l.last
+
+	require.Zero(t, l.reserve(now))
+	require.Zero(t, l.reserve(now))
+	require.Equal(t, 100*time.Millisecond, l.reserve(now))
+	require.Equal(t, 200*time.Millisecond, l.reserve(now))
+
+	l.cancel()
+	require.Equal(t, 100*time.Millisecond, l.reserve(now.Add(100*time.Millisecond)))
+	require.Zero(t, l.reserve(now.Add(time.Hour)))
+}
+
+func TestRateLimiter_WaitHonorsDeadline(t *testing.T) {
+	t.Parallel()
+	l := newRateLimiter(rateLimit{rps: 1, burst: 1})
+	require.NoError(t, l.wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	require.ErrorIs(t, l.wait(ctx), context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestWithRateLimit_LimitsServiceRequests(t *testing.T) {
+	t.Parallel()
+	var sent atomic.Int32
+	client, err := NewRawClient("https://moi.test", "key",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			sent.Add(1)
+			return envelopeResponse(`{}`), nil
+		})}),
+		WithRateLimit(1000, 1),
+		WithEndpointRateLimit(EndpointGroupGenAI, 1, 1))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1})
+		require.NoError(t, err)
+	}
+	_, err = client.GetGenAIJob(ctx, "j1")
+	require.NoError(t, err)
+	_, err = client.GetGenAIJob(ctx, "j1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, int32(4), sent.Load())
+}
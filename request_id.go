@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns a context whose API calls send id as their X-Request-ID,
+// so that every call made while serving one incoming request shares its ID.
+//
+// WithRequestID takes precedence over the context.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		ctx := sdk.ContextWithRequestID(r.Context(), r.Header.Get("X-Request-ID"))
+//		resp, err := client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: 123})
+//		...
+//	}
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored with ContextWithRequestID, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// requestIDFor returns the X-Request-ID of a call: the ID set with WithRequestID, else
+// the ID carried by ctx, else the ID derived by the client's WithRequestIDFunc, else
+// the ID generated for the call.
+func (c *RawClient) requestIDFor(ctx context.Context, opts callOptions) string {
+	if opts.requestID != "" {
+		return opts.requestID
+	}
+	if id := RequestIDFromContext(ctx); id != "" {
+		return id
+	}
+	if c != nil && c.requestIDFunc != nil && ctx != nil {
+		if id := c.requestIDFunc(ctx); id != "" {
+			return id
+		}
+	}
+	return opts.autoRequestID
+}
+
+// newRequestID returns a random (version 4) UUID.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// ResponseMetadata describes the HTTP response of a call. Pass it to
+// WithResponseMetadata to have it filled in.
+type ResponseMetadata struct {
+	// RequestID is the X-Request-ID of the call, as echoed by the server or else as sent.
+	RequestID string
+	// StatusCode is the HTTP status code.
+	StatusCode int
+	// Header contains the HTTP response headers.
+	Header http.Header
+}
+
+// captureResponse fills the metadata requested with WithResponseMetadata.
+func (co callOptions) captureResponse(resp *http.Response) {
+	if co.responseMetadata == nil || resp == nil {
+		return
+	}
+	*co.responseMetadata = ResponseMetadata{
+		RequestID:  responseRequestID(resp),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+	}
+}
+
+// responseRequestID returns the request ID echoed in resp, or the one sent with its request.
+func responseRequestID(resp *http.Response) string {
+	if resp == nil {
+		return ""
+	}
+	if id := resp.Header.Get(headerRequestID); id != "" {
+		return id
+	}
+	if resp.Request != nil {
+		return resp.Request.Header.Get(headerRequestID)
+	}
+	return ""
+}
+
+// newHTTPError builds the error for a non-2xx response whose body has been read.
+func newHTTPError(resp *http.Response, body []byte) *HTTPError {
+	return &HTTPError{StatusCode: resp.StatusCode, Body: body, RequestID: responseRequestID(resp)}
+}
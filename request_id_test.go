@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type traceIDKey struct{}
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	var sent []string
+	client, err := NewRawClient("https://moi.test", "key",
+		WithRequestIDFunc(func(ctx context.Context) string {
+			trace, _ := ctx.Value(traceIDKey{}).(string)
+			return trace
+		}),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			sent = append(sent, r.Header.Get(headerRequestID))
+			if r.URL.Path == "/catalog/delete" {
+				resp := errorEnvelopeResponse("ErrInternal", "boom")
+				resp.StatusCode = http.StatusInternalServerError
+				return resp, nil
+			}
+			if r.URL.Path == "/catalog/update" {
+				return errorEnvelopeResponse("ErrNotFound", "catalog not exist"), nil
+			}
+			return envelopeResponse(`{}`), nil
+		})}),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// Generated per call
+	var md ResponseMetadata
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1}, WithResponseMetadata(&md))
+	require.NoError(t, err)
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), sent[0])
+	require.NotEqual(t, sent[0], sent[1])
+	require.Equal(t, sent[0], md.RequestID)
+	require.Equal(t, http.StatusOK, md.StatusCode)
+
+	// Explicit, context and derived IDs, in order of precedence
+	_, err = client.GetCatalog(ContextWithRequestID(ctx, "from-ctx"), &CatalogInfoRequest{CatalogID: 1}, WithRequestID("explicit"))
+	require.NoError(t, err)
+	_, err = client.GetCatalog(ContextWithRequestID(ctx, "from-ctx"), &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	_, err = client.GetCatalog(context.WithValue(ctx, traceIDKey{}, "trace-1"), &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"explicit", "from-ctx", "trace-1"}, sent[2:])
+
+	// Errors report the ID that was sent
+	_, err = client.UpdateCatalog(ctx, &CatalogUpdateRequest{CatalogID: 1})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, sent[len(sent)-1], apiErr.RequestID)
+
+	_, err = client.DeleteCatalog(ctx, &CatalogDeleteRequest{CatalogID: 1}, WithRequestID("req-9"))
+	var httpErr *HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	require.Equal(t, "req-9", httpErr.RequestID)
+	require.Contains(t, httpErr.Error(), "request_id=req-9")
+}
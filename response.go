@@ -7,4 +7,5 @@ type apiEnvelope struct {
 	Msg       string          `json:"msg"`
 	Data      json.RawMessage `json:"data"`
 	RequestID string          `json:"request_id"`
+	Details   json.RawMessage `json:"details,omitempty"`
 }
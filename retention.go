@@ -0,0 +1,145 @@
+package sdk
+
+import (
+	"context"
+)
+
+// CreateRetentionPolicy creates a retention policy for a volume, folder or workflow.
+//
+// File policies delete files older than MaxAgeDays; workflow policies keep only the
+// most recent KeepLastJobs job records.
+//
+// Example:
+//
+//	resp, err := client.CreateRetentionPolicy(ctx, &sdk.RetentionPolicyCreateRequest{
+//		Name:       "drop-old-uploads",
+//		TargetType: sdk.RetentionTargetFolder,
+//		TargetID:   "folder-id-123",
+//		MaxAgeDays: 30,
+//		Enabled:    true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Created retention policy ID: %s\n", resp.ID)
+func (c *RawClient) CreateRetentionPolicy(ctx context.Context, req *RetentionPolicyCreateRequest, opts ...CallOption) (*RetentionPolicyCreateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp RetentionPolicyCreateResponse
+	if err := c.postJSON(ctx, "/catalog/retention/create", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateRetentionPolicy updates the name, rules or enabled state of a retention policy.
+//
+// Only non-nil rule fields are changed.
+//
+// Example:
+//
+//	days := 7
+//	resp, err := client.UpdateRetentionPolicy(ctx, &sdk.RetentionPolicyUpdateRequest{
+//		ID:         "policy-id-123",
+//		MaxAgeDays: &days,
+//	})
+func (c *RawClient) UpdateRetentionPolicy(ctx context.Context, req *RetentionPolicyUpdateRequest, opts ...CallOption) (*RetentionPolicyUpdateResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp RetentionPolicyUpdateResponse
+	if err := c.postJSON(ctx, "/catalog/retention/update", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteRetentionPolicy deletes a retention policy.
+//
+// Files and job records already removed by the policy are not restored.
+//
+// Example:
+//
+//	resp, err := client.DeleteRetentionPolicy(ctx, &sdk.RetentionPolicyDeleteRequest{
+//		ID: "policy-id-123",
+//	})
+func (c *RawClient) DeleteRetentionPolicy(ctx context.Context, req *RetentionPolicyDeleteRequest, opts ...CallOption) (*RetentionPolicyDeleteResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp RetentionPolicyDeleteResponse
+	if err := c.postJSON(ctx, "/catalog/retention/delete", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetRetentionPolicy retrieves a retention policy by ID.
+//
+// Example:
+//
+//	policy, err := client.GetRetentionPolicy(ctx, &sdk.RetentionPolicyInfoRequest{
+//		ID: "policy-id-123",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Policy %s keeps files for %d days\n", policy.Name, policy.MaxAgeDays)
+func (c *RawClient) GetRetentionPolicy(ctx context.Context, req *RetentionPolicyInfoRequest, opts ...CallOption) (*RetentionPolicy, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp RetentionPolicy
+	if err := c.postJSON(ctx, "/catalog/retention/info", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListRetentionPolicies lists retention policies, optionally filtered by target.
+//
+// Example:
+//
+//	resp, err := client.ListRetentionPolicies(ctx, &sdk.RetentionPolicyListRequest{
+//		TargetType: sdk.RetentionTargetWorkflow,
+//		TargetID:   "workflow-id-123",
+//	})
+func (c *RawClient) ListRetentionPolicies(ctx context.Context, req *RetentionPolicyListRequest, opts ...CallOption) (*RetentionPolicyListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp RetentionPolicyListResponse
+	if err := c.postJSON(ctx, "/catalog/retention/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// PreviewRetentionPolicy reports what a retention policy would delete if it ran now.
+//
+// Either a saved policy ID or an unsaved policy definition can be previewed; nothing is deleted.
+//
+// Example:
+//
+//	preview, err := client.PreviewRetentionPolicy(ctx, &sdk.RetentionPreviewRequest{
+//		Policy: &sdk.RetentionPolicyCreateRequest{
+//			TargetType: sdk.RetentionTargetVolume,
+//			TargetID:   "volume-id-123",
+//			MaxAgeDays: 90,
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Would delete %d files (%d bytes)\n", len(preview.Files), preview.TotalSize)
+func (c *RawClient) PreviewRetentionPolicy(ctx context.Context, req *RetentionPreviewRequest, opts ...CallOption) (*RetentionPreviewResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp RetentionPreviewResponse
+	if err := c.postJSON(ctx, "/catalog/retention/preview", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
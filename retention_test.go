@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionNilRequestErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Create", func() error { _, err := client.CreateRetentionPolicy(ctx, nil); return err }},
+		{"Update", func() error { _, err := client.UpdateRetentionPolicy(ctx, nil); return err }},
+		{"Delete", func() error { _, err := client.DeleteRetentionPolicy(ctx, nil); return err }},
+		{"Info", func() error { _, err := client.GetRetentionPolicy(ctx, nil); return err }},
+		{"List", func() error { _, err := client.ListRetentionPolicies(ctx, nil); return err }},
+		{"Preview", func() error { _, err := client.PreviewRetentionPolicy(ctx, nil); return err }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, tc.call(), ErrNilRequest)
+		})
+	}
+}
+
+func TestPreviewRetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/retention/preview", r.URL.Path)
+		var req RetentionPreviewRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, RetentionTargetVolume, req.Policy.TargetType)
+		require.Equal(t, 90, req.Policy.MaxAgeDays)
+		return envelopeResponse(`{"files":[{"id":"f1","name":"old.pdf","size":10}],"total_size":10}`), nil
+	})
+
+	resp, err := client.PreviewRetentionPolicy(context.Background(), &RetentionPreviewRequest{
+		Policy: &RetentionPolicyCreateRequest{TargetType: RetentionTargetVolume, TargetID: "v1", MaxAgeDays: 90},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Files, 1)
+	require.Equal(t, int64(10), resp.TotalSize)
+}
@@ -0,0 +1,156 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+// AccessAction is a coarse kind of access to an object, used by CanUserAccess.
+type AccessAction string
+
+const (
+	// AccessRead covers reading an object's content: querying a table, reading a
+	// volume, or listing a catalog or database.
+	AccessRead AccessAction = "read"
+	// AccessWrite covers changing an object's content or creating objects under it.
+	AccessWrite AccessAction = "write"
+	// AccessAdmin covers altering or dropping the object itself.
+	AccessAdmin AccessAction = "admin"
+)
+
+// accessPrivCodes lists, per object type and action, the privilege codes that are
+// each sufficient on their own to grant the action.
+var accessPrivCodes = map[ObjType]map[AccessAction][]PrivCode{
+	ObjTypeCatalog: {
+		AccessRead:  {PrivCode_QueryCatalog},
+		AccessWrite: {PrivCode_UpdateCatalog, PrivCode_CreateDatabase},
+		AccessAdmin: {PrivCode_DeleteCatalog},
+	},
+	ObjTypeDatabase: {
+		AccessRead:  {PrivCode_QueryDatabase, PrivCode_ShowTables},
+		AccessWrite: {PrivCode_UpdateDatabase, PrivCode_CreateTable, PrivCode_CreateView, PrivCode_CreateVolume},
+		AccessAdmin: {PrivCode_DeleteDatabase},
+	},
+	ObjTypeTable: {
+		AccessRead:  {PrivCode_TableSelect},
+		AccessWrite: {PrivCode_TableInsert, PrivCode_TableUpdate, PrivCode_TableDelete, PrivCode_TableTruncate},
+		AccessAdmin: {PrivCode_AlterTable, PrivCode_DropTable},
+	},
+	ObjTypeVolume: {
+		AccessRead:  {PrivCode_VolumeRead},
+		AccessWrite: {PrivCode_VolumeWrite},
+		AccessAdmin: {PrivCode_UpdateVolume, PrivCode_DeleteVolume},
+	},
+}
+
+// AccessGrant is a privilege held through one of a user's roles.
+type AccessGrant struct {
+	RoleID   RoleID
+	RoleName string
+	// Privilege is the matching privilege; its ObjType and ObjID are empty when
+	// the role holds the code as a global privilege.
+	Privilege RolePrivilege
+}
+
+// AccessDecision is the answer of CanUserAccess.
+type AccessDecision struct {
+	Allowed bool
+	// Grants lists every role privilege that permits the action. It is empty
+	// when Allowed is false.
+	Grants []AccessGrant
+	// Reason explains the decision in a form suitable for display.
+	Reason string
+}
+
+// CanUserAccess reports whether a user may perform action on an object, and which
+// of the user's roles and privileges grant it. objType must be ObjTypeCatalog,
+// ObjTypeDatabase, ObjTypeTable or ObjTypeVolume; use CanUserAccessFile for files.
+//
+// The answer is computed from the user's role definitions, so it reflects the
+// privileges as the SDK understands them; the service remains the final
+// authority.
+//
+// Example:
+//
+//	decision, err := sdkClient.CanUserAccess(ctx, 42, sdk.ObjTypeTable, "123", sdk.AccessWrite)
+//	if err != nil {
+//		return err
+//	}
+//	if !decision.Allowed {
+//		fmt.Println(decision.Reason)
+//	}
+func (c *SDKClient) CanUserAccess(ctx context.Context, userID UserID, objType ObjType, objID string, action AccessAction, opts ...CallOption) (*AccessDecision, error) {
+	if userID == 0 {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if objID == "" {
+		return nil, fmt.Errorf("object id is required")
+	}
+	actions, ok := accessPrivCodes[objType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported object type %q", objType)
+	}
+	codes, ok := actions[action]
+	if !ok {
+		return nil, fmt.Errorf("unsupported access action %q", action)
+	}
+
+	user, err := c.raw.GetUserDetail(ctx, &UserDetailInfoRequest{UserID: userID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user detail: %w", err)
+	}
+
+	wanted := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		wanted[string(code)] = true
+	}
+	decision := &AccessDecision{}
+	for _, r := range user.RoleList {
+		if r == nil {
+			continue
+		}
+		role, err := c.raw.GetRole(ctx, &RoleInfoRequest{RoleID: r.ID}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get role %d: %w", r.ID, err)
+		}
+		for _, p := range rolePrivileges(role) {
+			if !wanted[p.Code] {
+				continue
+			}
+			if p.ObjType != "" && (p.ObjType != objType.String() || p.ObjID != objID) {
+				continue
+			}
+			decision.Grants = append(decision.Grants, AccessGrant{RoleID: role.RoleID, RoleName: role.RoleName, Privilege: p})
+		}
+	}
+
+	decision.Allowed = len(decision.Grants) > 0
+	if decision.Allowed {
+		g := decision.Grants[0]
+		scope := "globally"
+		if g.Privilege.ObjType != "" {
+			scope = "on " + objType.String() + " " + objID
+		}
+		decision.Reason = fmt.Sprintf("role %q grants %s %s", g.RoleName, g.Privilege.Code, scope)
+	} else {
+		decision.Reason = fmt.Sprintf("no role of user %d grants %s on %s %s", userID, action, objType, objID)
+	}
+	return decision, nil
+}
+
+// CanUserAccessFile is like CanUserAccess for a file. Files carry no privileges
+// of their own, so the decision is made on the volume that holds the file.
+//
+// Example:
+//
+//	decision, err := sdkClient.CanUserAccessFile(ctx, 42, "file-123", sdk.AccessRead)
+func (c *SDKClient) CanUserAccessFile(ctx context.Context, userID UserID, fileID FileID, action AccessAction, opts ...CallOption) (*AccessDecision, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file_id is required")
+	}
+	file, err := c.raw.GetFile(ctx, &FileInfoRequest{FileID: fileID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+	return c.CanUserAccess(ctx, userID, ObjTypeVolume, file.VolumeID, action, opts...)
+}
@@ -0,0 +1,66 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanUserAccess(t *testing.T) {
+	t.Parallel()
+
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/user/detail_info":
+			return envelopeResponse(`{"id":42,"name":"alice","role_list":[{"id":5,"name":"analyst"},{"id":6,"name":"ops"}]}`), nil
+		case "/role/info":
+			var req RoleInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.RoleID == 5 {
+				return envelopeResponse(testRoleInfo), nil
+			}
+			return envelopeResponse(`{"id":6,"name":"ops","authority_list":[{"code":"DV6"}],
+				"obj_authority_list":[{"id":"v1","category":"volume","authority_code_list":[{"code":"DV5"}]}]}`), nil
+		case "/catalog/file/info":
+			return envelopeResponse(`{"id":"f1","volume_id":"v1"}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+	ctx := context.Background()
+
+	decision, err := client.CanUserAccess(ctx, 42, ObjTypeTable, "123", AccessRead)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Len(t, decision.Grants, 1)
+	require.Equal(t, RoleID(5), decision.Grants[0].RoleID)
+	require.Equal(t, "DT8", decision.Grants[0].Privilege.Code)
+	require.Contains(t, decision.Reason, `role "analyst" grants DT8 on table 123`)
+
+	decision, err = client.CanUserAccess(ctx, 42, ObjTypeTable, "456", AccessRead)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+	require.Empty(t, decision.Grants)
+
+	decision, err = client.CanUserAccess(ctx, 42, ObjTypeTable, "123", AccessAdmin)
+	require.NoError(t, err)
+	require.False(t, decision.Allowed)
+
+	decision, err = client.CanUserAccessFile(ctx, 42, "f1", AccessWrite)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Contains(t, decision.Reason, "globally")
+
+	decision, err = client.CanUserAccessFile(ctx, 42, "f1", AccessRead)
+	require.NoError(t, err)
+	require.True(t, decision.Allowed)
+	require.Equal(t, "v1", decision.Grants[0].Privilege.ObjID)
+
+	_, err = client.CanUserAccess(ctx, 42, ObjTypeWorkFlow, "1", AccessRead)
+	require.ErrorContains(t, err, "unsupported object type")
+	_, err = client.CanUserAccess(ctx, 42, ObjTypeTable, "1", "drop")
+	require.ErrorContains(t, err, "unsupported access action")
+}
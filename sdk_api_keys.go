@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// APIKeyRotationPolicy controls RotateUserAPIKeys.
+type APIKeyRotationPolicy struct {
+	// RotateBefore rotates keys that expire within this duration. Keys without an
+	// expiration date are only rotated when RotateBefore is negative.
+	RotateBefore time.Duration
+	// TTL is the lifetime of the replacement keys; zero creates keys that never expire.
+	TTL time.Duration
+	// KeepOld leaves the old keys active so that clients can switch over; they then
+	// expire on their own schedule or must be revoked with RevokeUserAPIKey.
+	KeepOld bool
+}
+
+// RotateUserAPIKeys replaces the API keys of a user that are due for rotation
+// according to policy. Each replacement keeps the description and scopes of the
+// key it replaces, and the old key is revoked unless policy.KeepOld is set.
+//
+// The returned responses carry the secrets of the new keys, in the order the old
+// keys were listed. If an error occurs, the keys rotated so far are returned with it.
+//
+// Example:
+//
+//	rotated, err := sdkClient.RotateUserAPIKeys(ctx, 123, sdk.APIKeyRotationPolicy{
+//		RotateBefore: 7 * 24 * time.Hour,
+//		TTL:          90 * 24 * time.Hour,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, key := range rotated {
+//		fmt.Printf("new key %s expires %s\n", key.KeyID, key.ExpiresAt)
+//	}
+func (c *SDKClient) RotateUserAPIKeys(ctx context.Context, userID UserID, policy APIKeyRotationPolicy, opts ...CallOption) ([]*APIKeyCreateResponse, error) {
+	opts = withoutIdempotencyKey(opts)
+	if userID == 0 {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if policy.TTL < 0 {
+		return nil, fmt.Errorf("ttl cannot be negative")
+	}
+	list, err := c.raw.ListUserAPIKeys(ctx, &APIKeyListRequest{UserID: userID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	now := time.Now()
+	var rotated []*APIKeyCreateResponse
+	for _, key := range list.List {
+		if key.Revoked {
+			continue
+		}
+		due, err := apiKeyDue(key, now, policy.RotateBefore)
+		if err != nil {
+			return rotated, err
+		}
+		if !due {
+			continue
+		}
+
+		req := &APIKeyCreateRequest{UserID: userID, Description: key.Description, Scopes: key.Scopes}
+		if policy.TTL > 0 {
+			req.ExpiresAt = now.Add(policy.TTL).UTC().Format(time.RFC3339)
+		}
+		created, err := c.raw.CreateUserAPIKey(ctx, req, opts...)
+		if err != nil {
+			return rotated, fmt.Errorf("failed to create replacement for api key %s: %w", key.KeyID, err)
+		}
+		rotated = append(rotated, created)
+		if policy.KeepOld {
+			continue
+		}
+		if _, err := c.raw.RevokeUserAPIKey(ctx, &APIKeyRevokeRequest{UserID: userID, KeyID: key.KeyID}, opts...); err != nil {
+			return rotated, fmt.Errorf("failed to revoke api key %s: %w", key.KeyID, err)
+		}
+	}
+	return rotated, nil
+}
+
+// apiKeyDue reports whether key expires within rotateBefore of now.
+func apiKeyDue(key APIKeyInfo, now time.Time, rotateBefore time.Duration) (bool, error) {
+	if rotateBefore < 0 {
+		return true, nil
+	}
+	if key.ExpiresAt == "" {
+		return false, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, key.ExpiresAt)
+	if err != nil {
+		return false, fmt.Errorf("api key %s: invalid expires_at %q: %w", key.KeyID, key.ExpiresAt, err)
+	}
+	return expiresAt.Sub(now) <= rotateBefore, nil
+}
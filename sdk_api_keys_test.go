@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateUserAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	soon := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	later := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	var created []APIKeyCreateRequest
+	var revoked []APIKeyID
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/user/api-key/list":
+			return envelopeResponse(fmt.Sprintf(`{"key_list":[
+				{"key_id":"k1","description":"etl","scope_list":["DT8"],"expires_at":%q},
+				{"key_id":"k2","expires_at":%q},
+				{"key_id":"k3"}]}`, soon, later)), nil
+		case "/user/api-key/create":
+			var req APIKeyCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			created = append(created, req)
+			return envelopeResponse(`{"key_id":"k9","key":"secret"}`), nil
+		case "/user/api-key/revoke":
+			var req APIKeyRevokeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			revoked = append(revoked, req.KeyID)
+			return envelopeResponse(`{}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+
+	rotated, err := client.RotateUserAPIKeys(context.Background(), 7, APIKeyRotationPolicy{
+		RotateBefore: 24 * time.Hour,
+		TTL:          90 * 24 * time.Hour,
+	})
+	require.NoError(t, err)
+	require.Len(t, rotated, 1)
+	require.Equal(t, "secret", rotated[0].Key)
+	require.Len(t, created, 1)
+	require.Equal(t, "etl", created[0].Description)
+	require.Equal(t, []string{"DT8"}, created[0].Scopes)
+	require.NotEmpty(t, created[0].ExpiresAt)
+	require.Equal(t, []APIKeyID{"k1"}, revoked)
+
+	rotated, err = client.RotateUserAPIKeys(context.Background(), 7, APIKeyRotationPolicy{RotateBefore: -1, KeepOld: true})
+	require.NoError(t, err)
+	require.Len(t, rotated, 3)
+	require.Empty(t, created[3].ExpiresAt)
+	require.Len(t, revoked, 1)
+}
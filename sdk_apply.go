@@ -0,0 +1,973 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is a declarative description of catalog objects, workflows and knowledge
+// entries, applied with SDKClient.Apply. Specs are usually written in YAML or JSON
+// and read with ParseSpec.
+type Spec struct {
+	Catalogs  []CatalogSpec     `json:"catalogs,omitempty"`
+	Workflows []WorkflowSpec    `json:"workflows,omitempty"`
+	Knowledge []KnowledgeRecord `json:"knowledge,omitempty"`
+}
+
+// CatalogSpec describes a catalog and its databases. An empty comment leaves the
+// current comment unchanged, for all the objects of a spec.
+type CatalogSpec struct {
+	Name      string         `json:"name"`
+	Comment   string         `json:"comment,omitempty"`
+	Databases []DatabaseSpec `json:"databases,omitempty"`
+}
+
+// DatabaseSpec describes a database and its tables and volumes.
+type DatabaseSpec struct {
+	Name    string       `json:"name"`
+	Comment string       `json:"comment,omitempty"`
+	Tables  []TableSpec  `json:"tables,omitempty"`
+	Volumes []VolumeSpec `json:"volumes,omitempty"`
+}
+
+// TableSpec describes a table. Columns are matched by name; the comment of a table is
+// only set when the table is created.
+type TableSpec struct {
+	Name    string   `json:"name"`
+	Comment string   `json:"comment,omitempty"`
+	Columns []Column `json:"columns"`
+}
+
+// VolumeSpec describes a volume and its folders.
+type VolumeSpec struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+	// Folders are "/"-separated folder paths from the root of the volume; their
+	// parent folders are implied
+	Folders []string `json:"folders,omitempty"`
+}
+
+// WorkflowSpec describes a workflow, matched by name. Volumes are given as
+// "catalog/database/volume" paths, in the spec or in the service.
+type WorkflowSpec struct {
+	Name          string           `json:"name"`
+	SourceVolumes []string         `json:"source_volumes"`
+	TargetVolume  string           `json:"target_volume"`
+	FileTypes     []int            `json:"file_types,omitempty"`
+	ProcessMode   *ProcessMode     `json:"process_mode,omitempty"`
+	Workflow      *CatalogWorkflow `json:"workflow,omitempty"` // Graph of the workflow; not compared when nil
+}
+
+// ParseSpec reads a spec from a YAML or JSON document. Unknown fields are rejected,
+// so that a misspelled field does not silently drop part of the spec.
+//
+// Example:
+//
+//	spec, err := sdk.ParseSpec([]byte(`
+//	catalogs:
+//	  - name: sales
+//	    databases:
+//	      - name: orders
+//	        tables:
+//	          - name: line_items
+//	            columns:
+//	              - {name: id, type: int, is_pk: true}
+//	        volumes:
+//	          - name: raw
+//	            folders: [2024/q1]
+//	`))
+func ParseSpec(data []byte) (*Spec, error) {
+	// YAML is a superset of JSON; the document is converted to JSON so that the
+	// field names are those of the json tags
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(jsonData))
+	dec.DisallowUnknownFields()
+	var spec Spec
+	if doc != nil {
+		if err := dec.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("failed to parse spec: %w", err)
+		}
+	}
+	return &spec, nil
+}
+
+// ApplyOptions controls SDKClient.Apply.
+type ApplyOptions struct {
+	// DryRun computes and returns the plan without changing anything.
+	DryRun bool
+	// Prune deletes the objects that are missing from the spec: databases of the
+	// catalogs of the spec, tables, volumes and folders (with their content) of its
+	// databases and volumes, columns of its tables, workflows reading from volumes
+	// of its catalogs, and knowledge entries of the types it lists. Catalogs and
+	// reserved objects are never deleted.
+	Prune bool
+}
+
+// ChangeAction is what a Change does to an object.
+type ChangeAction string
+
+const (
+	ChangeCreate ChangeAction = "create"
+	ChangeUpdate ChangeAction = "update"
+	ChangeDelete ChangeAction = "delete"
+)
+
+// Change is one step of an ApplyPlan.
+type Change struct {
+	Action ChangeAction
+	// Kind is "catalog", "database", "table", "volume", "folder", "workflow" or "knowledge"
+	Kind string
+	// Path is "catalog/database/name" for catalog objects, with the folder path for
+	// folders, the name for workflows and "type/key" for knowledge entries
+	Path string
+	// Details lists the changed attributes of an update
+	Details []string
+	// Applied reports whether Apply made the change
+	Applied bool
+
+	run func(ctx context.Context, s *applyState) error
+}
+
+// ApplyPlan is the list of changes that bring the service to the state of a spec, in
+// the order they are applied: creations and updates from the outermost object in,
+// then deletions from the innermost object out.
+type ApplyPlan struct {
+	Changes []Change
+	DryRun  bool
+}
+
+// IsEmpty reports whether the service already matches the spec.
+func (p *ApplyPlan) IsEmpty() bool {
+	return p == nil || len(p.Changes) == 0
+}
+
+// String returns a human-readable preview of the plan, one change per line prefixed
+// with "+" (create), "~" (update) or "-" (delete), followed by a summary line.
+func (p *ApplyPlan) String() string {
+	if p.IsEmpty() {
+		return "No changes."
+	}
+	var b strings.Builder
+	counts := map[ChangeAction]int{}
+	for _, change := range p.Changes {
+		counts[change.Action]++
+		symbol := map[ChangeAction]string{ChangeCreate: "+", ChangeUpdate: "~", ChangeDelete: "-"}[change.Action]
+		fmt.Fprintf(&b, "%s %s %s\n", symbol, change.Kind, change.Path)
+		for _, detail := range change.Details {
+			fmt.Fprintf(&b, "    %s\n", detail)
+		}
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete.",
+		counts[ChangeCreate], counts[ChangeUpdate], counts[ChangeDelete])
+	return b.String()
+}
+
+// Apply compares spec with the objects of the service and creates, updates and, with
+// ApplyOptions.Prune, deletes objects so that they match. It returns the plan of the
+// changes, with Change.Applied set on those that were made; with ApplyOptions.DryRun
+// no change is made and the plan is only a preview.
+//
+// Catalogs, databases and volumes are matched by name, folders by path, workflows by
+// name and knowledge entries by type and key. Table columns are compared by name,
+// type, default and comment and changed with AlterTable; extra columns are only
+// dropped with ApplyOptions.Prune. Workflows are compared by volumes, file types and
+// process mode and, when the spec gives one, by graph with DiffWorkflows.
+//
+// The changes are applied in order and Apply stops at the first failure, without
+// undoing the changes already made; as they are computed from the current state,
+// applying the spec again resumes where it stopped.
+//
+// Example:
+//
+//	spec, err := sdk.ParseSpec(data)
+//	if err != nil {
+//		return err
+//	}
+//	plan, err := sdkClient.Apply(ctx, spec, sdk.ApplyOptions{DryRun: true})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(plan)
+//	if !plan.IsEmpty() {
+//		_, err = sdkClient.Apply(ctx, spec, sdk.ApplyOptions{})
+//	}
+func (c *SDKClient) Apply(ctx context.Context, spec *Spec, applyOpts ApplyOptions, opts ...CallOption) (*ApplyPlan, error) {
+	opts = withoutIdempotencyKey(opts)
+	if spec == nil {
+		return nil, fmt.Errorf("spec is required")
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	p := &specPlanner{
+		c:     c,
+		opts:  opts,
+		prune: applyOpts.Prune,
+		state: &applyState{
+			catalogs:  make(map[string]CatalogID),
+			databases: make(map[string]DatabaseID),
+			volumes:   make(map[string]VolumeID),
+			folders:   make(map[string]FileID),
+		},
+		newVolumes:     make(map[string]bool),
+		managedVolumes: make(map[string]bool),
+	}
+	if err := p.plan(ctx, spec); err != nil {
+		return nil, fmt.Errorf("failed to plan changes: %w", err)
+	}
+	plan := &ApplyPlan{Changes: p.changes, DryRun: applyOpts.DryRun}
+	for _, level := range p.deletes {
+		plan.Changes = append(plan.Changes, level...)
+	}
+	if applyOpts.DryRun {
+		return plan, nil
+	}
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		if err := change.run(ctx, p.state); err != nil {
+			return plan, fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Kind, change.Path, err)
+		}
+		change.Applied = true
+	}
+	return plan, nil
+}
+
+func (spec *Spec) validate() error {
+	catalogs := make(map[string]bool)
+	for i, catalog := range spec.Catalogs {
+		if err := validateSpecName(catalog.Name, catalogs); err != nil {
+			return fmt.Errorf("catalogs[%d]: %w", i, err)
+		}
+		databases := make(map[string]bool)
+		for j, db := range catalog.Databases {
+			if err := validateSpecName(db.Name, databases); err != nil {
+				return fmt.Errorf("catalog %s: databases[%d]: %w", catalog.Name, j, err)
+			}
+			dbPath := catalog.Name + "/" + db.Name
+			// Tables and volumes share the namespace of the database
+			children := make(map[string]bool)
+			for k, table := range db.Tables {
+				if err := validateSpecName(table.Name, children); err != nil {
+					return fmt.Errorf("database %s: tables[%d]: %w", dbPath, k, err)
+				}
+				if len(table.Columns) == 0 {
+					return fmt.Errorf("table %s/%s: columns are required", dbPath, table.Name)
+				}
+				columns := make(map[string]bool)
+				for l, column := range table.Columns {
+					if err := validateSpecName(column.Name, columns); err != nil {
+						return fmt.Errorf("table %s/%s: columns[%d]: %w", dbPath, table.Name, l, err)
+					}
+				}
+			}
+			for k, volume := range db.Volumes {
+				if err := validateSpecName(volume.Name, children); err != nil {
+					return fmt.Errorf("database %s: volumes[%d]: %w", dbPath, k, err)
+				}
+				for _, folder := range volume.Folders {
+					if _, err := splitResolvePath(folder, "/"); err != nil {
+						return fmt.Errorf("volume %s/%s: folder %q: %w", dbPath, volume.Name, folder, err)
+					}
+				}
+			}
+		}
+	}
+
+	workflows := make(map[string]bool)
+	for i, workflow := range spec.Workflows {
+		if err := validateSpecName(workflow.Name, workflows); err != nil {
+			return fmt.Errorf("workflows[%d]: %w", i, err)
+		}
+		if len(workflow.SourceVolumes) == 0 {
+			return fmt.Errorf("workflow %s: source_volumes are required", workflow.Name)
+		}
+		if workflow.TargetVolume == "" {
+			return fmt.Errorf("workflow %s: target_volume is required", workflow.Name)
+		}
+		for _, volume := range append(slices.Clone(workflow.SourceVolumes), workflow.TargetVolume) {
+			if segments, err := splitResolvePath(volume, "/"); err != nil || len(segments) != 3 {
+				return fmt.Errorf("workflow %s: volume %q is not a catalog/database/volume path", workflow.Name, volume)
+			}
+		}
+	}
+
+	knowledge := make(map[string]bool)
+	for i := range spec.Knowledge {
+		record := &spec.Knowledge[i]
+		if err := validateKnowledgeRecord(record); err != nil {
+			return fmt.Errorf("knowledge[%d]: %w", i, err)
+		}
+		if err := validateSpecName(record.Type+"/"+record.Key, knowledge); err != nil {
+			return fmt.Errorf("knowledge[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validateSpecName checks that name is set and not in seen, and adds it.
+func validateSpecName(name string, seen map[string]bool) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if seen[name] {
+		return fmt.Errorf("duplicate name %s", name)
+	}
+	seen[name] = true
+	return nil
+}
+
+// applyState holds the IDs of the objects of a spec, by path. It is filled with the
+// existing objects while planning and with the created ones while applying, so that
+// a change can refer to the objects created by the changes before it.
+type applyState struct {
+	catalogs  map[string]CatalogID  // By name
+	databases map[string]DatabaseID // By "catalog/database"
+	volumes   map[string]VolumeID   // By "catalog/database/volume"
+	folders   map[string]FileID     // By "catalog/database/volume/folder/path"
+}
+
+// Levels of the deletions of a plan, applied in this order
+const (
+	deleteKnowledge = iota
+	deleteWorkflows
+	deleteFolders
+	deleteDatabaseChildren
+	deleteDatabases
+	deleteLevels
+)
+
+// specPlanner computes the changes of Apply.
+type specPlanner struct {
+	c       *SDKClient
+	opts    []CallOption
+	prune   bool
+	state   *applyState
+	changes []Change
+	deletes [deleteLevels][]Change
+	// newVolumes are the paths of the volumes the plan creates
+	newVolumes map[string]bool
+	// managedVolumes are the IDs of the existing volumes of the catalogs of the spec
+	managedVolumes map[string]bool
+}
+
+func (p *specPlanner) plan(ctx context.Context, spec *Spec) error {
+	if len(spec.Catalogs) > 0 {
+		resp, err := p.c.raw.ListCatalogs(ctx, p.opts...)
+		if err != nil {
+			return err
+		}
+		actual := make(map[string]*CatalogResponse)
+		for i := range resp.List {
+			actual[resp.List[i].CatalogName] = &resp.List[i]
+		}
+		for _, catalog := range spec.Catalogs {
+			if err := p.planCatalog(ctx, catalog, actual[catalog.Name]); err != nil {
+				return err
+			}
+		}
+	}
+	if len(spec.Workflows) > 0 || p.prune {
+		if err := p.planWorkflows(ctx, spec.Workflows); err != nil {
+			return err
+		}
+	}
+	return p.planKnowledge(ctx, spec.Knowledge)
+}
+
+func (p *specPlanner) create(kind, path string, run func(ctx context.Context, s *applyState) error) {
+	p.changes = append(p.changes, Change{Action: ChangeCreate, Kind: kind, Path: path, run: run})
+}
+
+func (p *specPlanner) update(kind, path string, details []string, run func(ctx context.Context, s *applyState) error) {
+	p.changes = append(p.changes, Change{Action: ChangeUpdate, Kind: kind, Path: path, Details: details, run: run})
+}
+
+func (p *specPlanner) delete(level int, kind, path string, run func(ctx context.Context, s *applyState) error) {
+	p.deletes[level] = append(p.deletes[level], Change{Action: ChangeDelete, Kind: kind, Path: path, run: run})
+}
+
+// planCatalog plans the changes of a catalog; cur is the existing catalog, or nil.
+func (p *specPlanner) planCatalog(ctx context.Context, spec CatalogSpec, cur *CatalogResponse) error {
+	raw := p.c.raw
+	if cur == nil {
+		p.create("catalog", spec.Name, func(ctx context.Context, s *applyState) error {
+			resp, err := raw.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: spec.Name, Comment: spec.Comment}, p.opts...)
+			if err != nil {
+				return err
+			}
+			s.catalogs[spec.Name] = resp.CatalogID
+			return nil
+		})
+		for _, db := range spec.Databases {
+			if err := p.planDatabase(ctx, spec.Name, db, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p.state.catalogs[spec.Name] = cur.CatalogID
+	if spec.Comment != "" && spec.Comment != cur.Comment {
+		p.update("catalog", spec.Name, []string{commentDetail(cur.Comment, spec.Comment)}, func(ctx context.Context, s *applyState) error {
+			_, err := raw.UpdateCatalog(ctx, &CatalogUpdateRequest{CatalogID: cur.CatalogID, CatalogName: cur.CatalogName, Comment: spec.Comment}, p.opts...)
+			return err
+		})
+	}
+	resp, err := raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: cur.CatalogID}, p.opts...)
+	if err != nil {
+		return err
+	}
+	wanted := make(map[string]bool)
+	for _, db := range spec.Databases {
+		wanted[db.Name] = true
+		var actual *DatabaseResponse
+		for i := range resp.List {
+			if resp.List[i].DatabaseName == db.Name {
+				actual = &resp.List[i]
+				break
+			}
+		}
+		if err := p.planDatabase(ctx, spec.Name, db, actual); err != nil {
+			return err
+		}
+	}
+	for _, db := range resp.List {
+		if !p.prune || wanted[db.DatabaseName] || db.Reserved {
+			continue
+		}
+		if err := p.listManagedVolumes(ctx, db.DatabaseID); err != nil {
+			return err
+		}
+		id := db.DatabaseID
+		p.delete(deleteDatabases, "database", spec.Name+"/"+db.DatabaseName, func(ctx context.Context, s *applyState) error {
+			_, err := raw.DeleteDatabase(ctx, &DatabaseDeleteRequest{DatabaseID: id}, p.opts...)
+			return err
+		})
+	}
+	return nil
+}
+
+// listManagedVolumes adds the volumes of a database deleted by the plan to the managed
+// volumes, so that the workflows reading from them are pruned too.
+func (p *specPlanner) listManagedVolumes(ctx context.Context, databaseID DatabaseID) error {
+	resp, err := p.c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID}, p.opts...)
+	if err != nil {
+		return err
+	}
+	for _, child := range resp.List {
+		if strings.EqualFold(child.Typ, "volume") {
+			p.managedVolumes[child.ID] = true
+		}
+	}
+	return nil
+}
+
+// planDatabase plans the changes of a database; cur is the existing database, or nil.
+func (p *specPlanner) planDatabase(ctx context.Context, catalogName string, spec DatabaseSpec, cur *DatabaseResponse) error {
+	raw := p.c.raw
+	dbPath := catalogName + "/" + spec.Name
+	if cur == nil {
+		p.create("database", dbPath, func(ctx context.Context, s *applyState) error {
+			resp, err := raw.CreateDatabase(ctx, &DatabaseCreateRequest{DatabaseName: spec.Name, CatalogID: s.catalogs[catalogName], Comment: spec.Comment}, p.opts...)
+			if err != nil {
+				return err
+			}
+			s.databases[dbPath] = resp.DatabaseID
+			return nil
+		})
+		for _, table := range spec.Tables {
+			if err := p.planTable(ctx, dbPath, table, nil); err != nil {
+				return err
+			}
+		}
+		for _, volume := range spec.Volumes {
+			if err := p.planVolume(ctx, dbPath, volume, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	p.state.databases[dbPath] = cur.DatabaseID
+	if spec.Comment != "" && spec.Comment != cur.Comment {
+		p.update("database", dbPath, []string{commentDetail(cur.Comment, spec.Comment)}, func(ctx context.Context, s *applyState) error {
+			_, err := raw.UpdateDatabase(ctx, &DatabaseUpdateRequest{DatabaseID: cur.DatabaseID, Comment: spec.Comment}, p.opts...)
+			return err
+		})
+	}
+	resp, err := raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: cur.DatabaseID}, p.opts...)
+	if err != nil {
+		return err
+	}
+	tables := make(map[string]*DatabaseChildrenResponse)
+	volumes := make(map[string]*DatabaseChildrenResponse)
+	for i := range resp.List {
+		child := &resp.List[i]
+		switch strings.ToLower(child.Typ) {
+		case "table":
+			tables[child.Name] = child
+		case "volume":
+			volumes[child.Name] = child
+			p.managedVolumes[child.ID] = true
+		}
+	}
+	for _, table := range spec.Tables {
+		if err := p.planTable(ctx, dbPath, table, tables[table.Name]); err != nil {
+			return err
+		}
+		delete(tables, table.Name)
+	}
+	for _, volume := range spec.Volumes {
+		if err := p.planVolume(ctx, dbPath, volume, volumes[volume.Name]); err != nil {
+			return err
+		}
+		delete(volumes, volume.Name)
+	}
+	if !p.prune {
+		return nil
+	}
+	for _, name := range sortedKeys(tables) {
+		child := tables[name]
+		if child.Reserved {
+			continue
+		}
+		id, err := strconv.ParseInt(child.ID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid id %q of table %s/%s: %w", child.ID, dbPath, name, err)
+		}
+		p.delete(deleteDatabaseChildren, "table", dbPath+"/"+name, func(ctx context.Context, s *applyState) error {
+			_, err := raw.DeleteTable(ctx, &TableDeleteRequest{TableID: TableID(id)}, p.opts...)
+			return err
+		})
+	}
+	for _, name := range sortedKeys(volumes) {
+		child := volumes[name]
+		if child.Reserved {
+			continue
+		}
+		p.delete(deleteDatabaseChildren, "volume", dbPath+"/"+name, func(ctx context.Context, s *applyState) error {
+			_, err := raw.DeleteVolume(ctx, &VolumeDeleteRequest{VolumeID: VolumeID(child.ID)}, p.opts...)
+			return err
+		})
+	}
+	return nil
+}
+
+// planTable plans the changes of a table; cur is the existing table, or nil.
+func (p *specPlanner) planTable(ctx context.Context, dbPath string, spec TableSpec, cur *DatabaseChildrenResponse) error {
+	raw := p.c.raw
+	tablePath := dbPath + "/" + spec.Name
+	if cur == nil {
+		p.create("table", tablePath, func(ctx context.Context, s *applyState) error {
+			_, err := raw.CreateTable(ctx, &TableCreateRequest{DatabaseID: s.databases[dbPath], Name: spec.Name, Columns: spec.Columns, Comment: spec.Comment}, p.opts...)
+			return err
+		})
+		return nil
+	}
+
+	id, err := strconv.ParseInt(cur.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid id %q of table %s: %w", cur.ID, tablePath, err)
+	}
+	info, err := raw.GetTable(ctx, &TableInfoRequest{TableID: TableID(id)}, p.opts...)
+	if err != nil {
+		return err
+	}
+	req := &TableAlterRequest{TableID: TableID(id)}
+	var details []string
+	actual := make(map[string]Column)
+	for _, column := range info.Columns {
+		actual[column.Name] = column
+	}
+	for _, column := range spec.Columns {
+		old, ok := actual[column.Name]
+		delete(actual, column.Name)
+		switch {
+		case !ok:
+			req.AddColumns = append(req.AddColumns, column)
+			details = append(details, fmt.Sprintf("+ column %s %s", column.Name, column.Type))
+		case !strings.EqualFold(old.Type, column.Type) || old.Default != column.Default || old.Comment != column.Comment:
+			req.ModifyColumns = append(req.ModifyColumns, column)
+			details = append(details, fmt.Sprintf("~ column %s %s -> %s", column.Name, columnSummary(old), columnSummary(column)))
+		}
+	}
+	// Columns missing from the spec hold data: like other objects, they are only
+	// deleted when pruning
+	if p.prune {
+		for _, name := range sortedKeys(actual) {
+			req.DropColumns = append(req.DropColumns, name)
+			details = append(details, "- column "+name)
+		}
+	}
+	if len(details) > 0 {
+		p.update("table", tablePath, details, func(ctx context.Context, s *applyState) error {
+			_, err := raw.AlterTable(ctx, req, p.opts...)
+			return err
+		})
+	}
+	return nil
+}
+
+// planVolume plans the changes of a volume and its folders; cur is the existing
+// volume, or nil.
+func (p *specPlanner) planVolume(ctx context.Context, dbPath string, spec VolumeSpec, cur *DatabaseChildrenResponse) error {
+	raw := p.c.raw
+	volumePath := dbPath + "/" + spec.Name
+	existing := make(map[string]FileID)
+	if cur == nil {
+		p.newVolumes[volumePath] = true
+		p.create("volume", volumePath, func(ctx context.Context, s *applyState) error {
+			resp, err := raw.CreateVolume(ctx, &VolumeCreateRequest{Name: spec.Name, DatabaseID: s.databases[dbPath], Comment: spec.Comment}, p.opts...)
+			if err != nil {
+				return err
+			}
+			s.volumes[volumePath] = resp.VolumeID
+			return nil
+		})
+	} else {
+		volumeID := VolumeID(cur.ID)
+		p.state.volumes[volumePath] = volumeID
+		if spec.Comment != "" && spec.Comment != cur.Comment {
+			p.update("volume", volumePath, []string{commentDetail(cur.Comment, spec.Comment)}, func(ctx context.Context, s *applyState) error {
+				_, err := raw.UpdateVolume(ctx, &VolumeUpdateRequest{VolumeID: volumeID, Name: cur.Name, Comment: spec.Comment}, p.opts...)
+				return err
+			})
+		}
+		files := make(map[string]bool)
+		err := p.c.WalkVolume(ctx, volumeID, func(entry VolumeChildrenResponse, entryPath string) error {
+			if entry.IsFolder() {
+				existing[entryPath] = FileID(entry.ID)
+			} else {
+				files[entryPath] = true
+			}
+			return nil
+		}, p.opts...)
+		if err != nil {
+			return err
+		}
+		for _, folder := range spec.Folders {
+			for f := strings.Trim(folder, "/"); f != "."; f = path.Dir(f) {
+				if files[f] {
+					return fmt.Errorf("folder %s/%s is a file", volumePath, f)
+				}
+			}
+		}
+	}
+
+	// The folders of the spec and their parents, parents first
+	wanted := make(map[string]bool)
+	for _, folder := range spec.Folders {
+		for f := strings.Trim(folder, "/"); f != "."; f = path.Dir(f) {
+			wanted[f] = true
+		}
+	}
+	for _, folder := range sortedKeys(wanted) {
+		folderPath := volumePath + "/" + folder
+		if id, ok := existing[folder]; ok {
+			p.state.folders[folderPath] = id
+			continue
+		}
+		p.create("folder", folderPath, func(ctx context.Context, s *applyState) error {
+			var parentID FileID
+			if dir := path.Dir(folder); dir != "." {
+				parentID = s.folders[volumePath+"/"+dir]
+			}
+			resp, err := raw.CreateFolder(ctx, &FolderCreateRequest{Name: path.Base(folder), VolumeID: s.volumes[volumePath], ParentID: parentID}, p.opts...)
+			if err != nil {
+				return err
+			}
+			s.folders[folderPath] = resp.FolderID
+			return nil
+		})
+	}
+	if !p.prune {
+		return nil
+	}
+	for _, folder := range sortedKeys(existing) {
+		// Deleting a folder deletes its content, so only the outermost extra folders are deleted
+		if dir := path.Dir(folder); wanted[folder] || (dir != "." && !wanted[dir]) {
+			continue
+		}
+		id := existing[folder]
+		p.delete(deleteFolders, "folder", volumePath+"/"+folder, func(ctx context.Context, s *applyState) error {
+			_, err := raw.DeleteFolder(ctx, &FolderDeleteRequest{FolderID: id}, p.opts...)
+			return err
+		})
+	}
+	return nil
+}
+
+// planWorkflows plans the changes of the workflows of the spec.
+func (p *specPlanner) planWorkflows(ctx context.Context, specs []WorkflowSpec) error {
+	raw := p.c.raw
+	var all []WorkflowResponse
+	for page := 1; ; page++ {
+		if err := raw.checkPageLimit(page); err != nil {
+			return err
+		}
+		resp, err := raw.ListWorkflows(ctx, &WorkflowListRequest{Page: page, PageSize: listPageSize}, p.opts...)
+		if err != nil {
+			return err
+		}
+		all = append(all, resp.Workflows...)
+		if len(resp.Workflows) == 0 || len(all) >= resp.Total {
+			break
+		}
+	}
+
+	wanted := make(map[string]bool)
+	for _, spec := range specs {
+		wanted[spec.Name] = true
+		for _, volume := range append(slices.Clone(spec.SourceVolumes), spec.TargetVolume) {
+			if err := p.resolveVolume(ctx, volume); err != nil {
+				return fmt.Errorf("workflow %s: %w", spec.Name, err)
+			}
+		}
+		var cur *WorkflowResponse
+		for i := range all {
+			if all[i].Name == spec.Name {
+				cur = &all[i]
+				break
+			}
+		}
+		if cur == nil {
+			p.create("workflow", spec.Name, func(ctx context.Context, s *applyState) error {
+				_, err := raw.CreateWorkflow(ctx, spec.metadata(s), p.opts...)
+				return err
+			})
+			continue
+		}
+		details := p.workflowDetails(spec, cur)
+		if spec.Workflow != nil {
+			graph, err := p.workflowGraphDetails(ctx, spec, cur.ID)
+			if err != nil {
+				return fmt.Errorf("workflow %s: %w", spec.Name, err)
+			}
+			details = append(details, graph...)
+		}
+		if len(details) > 0 {
+			id := cur.ID
+			p.update("workflow", spec.Name, details, func(ctx context.Context, s *applyState) error {
+				_, err := raw.UpdateWorkflow(ctx, id, spec.metadata(s), p.opts...)
+				return err
+			})
+		}
+	}
+
+	if !p.prune {
+		return nil
+	}
+	for _, workflow := range all {
+		if wanted[workflow.Name] || !slices.ContainsFunc(parseIDList(workflow.SourceVolumeIDs), func(id string) bool { return p.managedVolumes[id] }) {
+			continue
+		}
+		id := workflow.ID
+		p.delete(deleteWorkflows, "workflow", workflow.Name, func(ctx context.Context, s *applyState) error {
+			_, err := raw.DeleteWorkflow(ctx, id, p.opts...)
+			return err
+		})
+	}
+	return nil
+}
+
+// resolveVolume checks that the volume at volumePath exists or is created by the plan.
+func (p *specPlanner) resolveVolume(ctx context.Context, volumePath string) error {
+	volumePath = strings.Trim(volumePath, "/")
+	if _, ok := p.state.volumes[volumePath]; ok || p.newVolumes[volumePath] {
+		return nil
+	}
+	resolved, err := p.c.Resolve(ctx, volumePath, p.opts...)
+	if err != nil {
+		return err
+	}
+	p.state.volumes[volumePath] = resolved.VolumeID
+	return nil
+}
+
+// workflowDetails returns the differences between a workflow and its spec.
+func (p *specPlanner) workflowDetails(spec WorkflowSpec, cur *WorkflowResponse) []string {
+	var details []string
+	var sources []string
+	known := true
+	for _, volume := range spec.SourceVolumes {
+		volume = strings.Trim(volume, "/")
+		known = known && !p.newVolumes[volume]
+		sources = append(sources, string(p.state.volumes[volume]))
+	}
+	if !known || !sameStrings(sources, parseIDList(cur.SourceVolumeIDs)) {
+		details = append(details, "source_volumes: "+strings.Join(spec.SourceVolumes, ", "))
+	}
+	target := strings.Trim(spec.TargetVolume, "/")
+	if p.newVolumes[target] || string(p.state.volumes[target]) != cur.TargetVolumeID {
+		details = append(details, "target_volume: "+spec.TargetVolume)
+	}
+	fileTypes := make([]string, len(spec.FileTypes))
+	for i, fileType := range spec.FileTypes {
+		fileTypes[i] = strconv.Itoa(fileType)
+	}
+	if !sameStrings(fileTypes, parseIDList(cur.FileTypes)) {
+		details = append(details, fmt.Sprintf("file_types: [%s] -> [%s]", cur.FileTypes, strings.Join(fileTypes, ",")))
+	}
+	if mode := spec.ProcessMode; mode != nil && (mode.Interval != cur.FlowInterval || mode.Offset != cur.FlowOffset) {
+		details = append(details, fmt.Sprintf("process_mode: interval %d offset %d -> interval %d offset %d",
+			cur.FlowInterval, cur.FlowOffset, mode.Interval, mode.Offset))
+	}
+	return details
+}
+
+// workflowGraphDetails returns the differences between the graph of the workflow id
+// and the one of its spec, one line of WorkflowDiff.Summary per difference.
+func (p *specPlanner) workflowGraphDetails(ctx context.Context, spec WorkflowSpec, id string) ([]string, error) {
+	cur, err := p.c.raw.GetWorkflow(ctx, id, p.opts...)
+	if err != nil {
+		return nil, err
+	}
+	var graph *CatalogWorkflow
+	if content := strings.TrimSpace(cur.Content); content != "" && content != "null" {
+		graph = &CatalogWorkflow{}
+		if err := json.Unmarshal([]byte(content), graph); err != nil {
+			return nil, fmt.Errorf("decode workflow definition: %w", err)
+		}
+	}
+	diff := DiffWorkflows(&WorkflowMetadata{Workflow: graph}, &WorkflowMetadata{Workflow: spec.Workflow})
+	if diff.IsEmpty() {
+		return nil, nil
+	}
+	return strings.Split(strings.TrimSuffix(diff.Summary(), "\n"), "\n"), nil
+}
+
+// metadata returns the workflow to create or update, with the volume IDs in s.
+func (spec WorkflowSpec) metadata(s *applyState) *WorkflowMetadata {
+	sources := make([]string, len(spec.SourceVolumes))
+	for i, volume := range spec.SourceVolumes {
+		sources[i] = string(s.volumes[strings.Trim(volume, "/")])
+	}
+	mode := spec.ProcessMode
+	if mode == nil {
+		mode = &ProcessMode{}
+	}
+	return &WorkflowMetadata{
+		Name:              spec.Name,
+		SourceVolumeNames: []string{},
+		SourceVolumeIDs:   sources,
+		TargetVolumeID:    string(s.volumes[strings.Trim(spec.TargetVolume, "/")]),
+		ProcessMode:       mode,
+		FileTypes:         spec.FileTypes,
+		Workflow:          spec.Workflow,
+	}
+}
+
+// planKnowledge plans the changes of the knowledge entries of the spec.
+func (p *specPlanner) planKnowledge(ctx context.Context, records []KnowledgeRecord) error {
+	raw := p.c.raw
+	byType := make(map[string][]KnowledgeRecord)
+	for _, record := range records {
+		byType[record.Type] = append(byType[record.Type], record)
+	}
+	for _, knowledgeType := range sortedKeys(byType) {
+		entries, err := p.c.listAllKnowledge(ctx, knowledgeType, p.opts...)
+		if err != nil {
+			return err
+		}
+		matched := make(map[Nl2SqlKnowledgeID]bool)
+		for _, record := range byType[knowledgeType] {
+			recordPath := record.Type + "/" + record.Key
+			var cur *Nl2SqlKnowledgeResponse
+			for _, entry := range entries {
+				if entry.Key == record.Key && !matched[entry.ID] {
+					cur = entry
+					break
+				}
+			}
+			if cur == nil {
+				p.create("knowledge", recordPath, func(ctx context.Context, s *applyState) error {
+					_, err := raw.CreateKnowledge(ctx, &NL2SQLKnowledgeCreateRequest{
+						Type:            record.Type,
+						Key:             record.Key,
+						Value:           record.Value,
+						AssociateTables: record.AssociateTables,
+						ExplanationType: record.ExplanationType,
+					}, p.opts...)
+					return err
+				})
+				continue
+			}
+			matched[cur.ID] = true
+			var details []string
+			if !slices.Equal(cur.Value, record.Value) {
+				details = append(details, "value")
+			}
+			if !slices.Equal(cur.AssociateTables, record.AssociateTables) && len(cur.AssociateTables)+len(record.AssociateTables) > 0 {
+				details = append(details, "associate_tables: "+strings.Join(record.AssociateTables, ", "))
+			}
+			if len(details) == 0 {
+				continue
+			}
+			id := cur.ID
+			p.update("knowledge", recordPath, details, func(ctx context.Context, s *applyState) error {
+				_, err := raw.UpdateKnowledge(ctx, &NL2SQLKnowledgeUpdateRequest{
+					ID:              id,
+					Type:            record.Type,
+					Key:             record.Key,
+					Value:           record.Value,
+					AssociateTables: record.AssociateTables,
+					ExplanationType: record.ExplanationType,
+				}, p.opts...)
+				return err
+			})
+		}
+		if !p.prune {
+			continue
+		}
+		for _, entry := range entries {
+			if matched[entry.ID] {
+				continue
+			}
+			id := entry.ID
+			p.delete(deleteKnowledge, "knowledge", entry.Type+"/"+entry.Key, func(ctx context.Context, s *applyState) error {
+				_, err := raw.DeleteKnowledge(ctx, &NL2SQLKnowledgeDeleteRequest{ID: id}, p.opts...)
+				return err
+			})
+		}
+	}
+	return nil
+}
+
+func commentDetail(from, to string) string {
+	return fmt.Sprintf("comment: %q -> %q", from, to)
+}
+
+func columnSummary(column Column) string {
+	s := column.Type
+	if column.Default != "" {
+		s += " default " + column.Default
+	}
+	if column.Comment != "" {
+		s += fmt.Sprintf(" comment %q", column.Comment)
+	}
+	return s
+}
+
+// sameStrings reports whether a and b hold the same strings, in any order.
+func sameStrings(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	sort.Strings(a)
+	sort.Strings(b)
+	return slices.Equal(a, b)
+}
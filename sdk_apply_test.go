@@ -0,0 +1,311 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testApplySpec = `
+catalogs:
+  - name: sales
+    comment: Sales data
+    databases:
+      - name: orders
+        tables:
+          - name: line_items
+            columns:
+              - {name: id, type: int, is_pk: true}
+              - {name: note, type: varchar(64)}
+              - {name: sku, type: varchar(32)}
+        volumes:
+          - name: raw
+            folders: [2024/q1/a]
+          - name: processed
+            folders: [out]
+  - name: hr
+    databases:
+      - name: people
+workflows:
+  - name: ingest
+    source_volumes: [sales/orders/raw]
+    target_volume: sales/orders/processed
+    file_types: [1, 2]
+knowledge:
+  - {type: glossary, key: GMV, value: [gross merchandise value]}
+  - {type: glossary, key: AOV, value: [average order value]}
+`
+
+// newApplyFake serves a catalog "sales" with a database "orders" that partly matches
+// testApplySpec, and records the calls that change objects.
+func newApplyFake(t *testing.T) (*SDKClient, *[]string) {
+	var calls []string
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(r.Body)
+			require.NoError(t, err)
+		}
+		var req map[string]interface{}
+		if len(body) > 0 {
+			require.NoError(t, json.Unmarshal(body, &req))
+		}
+		switch r.URL.Path {
+		case "/catalog/list":
+			return envelopeResponse(`{"list":[{"id":1,"name":"sales","description":"old"},{"id":2,"name":"other"}]}`), nil
+		case "/catalog/database/list":
+			return envelopeResponse(`{"list":[{"id":10,"name":"orders"},{"id":11,"name":"legacy"},{"id":12,"name":"system","reserved":true}]}`), nil
+		case "/catalog/database/children":
+			if req["id"] == float64(11) {
+				return envelopeResponse(`{"list":[{"id":"v9","name":"dump","type":"volume"}]}`), nil
+			}
+			return envelopeResponse(`{"list":[
+				{"id":"100","name":"line_items","type":"table"},
+				{"id":"101","name":"old_table","type":"table"},
+				{"id":"v1","name":"raw","type":"volume"}]}`), nil
+		case "/catalog/table/info":
+			return envelopeResponse(`{"name":"line_items","columns":[
+				{"name":"id","type":"INT","is_pk":true},
+				{"name":"note","type":"varchar(10)"},
+				{"name":"legacy","type":"int"}]}`), nil
+		case "/catalog/file/list":
+			var listReq FileListRequest
+			require.NoError(t, json.Unmarshal(body, &listReq))
+			switch listReq.Filters[1].Values[0] {
+			case "":
+				return envelopeResponse(`{"total":3,"list":[
+					{"id":"d1","name":"2024","file_type":"dir"},
+					{"id":"d2","name":"tmp","file_type":"dir"},
+					{"id":"f1","name":"README.md","file_type":"md"}]}`), nil
+			case "d1":
+				return envelopeResponse(`{"total":1,"list":[{"id":"d3","name":"q1","file_type":"dir"}]}`), nil
+			case "d2":
+				return envelopeResponse(`{"total":1,"list":[{"id":"d4","name":"old","file_type":"dir"}]}`), nil
+			}
+			return envelopeResponse(`{"total":0,"list":[]}`), nil
+		case "/v1/genai/workflow":
+			if r.Method == http.MethodGet {
+				return envelopeResponse(`{"total":3,"workflows":[
+					{"id":"w1","name":"ingest","source_volume_ids":"v1","target_volume_id":"v2","file_types":"1,2"},
+					{"id":"w2","name":"dump-old","source_volume_ids":"[\"v9\"]"},
+					{"id":"w3","name":"elsewhere","source_volume_ids":"vx"}]}`), nil
+			}
+		case "/catalog/nl2sql_knowledge/list":
+			return envelopeResponse(`{"total":2,"list":[
+				{"id":1,"type":"glossary","key":"GMV","value":["gmv"]},
+				{"id":2,"type":"glossary","key":"ARPU","value":["arpu"]}]}`), nil
+		}
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+		switch r.URL.Path {
+		case "/catalog/create":
+			return envelopeResponse(`{"id":3}`), nil
+		case "/catalog/database/create":
+			return envelopeResponse(`{"id":30}`), nil
+		case "/catalog/volume/create":
+			return envelopeResponse(`{"id":"v2"}`), nil
+		case "/catalog/folder/create":
+			return envelopeResponse(`{"id":"n-` + req["name"].(string) + `"}`), nil
+		case "/v1/genai/workflow/w1":
+			return envelopeResponse(`{"id":"w1"}`), nil
+		}
+		return envelopeResponse(`{}`), nil
+	}))
+	return client, &calls
+}
+
+func TestApply_Plan(t *testing.T) {
+	t.Parallel()
+	client, calls := newApplyFake(t)
+	spec, err := ParseSpec([]byte(testApplySpec))
+	require.NoError(t, err)
+
+	plan, err := client.Apply(context.Background(), spec, ApplyOptions{DryRun: true, Prune: true})
+	require.NoError(t, err)
+	require.Empty(t, *calls)
+	require.Equal(t, strings.TrimSpace(`
+~ catalog sales
+    comment: "old" -> "Sales data"
+~ table sales/orders/line_items
+    ~ column note varchar(10) -> varchar(64)
+    + column sku varchar(32)
+    - column legacy
++ folder sales/orders/raw/2024/q1/a
++ volume sales/orders/processed
++ folder sales/orders/processed/out
++ catalog hr
++ database hr/people
+~ workflow ingest
+    target_volume: sales/orders/processed
+~ knowledge glossary/GMV
+    value
++ knowledge glossary/AOV
+- knowledge glossary/ARPU
+- workflow dump-old
+- folder sales/orders/raw/tmp
+- table sales/orders/old_table
+- database sales/legacy
+Plan: 6 to create, 4 to update, 5 to delete.`), plan.String())
+	for _, change := range plan.Changes {
+		require.False(t, change.Applied)
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+	client, calls := newApplyFake(t)
+	spec, err := ParseSpec([]byte(testApplySpec))
+	require.NoError(t, err)
+
+	plan, err := client.Apply(context.Background(), spec, ApplyOptions{})
+	require.NoError(t, err)
+	for _, change := range plan.Changes {
+		require.True(t, change.Applied)
+		require.NotEqual(t, ChangeDelete, change.Action)
+	}
+	require.Equal(t, []string{
+		`POST /catalog/update {"id":1,"name":"sales","description":"Sales data"}`,
+		`POST /catalog/table/alter {"id":100,"add_columns":[{"name":"sku","type":"varchar(32)","is_pk":false,"default":"","comment":""}],"modify_columns":[{"name":"note","type":"varchar(64)","is_pk":false,"default":"","comment":""}]}`,
+		`POST /catalog/folder/create {"name":"a","volume_id":"v1","parent_id":"d3"}`,
+		`POST /catalog/volume/create {"name":"processed","database_id":10,"description":""}`,
+		`POST /catalog/folder/create {"name":"out","volume_id":"v2","parent_id":""}`,
+		`POST /catalog/create {"name":"hr","description":""}`,
+		`POST /catalog/database/create {"name":"people","description":"","catalog_id":3}`,
+		`PUT /v1/genai/workflow/w1 {"name":"ingest","source_volume_names":[],"source_volume_ids":["v1"],"target_volume_id":"v2","process_mode":{"interval":0,"offset":0},"file_types":[1,2]}`,
+		`POST /catalog/nl2sql_knowledge/update {"id":1,"knowledge_type":"glossary","knowledge_key":"GMV","knowledge_value":["gross merchandise value"],"embedding":null,"associate_tables":null,"explanation_type":""}`,
+		`POST /catalog/nl2sql_knowledge/create {"knowledge_type":"glossary","knowledge_key":"AOV","knowledge_value":["average order value"],"embedding":null,"associate_tables":null,"explanation_type":""}`,
+	}, *calls)
+}
+
+func TestApply_PruneDropsColumns(t *testing.T) {
+	t.Parallel()
+	spec, err := ParseSpec([]byte(testApplySpec))
+	require.NoError(t, err)
+
+	// Without Prune, the column "legacy" missing from the spec is left alone
+	client, _ := newApplyFake(t)
+	plan, err := client.Apply(context.Background(), spec, ApplyOptions{DryRun: true})
+	require.NoError(t, err)
+	require.NotContains(t, plan.String(), "legacy")
+
+	client, calls := newApplyFake(t)
+	_, err = client.Apply(context.Background(), spec, ApplyOptions{Prune: true})
+	require.NoError(t, err)
+	require.Contains(t, *calls, `POST /catalog/table/alter {"id":100,"add_columns":[{"name":"sku","type":"varchar(32)","is_pk":false,"default":"","comment":""}],"drop_columns":["legacy"],"modify_columns":[{"name":"note","type":"varchar(64)","is_pk":false,"default":"","comment":""}]}`)
+}
+
+func TestApply_StopsAtFailure(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/list":
+			return envelopeResponse(`{"list":[]}`), nil
+		case "/catalog/create":
+			return envelopeResponse(`{"id":3}`), nil
+		}
+		return errorEnvelopeResponse("ErrPermissionDenied", "denied"), nil
+	}))
+	spec := &Spec{Catalogs: []CatalogSpec{{Name: "hr", Databases: []DatabaseSpec{{Name: "people"}}}}}
+	plan, err := client.Apply(context.Background(), spec, ApplyOptions{})
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.ErrorContains(t, err, "failed to create database hr/people")
+	require.True(t, plan.Changes[0].Applied)
+	require.False(t, plan.Changes[1].Applied)
+}
+
+func TestApply_NoChanges(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newFakeClient(nil))
+	plan, err := client.Apply(context.Background(), &Spec{}, ApplyOptions{})
+	require.NoError(t, err)
+	require.True(t, plan.IsEmpty())
+	require.Equal(t, "No changes.", plan.String())
+}
+
+func TestParseSpec(t *testing.T) {
+	t.Parallel()
+	spec, err := ParseSpec([]byte(`{"catalogs":[{"name":"sales","databases":[{"name":"orders"}]}]}`))
+	require.NoError(t, err)
+	require.Equal(t, &Spec{Catalogs: []CatalogSpec{{Name: "sales", Databases: []DatabaseSpec{{Name: "orders"}}}}}, spec)
+
+	spec, err = ParseSpec(nil)
+	require.NoError(t, err)
+	require.Equal(t, &Spec{}, spec)
+
+	_, err = ParseSpec([]byte("catalogs:\n  - name: sales\n    databse: []\n"))
+	require.ErrorContains(t, err, `unknown field "databse"`)
+	_, err = ParseSpec([]byte("catalogs: [unclosed"))
+	require.ErrorContains(t, err, "failed to parse spec")
+}
+
+func TestApply_InvalidSpec(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newFakeClient(nil))
+	for spec, want := range map[*Spec]string{
+		nil: "spec is required",
+		{Catalogs: []CatalogSpec{{Name: "a"}, {Name: "a"}}}:                                                                                           "duplicate name a",
+		{Catalogs: []CatalogSpec{{Name: "a", Databases: []DatabaseSpec{{Name: "d", Tables: []TableSpec{{Name: "t"}}}}}}}:                              "table a/d/t: columns are required",
+		{Catalogs: []CatalogSpec{{Name: "a", Databases: []DatabaseSpec{{Name: "d", Volumes: []VolumeSpec{{Name: "v", Folders: []string{"x//y"}}}}}}}}: "empty name",
+		{Workflows: []WorkflowSpec{{Name: "w", SourceVolumes: []string{"a/b"}, TargetVolume: "a/b/c"}}}:                                               `volume "a/b" is not a catalog/database/volume path`,
+		{Knowledge: []KnowledgeRecord{{Type: "glossary", Key: "k"}}}:                                                                                  "knowledge[0]: value is required",
+	} {
+		_, err := client.Apply(context.Background(), spec, ApplyOptions{})
+		require.ErrorContains(t, err, want)
+	}
+}
+
+func TestApply_WorkflowGraphChanged(t *testing.T) {
+	t.Parallel()
+	var updates []string
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/list":
+			return envelopeResponse(`{"list":[{"id":1,"name":"sales"}]}`), nil
+		case "/catalog/database/list":
+			return envelopeResponse(`{"list":[{"id":10,"name":"orders"}]}`), nil
+		case "/catalog/database/children":
+			return envelopeResponse(`{"list":[{"id":"v1","name":"raw","type":"volume"},{"id":"v2","name":"processed","type":"volume"}]}`), nil
+		case "/v1/genai/workflow":
+			return envelopeResponse(`{"total":1,"workflows":[{"id":"w1","name":"ingest","source_volume_ids":"v1","target_volume_id":"v2"}]}`), nil
+		case "/v1/genai/workflow/w1":
+			if r.Method == http.MethodGet {
+				content, err := json.Marshal(`{"node":[{"id":"ChunkNode_1","type":"ChunkNode","init_parameters":{"ChunkSplitter":{"chunk_size":500}}}],"connections":[]}`)
+				require.NoError(t, err)
+				return envelopeResponse(`{"id":"w1","content":` + string(content) + `}`), nil
+			}
+			updates = append(updates, r.Method)
+			return envelopeResponse(`{"id":"w1"}`), nil
+		}
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		return nil, nil
+	}))
+	spec := func(chunkSize int) *Spec {
+		return &Spec{Workflows: []WorkflowSpec{{
+			Name:          "ingest",
+			SourceVolumes: []string{"sales/orders/raw"},
+			TargetVolume:  "sales/orders/processed",
+			Workflow: &CatalogWorkflow{Nodes: []CatalogWorkflowNode{{
+				ID:             "ChunkNode_1",
+				Type:           "ChunkNode",
+				InitParameters: map[string]map[string]interface{}{"ChunkSplitter": {"chunk_size": chunkSize}},
+			}}},
+		}}}
+	}
+
+	plan, err := client.Apply(context.Background(), spec(500), ApplyOptions{DryRun: true})
+	require.NoError(t, err)
+	require.True(t, plan.IsEmpty())
+
+	plan, err = client.Apply(context.Background(), spec(800), ApplyOptions{})
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimSpace(`
+~ workflow ingest
+    ~ param ChunkNode_1 ChunkSplitter.chunk_size: 500 -> 800
+Plan: 0 to create, 1 to update, 0 to delete.`), plan.String())
+	require.Equal(t, []string{http.MethodPut}, updates)
+}
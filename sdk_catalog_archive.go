@@ -0,0 +1,352 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// catalogArchiveVersion is the format version written by ExportCatalog.
+const catalogArchiveVersion = 1
+
+// CatalogArchive is the portable description of a catalog written by ExportCatalog
+// and read by ImportCatalog. It holds structure and metadata only: table rows and
+// file contents are not included.
+type CatalogArchive struct {
+	Version   int                `json:"version"`
+	Name      string             `json:"name"`
+	Comment   string             `json:"comment,omitempty"`
+	Databases []ArchivedDatabase `json:"databases"`
+}
+
+// ArchivedDatabase is a database of a CatalogArchive.
+type ArchivedDatabase struct {
+	Name    string           `json:"name"`
+	Comment string           `json:"comment,omitempty"`
+	Tables  []ArchivedTable  `json:"tables,omitempty"`
+	Volumes []ArchivedVolume `json:"volumes,omitempty"`
+}
+
+// ArchivedTable is the schema of a table of a CatalogArchive.
+type ArchivedTable struct {
+	Name    string   `json:"name"`
+	Comment string   `json:"comment,omitempty"`
+	Columns []Column `json:"columns"`
+}
+
+// ArchivedVolume is a volume of a CatalogArchive.
+type ArchivedVolume struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment,omitempty"`
+	// Folders are the slash-separated paths of the folders of the volume, parents
+	// before their children.
+	Folders []string `json:"folders,omitempty"`
+}
+
+// CatalogImportOptions controls ImportCatalog.
+type CatalogImportOptions struct {
+	// RenameTo creates the catalog under this name instead of the archived one.
+	RenameTo string
+	// SkipExisting reuses the catalog, databases, tables, volumes and folders that
+	// already exist under the same name instead of failing. Existing tables are left
+	// unchanged even if their schema differs from the archive.
+	SkipExisting bool
+}
+
+// CatalogImportResult reports the outcome of ImportCatalog.
+type CatalogImportResult struct {
+	CatalogID CatalogID
+	// CatalogExisted reports whether the catalog was reused rather than created.
+	CatalogExisted bool
+	// Created and Skipped list the slash-separated paths, relative to the catalog,
+	// of the objects that were created or already existed, e.g. "sales/orders".
+	Created []string
+	Skipped []string
+}
+
+// ExportCatalog writes a portable archive of a catalog to w: its databases, table
+// schemas, volumes and folder structure, with their comments. The archive is a
+// JSON-encoded CatalogArchive and can be restored with ImportCatalog, for example
+// to promote a catalog from a development to a production environment.
+//
+// Reserved databases, tables and volumes are managed by the service and are not
+// exported. Objects are sorted by name so that exports of the same catalog are
+// identical.
+//
+// Example:
+//
+//	f, err := os.Create("sales-catalog.json")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	if _, err := sdkClient.ExportCatalog(ctx, 123, f); err != nil {
+//		return err
+//	}
+func (c *SDKClient) ExportCatalog(ctx context.Context, catalogID CatalogID, w io.Writer, opts ...CallOption) (*CatalogArchive, error) {
+	if catalogID == 0 {
+		return nil, fmt.Errorf("catalog_id is required")
+	}
+	if w == nil {
+		return nil, fmt.Errorf("writer cannot be nil")
+	}
+	catalog, err := c.raw.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: catalogID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog: %w", err)
+	}
+	archive := &CatalogArchive{Version: catalogArchiveVersion, Name: catalog.CatalogName, Comment: catalog.Comment}
+
+	databases, err := c.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: catalogID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list databases: %w", err)
+	}
+	for _, db := range databases.List {
+		if db.Reserved {
+			continue
+		}
+		archived, err := c.exportDatabase(ctx, db, opts...)
+		if err != nil {
+			return nil, err
+		}
+		archive.Databases = append(archive.Databases, *archived)
+	}
+	sort.Slice(archive.Databases, func(i, j int) bool { return archive.Databases[i].Name < archive.Databases[j].Name })
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(archive); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return archive, nil
+}
+
+func (c *SDKClient) exportDatabase(ctx context.Context, db DatabaseResponse, opts ...CallOption) (*ArchivedDatabase, error) {
+	archived := &ArchivedDatabase{Name: db.DatabaseName, Comment: db.Comment}
+	children, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: db.DatabaseID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list database %q: %w", db.DatabaseName, err)
+	}
+	for _, child := range children.List {
+		if child.Reserved {
+			continue
+		}
+		switch {
+		case strings.EqualFold(child.Typ, "table"):
+			tableID, err := strconv.ParseInt(child.ID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("table %s/%s: invalid id %q", db.DatabaseName, child.Name, child.ID)
+			}
+			table, err := c.raw.GetTable(ctx, &TableInfoRequest{TableID: TableID(tableID)}, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get table %s/%s: %w", db.DatabaseName, child.Name, err)
+			}
+			archived.Tables = append(archived.Tables, ArchivedTable{Name: child.Name, Comment: table.Comment, Columns: table.Columns})
+
+		case strings.EqualFold(child.Typ, "volume"):
+			volume := ArchivedVolume{Name: child.Name, Comment: child.Comment}
+			err := c.WalkVolume(ctx, VolumeID(child.ID), func(entry VolumeChildrenResponse, p string) error {
+				if entry.IsFolder() {
+					volume.Folders = append(volume.Folders, p)
+				}
+				return nil
+			}, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to walk volume %s/%s: %w", db.DatabaseName, child.Name, err)
+			}
+			archived.Volumes = append(archived.Volumes, volume)
+		}
+	}
+	sort.Slice(archived.Tables, func(i, j int) bool { return archived.Tables[i].Name < archived.Tables[j].Name })
+	sort.Slice(archived.Volumes, func(i, j int) bool { return archived.Volumes[i].Name < archived.Volumes[j].Name })
+	return archived, nil
+}
+
+// ImportCatalog recreates a catalog from an archive written by ExportCatalog.
+//
+// Without SkipExisting, the import fails with an error matching ErrAlreadyExists as
+// soon as an object of the archive already exists. Objects created before a failure
+// are left in place and reported in the returned result; importing again with
+// SkipExisting resumes from where it stopped.
+//
+// Example:
+//
+//	f, err := os.Open("sales-catalog.json")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	result, err := sdkClient.ImportCatalog(ctx, f, sdk.CatalogImportOptions{
+//		RenameTo:     "sales-prod",
+//		SkipExisting: true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("created %d objects in catalog %d\n", len(result.Created), result.CatalogID)
+func (c *SDKClient) ImportCatalog(ctx context.Context, r io.Reader, importOpts CatalogImportOptions, opts ...CallOption) (*CatalogImportResult, error) {
+	opts = withoutIdempotencyKey(opts)
+	if r == nil {
+		return nil, fmt.Errorf("reader cannot be nil")
+	}
+	var archive CatalogArchive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if archive.Version != catalogArchiveVersion {
+		return nil, fmt.Errorf("unsupported catalog archive version %d", archive.Version)
+	}
+	name := archive.Name
+	if importOpts.RenameTo != "" {
+		name = importOpts.RenameTo
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("catalog name is required")
+	}
+
+	result := &CatalogImportResult{}
+	// exists records that the object at p already exists, or fails without SkipExisting.
+	exists := func(p string) error {
+		if !importOpts.SkipExisting {
+			return fmt.Errorf("%q already exists: %w", p, ErrAlreadyExists)
+		}
+		result.Skipped = append(result.Skipped, p)
+		return nil
+	}
+
+	catalogID, catalogExisted, err := c.findCatalogByName(ctx, name, opts...)
+	if err != nil {
+		return result, fmt.Errorf("failed to look up catalog: %w", err)
+	}
+	if catalogExisted {
+		if !importOpts.SkipExisting {
+			return result, fmt.Errorf("catalog %q already exists: %w", name, ErrAlreadyExists)
+		}
+		result.CatalogExisted = true
+	} else {
+		resp, err := c.raw.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: name, Comment: archive.Comment}, opts...)
+		if err != nil {
+			return result, fmt.Errorf("failed to create catalog: %w", err)
+		}
+		catalogID = resp.CatalogID
+	}
+	result.CatalogID = catalogID
+
+	for _, db := range archive.Databases {
+		var databaseID DatabaseID
+		var children map[string]DatabaseChildrenResponse
+		found := false
+		if catalogExisted {
+			databaseID, found, err = c.findDatabaseByName(ctx, catalogID, db.Name, opts...)
+			if err != nil {
+				return result, fmt.Errorf("failed to look up database %q: %w", db.Name, err)
+			}
+		}
+		if found {
+			if err := exists(db.Name); err != nil {
+				return result, err
+			}
+			resp, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID}, opts...)
+			if err != nil {
+				return result, fmt.Errorf("failed to list database %q: %w", db.Name, err)
+			}
+			children = make(map[string]DatabaseChildrenResponse, len(resp.List))
+			for _, child := range resp.List {
+				children[strings.ToLower(child.Typ)+"/"+child.Name] = child
+			}
+		} else {
+			resp, err := c.raw.CreateDatabase(ctx, &DatabaseCreateRequest{DatabaseName: db.Name, Comment: db.Comment, CatalogID: catalogID}, opts...)
+			if err != nil {
+				return result, fmt.Errorf("failed to create database %q: %w", db.Name, err)
+			}
+			databaseID = resp.DatabaseID
+			result.Created = append(result.Created, db.Name)
+		}
+
+		for _, table := range db.Tables {
+			p := path.Join(db.Name, table.Name)
+			if _, ok := children["table/"+table.Name]; ok {
+				if err := exists(p); err != nil {
+					return result, err
+				}
+				continue
+			}
+			_, err := c.raw.CreateTable(ctx, &TableCreateRequest{DatabaseID: databaseID, Name: table.Name, Columns: table.Columns, Comment: table.Comment}, opts...)
+			if err != nil {
+				return result, fmt.Errorf("failed to create table %q: %w", p, err)
+			}
+			result.Created = append(result.Created, p)
+		}
+
+		for _, volume := range db.Volumes {
+			if err := c.importVolume(ctx, databaseID, db.Name, volume, children, result, exists, opts...); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// importVolume creates an archived volume and its folders, reusing those that exist.
+func (c *SDKClient) importVolume(ctx context.Context, databaseID DatabaseID, dbName string, volume ArchivedVolume, children map[string]DatabaseChildrenResponse, result *CatalogImportResult, exists func(string) error, opts ...CallOption) error {
+	volumePath := path.Join(dbName, volume.Name)
+	folderIDs := map[string]FileID{"": ""}
+	var volumeID VolumeID
+	if child, ok := children["volume/"+volume.Name]; ok {
+		if err := exists(volumePath); err != nil {
+			return err
+		}
+		volumeID = VolumeID(child.ID)
+		err := c.WalkVolume(ctx, volumeID, func(entry VolumeChildrenResponse, p string) error {
+			if entry.IsFolder() {
+				folderIDs[p] = FileID(entry.ID)
+			}
+			return nil
+		}, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to walk volume %q: %w", volumePath, err)
+		}
+	} else {
+		resp, err := c.raw.CreateVolume(ctx, &VolumeCreateRequest{Name: volume.Name, DatabaseID: databaseID, Comment: volume.Comment}, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to create volume %q: %w", volumePath, err)
+		}
+		volumeID = resp.VolumeID
+		result.Created = append(result.Created, volumePath)
+	}
+
+	folders := append([]string(nil), volume.Folders...)
+	sort.Strings(folders)
+	for _, folder := range folders {
+		folder = strings.Trim(path.Clean("/"+folder), "/")
+		if folder == "" {
+			continue
+		}
+		p := path.Join(volumePath, folder)
+		if _, ok := folderIDs[folder]; ok {
+			if err := exists(p); err != nil {
+				return err
+			}
+			continue
+		}
+		parent := path.Dir(folder)
+		if parent == "." {
+			parent = ""
+		}
+		parentID, ok := folderIDs[parent]
+		if !ok {
+			return fmt.Errorf("folder %q: parent folder is missing from the archive", p)
+		}
+		resp, err := c.raw.CreateFolder(ctx, &FolderCreateRequest{Name: path.Base(folder), VolumeID: volumeID, ParentID: parentID}, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to create folder %q: %w", p, err)
+		}
+		folderIDs[folder] = resp.FolderID
+		result.Created = append(result.Created, p)
+	}
+	return nil
+}
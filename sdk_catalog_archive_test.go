@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportCatalog(t *testing.T) {
+	t.Parallel()
+
+	listings := map[string]string{
+		"":   `{"total":2,"list":[{"id":"f1","name":"a.txt","file_type":"1"},{"id":"d1","name":"raw","file_type":"10"}]}`,
+		"d1": `{"total":1,"list":[{"id":"d2","name":"2024","file_type":"10"}]}`,
+		"d2": `{"total":0,"list":[]}`,
+	}
+	var mu sync.Mutex
+	var creates []string
+	catalogs := `{"list":[]}`
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/catalog/info":
+			return envelopeResponse(`{"id":1,"name":"sales","description":"dev"}`), nil
+		case "/catalog/database/list":
+			return envelopeResponse(`{"list":[{"id":10,"name":"orders"},{"id":11,"name":"sys","reserved":true}]}`), nil
+		case "/catalog/database/children":
+			return envelopeResponse(`{"list":[{"id":"100","name":"items","type":"table"},{"id":"v1","name":"docs","type":"volume","description":"files"}]}`), nil
+		case "/catalog/table/info":
+			return envelopeResponse(`{"name":"items","comment":"line items","columns":[{"name":"id","type":"int","is_pk":true}]}`), nil
+		case "/catalog/file/list":
+			filters := body["filters"].([]interface{})
+			parent := filters[1].(map[string]interface{})["values"].([]interface{})[0].(string)
+			return envelopeResponse(listings[parent]), nil
+		case "/catalog/list":
+			return envelopeResponse(catalogs), nil
+		case "/catalog/create", "/catalog/database/create", "/catalog/table/create", "/catalog/volume/create", "/catalog/folder/create":
+			mu.Lock()
+			creates = append(creates, r.URL.Path+" "+body["name"].(string))
+			mu.Unlock()
+			if r.URL.Path == "/catalog/volume/create" || r.URL.Path == "/catalog/folder/create" {
+				return envelopeResponse(`{"id":"id-` + body["name"].(string) + `"}`), nil
+			}
+			return envelopeResponse(`{"id":7}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	archive, err := client.ExportCatalog(ctx, 1, &buf)
+	require.NoError(t, err)
+	require.Len(t, archive.Databases, 1)
+	db := archive.Databases[0]
+	require.Equal(t, "orders", db.Name)
+	require.Equal(t, "line items", db.Tables[0].Comment)
+	require.True(t, db.Tables[0].Columns[0].IsPk)
+	require.Equal(t, []string{"raw", "raw/2024"}, db.Volumes[0].Folders)
+
+	result, err := client.ImportCatalog(ctx, bytes.NewReader(buf.Bytes()), CatalogImportOptions{RenameTo: "sales-prod"})
+	require.NoError(t, err)
+	require.Equal(t, CatalogID(7), result.CatalogID)
+	require.False(t, result.CatalogExisted)
+	require.Equal(t, []string{"orders", "orders/items", "orders/docs", "orders/docs/raw", "orders/docs/raw/2024"}, result.Created)
+	require.Equal(t, []string{
+		"/catalog/create sales-prod",
+		"/catalog/database/create orders",
+		"/catalog/table/create items",
+		"/catalog/volume/create docs",
+		"/catalog/folder/create raw",
+		"/catalog/folder/create 2024",
+	}, creates)
+
+	catalogs = `{"list":[{"id":1,"name":"sales"}]}`
+	_, err = client.ImportCatalog(ctx, bytes.NewReader(buf.Bytes()), CatalogImportOptions{})
+	require.ErrorIs(t, err, ErrAlreadyExists)
+
+	_, err = client.ImportCatalog(ctx, bytes.NewReader([]byte(`{"version":9}`)), CatalogImportOptions{})
+	require.ErrorContains(t, err, "unsupported catalog archive version")
+}
@@ -2,7 +2,6 @@ package sdk
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -99,6 +98,9 @@ func (c *SDKClient) CreateTableRole(ctx context.Context, roleName string, commen
 	maxPages := 1000 // Safety limit to avoid infinite loops
 
 	for page <= maxPages {
+		if err := c.raw.checkPageLimit(page); err != nil {
+			return 0, false, fmt.Errorf("failed to find role '%s': %w", roleName, err)
+		}
 		// Use filters to search by role name (matching frontend example format)
 		roleListReq := &RoleListRequest{
 			Keyword: "",
@@ -206,76 +208,74 @@ func (c *SDKClient) CreateTableRole(ctx context.Context, roleName string, commen
 	if err != nil {
 		// If creation fails due to role already existing, try to find it again
 		// This handles the case where ListRoles failed but the role exists
-		var apiErr *APIError
-		if errors.As(err, &apiErr) && apiErr != nil {
-			// Check if error indicates role already exists
-			errMsg := strings.ToLower(apiErr.Message)
-			if strings.Contains(errMsg, "already exists") || strings.Contains(errMsg, "duplicate") {
-				// Try to list roles one more time to find the existing role with pagination
-				// Use the same pagination logic as initial search
-				retryPage := 1
-				retryPageSize := 100
-				retryMaxPages := 1000 // Safety limit
-				for retryPage <= retryMaxPages {
-					retryListReq := &RoleListRequest{
-						Keyword: "",
-						CommonCondition: CommonCondition{
-							Page:     retryPage,
-							PageSize: retryPageSize,
-							Order:    "desc",
-							OrderBy:  "created_at",
-							Filters: []CommonFilter{
-								{
-									Name:   "name_description",
-									Values: []string{roleName},
-									Fuzzy:  true,
-								},
+		if IsAlreadyExists(err) {
+			// Try to list roles one more time to find the existing role with pagination
+			// Use the same pagination logic as initial search
+			retryPage := 1
+			retryPageSize := 100
+			retryMaxPages := 1000 // Safety limit
+			for retryPage <= retryMaxPages {
+				if err := c.raw.checkPageLimit(retryPage); err != nil {
+					return 0, false, fmt.Errorf("failed to find role '%s': %w", roleName, err)
+				}
+				retryListReq := &RoleListRequest{
+					Keyword: "",
+					CommonCondition: CommonCondition{
+						Page:     retryPage,
+						PageSize: retryPageSize,
+						Order:    "desc",
+						OrderBy:  "created_at",
+						Filters: []CommonFilter{
+							{
+								Name:   "name_description",
+								Values: []string{roleName},
+								Fuzzy:  true,
 							},
 						},
-					}
-					retryListResp, retryErr := c.raw.ListRoles(ctx, retryListReq)
-					if retryErr != nil {
-						// If listing fails for this page, try next page (might be a transient error)
-						// But if it's the first page, break
-						if retryPage == 1 {
-							break
-						}
-						// For subsequent pages, if error occurs, assume we've reached the end
-						break
-					}
-
-					if retryListResp == nil || len(retryListResp.List) == 0 {
-						// No more results
+					},
+				}
+				retryListResp, retryErr := c.raw.ListRoles(ctx, retryListReq)
+				if retryErr != nil {
+					// If listing fails for this page, try next page (might be a transient error)
+					// But if it's the first page, break
+					if retryPage == 1 {
 						break
 					}
+					// For subsequent pages, if error occurs, assume we've reached the end
+					break
+				}
 
-					// Search for the role by name in current page
-					for i := range retryListResp.List {
-						if retryListResp.List[i].RoleName == roleName {
-							return retryListResp.List[i].RoleID, false, nil
-						}
-					}
+				if retryListResp == nil || len(retryListResp.List) == 0 {
+					// No more results
+					break
+				}
 
-					// Check if there are more pages
-					// Stop if current page has fewer results than pageSize
-					if len(retryListResp.List) < retryPageSize {
-						// No more pages
-						break
+				// Search for the role by name in current page
+				for i := range retryListResp.List {
+					if retryListResp.List[i].RoleName == roleName {
+						return retryListResp.List[i].RoleID, false, nil
 					}
+				}
 
-					// Also check Total to avoid infinite loops
-					if retryListResp.Total > 0 && retryPage*retryPageSize >= retryListResp.Total {
-						// Reached the total number of roles
-						break
-					}
+				// Check if there are more pages
+				// Stop if current page has fewer results than pageSize
+				if len(retryListResp.List) < retryPageSize {
+					// No more pages
+					break
+				}
 
-					// Continue to next page
-					retryPage++
+				// Also check Total to avoid infinite loops
+				if retryListResp.Total > 0 && retryPage*retryPageSize >= retryListResp.Total {
+					// Reached the total number of roles
+					break
 				}
-				// If ListRoles still fails, we can't find the role, but we know it exists
-				// Return a more user-friendly error message
-				return 0, false, fmt.Errorf("role '%s' already exists but could not be retrieved", roleName)
+
+				// Continue to next page
+				retryPage++
 			}
+			// If ListRoles still fails, we can't find the role, but we know it exists
+			// Return a more user-friendly error message
+			return 0, false, fmt.Errorf("role '%s' already exists but could not be retrieved", roleName)
 		}
 		return 0, false, fmt.Errorf("failed to create role: %w", err)
 	}
@@ -658,6 +658,7 @@ func (c *SDKClient) ImportLocalFilesToVolume(ctx context.Context, filePaths []st
 //
 // The statement must reference tables using fully qualified names (database.table).
 // This requirement allows the catalog service to route the query to the correct database.
+// Use RunSQLWithParams rather than formatting values into statement.
 func (c *SDKClient) RunSQL(ctx context.Context, statement string, opts ...CallOption) (*NL2SQLRunSQLResponse, error) {
 	if strings.TrimSpace(statement) == "" {
 		return nil, fmt.Errorf("statement is required")
@@ -1017,3 +1018,37 @@ func (c *SDKClient) FindFilesByName(ctx context.Context, fileName string, volume
 	// Call the raw client's ListFiles method
 	return c.raw.ListFiles(ctx, req, opts...)
 }
+
+// listAllFilesPageSize is the page size used when iterating over every file in a volume.
+const listAllFilesPageSize = 100
+
+// listAllFiles returns every file matching filters, following pagination until the
+// reported total is reached or a page comes back empty. Pages are read from one
+// listing snapshot when the service supports it. It fails with
+// ErrMaxPagesExceeded rather than fetch more pages than the client's WithMaxPages limit.
+func (c *SDKClient) listAllFiles(ctx context.Context, filters []CommonFilter, opts ...CallOption) ([]VolumeChildrenResponse, error) {
+	var all []VolumeChildrenResponse
+	var snapshotToken string
+	for page := 1; ; page++ {
+		if err := c.raw.checkPageLimit(page); err != nil {
+			return nil, err
+		}
+		resp, err := c.raw.ListFiles(ctx, &FileListRequest{
+			CommonCondition: CommonCondition{
+				Page:     page,
+				PageSize: listAllFilesPageSize,
+				Filters:  filters,
+			},
+			Snapshot:      true,
+			SnapshotToken: snapshotToken,
+		}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		snapshotToken = resp.SnapshotToken
+		all = append(all, resp.List...)
+		if len(resp.List) == 0 || len(all) >= resp.Total {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Polling defaults of WaitUntilVisible; visibility lags are usually short, so polling
+// starts faster than for long-running jobs.
+const (
+	defaultVisibilityPollInterval    = 200 * time.Millisecond
+	defaultVisibilityMaxPollInterval = 2 * time.Second
+	defaultVisibilityTimeout         = 30 * time.Second
+)
+
+// objectRefKind identifies the kind of object an ObjectRef points to.
+type objectRefKind string
+
+const (
+	objectRefCatalog  objectRefKind = "catalog"
+	objectRefDatabase objectRefKind = "database"
+	objectRefVolume   objectRefKind = "volume"
+	objectRefTable    objectRefKind = "table"
+	objectRefFile     objectRefKind = "file"
+)
+
+// ObjectRef identifies an object by its name within its parent, the way list calls
+// find it. Create one with CatalogRef, DatabaseRef, VolumeRef, TableRef or FileRef.
+type ObjectRef struct {
+	kind       objectRefKind
+	catalogID  CatalogID
+	databaseID DatabaseID
+	volumeID   VolumeID
+	name       string
+}
+
+// CatalogRef refers to the catalog with the given name.
+func CatalogRef(name string) ObjectRef {
+	return ObjectRef{kind: objectRefCatalog, name: name}
+}
+
+// DatabaseRef refers to the database with the given name in a catalog.
+func DatabaseRef(catalogID CatalogID, name string) ObjectRef {
+	return ObjectRef{kind: objectRefDatabase, catalogID: catalogID, name: name}
+}
+
+// VolumeRef refers to the volume with the given name in a database.
+func VolumeRef(databaseID DatabaseID, name string) ObjectRef {
+	return ObjectRef{kind: objectRefVolume, databaseID: databaseID, name: name}
+}
+
+// TableRef refers to the table with the given name in a database.
+func TableRef(databaseID DatabaseID, name string) ObjectRef {
+	return ObjectRef{kind: objectRefTable, databaseID: databaseID, name: name}
+}
+
+// FileRef refers to the file with the given name in the root folder of a volume.
+func FileRef(volumeID VolumeID, name string) ObjectRef {
+	return ObjectRef{kind: objectRefFile, volumeID: volumeID, name: name}
+}
+
+// String returns a description of the reference, e.g. `volume "docs" in database 12`.
+func (r ObjectRef) String() string {
+	switch r.kind {
+	case objectRefCatalog:
+		return fmt.Sprintf("catalog %q", r.name)
+	case objectRefDatabase:
+		return fmt.Sprintf("database %q in catalog %d", r.name, r.catalogID)
+	case objectRefVolume, objectRefTable:
+		return fmt.Sprintf("%s %q in database %d", r.kind, r.name, r.databaseID)
+	case objectRefFile:
+		return fmt.Sprintf("file %q in volume %s", r.name, r.volumeID)
+	default:
+		return fmt.Sprintf("object %q", r.name)
+	}
+}
+
+// WaitUntilVisible polls until the referenced object is returned by list calls.
+//
+// Creates are not always immediately visible in list and tree calls. WaitUntilVisible
+// replaces fixed sleeps after a create with bounded polling: unless overridden by
+// waitOpts, it polls every 200ms, backing off to 2s, for at most 30s. If the object
+// is still not visible when the wait ends, the error is a *WaitTimeoutError.
+//
+// Example:
+//
+//	resp, err := client.CreateVolume(ctx, &sdk.VolumeCreateRequest{Name: "docs", DatabaseID: dbID})
+//	if err != nil {
+//		return err
+//	}
+//	if err := sdkClient.WaitUntilVisible(ctx, sdk.VolumeRef(dbID, "docs"), sdk.WaitOptions{}); err != nil {
+//		return err
+//	}
+func (c *SDKClient) WaitUntilVisible(ctx context.Context, ref ObjectRef, waitOpts WaitOptions, opts ...CallOption) error {
+	if strings.TrimSpace(ref.name) == "" {
+		return fmt.Errorf("object name is required")
+	}
+	if waitOpts.PollInterval <= 0 {
+		waitOpts.PollInterval = defaultVisibilityPollInterval
+	}
+	if waitOpts.MaxPollInterval <= 0 {
+		waitOpts.MaxPollInterval = defaultVisibilityMaxPollInterval
+	}
+	if waitOpts.Timeout <= 0 {
+		waitOpts.Timeout = defaultVisibilityTimeout
+	}
+	return pollUntil(ctx, waitOpts, ref.String(), func(ctx context.Context) (string, bool, error) {
+		visible, err := c.isVisible(ctx, ref, opts...)
+		if err != nil {
+			return "", false, err
+		}
+		if visible {
+			return "visible", true, nil
+		}
+		return "not visible", false, nil
+	})
+}
+
+// isVisible reports whether the referenced object is returned by the list call of its parent.
+func (c *SDKClient) isVisible(ctx context.Context, ref ObjectRef, opts ...CallOption) (bool, error) {
+	switch ref.kind {
+	case objectRefCatalog:
+		_, found, err := c.findCatalogByName(ctx, ref.name, opts...)
+		return found, err
+	case objectRefDatabase:
+		_, found, err := c.findDatabaseByName(ctx, ref.catalogID, ref.name, opts...)
+		return found, err
+	case objectRefVolume, objectRefTable:
+		resp, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: ref.databaseID}, opts...)
+		if err != nil {
+			return false, err
+		}
+		for _, child := range resp.List {
+			if child.Name == ref.name && strings.EqualFold(child.Typ, string(ref.kind)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case objectRefFile:
+		resp, err := c.FindFilesByName(ctx, ref.name, ref.volumeID, opts...)
+		if err != nil {
+			return false, err
+		}
+		for _, file := range resp.List {
+			if file.Name == ref.name {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unsupported object reference %s", ref)
+	}
+}
+
+// waitVisibleAfterCreate waits for a newly created object when WithConsistencyWait is set.
+func (c *SDKClient) waitVisibleAfterCreate(ctx context.Context, ref ObjectRef, opts ...CallOption) error {
+	callOpts := newCallOptions(opts...)
+	if callOpts.consistencyWait == nil {
+		return nil
+	}
+	if err := c.WaitUntilVisible(ctx, ref, *callOpts.consistencyWait, opts...); err != nil {
+		return fmt.Errorf("failed to wait for %s: %w", ref, err)
+	}
+	return nil
+}
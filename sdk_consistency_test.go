@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitUntilVisible(t *testing.T) {
+	t.Parallel()
+
+	var listCalls int32
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/list":
+			if atomic.AddInt32(&listCalls, 1) < 3 {
+				return envelopeResponse(`{"list":[]}`), nil
+			}
+			return envelopeResponse(`{"list":[{"id":1,"name":"sales"}]}`), nil
+		case "/catalog/database/children":
+			return envelopeResponse(`{"list":[{"id":"v1","name":"docs","type":"volume"}]}`), nil
+		}
+		return errorEnvelopeResponse("ErrInternal", "unexpected path "+r.URL.Path), nil
+	}))
+	ctx := context.Background()
+	fast := WaitOptions{PollInterval: time.Millisecond, Timeout: time.Second}
+
+	require.NoError(t, client.WaitUntilVisible(ctx, CatalogRef("sales"), fast))
+	require.EqualValues(t, 3, atomic.LoadInt32(&listCalls))
+
+	require.NoError(t, client.WaitUntilVisible(ctx, VolumeRef(12, "docs"), fast))
+
+	// A table with the same name as the volume is not visible
+	err := client.WaitUntilVisible(ctx, TableRef(12, "docs"), WaitOptions{PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond})
+	var timeoutErr *WaitTimeoutError
+	require.True(t, errors.As(err, &timeoutErr))
+	require.Equal(t, "not visible", timeoutErr.LastStatus)
+	require.Contains(t, err.Error(), `table "docs" in database 12`)
+
+	require.ErrorContains(t, client.WaitUntilVisible(ctx, CatalogRef(""), fast), "object name is required")
+}
+
+func TestWithConsistencyWait(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, newCallOptions().consistencyWait)
+	co := newCallOptions(WithConsistencyWait(WaitOptions{Timeout: time.Second}))
+	require.NotNil(t, co.consistencyWait)
+	require.Equal(t, time.Second, co.consistencyWait.Timeout)
+
+	// Without the option no list call is made
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+	require.NoError(t, client.waitVisibleAfterCreate(context.Background(), CatalogRef("sales")))
+}
@@ -0,0 +1,206 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// EnsureCatalog returns the ID of the catalog named name, creating the catalog if it
+// does not exist. created reports whether this call created it.
+//
+// A creation that fails because another caller created the catalog in the meantime
+// returns that catalog, so concurrent calls with the same name agree on one ID. With
+// WithConsistencyWait, a created catalog is awaited until list calls return it.
+//
+// Example:
+//
+//	catalogID, created, err := sdkClient.EnsureCatalog(ctx, "sales")
+//	if err != nil {
+//		return err
+//	}
+//	if created {
+//		fmt.Printf("created catalog %d\n", catalogID)
+//	}
+func (c *SDKClient) EnsureCatalog(ctx context.Context, name string, opts ...CallOption) (catalogID CatalogID, created bool, err error) {
+	if strings.TrimSpace(name) == "" {
+		return 0, false, fmt.Errorf("name is required")
+	}
+	catalogID, created, err = ensureObject("catalog", func() (CatalogID, bool, error) {
+		return c.findCatalogByName(ctx, name, opts...)
+	}, func() (CatalogID, error) {
+		resp, err := c.raw.CreateCatalog(ctx, &CatalogCreateRequest{CatalogName: name}, opts...)
+		if err != nil {
+			return 0, err
+		}
+		return resp.CatalogID, nil
+	})
+	if err == nil && created {
+		err = c.waitVisibleAfterCreate(ctx, CatalogRef(name), opts...)
+	}
+	return catalogID, created, err
+}
+
+// EnsureDatabase returns the ID of the database named name in a catalog, creating the
+// database if it does not exist. created reports whether this call created it.
+//
+// Concurrent creations and WithConsistencyWait are handled like in EnsureCatalog.
+//
+// Example:
+//
+//	databaseID, _, err := sdkClient.EnsureDatabase(ctx, catalogID, "orders")
+func (c *SDKClient) EnsureDatabase(ctx context.Context, catalogID CatalogID, name string, opts ...CallOption) (databaseID DatabaseID, created bool, err error) {
+	if catalogID == 0 {
+		return 0, false, fmt.Errorf("catalog_id is required")
+	}
+	if strings.TrimSpace(name) == "" {
+		return 0, false, fmt.Errorf("name is required")
+	}
+	databaseID, created, err = ensureObject("database", func() (DatabaseID, bool, error) {
+		return c.findDatabaseByName(ctx, catalogID, name, opts...)
+	}, func() (DatabaseID, error) {
+		resp, err := c.raw.CreateDatabase(ctx, &DatabaseCreateRequest{DatabaseName: name, CatalogID: catalogID}, opts...)
+		if err != nil {
+			return 0, err
+		}
+		return resp.DatabaseID, nil
+	})
+	if err == nil && created {
+		err = c.waitVisibleAfterCreate(ctx, DatabaseRef(catalogID, name), opts...)
+	}
+	return databaseID, created, err
+}
+
+// EnsureVolume returns the ID of the volume named name in a database, creating the
+// volume if it does not exist. created reports whether this call created it.
+//
+// Concurrent creations and WithConsistencyWait are handled like in EnsureCatalog.
+//
+// Example:
+//
+//	volumeID, _, err := sdkClient.EnsureVolume(ctx, databaseID, "raw")
+func (c *SDKClient) EnsureVolume(ctx context.Context, databaseID DatabaseID, name string, opts ...CallOption) (volumeID VolumeID, created bool, err error) {
+	if databaseID == 0 {
+		return "", false, fmt.Errorf("database_id is required")
+	}
+	if strings.TrimSpace(name) == "" {
+		return "", false, fmt.Errorf("name is required")
+	}
+	volumeID, created, err = ensureObject("volume", func() (VolumeID, bool, error) {
+		return c.findVolumeByName(ctx, databaseID, name, opts...)
+	}, func() (VolumeID, error) {
+		resp, err := c.raw.CreateVolume(ctx, &VolumeCreateRequest{Name: name, DatabaseID: databaseID}, opts...)
+		if err != nil {
+			return "", err
+		}
+		return resp.VolumeID, nil
+	})
+	if err == nil && created {
+		err = c.waitVisibleAfterCreate(ctx, VolumeRef(databaseID, name), opts...)
+	}
+	return volumeID, created, err
+}
+
+// EnsureFolderPath returns the ID of the folder at folderPath, a "/"-separated path
+// from the root of a volume, creating the folders of the path that do not exist.
+// created lists the IDs of the folders created by this call, outermost first.
+//
+// An entry of the path that exists as a file fails the call. Concurrent creations are
+// handled like in EnsureCatalog.
+//
+// Example:
+//
+//	folderID, _, err := sdkClient.EnsureFolderPath(ctx, volumeID, "reports/2024/q1")
+//	if err != nil {
+//		return err
+//	}
+//	_, err = rawClient.CreateFile(ctx, &sdk.FileCreateRequest{Name: "summary.pdf", VolumeID: volumeID, ParentID: folderID})
+func (c *SDKClient) EnsureFolderPath(ctx context.Context, volumeID VolumeID, folderPath string, opts ...CallOption) (folderID FileID, created []FileID, err error) {
+	opts = withoutIdempotencyKey(opts)
+	folderID, err = c.ensureFolderPath(ctx, volumeID, folderPath, func(_ string, id FileID) {
+		created = append(created, id)
+	}, opts...)
+	return folderID, created, err
+}
+
+// ensureFolderPath implements EnsureFolderPath, calling onCreate with the path and ID
+// of each folder it creates, outermost first.
+func (c *SDKClient) ensureFolderPath(ctx context.Context, volumeID VolumeID, folderPath string, onCreate func(folderPath string, id FileID), opts ...CallOption) (folderID FileID, err error) {
+	if volumeID == "" {
+		return "", fmt.Errorf("volume_id is required")
+	}
+	segments, err := splitResolvePath(folderPath, "/")
+	if err != nil {
+		return "", err
+	}
+	parentCreated := false
+	for i, name := range segments {
+		parentID := folderID
+		p := strings.Join(segments[:i+1], "/")
+		id, isNew, err := ensureObject("folder "+p, func() (FileID, bool, error) {
+			if parentCreated {
+				// A new folder is empty
+				return "", false, nil
+			}
+			children, err := c.listAllFiles(ctx, []CommonFilter{
+				{Name: "volume_id", Values: []string{string(volumeID)}},
+				{Name: "parent_id", Values: []string{string(parentID)}},
+			}, opts...)
+			if err != nil {
+				return "", false, err
+			}
+			for _, child := range children {
+				if child.Name != name {
+					continue
+				}
+				if !child.IsFolder() {
+					return "", false, fmt.Errorf("%s is a file", p)
+				}
+				return FileID(child.ID), true, nil
+			}
+			return "", false, nil
+		}, func() (FileID, error) {
+			resp, err := c.raw.CreateFolder(ctx, &FolderCreateRequest{Name: name, VolumeID: volumeID, ParentID: parentID}, opts...)
+			if err != nil {
+				return "", err
+			}
+			return resp.FolderID, nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if isNew {
+			onCreate(p, id)
+		}
+		folderID, parentCreated = id, isNew
+	}
+	return folderID, nil
+}
+
+// ensureObject looks an object up with find and creates it with create if it is
+// missing. If the creation fails because the object was created since the lookup, the
+// object is looked up again and returned with created=false.
+func ensureObject[ID any](kind string, find func() (ID, bool, error), create func() (ID, error)) (id ID, created bool, err error) {
+	id, found, err := find()
+	if err != nil {
+		return id, false, fmt.Errorf("failed to look up %s: %w", kind, err)
+	}
+	if found {
+		return id, false, nil
+	}
+	id, err = create()
+	if err == nil {
+		return id, true, nil
+	}
+	if !IsAlreadyExists(err) {
+		return id, false, fmt.Errorf("failed to create %s: %w", kind, err)
+	}
+	existing, found, findErr := find()
+	if findErr != nil {
+		return id, false, fmt.Errorf("failed to look up %s: %w", kind, findErr)
+	}
+	if !found {
+		return id, false, fmt.Errorf("failed to create %s: %w", kind, err)
+	}
+	return existing, false, nil
+}
@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureCatalogDatabaseVolume(t *testing.T) {
+	t.Parallel()
+	var created []string
+	listed := map[string]int{}
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		listed[r.URL.Path]++
+		switch r.URL.Path {
+		case "/catalog/list":
+			return envelopeResponse(`{"list":[{"id":1,"name":"sales"}]}`), nil
+		case "/catalog/create":
+			created = append(created, "catalog")
+			return envelopeResponse(`{"id":2}`), nil
+		case "/catalog/database/list":
+			if listed[r.URL.Path] == 1 {
+				return envelopeResponse(`{"list":[]}`), nil
+			}
+			// Created concurrently by another caller
+			return envelopeResponse(`{"list":[{"id":20,"name":"orders"}]}`), nil
+		case "/catalog/database/create":
+			return errorEnvelopeResponse("ErrAlreadyExists", "database exists"), nil
+		case "/catalog/database/children":
+			return envelopeResponse(`{"list":[{"id":"v1","name":"raw","type":"volume"}]}`), nil
+		case "/catalog/volume/create":
+			return errorEnvelopeResponse("ErrPermissionDenied", "denied"), nil
+		}
+		return nil, nil
+	}))
+	ctx := context.Background()
+
+	catalogID, isNew, err := client.EnsureCatalog(ctx, "sales")
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.Equal(t, CatalogID(1), catalogID)
+	catalogID, isNew, err = client.EnsureCatalog(ctx, "hr")
+	require.NoError(t, err)
+	require.True(t, isNew)
+	require.Equal(t, CatalogID(2), catalogID)
+	require.Equal(t, []string{"catalog"}, created)
+
+	databaseID, isNew, err := client.EnsureDatabase(ctx, 1, "orders")
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.Equal(t, DatabaseID(20), databaseID)
+
+	volumeID, isNew, err := client.EnsureVolume(ctx, 20, "raw")
+	require.NoError(t, err)
+	require.False(t, isNew)
+	require.Equal(t, VolumeID("v1"), volumeID)
+	_, _, err = client.EnsureVolume(ctx, 20, "processed")
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.ErrorContains(t, err, "failed to create volume")
+
+	_, _, err = client.EnsureCatalog(ctx, " ")
+	require.ErrorContains(t, err, "name is required")
+	_, _, err = client.EnsureDatabase(ctx, 0, "orders")
+	require.ErrorContains(t, err, "catalog_id is required")
+	_, _, err = client.EnsureVolume(ctx, 0, "raw")
+	require.ErrorContains(t, err, "database_id is required")
+}
+
+func TestEnsureFolderPath(t *testing.T) {
+	t.Parallel()
+	var folders []FolderCreateRequest
+	listedParents := []string{}
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/file/list":
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			parent := req.Filters[1].Values[0]
+			listedParents = append(listedParents, parent)
+			if parent == "" {
+				return envelopeResponse(`{"total":2,"list":[
+					{"id":"d1","name":"reports","file_type":"dir"},
+					{"id":"f1","name":"notes","file_type":"txt"}]}`), nil
+			}
+			return envelopeResponse(`{"total":0,"list":[]}`), nil
+		case "/catalog/folder/create":
+			var req FolderCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			folders = append(folders, req)
+			return envelopeResponse(`{"id":"n` + req.Name + `","name":"` + req.Name + `"}`), nil
+		}
+		return nil, nil
+	}))
+	ctx := context.Background()
+
+	folderID, created, err := client.EnsureFolderPath(ctx, "v1", "/reports/2024/q1/")
+	require.NoError(t, err)
+	require.Equal(t, FileID("nq1"), folderID)
+	require.Equal(t, []FileID{"n2024", "nq1"}, created)
+	require.Equal(t, []FolderCreateRequest{
+		{Name: "2024", VolumeID: "v1", ParentID: "d1"},
+		{Name: "q1", VolumeID: "v1", ParentID: "n2024"},
+	}, folders)
+	// The new folder 2024 is not listed
+	require.Equal(t, []string{"", "d1"}, listedParents)
+
+	folderID, created, err = client.EnsureFolderPath(ctx, "v1", "reports")
+	require.NoError(t, err)
+	require.Equal(t, FileID("d1"), folderID)
+	require.Empty(t, created)
+
+	_, _, err = client.EnsureFolderPath(ctx, "v1", "notes/a")
+	require.ErrorContains(t, err, "notes is a file")
+	_, _, err = client.EnsureFolderPath(ctx, "", "a")
+	require.ErrorContains(t, err, "volume_id is required")
+}
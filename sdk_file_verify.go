@@ -0,0 +1,116 @@
+package sdk
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// FileVerifyMethod tells how VerifyFile obtained the digest of the stored file.
+type FileVerifyMethod string
+
+const (
+	FileVerifySize     FileVerifyMethod = "size"     // Sizes differ; no digest was needed
+	FileVerifySHA256   FileVerifyMethod = "sha256"   // SHA-256 digest reported by the server
+	FileVerifyMD5      FileVerifyMethod = "md5"      // MD5 digest reported by the server
+	FileVerifyDownload FileVerifyMethod = "download" // Content downloaded and hashed locally
+)
+
+// FileVerifyReport is the outcome of VerifyFile.
+type FileVerifyReport struct {
+	FileID    FileID
+	LocalPath string
+	Match     bool
+	Method    FileVerifyMethod
+	// Reason explains the outcome in a form suitable for display.
+	Reason string
+
+	LocalSize    int64
+	RemoteSize   int64
+	LocalMD5     string
+	LocalSHA256  string
+	RemoteMD5    string // Empty when neither reported by the server nor computed
+	RemoteSHA256 string
+}
+
+// VerifyFile checks whether a stored file has the same content as a local file.
+//
+// The local file is hashed and compared with the digest reported in the file
+// information. When the server reports no digest, the stored content is downloaded
+// and hashed, so verifying large files then costs a full transfer.
+//
+// Example:
+//
+//	report, err := sdkClient.VerifyFile(ctx, "file-123", "/data/report.pdf")
+//	if err != nil {
+//		return err
+//	}
+//	if !report.Match {
+//		fmt.Println(report.Reason)
+//	}
+func (c *SDKClient) VerifyFile(ctx context.Context, fileID FileID, localPath string, opts ...CallOption) (*FileVerifyReport, error) {
+	if fileID == "" {
+		return nil, fmt.Errorf("file_id is required")
+	}
+	f, err := os.Open(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open local file: %w", err)
+	}
+	defer f.Close()
+
+	sha := sha256.New()
+	md5sum := md5.New()
+	size, err := io.Copy(io.MultiWriter(sha, md5sum), f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local file: %w", err)
+	}
+	report := &FileVerifyReport{
+		FileID:      fileID,
+		LocalPath:   localPath,
+		LocalSize:   size,
+		LocalSHA256: hex.EncodeToString(sha.Sum(nil)),
+		LocalMD5:    hex.EncodeToString(md5sum.Sum(nil)),
+	}
+
+	info, err := c.raw.GetFile(ctx, &FileInfoRequest{FileID: fileID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+	report.RemoteSize = info.Size
+	report.RemoteSHA256 = strings.ToLower(info.SHA256)
+	report.RemoteMD5 = strings.ToLower(info.MD5)
+
+	switch {
+	case info.Size != size:
+		report.Method = FileVerifySize
+		report.Reason = fmt.Sprintf("size differs: local %d bytes, stored %d bytes", size, info.Size)
+		return report, nil
+	case report.RemoteSHA256 != "":
+		report.Method = FileVerifySHA256
+		report.Match = report.RemoteSHA256 == report.LocalSHA256
+	case report.RemoteMD5 != "":
+		report.Method = FileVerifyMD5
+		report.Match = report.RemoteMD5 == report.LocalMD5
+	default:
+		report.Method = FileVerifyDownload
+		result, err := c.raw.DownloadFile(ctx, &FileDownloadRequest{FileID: fileID, VolumeID: VolumeID(info.VolumeID)}, io.Discard, opts...)
+		if err != nil && !IsChecksumMismatch(err) {
+			return nil, fmt.Errorf("failed to download file: %w", err)
+		}
+		report.RemoteSHA256 = result.SHA256
+		report.RemoteMD5 = result.MD5
+		report.Match = result.SHA256 == report.LocalSHA256
+	}
+
+	if report.Match {
+		report.Reason = fmt.Sprintf("content matches (%s)", report.Method)
+	} else {
+		report.Reason = fmt.Sprintf("content differs (%s)", report.Method)
+	}
+	return report, nil
+}
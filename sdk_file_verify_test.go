@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyFile(t *testing.T) {
+	t.Parallel()
+	const content = "local content"
+	path := filepath.Join(t.TempDir(), "a.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	sum := sha256.Sum256([]byte(content))
+	shaHex := hex.EncodeToString(sum[:])
+
+	newClient := func(info string, stored string) *SDKClient {
+		return NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/catalog/file/info":
+				return envelopeResponse(info), nil
+			case "/catalog/file/download":
+				return envelopeResponse(`{"link":"/storage/obj"}`), nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(stored))}, nil
+		}))
+	}
+	ctx := context.Background()
+
+	report, err := newClient(`{"id":"f1","size":13,"volume_id":"v1","sha256":"`+strings.ToUpper(shaHex)+`"}`, "").VerifyFile(ctx, "f1", path)
+	require.NoError(t, err)
+	require.True(t, report.Match)
+	require.Equal(t, FileVerifySHA256, report.Method)
+
+	report, err = newClient(`{"id":"f1","size":12,"volume_id":"v1"}`, "").VerifyFile(ctx, "f1", path)
+	require.NoError(t, err)
+	require.False(t, report.Match)
+	require.Equal(t, FileVerifySize, report.Method)
+
+	report, err = newClient(`{"id":"f1","size":13,"volume_id":"v1"}`, "other content").VerifyFile(ctx, "f1", path)
+	require.NoError(t, err)
+	require.False(t, report.Match)
+	require.Equal(t, FileVerifyDownload, report.Method)
+	require.Contains(t, report.Reason, "differs")
+
+	report, err = newClient(`{"id":"f1","size":13,"volume_id":"v1"}`, content).VerifyFile(ctx, "f1", path)
+	require.NoError(t, err)
+	require.True(t, report.Match)
+	require.Equal(t, shaHex, report.RemoteSHA256)
+
+	_, err = newClient(`{}`, "").VerifyFile(ctx, "f1", filepath.Join(t.TempDir(), "missing"))
+	require.ErrorContains(t, err, "failed to open local file")
+}
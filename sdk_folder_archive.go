@@ -0,0 +1,226 @@
+package sdk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArchiveOptions describes the archive written by DownloadFolderArchive.
+type ArchiveOptions struct {
+	Format ArchiveFormat // Format defaults to ArchiveZip
+	// IncludeSubfolders adds the content of subfolders, recursively. Otherwise only
+	// the files directly in the folder are archived.
+	IncludeSubfolders bool
+}
+
+// FolderArchiveResult describes a completed DownloadFolderArchive transfer.
+type FolderArchiveResult struct {
+	Size int64 // Size is the number of archive bytes written
+	// Files is the number of files added when the archive was assembled by the SDK.
+	// It is zero when the server built the archive.
+	Files int
+	// ClientSide reports whether the archive was assembled by the SDK because the
+	// server does not build archives.
+	ClientSide bool
+}
+
+// DownloadFolderArchive writes a zip or tar.gz archive of a folder's content to w.
+//
+// The archive is built by the server when it supports it, and otherwise assembled
+// by the SDK from the individual files, downloaded one after another. Paths in the
+// archive are relative to the folder. Either way the archive is streamed, so it is
+// never held in memory.
+//
+// Example:
+//
+//	f, _ := os.Create("reports.zip")
+//	defer f.Close()
+//
+//	result, err := sdkClient.DownloadFolderArchive(ctx, "folder-id-123", f, sdk.ArchiveOptions{
+//		Format:            sdk.ArchiveZip,
+//		IncludeSubfolders: true,
+//	})
+func (c *SDKClient) DownloadFolderArchive(ctx context.Context, folderID FileID, w io.Writer, archiveOpts ArchiveOptions, opts ...CallOption) (*FolderArchiveResult, error) {
+	if folderID == "" {
+		return nil, fmt.Errorf("folder_id is required")
+	}
+	if w == nil {
+		return nil, fmt.Errorf("writer is required")
+	}
+	format := archiveOpts.Format
+	if format == "" {
+		format = ArchiveZip
+	}
+	if format != ArchiveZip && format != ArchiveTarGz {
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+
+	callOpts := newCallOptions(opts...)
+	dst := &downloadWriter{w: w}
+	getLink := func(ctx context.Context) (string, error) {
+		var resp FolderArchiveResponse
+		req := &FolderArchiveRequest{FolderID: folderID, Format: format, IncludeSubfolders: archiveOpts.IncludeSubfolders}
+		if err := c.raw.postJSON(ctx, "/catalog/folder/archive", req, &resp, opts...); err != nil {
+			return "", err
+		}
+		return resp.Url, nil
+	}
+	err := c.raw.downloadWithRetries(ctx, callOpts, getLink, dst, &FileDownloadResult{}, "folder_id", folderID)
+	if err == nil {
+		return &FolderArchiveResult{Size: dst.written}, nil
+	}
+	if dst.written > 0 || !isBatchEndpointUnsupported(err) {
+		return nil, fmt.Errorf("download folder archive %s: %w", folderID, err)
+	}
+
+	files, err := c.archiveFolder(ctx, folderID, dst, format, archiveOpts.IncludeSubfolders, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("download folder archive %s: %w", folderID, err)
+	}
+	return &FolderArchiveResult{Size: dst.written, Files: files, ClientSide: true}, nil
+}
+
+// archiveWriter adds folders and files to an archive being assembled.
+type archiveWriter interface {
+	addFolder(name string, modTime time.Time) error
+	addFile(name string, size int64, modTime time.Time) (io.Writer, error)
+	Close() error
+}
+
+// archiveFolder assembles the archive of a folder from its files and returns the
+// number of files added.
+func (c *SDKClient) archiveFolder(ctx context.Context, folderID FileID, w io.Writer, format ArchiveFormat, recursive bool, opts ...CallOption) (int, error) {
+	folder, err := c.raw.GetFile(ctx, &FileInfoRequest{FileID: folderID}, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get folder info: %w", err)
+	}
+	volumeID := VolumeID(folder.VolumeID)
+
+	var aw archiveWriter
+	if format == ArchiveTarGz {
+		aw = newTarGzArchive(w)
+	} else {
+		aw = &zipArchive{zw: zip.NewWriter(w)}
+	}
+
+	files := 0
+	var add func(parentID, dir string) error
+	add = func(parentID, dir string) error {
+		children, err := c.listAllFiles(ctx, []CommonFilter{
+			{Name: "volume_id", Values: []string{string(volumeID)}},
+			{Name: "parent_id", Values: []string{parentID}},
+		}, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to list %q: %w", "/"+dir, err)
+		}
+		sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+		for _, child := range children {
+			if !isArchiveEntryName(child.Name) {
+				return fmt.Errorf("invalid name %q in %q", child.Name, "/"+dir)
+			}
+			name := path.Join(dir, child.Name)
+			modTime := parseArchiveTime(child.UpdatedAt)
+			if child.IsFolder() {
+				if !recursive {
+					continue
+				}
+				if err := aw.addFolder(name, modTime); err != nil {
+					return err
+				}
+				if err := add(child.ID, name); err != nil {
+					return err
+				}
+				continue
+			}
+			entry, err := aw.addFile(name, child.Size, modTime)
+			if err != nil {
+				return err
+			}
+			if _, err := c.raw.DownloadFile(ctx, &FileDownloadRequest{FileID: FileID(child.ID), VolumeID: volumeID}, entry, opts...); err != nil {
+				return err
+			}
+			files++
+		}
+		return nil
+	}
+	if err := add(string(folderID), ""); err != nil {
+		return 0, err
+	}
+	if err := aw.Close(); err != nil {
+		return 0, err
+	}
+	return files, nil
+}
+
+// isArchiveEntryName reports whether name is a single path element that can safely
+// be joined into an archive entry name, so that a name such as "../x" cannot make
+// the archive extract outside of its directory.
+func isArchiveEntryName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// parseArchiveTime parses a timestamp of the service, falling back to the current
+// time when it is missing or malformed.
+func parseArchiveTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, time.DateTime} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+type zipArchive struct {
+	zw *zip.Writer
+}
+
+func (a *zipArchive) addFolder(name string, modTime time.Time) error {
+	_, err := a.zw.CreateHeader(&zip.FileHeader{Name: name + "/", Modified: modTime})
+	return err
+}
+
+func (a *zipArchive) addFile(name string, _ int64, modTime time.Time) (io.Writer, error) {
+	return a.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modTime})
+}
+
+func (a *zipArchive) Close() error {
+	return a.zw.Close()
+}
+
+type tarGzArchive struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func newTarGzArchive(w io.Writer) *tarGzArchive {
+	gz := gzip.NewWriter(w)
+	return &tarGzArchive{gz: gz, tw: tar.NewWriter(gz)}
+}
+
+func (a *tarGzArchive) addFolder(name string, modTime time.Time) error {
+	return a.tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: 0o755, ModTime: modTime})
+}
+
+// addFile relies on the size reported by the listing, as tar headers precede the
+// content; a file whose content has a different size fails the archive.
+func (a *tarGzArchive) addFile(name string, size int64, modTime time.Time) (io.Writer, error) {
+	if err := a.tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o644, Size: size, ModTime: modTime}); err != nil {
+		return nil, err
+	}
+	return a.tw, nil
+}
+
+func (a *tarGzArchive) Close() error {
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
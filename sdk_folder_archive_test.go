@@ -0,0 +1,144 @@
+package sdk
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadFolderArchive_Native(t *testing.T) {
+	t.Parallel()
+	var req FolderArchiveRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/catalog/folder/archive" {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			return envelopeResponse(`{"link":"/storage/archive.zip"}`), nil
+		}
+		require.Equal(t, "/storage/archive.zip", r.URL.Path)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("PK-archive"))}, nil
+	}))
+
+	var buf bytes.Buffer
+	result, err := client.DownloadFolderArchive(context.Background(), "d1", &buf, ArchiveOptions{IncludeSubfolders: true})
+	require.NoError(t, err)
+	require.Equal(t, FolderArchiveRequest{FolderID: "d1", Format: ArchiveZip, IncludeSubfolders: true}, req)
+	require.Equal(t, "PK-archive", buf.String())
+	require.Equal(t, &FolderArchiveResult{Size: int64(buf.Len())}, result)
+}
+
+// newArchiveFakeClient serves folder d1 of volume v1 holding a.txt and sub/b.txt,
+// without a native archive endpoint.
+func newArchiveFakeClient(t *testing.T) *SDKClient {
+	return NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/folder/archive":
+			return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("404 page not found"))}, nil
+		case "/catalog/file/info":
+			return envelopeResponse(`{"id":"d1","volume_id":"v1"}`), nil
+		case "/catalog/file/list":
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			switch req.Filters[1].Values[0] {
+			case "d1":
+				return envelopeResponse(`{"total":2,"list":[
+					{"id":"d2","name":"sub","file_type":"dir"},
+					{"id":"f1","name":"a.txt","size":5,"updated_at":"2026-01-02 03:04:05"}]}`), nil
+			case "d2":
+				return envelopeResponse(`{"total":1,"list":[{"id":"f2","name":"b.txt","size":3}]}`), nil
+			}
+		case "/catalog/file/download":
+			var req FileDownloadRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			return envelopeResponse(`{"link":"/storage/` + string(req.FileID) + `"}`), nil
+		case "/storage/f1":
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("hello"))}, nil
+		case "/storage/f2":
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("bye"))}, nil
+		}
+		t.Fatalf("unexpected request %s", r.URL.Path)
+		return nil, nil
+	}))
+}
+
+func TestDownloadFolderArchive_ClientSideZip(t *testing.T) {
+	t.Parallel()
+	client := newArchiveFakeClient(t)
+
+	var buf bytes.Buffer
+	result, err := client.DownloadFolderArchive(context.Background(), "d1", &buf, ArchiveOptions{IncludeSubfolders: true})
+	require.NoError(t, err)
+	require.True(t, result.ClientSide)
+	require.Equal(t, 2, result.Files)
+	require.Equal(t, int64(buf.Len()), result.Size)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	contents := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		contents[f.Name] = string(data)
+	}
+	require.Equal(t, map[string]string{"a.txt": "hello", "sub/": "", "sub/b.txt": "bye"}, contents)
+}
+
+func TestDownloadFolderArchive_ClientSideTarGz(t *testing.T) {
+	t.Parallel()
+	client := newArchiveFakeClient(t)
+
+	var buf bytes.Buffer
+	result, err := client.DownloadFolderArchive(context.Background(), "d1", &buf, ArchiveOptions{Format: ArchiveTarGz})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Files)
+
+	gz, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	hdr, err := tr.Next()
+	require.NoError(t, err)
+	require.Equal(t, "a.txt", hdr.Name)
+	require.Equal(t, 2026, hdr.ModTime.Year())
+	data, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(data))
+	_, err = tr.Next()
+	require.Equal(t, io.EOF, err)
+
+	_, err = client.DownloadFolderArchive(context.Background(), "d1", &buf, ArchiveOptions{Format: "rar"})
+	require.ErrorContains(t, err, "unsupported archive format")
+}
+
+func TestDownloadFolderArchive_RejectsUnsafeNames(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"..", "../evil.txt", "a/b.txt", `..\evil.txt`, ""} {
+		client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/catalog/folder/archive":
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("404 page not found"))}, nil
+			case "/catalog/file/info":
+				return envelopeResponse(`{"id":"d1","volume_id":"v1"}`), nil
+			case "/catalog/file/list":
+				list, err := json.Marshal([]VolumeChildrenResponse{{ID: "f1", Name: name, Size: 5}})
+				require.NoError(t, err)
+				return envelopeResponse(`{"total":1,"list":` + string(list) + `}`), nil
+			}
+			t.Fatalf("unexpected request %s", r.URL.Path)
+			return nil, nil
+		}))
+
+		var buf bytes.Buffer
+		_, err := client.DownloadFolderArchive(context.Background(), "d1", &buf, ArchiveOptions{})
+		require.ErrorContains(t, err, "invalid name", name)
+	}
+}
@@ -0,0 +1,285 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+)
+
+// maxRenameAttempts bounds the names tried for a folder under NameConflictRename.
+const maxRenameAttempts = 100
+
+// FolderTransferOptions controls CopyFolder and MoveFolder.
+type FolderTransferOptions struct {
+	// ConflictPolicy decides what happens when the target already holds an entry
+	// with the same name. With NameConflictOverwrite, folders are merged into the
+	// existing ones and files replace existing files.
+	ConflictPolicy NameConflictPolicy
+	// Progress, if set, receives an update after every folder and file transferred.
+	// Updates are dropped while the receiver is not ready; since each one carries
+	// running totals, none is needed to follow the transfer. The channel is not
+	// closed when the transfer ends.
+	Progress chan<- FolderTransferProgress
+}
+
+// FolderTransferProgress reports the progress of CopyFolder or MoveFolder.
+type FolderTransferProgress struct {
+	ItemsDone  int   // Folders and files transferred so far
+	ItemsTotal int   // Folders and files to transfer, including the top-level folder
+	BytesDone  int64 // Size of the files transferred so far
+	BytesTotal int64
+	// Path is the last item transferred, relative to the parent of the source folder.
+	Path string
+}
+
+// FolderTransferResult describes a completed CopyFolder or MoveFolder.
+type FolderTransferResult struct {
+	FolderID FileID // The folder at the target holding the transferred content
+	Name     string // Its name, which differs from the source under NameConflictRename
+	Folders  int    // Folders transferred, including the top-level folder
+	Files    int
+	Bytes    int64
+}
+
+// CopyFolder copies a folder and everything beneath it to another location, in the
+// same or another volume, reporting progress as it goes.
+//
+// Unlike RawClient.CopyFolder, which copies in a single server call, the content is
+// copied one file at a time, so the progress can be followed and a failure leaves
+// the files copied so far in place. The source is not changed.
+//
+// Example:
+//
+//	progress := make(chan sdk.FolderTransferProgress, 16)
+//	go func() {
+//		for p := range progress {
+//			fmt.Printf("%d/%d items, %d/%d bytes\n", p.ItemsDone, p.ItemsTotal, p.BytesDone, p.BytesTotal)
+//		}
+//	}()
+//	result, err := sdkClient.CopyFolder(ctx, "folder-id-123", "volume-id-456", "", sdk.FolderTransferOptions{
+//		ConflictPolicy: sdk.NameConflictRename,
+//		Progress:       progress,
+//	})
+//	close(progress)
+func (c *SDKClient) CopyFolder(ctx context.Context, folderID FileID, targetVolumeID VolumeID, targetParentID FileID, transferOpts FolderTransferOptions, opts ...CallOption) (*FolderTransferResult, error) {
+	return c.transferFolder(ctx, folderID, targetVolumeID, targetParentID, transferOpts, false, opts...)
+}
+
+// MoveFolder moves a folder and everything beneath it to another location, in the
+// same or another volume, reporting progress as it goes.
+//
+// Files are moved one at a time, keeping their IDs, into folders recreated at the
+// target, which must not be the folder itself or lie beneath it. Once every file is
+// moved, the source folder is deleted, with its emptied subfolders, by a single
+// DeleteFolder call. If the move fails part way, the files not yet moved stay in
+// the source folder and the call can be repeated with NameConflictOverwrite to
+// finish it.
+//
+// Example:
+//
+//	result, err := sdkClient.MoveFolder(ctx, "folder-id-123", "archive-volume-id", "", sdk.FolderTransferOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Moved %d files into %s\n", result.Files, result.FolderID)
+func (c *SDKClient) MoveFolder(ctx context.Context, folderID FileID, targetVolumeID VolumeID, targetParentID FileID, transferOpts FolderTransferOptions, opts ...CallOption) (*FolderTransferResult, error) {
+	return c.transferFolder(ctx, folderID, targetVolumeID, targetParentID, transferOpts, true, opts...)
+}
+
+func (c *SDKClient) transferFolder(ctx context.Context, folderID FileID, targetVolumeID VolumeID, targetParentID FileID, transferOpts FolderTransferOptions, move bool, opts ...CallOption) (*FolderTransferResult, error) {
+	opts = withoutIdempotencyKey(opts)
+	if folderID == "" {
+		return nil, fmt.Errorf("folder_id is required")
+	}
+	if targetVolumeID == "" {
+		return nil, fmt.Errorf("target_volume_id is required")
+	}
+	source, err := c.raw.GetFile(ctx, &FileInfoRequest{FileID: folderID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folder info: %w", err)
+	}
+	root := &VolumeTreeNode{Entry: VolumeChildrenResponse{ID: string(folderID), Name: source.Name}, Path: source.Name}
+	progress := FolderTransferProgress{ItemsTotal: 1}
+	if err := c.listFolderTree(ctx, VolumeID(source.VolumeID), root, &progress, opts...); err != nil {
+		return nil, err
+	}
+
+	if VolumeID(source.VolumeID) == targetVolumeID && treeHasFolder(root, targetParentID) {
+		return nil, fmt.Errorf("target folder %s is inside the source folder", targetParentID)
+	}
+
+	t := &folderTransfer{
+		client:   c,
+		opts:     opts,
+		policy:   transferOpts.ConflictPolicy,
+		move:     move,
+		volume:   targetVolumeID,
+		progress: progress,
+		report:   transferOpts.Progress,
+		result:   &FolderTransferResult{},
+	}
+	targetID, name, err := t.ensureFolder(ctx, targetParentID, source.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create folder %q: %w", source.Name, err)
+	}
+	// NameConflictOverwrite into the parent of the source resolves to the source
+	// itself, which a move would then delete with everything "moved" into it
+	if targetID == folderID {
+		return nil, fmt.Errorf("target folder is the source folder %s", folderID)
+	}
+	t.result.FolderID, t.result.Name = targetID, name
+	t.result.Folders++
+	t.done(root.Path, 0)
+	if err := t.transferChildren(ctx, root, targetID); err != nil {
+		return t.result, err
+	}
+
+	if move {
+		if _, err := c.raw.DeleteFolder(ctx, &FolderDeleteRequest{FolderID: folderID}, opts...); err != nil {
+			return t.result, fmt.Errorf("failed to delete source folder: %w", err)
+		}
+	}
+	return t.result, nil
+}
+
+// listFolderTree lists node recursively, adding the items and bytes found to the
+// totals of progress.
+func (c *SDKClient) listFolderTree(ctx context.Context, volumeID VolumeID, node *VolumeTreeNode, progress *FolderTransferProgress, opts ...CallOption) error {
+	children, err := c.listAllFiles(ctx, []CommonFilter{
+		{Name: "volume_id", Values: []string{string(volumeID)}},
+		{Name: "parent_id", Values: []string{node.Entry.ID}},
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to list %q: %w", node.Path, err)
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	node.Children = make([]*VolumeTreeNode, 0, len(children))
+	for _, child := range children {
+		childNode := &VolumeTreeNode{Entry: child, Path: path.Join(node.Path, child.Name)}
+		node.Children = append(node.Children, childNode)
+		progress.ItemsTotal++
+		if child.IsFolder() {
+			if err := c.listFolderTree(ctx, volumeID, childNode, progress, opts...); err != nil {
+				return err
+			}
+			continue
+		}
+		progress.BytesTotal += child.Size
+	}
+	return nil
+}
+
+// treeHasFolder reports whether id is node or one of the folders beneath it.
+func treeHasFolder(node *VolumeTreeNode, id FileID) bool {
+	if FileID(node.Entry.ID) == id {
+		return true
+	}
+	for _, child := range node.Children {
+		if child.Entry.IsFolder() && treeHasFolder(child, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// folderTransfer holds the state of a CopyFolder or MoveFolder call.
+type folderTransfer struct {
+	client   *SDKClient
+	opts     []CallOption
+	policy   NameConflictPolicy
+	move     bool
+	volume   VolumeID
+	progress FolderTransferProgress
+	report   chan<- FolderTransferProgress
+	result   *FolderTransferResult
+}
+
+func (t *folderTransfer) transferChildren(ctx context.Context, node *VolumeTreeNode, targetID FileID) error {
+	raw := t.client.raw
+	for _, child := range node.Children {
+		if child.Entry.IsFolder() {
+			childID, _, err := t.ensureFolder(ctx, targetID, child.Entry.Name)
+			if err != nil {
+				return fmt.Errorf("failed to create folder %q: %w", child.Path, err)
+			}
+			t.result.Folders++
+			t.done(child.Path, 0)
+			if err := t.transferChildren(ctx, child, childID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fileID := FileID(child.Entry.ID)
+		var err error
+		if t.move {
+			_, err = raw.MoveFile(ctx, &FileMoveRequest{FileID: fileID, TargetVolumeID: t.volume, TargetParentID: targetID, ConflictPolicy: t.policy}, t.opts...)
+		} else {
+			_, err = raw.CopyFile(ctx, &FileCopyRequest{FileID: fileID, TargetVolumeID: t.volume, TargetParentID: targetID, ConflictPolicy: t.policy}, t.opts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to transfer %q: %w", child.Path, err)
+		}
+		t.result.Files++
+		t.result.Bytes += child.Entry.Size
+		t.done(child.Path, child.Entry.Size)
+	}
+	return nil
+}
+
+// ensureFolder creates a folder named name under parentID in the target volume,
+// resolving a name conflict according to the policy. It returns the folder to use
+// and its name.
+func (t *folderTransfer) ensureFolder(ctx context.Context, parentID FileID, name string) (FileID, string, error) {
+	raw := t.client.raw
+	resp, err := raw.CreateFolder(ctx, &FolderCreateRequest{Name: name, VolumeID: t.volume, ParentID: parentID}, t.opts...)
+	if err == nil {
+		return resp.FolderID, name, nil
+	}
+	if !IsAlreadyExists(err) {
+		return "", "", err
+	}
+
+	switch t.policy {
+	case NameConflictOverwrite:
+		children, listErr := t.client.listAllFiles(ctx, []CommonFilter{
+			{Name: "volume_id", Values: []string{string(t.volume)}},
+			{Name: "parent_id", Values: []string{string(parentID)}},
+		}, t.opts...)
+		if listErr != nil {
+			return "", "", listErr
+		}
+		for _, child := range children {
+			if child.Name == name && child.IsFolder() {
+				return FileID(child.ID), name, nil
+			}
+		}
+	case NameConflictRename:
+		for i := 1; i <= maxRenameAttempts; i++ {
+			candidate := fmt.Sprintf("%s (%d)", name, i)
+			resp, err := raw.CreateFolder(ctx, &FolderCreateRequest{Name: candidate, VolumeID: t.volume, ParentID: parentID}, t.opts...)
+			if err == nil {
+				return resp.FolderID, candidate, nil
+			}
+			if !IsAlreadyExists(err) {
+				return "", "", err
+			}
+		}
+	}
+	return "", "", err
+}
+
+// done records a transferred item and reports the progress.
+func (t *folderTransfer) done(p string, size int64) {
+	t.progress.ItemsDone++
+	t.progress.BytesDone += size
+	t.progress.Path = p
+	if t.report == nil {
+		return
+	}
+	select {
+	case t.report <- t.progress:
+	default:
+	}
+}
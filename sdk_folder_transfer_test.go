@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// transferFake serves folder d1 named "docs" of volume v1 holding a.txt and
+// sub/b.txt, and records the calls made against the target.
+type transferFake struct {
+	t        *testing.T
+	mu       sync.Mutex
+	taken    map[string]bool // Folder names already present under the target parent
+	folders  []FolderCreateRequest
+	moved    []FileMoveRequest
+	copied   []FileCopyRequest
+	deleted  []FolderDeleteRequest
+	nextID   int
+	existing string // ID of the folder listed under the target parent
+}
+
+func (f *transferFake) client() *SDKClient {
+	t := f.t
+	return NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch r.URL.Path {
+		case "/catalog/file/info":
+			return envelopeResponse(`{"id":"d1","name":"docs","volume_id":"v1"}`), nil
+		case "/catalog/file/list":
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			switch req.Filters[1].Values[0] {
+			case "d1":
+				return envelopeResponse(`{"total":2,"list":[
+					{"id":"f1","name":"a.txt","size":5},
+					{"id":"d2","name":"sub","file_type":"dir"}]}`), nil
+			case "d2":
+				return envelopeResponse(`{"total":1,"list":[{"id":"f2","name":"b.txt","size":3}]}`), nil
+			case "p1":
+				return envelopeResponse(`{"total":1,"list":[{"id":"` + f.existing + `","name":"docs","file_type":"dir"}]}`), nil
+			}
+		case "/catalog/folder/create":
+			var req FolderCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.ParentID == "p1" && f.taken[req.Name] {
+				return errorEnvelopeResponse("ErrAlreadyExists", "folder exists"), nil
+			}
+			f.folders = append(f.folders, req)
+			f.nextID++
+			return envelopeResponse(`{"id":"t` + string(rune('0'+f.nextID)) + `","name":"` + req.Name + `"}`), nil
+		case "/catalog/file/move":
+			var req FileMoveRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			f.moved = append(f.moved, req)
+			return envelopeResponse(`{}`), nil
+		case "/catalog/file/copy":
+			var req FileCopyRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			f.copied = append(f.copied, req)
+			return envelopeResponse(`{}`), nil
+		case "/catalog/folder/delete":
+			var req FolderDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			f.deleted = append(f.deleted, req)
+			return envelopeResponse(`{}`), nil
+		}
+		t.Fatalf("unexpected request %s", r.URL.Path)
+		return nil, nil
+	}))
+}
+
+func TestCopyFolder_Recursive(t *testing.T) {
+	t.Parallel()
+	fake := &transferFake{t: t}
+	progress := make(chan FolderTransferProgress, 10)
+
+	result, err := fake.client().CopyFolder(context.Background(), "d1", "v2", "p1", FolderTransferOptions{Progress: progress})
+	require.NoError(t, err)
+	require.Equal(t, &FolderTransferResult{FolderID: "t1", Name: "docs", Folders: 2, Files: 2, Bytes: 8}, result)
+	require.Equal(t, []FolderCreateRequest{
+		{Name: "docs", VolumeID: "v2", ParentID: "p1"},
+		{Name: "sub", VolumeID: "v2", ParentID: "t1"},
+	}, fake.folders)
+	require.Equal(t, []FileCopyRequest{
+		{FileID: "f1", TargetVolumeID: "v2", TargetParentID: "t1"},
+		{FileID: "f2", TargetVolumeID: "v2", TargetParentID: "t2"},
+	}, fake.copied)
+	require.Empty(t, fake.moved)
+	require.Empty(t, fake.deleted)
+
+	close(progress)
+	var updates []FolderTransferProgress
+	for p := range progress {
+		updates = append(updates, p)
+	}
+	require.Equal(t, []FolderTransferProgress{
+		{ItemsDone: 1, ItemsTotal: 4, BytesTotal: 8, Path: "docs"},
+		{ItemsDone: 2, ItemsTotal: 4, BytesDone: 5, BytesTotal: 8, Path: "docs/a.txt"},
+		{ItemsDone: 3, ItemsTotal: 4, BytesDone: 5, BytesTotal: 8, Path: "docs/sub"},
+		{ItemsDone: 4, ItemsTotal: 4, BytesDone: 8, BytesTotal: 8, Path: "docs/sub/b.txt"},
+	}, updates)
+}
+
+func TestMoveFolder_RenameOnConflict(t *testing.T) {
+	t.Parallel()
+	fake := &transferFake{t: t, taken: map[string]bool{"docs": true, "docs (1)": true}}
+
+	result, err := fake.client().MoveFolder(context.Background(), "d1", "v2", "p1", FolderTransferOptions{ConflictPolicy: NameConflictRename})
+	require.NoError(t, err)
+	require.Equal(t, "docs (2)", result.Name)
+	require.Equal(t, FolderCreateRequest{Name: "docs (2)", VolumeID: "v2", ParentID: "p1"}, fake.folders[0])
+	require.Len(t, fake.moved, 2)
+	require.Equal(t, NameConflictRename, fake.moved[0].ConflictPolicy)
+	require.Equal(t, []FolderDeleteRequest{{FolderID: "d1"}}, fake.deleted)
+}
+
+func TestCopyFolder_OverwriteMergesExistingFolder(t *testing.T) {
+	t.Parallel()
+	fake := &transferFake{t: t, taken: map[string]bool{"docs": true}, existing: "e1"}
+
+	result, err := fake.client().CopyFolder(context.Background(), "d1", "v2", "p1", FolderTransferOptions{ConflictPolicy: NameConflictOverwrite})
+	require.NoError(t, err)
+	require.Equal(t, FileID("e1"), result.FolderID)
+	require.Equal(t, FileID("e1"), fake.copied[0].TargetParentID)
+}
+
+func TestCopyFolder_ConflictFails(t *testing.T) {
+	t.Parallel()
+	fake := &transferFake{t: t, taken: map[string]bool{"docs": true}}
+
+	_, err := fake.client().CopyFolder(context.Background(), "d1", "v2", "p1", FolderTransferOptions{})
+	require.ErrorIs(t, err, ErrAlreadyExists)
+	require.Empty(t, fake.copied)
+}
+
+func TestMoveFolder_IntoItself(t *testing.T) {
+	t.Parallel()
+	fake := &transferFake{t: t}
+
+	_, err := fake.client().MoveFolder(context.Background(), "d1", "v1", "d2", FolderTransferOptions{})
+	require.ErrorContains(t, err, "inside the source folder")
+	_, err = fake.client().MoveFolder(context.Background(), "d1", "v1", "d1", FolderTransferOptions{})
+	require.ErrorContains(t, err, "inside the source folder")
+	require.Empty(t, fake.folders)
+
+	// The same folder IDs in another volume are unrelated
+	_, err = fake.client().CopyFolder(context.Background(), "d1", "v2", "d2", FolderTransferOptions{})
+	require.NoError(t, err)
+}
+
+func TestMoveFolder_OverwriteIntoOwnParent(t *testing.T) {
+	t.Parallel()
+	fake := &transferFake{t: t, taken: map[string]bool{"docs": true}, existing: "d1"}
+
+	_, err := fake.client().MoveFolder(context.Background(), "d1", "v1", "p1", FolderTransferOptions{ConflictPolicy: NameConflictOverwrite})
+	require.ErrorContains(t, err, "target folder is the source folder")
+	require.Empty(t, fake.moved)
+	require.Empty(t, fake.deleted)
+}
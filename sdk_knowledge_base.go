@@ -0,0 +1,170 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Naming conventions used by EnsureKnowledgeBase for the resources of a knowledge base.
+const (
+	knowledgeBaseSourceVolumeSuffix = "_source"
+	knowledgeBaseTargetVolumeSuffix = "_target"
+	knowledgeBaseWorkflowSuffix     = "_workflow"
+)
+
+// KnowledgeBase holds the IDs of all resources that make up a standard RAG knowledge base.
+type KnowledgeBase struct {
+	CatalogID      CatalogID
+	DatabaseID     DatabaseID
+	SourceVolumeID VolumeID
+	TargetVolumeID VolumeID
+	WorkflowID     string
+	// Created lists the kinds of resources ("catalog", "database", "source_volume",
+	// "target_volume", "workflow") that were created by this call. It is empty when
+	// the knowledge base already existed.
+	Created []string
+}
+
+// EnsureKnowledgeBase creates or reuses the catalog, database, source volume, target
+// volume and document processing workflow of a standard RAG knowledge base.
+//
+// Resources are looked up by name and only created when missing, so the call is safe
+// to repeat. The conventions are:
+//   - the catalog is named catalogName
+//   - the database is named name
+//   - the volumes are named name+"_source" and name+"_target"
+//   - the workflow is named name+"_workflow" and wired from the source to the target
+//     volume using the pipeline of CreateDocumentProcessingWorkflow
+//
+// An existing workflow is detected through the workflow references of the source
+// volume. If the workflow creation fails, resources created earlier in the call are
+// left in place; calling EnsureKnowledgeBase again resumes from where it stopped.
+//
+// With WithConsistencyWait, every created catalog, database and volume is awaited
+// until list calls return it, so callers and repeated calls can rely on it right away.
+//
+// Example:
+//
+//	kb, err := sdkClient.EnsureKnowledgeBase(ctx, "tenant-a", "product-docs")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("upload documents to volume %s\n", kb.SourceVolumeID)
+func (c *SDKClient) EnsureKnowledgeBase(ctx context.Context, catalogName string, name string, opts ...CallOption) (*KnowledgeBase, error) {
+	opts = withoutIdempotencyKey(opts)
+	if strings.TrimSpace(catalogName) == "" {
+		return nil, fmt.Errorf("catalog_name is required")
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+
+	kb := &KnowledgeBase{}
+
+	catalogID, created, err := c.EnsureCatalog(ctx, catalogName, opts...)
+	if created {
+		kb.Created = append(kb.Created, "catalog")
+	}
+	if err != nil {
+		return nil, err
+	}
+	kb.CatalogID = catalogID
+
+	databaseID, created, err := c.EnsureDatabase(ctx, catalogID, name, opts...)
+	if created {
+		kb.Created = append(kb.Created, "database")
+	}
+	if err != nil {
+		return nil, err
+	}
+	kb.DatabaseID = databaseID
+
+	ensureVolume := func(volumeName, kind string) (VolumeID, error) {
+		volumeID, created, err := c.EnsureVolume(ctx, databaseID, volumeName, opts...)
+		if created {
+			kb.Created = append(kb.Created, kind)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to ensure %s: %w", kind, err)
+		}
+		return volumeID, nil
+	}
+	if kb.SourceVolumeID, err = ensureVolume(name+knowledgeBaseSourceVolumeSuffix, "source_volume"); err != nil {
+		return nil, err
+	}
+	if kb.TargetVolumeID, err = ensureVolume(name+knowledgeBaseTargetVolumeSuffix, "target_volume"); err != nil {
+		return nil, err
+	}
+
+	workflowID, found, err := c.findVolumeWorkflow(ctx, kb.SourceVolumeID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up workflow: %w", err)
+	}
+	if !found {
+		workflowID, err = c.CreateDocumentProcessingWorkflow(ctx, name+knowledgeBaseWorkflowSuffix, kb.SourceVolumeID, kb.TargetVolumeID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		kb.Created = append(kb.Created, "workflow")
+	}
+	kb.WorkflowID = workflowID
+
+	return kb, nil
+}
+
+// findCatalogByName returns the ID of the catalog with the given name.
+func (c *SDKClient) findCatalogByName(ctx context.Context, name string, opts ...CallOption) (CatalogID, bool, error) {
+	resp, err := c.raw.ListCatalogs(ctx, opts...)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, catalog := range resp.List {
+		if catalog.CatalogName == name {
+			return catalog.CatalogID, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// findDatabaseByName returns the ID of the database with the given name in a catalog.
+func (c *SDKClient) findDatabaseByName(ctx context.Context, catalogID CatalogID, name string, opts ...CallOption) (DatabaseID, bool, error) {
+	resp, err := c.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: catalogID}, opts...)
+	if err != nil {
+		return 0, false, err
+	}
+	for _, db := range resp.List {
+		if db.DatabaseName == name {
+			return db.DatabaseID, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// findVolumeByName returns the ID of the volume with the given name in a database.
+func (c *SDKClient) findVolumeByName(ctx context.Context, databaseID DatabaseID, name string, opts ...CallOption) (VolumeID, bool, error) {
+	resp, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID}, opts...)
+	if err != nil {
+		return "", false, err
+	}
+	for _, child := range resp.List {
+		if child.Name == name && strings.EqualFold(child.Typ, "volume") {
+			return VolumeID(child.ID), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// findVolumeWorkflow returns the ID of a workflow that references the given volume.
+func (c *SDKClient) findVolumeWorkflow(ctx context.Context, volumeID VolumeID, opts ...CallOption) (string, bool, error) {
+	resp, err := c.raw.GetVolumeRefList(ctx, &VolumeRefListRequest{VolumeID: volumeID}, opts...)
+	if err != nil {
+		return "", false, err
+	}
+	for _, ref := range resp.List {
+		if ref != nil && strings.EqualFold(ref.RefType, "workflow") && ref.RefID != "" {
+			return ref.RefID, true, nil
+		}
+	}
+	return "", false, nil
+}
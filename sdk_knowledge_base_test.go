@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureKnowledgeBase_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := NewSDKClient(&RawClient{})
+
+	_, err := client.EnsureKnowledgeBase(ctx, "", "kb")
+	require.ErrorContains(t, err, "catalog_name is required")
+
+	_, err = client.EnsureKnowledgeBase(ctx, "catalog", "  ")
+	require.ErrorContains(t, err, "name is required")
+}
+
+func TestEnsureKnowledgeBase_LiveFlow(t *testing.T) {
+	ctx := context.Background()
+	rawClient := newTestClient(t)
+	client := NewSDKClient(rawClient)
+
+	catalogName := randomName("sdk-kb-cat-")
+	kbName := randomName("sdk_kb_")
+
+	kb, err := client.EnsureKnowledgeBase(ctx, catalogName, kbName)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if _, err := rawClient.DeleteCatalog(ctx, &CatalogDeleteRequest{CatalogID: kb.CatalogID}); err != nil {
+			t.Logf("cleanup delete catalog failed: %v", err)
+		}
+	})
+	require.NotZero(t, kb.CatalogID)
+	require.NotZero(t, kb.DatabaseID)
+	require.NotEmpty(t, kb.SourceVolumeID)
+	require.NotEmpty(t, kb.TargetVolumeID)
+	require.NotEmpty(t, kb.WorkflowID)
+	require.Contains(t, kb.Created, "catalog")
+
+	// A second call must reuse the existing resources
+	again, err := client.EnsureKnowledgeBase(ctx, catalogName, kbName)
+	require.NoError(t, err)
+	require.Equal(t, kb.CatalogID, again.CatalogID)
+	require.Equal(t, kb.DatabaseID, again.DatabaseID)
+	require.Equal(t, kb.SourceVolumeID, again.SourceVolumeID)
+	require.Equal(t, kb.TargetVolumeID, again.TargetVolumeID)
+	require.NotContains(t, again.Created, "catalog")
+}
@@ -0,0 +1,390 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// KnowledgeFormat is the file format used by ImportKnowledge and ExportKnowledge.
+type KnowledgeFormat string
+
+const (
+	// KnowledgeFormatJSONL stores one KnowledgeRecord JSON object per line.
+	KnowledgeFormatJSONL KnowledgeFormat = "jsonl"
+	// KnowledgeFormatCSV stores one record per row under the header
+	// type,key,value,associate_tables,explanation_type. List cells hold JSON arrays.
+	KnowledgeFormatCSV KnowledgeFormat = "csv"
+)
+
+// knowledgeCSVHeader lists the columns written by ExportKnowledge in CSV format.
+var knowledgeCSVHeader = []string{"type", "key", "value", "associate_tables", "explanation_type"}
+
+// KnowledgeRecord is an NL2SQL knowledge entry in an import or export file.
+type KnowledgeRecord struct {
+	Type            string   `json:"type"`
+	Key             string   `json:"key"` // The question
+	Value           []string `json:"value"`
+	AssociateTables []string `json:"associate_tables,omitempty"`
+	ExplanationType string   `json:"explanation_type,omitempty"`
+}
+
+// KnowledgeImportOptions controls ImportKnowledge.
+type KnowledgeImportOptions struct {
+	// DedupOnQuestion updates the existing entry with the same type and key (question)
+	// instead of creating a duplicate. This also applies to repeated keys in the file,
+	// so the last record wins.
+	DedupOnQuestion bool
+
+	// DefaultType is used for records without a type.
+	DefaultType string
+}
+
+// KnowledgeRowError is a record that could not be imported.
+type KnowledgeRowError struct {
+	// Line is the line of the record in the file, starting from 1.
+	Line int
+	// Key is the key of the record, if it could be read.
+	Key string
+	Err error
+}
+
+// KnowledgeImportResult reports the outcome of ImportKnowledge.
+type KnowledgeImportResult struct {
+	Created int
+	Updated int
+	// Errors lists the records that were not imported, in file order.
+	Errors []KnowledgeRowError
+}
+
+// Err returns nil if every record was imported, or an error joining all row failures.
+func (r *KnowledgeImportResult) Err() error {
+	if r == nil || len(r.Errors) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Errors))
+	for i, row := range r.Errors {
+		errs[i] = fmt.Errorf("line %d (%s): %w", row.Line, row.Key, row.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// ImportKnowledge creates NL2SQL knowledge entries from the records read from r,
+// e.g. a knowledge base versioned in git.
+//
+// Records that cannot be parsed or are rejected by the service are reported in the
+// result and do not stop the import; an error is only returned when r cannot be read
+// as a whole, the existing entries cannot be listed, or ctx is done.
+//
+// Example:
+//
+//	f, err := os.Open("knowledge.jsonl")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	result, err := sdkClient.ImportKnowledge(ctx, f, sdk.KnowledgeFormatJSONL,
+//		sdk.KnowledgeImportOptions{DedupOnQuestion: true})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("created %d, updated %d\n", result.Created, result.Updated)
+//	for _, row := range result.Errors {
+//		fmt.Printf("line %d: %v\n", row.Line, row.Err)
+//	}
+func (c *SDKClient) ImportKnowledge(ctx context.Context, r io.Reader, format KnowledgeFormat, importOpts KnowledgeImportOptions, opts ...CallOption) (*KnowledgeImportResult, error) {
+	opts = withoutIdempotencyKey(opts)
+	if r == nil {
+		return nil, fmt.Errorf("reader cannot be nil")
+	}
+	if format != KnowledgeFormatJSONL && format != KnowledgeFormatCSV {
+		return nil, fmt.Errorf("unsupported knowledge format %q", format)
+	}
+	result := &KnowledgeImportResult{}
+	var existing map[[2]string]Nl2SqlKnowledgeID
+	if importOpts.DedupOnQuestion {
+		entries, err := c.listAllKnowledge(ctx, "", opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list knowledge: %w", err)
+		}
+		existing = make(map[[2]string]Nl2SqlKnowledgeID, len(entries))
+		for _, entry := range entries {
+			existing[[2]string{entry.Type, entry.Key}] = entry.ID
+		}
+	}
+
+	err := readKnowledgeRecords(r, format, func(line int, record *KnowledgeRecord, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err == nil {
+			if record.Type == "" {
+				record.Type = importOpts.DefaultType
+			}
+			err = validateKnowledgeRecord(record)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, KnowledgeRowError{Line: line, Key: record.Key, Err: err})
+			return nil
+		}
+
+		dedupKey := [2]string{record.Type, record.Key}
+		if id, ok := existing[dedupKey]; ok {
+			_, err = c.raw.UpdateKnowledge(ctx, &NL2SQLKnowledgeUpdateRequest{
+				ID:              id,
+				Type:            record.Type,
+				Key:             record.Key,
+				Value:           record.Value,
+				AssociateTables: record.AssociateTables,
+				ExplanationType: record.ExplanationType,
+			}, opts...)
+			if err == nil {
+				result.Updated++
+			}
+		} else {
+			var resp *NL2SQLKnowledgeCreateResponse
+			resp, err = c.raw.CreateKnowledge(ctx, &NL2SQLKnowledgeCreateRequest{
+				Type:            record.Type,
+				Key:             record.Key,
+				Value:           record.Value,
+				AssociateTables: record.AssociateTables,
+				ExplanationType: record.ExplanationType,
+			}, opts...)
+			if err == nil {
+				result.Created++
+				if existing != nil {
+					existing[dedupKey] = resp.ID
+				}
+			}
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, KnowledgeRowError{Line: line, Key: record.Key, Err: err})
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// ExportKnowledge writes the NL2SQL knowledge entries of type knowledgeType, or all
+// entries if it is empty, to w and returns the number of entries written.
+//
+// Entries are sorted by type and key and written without IDs or timestamps, so that
+// exports of the same knowledge base are identical and diff cleanly in git. The
+// output can be read back by ImportKnowledge.
+//
+// Example:
+//
+//	f, err := os.Create("knowledge.jsonl")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	n, err := sdkClient.ExportKnowledge(ctx, "", f, sdk.KnowledgeFormatJSONL)
+func (c *SDKClient) ExportKnowledge(ctx context.Context, knowledgeType string, w io.Writer, format KnowledgeFormat, opts ...CallOption) (int, error) {
+	if w == nil {
+		return 0, fmt.Errorf("writer cannot be nil")
+	}
+	if format != KnowledgeFormatJSONL && format != KnowledgeFormatCSV {
+		return 0, fmt.Errorf("unsupported knowledge format %q", format)
+	}
+	entries, err := c.listAllKnowledge(ctx, knowledgeType, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list knowledge: %w", err)
+	}
+	records := make([]KnowledgeRecord, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, KnowledgeRecord{
+			Type:            entry.Type,
+			Key:             entry.Key,
+			Value:           entry.Value,
+			AssociateTables: entry.AssociateTables,
+		})
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Type != records[j].Type {
+			return records[i].Type < records[j].Type
+		}
+		return records[i].Key < records[j].Key
+	})
+
+	if format == KnowledgeFormatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(knowledgeCSVHeader); err != nil {
+			return 0, err
+		}
+		for i, record := range records {
+			tables := ""
+			if len(record.AssociateTables) > 0 {
+				tables = knowledgeCSVList(record.AssociateTables)
+			}
+			if err := cw.Write([]string{record.Type, record.Key, knowledgeCSVList(record.Value), tables, record.ExplanationType}); err != nil {
+				return i, err
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return 0, err
+		}
+		return len(records), nil
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for i := range records {
+		if err := enc.Encode(&records[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(records), nil
+}
+
+// listAllKnowledge returns every NL2SQL knowledge entry of knowledgeType, or of all
+// types if it is empty.
+func (c *SDKClient) listAllKnowledge(ctx context.Context, knowledgeType string, opts ...CallOption) ([]*Nl2SqlKnowledgeResponse, error) {
+	var all []*Nl2SqlKnowledgeResponse
+	var seen int64
+	for page := 1; ; page++ {
+		if err := c.raw.checkPageLimit(page); err != nil {
+			return nil, err
+		}
+		resp, err := c.raw.ListKnowledge(ctx, &NL2SQLKnowledgeListRequest{
+			Type:       knowledgeType,
+			PageNumber: page,
+			PageSize:   listPageSize,
+		}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range resp.List {
+			if entry != nil {
+				all = append(all, entry)
+			}
+		}
+		seen += int64(len(resp.List))
+		if len(resp.List) == 0 || seen >= resp.Total {
+			return all, nil
+		}
+	}
+}
+
+// knowledgeCSVList encodes a list cell of the CSV format as a JSON array.
+func knowledgeCSVList(values []string) string {
+	if values == nil {
+		values = []string{}
+	}
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(values)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func validateKnowledgeRecord(record *KnowledgeRecord) error {
+	if strings.TrimSpace(record.Type) == "" {
+		return fmt.Errorf("type is required")
+	}
+	if strings.TrimSpace(record.Key) == "" {
+		return fmt.Errorf("key is required")
+	}
+	if len(record.Value) == 0 {
+		return fmt.Errorf("value is required")
+	}
+	return nil
+}
+
+// readKnowledgeRecords calls fn with every record read from r, or with the error
+// that prevented reading it. Blank lines are skipped. It stops with the error of fn,
+// or of r if the input cannot be read.
+func readKnowledgeRecords(r io.Reader, format KnowledgeFormat, fn func(line int, record *KnowledgeRecord, err error) error) error {
+	switch format {
+	case KnowledgeFormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" {
+				continue
+			}
+			record := &KnowledgeRecord{}
+			err := json.Unmarshal([]byte(text), record)
+			if err != nil {
+				err = fmt.Errorf("invalid JSON: %w", err)
+			}
+			if err := fn(line, record, err); err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+
+	case KnowledgeFormatCSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		header, err := cr.Read()
+		if err != nil {
+			return fmt.Errorf("read CSV header: %w", err)
+		}
+		columns := make(map[string]int, len(header))
+		for i, name := range header {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		for _, required := range []string{"key", "value"} {
+			if _, ok := columns[required]; !ok {
+				return fmt.Errorf("CSV header is missing the %q column", required)
+			}
+		}
+		for {
+			row, err := cr.Read()
+			if err == io.EOF {
+				return nil
+			}
+			var line int
+			record := &KnowledgeRecord{}
+			if err != nil {
+				var parseErr *csv.ParseError
+				if !errors.As(err, &parseErr) {
+					return err
+				}
+				line = parseErr.StartLine
+			} else {
+				line, _ = cr.FieldPos(0)
+				err = parseKnowledgeCSVRow(row, columns, record)
+			}
+			if err := fn(line, record, err); err != nil {
+				return err
+			}
+		}
+	}
+	return fmt.Errorf("unsupported knowledge format %q", format)
+}
+
+func parseKnowledgeCSVRow(row []string, columns map[string]int, record *KnowledgeRecord) error {
+	cell := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	record.Type = cell("type")
+	record.Key = cell("key")
+	record.ExplanationType = cell("explanation_type")
+	record.AssociateTables = parseIDList(cell("associate_tables"))
+	value := cell("value")
+	switch {
+	case strings.HasPrefix(value, "["):
+		if err := json.Unmarshal([]byte(value), &record.Value); err != nil {
+			return fmt.Errorf("invalid value: %w", err)
+		}
+	case value != "":
+		record.Value = []string{value}
+	}
+	return nil
+}
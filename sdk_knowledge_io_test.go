@@ -0,0 +1,171 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportKnowledge_JSONL(t *testing.T) {
+	t.Parallel()
+
+	var created []NL2SQLKnowledgeCreateRequest
+	var updated []NL2SQLKnowledgeUpdateRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/nl2sql_knowledge/list":
+			return envelopeResponse(`{"total":1,"list":[{"id":7,"type":"sql","key":"monthly revenue","value":["old"]}]}`), nil
+		case "/catalog/nl2sql_knowledge/create":
+			var req NL2SQLKnowledgeCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Key == "rejected" {
+				return errorEnvelopeResponse("ErrInvalidParam", "bad sql"), nil
+			}
+			created = append(created, req)
+			return envelopeResponse(fmt.Sprintf(`{"id":%d}`, 100+len(created))), nil
+		case "/catalog/nl2sql_knowledge/update":
+			var req NL2SQLKnowledgeUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			updated = append(updated, req)
+			return envelopeResponse(fmt.Sprintf(`{"id":%d}`, req.ID)), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+
+	input := strings.Join([]string{
+		`{"type":"sql","key":"monthly revenue","value":["select sum(amount) from orders"]}`,
+		`{"key":"top customers","value":["select 1"],"associate_tables":["sales.customers"]}`,
+		``,
+		`{not json`,
+		`{"type":"sql","key":"rejected","value":["x"]}`,
+		`{"type":"sql","key":"no value"}`,
+		`{"type":"sql","key":"top customers","value":["select 2"]}`,
+	}, "\n")
+	result, err := client.ImportKnowledge(context.Background(), strings.NewReader(input), KnowledgeFormatJSONL,
+		KnowledgeImportOptions{DedupOnQuestion: true, DefaultType: "sql"})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Created)
+	require.Equal(t, 2, result.Updated)
+
+	require.Len(t, created, 1)
+	require.Equal(t, "sql", created[0].Type)
+	require.Equal(t, []string{"sales.customers"}, created[0].AssociateTables)
+	require.Len(t, updated, 2)
+	require.Equal(t, Nl2SqlKnowledgeID(7), updated[0].ID)
+	require.Equal(t, Nl2SqlKnowledgeID(101), updated[1].ID)
+	require.Equal(t, []string{"select 2"}, updated[1].Value)
+
+	require.Len(t, result.Errors, 3)
+	require.Equal(t, 4, result.Errors[0].Line)
+	require.ErrorContains(t, result.Errors[0].Err, "invalid JSON")
+	require.Equal(t, "rejected", result.Errors[1].Key)
+	require.ErrorContains(t, result.Errors[2].Err, "value is required")
+	require.ErrorContains(t, result.Err(), "line 5 (rejected)")
+}
+
+func TestImportKnowledge_CSV(t *testing.T) {
+	t.Parallel()
+
+	var created []NL2SQLKnowledgeCreateRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/nl2sql_knowledge/create", r.URL.Path)
+		var req NL2SQLKnowledgeCreateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		created = append(created, req)
+		return envelopeResponse(`{"id":1}`), nil
+	}))
+
+	input := "key,value,associate_tables,type\n" +
+		"revenue,\"select a, b from t\",\"sales.t, sales.u\",sql\n" +
+		"multi,\"[\"\"select 1\"\",\"\"select 2\"\"]\",,sql\n" +
+		",select 3,,sql\n"
+	result, err := client.ImportKnowledge(context.Background(), strings.NewReader(input), KnowledgeFormatCSV, KnowledgeImportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Created)
+	require.Equal(t, []string{"select a, b from t"}, created[0].Value)
+	require.Equal(t, []string{"sales.t", "sales.u"}, created[0].AssociateTables)
+	require.Equal(t, []string{"select 1", "select 2"}, created[1].Value)
+	require.Len(t, result.Errors, 1)
+	require.Equal(t, 4, result.Errors[0].Line)
+	require.ErrorContains(t, result.Errors[0].Err, "key is required")
+
+	_, err = client.ImportKnowledge(context.Background(), strings.NewReader("type,key\n"), KnowledgeFormatCSV, KnowledgeImportOptions{})
+	require.ErrorContains(t, err, `missing the "value" column`)
+	_, err = client.ImportKnowledge(context.Background(), strings.NewReader(""), "xml", KnowledgeImportOptions{})
+	require.ErrorContains(t, err, "unsupported knowledge format")
+}
+
+func TestImportKnowledge_MalformedCSVRow(t *testing.T) {
+	t.Parallel()
+
+	var created []NL2SQLKnowledgeCreateRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var req NL2SQLKnowledgeCreateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		created = append(created, req)
+		return envelopeResponse(`{"id":1}`), nil
+	}))
+
+	input := "key,value,type\n" +
+		"\"bad\"x,select 1,sql\n" +
+		"revenue,select 2,sql\n"
+	result, err := client.ImportKnowledge(context.Background(), strings.NewReader(input), KnowledgeFormatCSV, KnowledgeImportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Created)
+	require.Equal(t, "revenue", created[0].Key)
+	require.Len(t, result.Errors, 1)
+	require.Equal(t, 2, result.Errors[0].Line)
+	require.ErrorContains(t, result.Errors[0].Err, "parse error")
+}
+
+func TestExportKnowledge(t *testing.T) {
+	t.Parallel()
+
+	var types []string
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var req NL2SQLKnowledgeListRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		types = append(types, req.Type)
+		return envelopeResponse(`{"total":2,"list":[
+			{"id":2,"type":"sql","key":"b","value":["select <b>"],"created_at":"2024-01-01"},
+			{"id":1,"type":"sql","key":"a","value":["select a"],"associate_tables":["db.t"]}
+		]}`), nil
+	}))
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	n, err := client.ExportKnowledge(ctx, "sql", &out, KnowledgeFormatJSONL)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []string{"sql"}, types)
+	require.Equal(t, `{"type":"sql","key":"a","value":["select a"],"associate_tables":["db.t"]}`+"\n"+
+		`{"type":"sql","key":"b","value":["select <b>"]}`+"\n", out.String())
+
+	out.Reset()
+	_, err = client.ExportKnowledge(ctx, "", &out, KnowledgeFormatCSV)
+	require.NoError(t, err)
+	require.Equal(t, "type,key,value,associate_tables,explanation_type\n"+
+		"sql,a,\"[\"\"select a\"\"]\",\"[\"\"db.t\"\"]\",\n"+
+		"sql,b,\"[\"\"select <b>\"\"]\",,\n", out.String())
+
+	// The export can be imported again
+	var created []NL2SQLKnowledgeCreateRequest
+	importer := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var req NL2SQLKnowledgeCreateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		created = append(created, req)
+		return envelopeResponse(`{"id":1}`), nil
+	}))
+	result, err := importer.ImportKnowledge(ctx, &out, KnowledgeFormatCSV, KnowledgeImportOptions{})
+	require.NoError(t, err)
+	require.NoError(t, result.Err())
+	require.Equal(t, []string{"db.t"}, created[0].AssociateTables)
+	require.Equal(t, []string{"select <b>"}, created[1].Value)
+}
@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+// OrphanedArtifactsResult reports the outcome of CollectOrphanedArtifacts.
+type OrphanedArtifactsResult struct {
+	// Scanned is the number of files inspected in the target volume.
+	Scanned int
+	// Orphans are the target-volume files whose source file no longer exists.
+	Orphans []VolumeChildrenResponse
+	// OrphanedSourceIDs are the deleted source file IDs still referenced by Orphans.
+	OrphanedSourceIDs []string
+	// Purged are the source file IDs whose artifacts were deleted. It is empty in dry-run mode.
+	Purged []string
+	// DryRun reports whether the call only identified orphans without deleting them.
+	DryRun bool
+}
+
+// CollectOrphanedArtifacts finds artifacts (chunks, embeddings and other workflow output)
+// in a target volume whose source file has been deleted, and purges them.
+//
+// Every file in the target volume that carries a RefFileID is checked against its source
+// file; when the source no longer exists, all artifacts referencing it are removed with
+// DeleteFileRef. With dryRun set, nothing is deleted and the result only lists the orphans.
+//
+// Example:
+//
+//	result, err := sdkClient.CollectOrphanedArtifacts(ctx, "target-volume-id", true)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%d of %d artifacts are orphaned\n", len(result.Orphans), result.Scanned)
+func (c *SDKClient) CollectOrphanedArtifacts(ctx context.Context, targetVolumeID VolumeID, dryRun bool, opts ...CallOption) (*OrphanedArtifactsResult, error) {
+	opts = withoutIdempotencyKey(opts)
+	if targetVolumeID == "" {
+		return nil, fmt.Errorf("target_volume_id is required")
+	}
+
+	files, err := c.listAllFiles(ctx, []CommonFilter{
+		{Name: "volume_id", Values: []string{string(targetVolumeID)}},
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list target volume files: %w", err)
+	}
+
+	result := &OrphanedArtifactsResult{Scanned: len(files), DryRun: dryRun}
+	sourceExists := make(map[string]bool)
+	for _, file := range files {
+		if file.RefFileID == "" {
+			continue
+		}
+		exists, checked := sourceExists[file.RefFileID]
+		if !checked {
+			_, err := c.raw.GetFile(ctx, &FileInfoRequest{FileID: FileID(file.RefFileID)}, opts...)
+			switch {
+			case err == nil:
+				exists = true
+			case IsNotFound(err):
+				exists = false
+				result.OrphanedSourceIDs = append(result.OrphanedSourceIDs, file.RefFileID)
+			default:
+				return nil, fmt.Errorf("failed to check source file %s: %w", file.RefFileID, err)
+			}
+			sourceExists[file.RefFileID] = exists
+		}
+		if !exists {
+			result.Orphans = append(result.Orphans, file)
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	for _, sourceID := range result.OrphanedSourceIDs {
+		if _, err := c.raw.DeleteFileRef(ctx, &FileDeleteRefRequest{RefFileID: sourceID}, opts...); err != nil {
+			return result, fmt.Errorf("failed to purge artifacts of source file %s: %w", sourceID, err)
+		}
+		result.Purged = append(result.Purged, sourceID)
+	}
+	return result, nil
+}
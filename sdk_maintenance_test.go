@@ -0,0 +1,79 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newOrphanTestClient(t *testing.T, purged *[]string) *SDKClient {
+	t.Helper()
+	var mu sync.Mutex
+	return NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var body map[string]interface{}
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		switch r.URL.Path {
+		case "/catalog/file/list":
+			return envelopeResponse(`{"total":4,"list":[
+				{"id":"a1","name":"chunk-1","ref_file_id":"src-live"},
+				{"id":"a2","name":"chunk-2","ref_file_id":"src-gone"},
+				{"id":"a3","name":"chunk-3","ref_file_id":"src-gone"},
+				{"id":"a4","name":"readme.txt"}
+			]}`), nil
+		case "/catalog/file/info":
+			if body["id"] == "src-gone" {
+				return errorEnvelopeResponse("ErrInternal", "file not exist"), nil
+			}
+			return envelopeResponse(`{"id":"src-live"}`), nil
+		case "/catalog/file/delete_ref":
+			mu.Lock()
+			*purged = append(*purged, body["id"].(string))
+			mu.Unlock()
+			return envelopeResponse(`{"id":"x"}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+}
+
+func TestCollectOrphanedArtifacts_DryRun(t *testing.T) {
+	t.Parallel()
+
+	var purged []string
+	client := newOrphanTestClient(t, &purged)
+
+	result, err := client.CollectOrphanedArtifacts(context.Background(), "target", true)
+	require.NoError(t, err)
+	require.True(t, result.DryRun)
+	require.Equal(t, 4, result.Scanned)
+	require.Len(t, result.Orphans, 2)
+	require.Equal(t, []string{"src-gone"}, result.OrphanedSourceIDs)
+	require.Empty(t, result.Purged)
+	require.Empty(t, purged)
+}
+
+func TestCollectOrphanedArtifacts_Purge(t *testing.T) {
+	t.Parallel()
+
+	var purged []string
+	client := newOrphanTestClient(t, &purged)
+
+	result, err := client.CollectOrphanedArtifacts(context.Background(), "target", false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"src-gone"}, result.Purged)
+	require.Equal(t, []string{"src-gone"}, purged)
+}
+
+func TestCollectOrphanedArtifacts_EmptyVolumeID(t *testing.T) {
+	t.Parallel()
+
+	client := NewSDKClient(&RawClient{})
+	_, err := client.CollectOrphanedArtifacts(context.Background(), "", true)
+	require.ErrorContains(t, err, "target_volume_id is required")
+}
@@ -0,0 +1,321 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProvisionPlan describes a catalog hierarchy for a Provisioner to create.
+type ProvisionPlan struct {
+	// Catalog is the name of the catalog; required
+	Catalog string
+	// Database is the name of the database in the catalog; required with Volumes or Tables
+	Database string
+	Volumes  []VolumePlan
+	Tables   []TablePlan
+}
+
+// VolumePlan describes a volume of a ProvisionPlan and the folders to create in it.
+type VolumePlan struct {
+	Name string
+	// Folders are "/"-separated folder paths from the root of the volume
+	Folders []string
+}
+
+// TablePlan describes a table of a ProvisionPlan.
+type TablePlan struct {
+	Name    string
+	Columns []Column
+	Comment string
+}
+
+// ProvisionAction is what a Provisioner did for one object of a plan.
+type ProvisionAction string
+
+const (
+	ProvisionCreated        ProvisionAction = "created"
+	ProvisionExisting       ProvisionAction = "existing" // Already existed; never rolled back
+	ProvisionFailed         ProvisionAction = "failed"
+	ProvisionRolledBack     ProvisionAction = "rolled_back"
+	ProvisionRollbackFailed ProvisionAction = "rollback_failed"
+)
+
+// ProvisionStep reports the outcome of one object of a plan.
+type ProvisionStep struct {
+	// Kind is "catalog", "database", "volume", "folder" or "table"
+	Kind string
+	// Name is the name of the object, or "volume/path" for folders
+	Name   string
+	ID     string
+	Action ProvisionAction
+	// Err is set for the actions ProvisionFailed and ProvisionRollbackFailed
+	Err error
+}
+
+// ProvisionReport is the outcome of Provisioner.Provision.
+type ProvisionReport struct {
+	CatalogID  CatalogID
+	DatabaseID DatabaseID
+	// Volumes maps volume names to their IDs
+	Volumes map[string]VolumeID
+	// Folders maps "volume/path" to folder IDs
+	Folders map[string]FileID
+	// Tables maps table names to their IDs
+	Tables map[string]TableID
+	// Steps lists the objects in the order they were provisioned
+	Steps []ProvisionStep
+	// RolledBack reports whether a failure triggered the rollback of created objects
+	RolledBack bool
+}
+
+// Created returns the steps of the objects created and kept.
+func (r *ProvisionReport) Created() []ProvisionStep {
+	if r == nil {
+		return nil
+	}
+	var out []ProvisionStep
+	for _, step := range r.Steps {
+		if step.Action == ProvisionCreated {
+			out = append(out, step)
+		}
+	}
+	return out
+}
+
+// Provisioner creates the objects of a ProvisionPlan as a unit: objects that already
+// exist are reused, and if any step fails, the objects created so far are deleted in
+// reverse order.
+//
+// The rollback is best effort, as the service has no transactions: objects whose
+// deletion fails are reported with ProvisionRollbackFailed.
+type Provisioner struct {
+	client *SDKClient
+}
+
+// NewProvisioner returns a Provisioner that creates objects with client.
+func NewProvisioner(client *SDKClient) *Provisioner {
+	if client == nil {
+		panic("SDKClient cannot be nil")
+	}
+	return &Provisioner{client: client}
+}
+
+// Provision creates the objects of plan in the order catalog, database, volumes,
+// folders, tables, and returns a report of every step.
+//
+// Objects are looked up by name like with EnsureCatalog; existing ones are reused,
+// even if they differ from the plan, e.g. a table with other columns. On failure, the
+// objects created by this call are deleted in reverse order and the report is
+// returned with the error. The rollback runs even if ctx is canceled.
+//
+// Example:
+//
+//	report, err := sdk.NewProvisioner(sdkClient).Provision(ctx, sdk.ProvisionPlan{
+//		Catalog:  "sales",
+//		Database: "orders",
+//		Volumes:  []sdk.VolumePlan{{Name: "raw", Folders: []string{"2024/q1"}}},
+//		Tables: []sdk.TablePlan{{Name: "line_items", Columns: []sdk.Column{
+//			{Name: "id", Type: "int", IsPk: true},
+//			{Name: "sku", Type: "varchar(64)"},
+//		}}},
+//	})
+//	if err != nil {
+//		return err // report.RolledBack tells whether created objects were deleted
+//	}
+//	fmt.Println("volume", report.Volumes["raw"], "table", report.Tables["line_items"])
+func (p *Provisioner) Provision(ctx context.Context, plan ProvisionPlan, opts ...CallOption) (*ProvisionReport, error) {
+	opts = withoutIdempotencyKey(opts)
+	if err := plan.validate(); err != nil {
+		return nil, err
+	}
+	run := &provisionRun{
+		client: p.client,
+		opts:   opts,
+		report: &ProvisionReport{
+			Volumes: make(map[string]VolumeID),
+			Folders: make(map[string]FileID),
+			Tables:  make(map[string]TableID),
+		},
+	}
+	if err := run.apply(ctx, plan); err != nil {
+		run.report.RolledBack = true
+		if rollbackErr := run.rollback(context.WithoutCancel(ctx)); rollbackErr != nil {
+			err = errors.Join(err, rollbackErr)
+		}
+		return run.report, err
+	}
+	return run.report, nil
+}
+
+func (plan ProvisionPlan) validate() error {
+	if strings.TrimSpace(plan.Catalog) == "" {
+		return fmt.Errorf("catalog is required")
+	}
+	if strings.TrimSpace(plan.Database) == "" && (len(plan.Volumes) > 0 || len(plan.Tables) > 0) {
+		return fmt.Errorf("database is required for volumes and tables")
+	}
+	for i, volume := range plan.Volumes {
+		if strings.TrimSpace(volume.Name) == "" {
+			return fmt.Errorf("volumes[%d]: name is required", i)
+		}
+	}
+	for i, table := range plan.Tables {
+		if strings.TrimSpace(table.Name) == "" {
+			return fmt.Errorf("tables[%d]: name is required", i)
+		}
+		if len(table.Columns) == 0 {
+			return fmt.Errorf("table %s: columns are required", table.Name)
+		}
+	}
+	return nil
+}
+
+// provisionRun is the state of one Provision call.
+type provisionRun struct {
+	client *SDKClient
+	opts   []CallOption
+	report *ProvisionReport
+}
+
+func (r *provisionRun) apply(ctx context.Context, plan ProvisionPlan) error {
+	c := r.client
+	catalogID, created, err := c.EnsureCatalog(ctx, plan.Catalog, r.opts...)
+	if err := r.record("catalog", plan.Catalog, strconv.FormatInt(int64(catalogID), 10), created, err); err != nil {
+		return err
+	}
+	r.report.CatalogID = catalogID
+	if plan.Database == "" {
+		return nil
+	}
+
+	databaseID, created, err := c.EnsureDatabase(ctx, catalogID, plan.Database, r.opts...)
+	if err := r.record("database", plan.Database, strconv.FormatInt(int64(databaseID), 10), created, err); err != nil {
+		return err
+	}
+	r.report.DatabaseID = databaseID
+
+	for _, volume := range plan.Volumes {
+		volumeID, created, err := c.EnsureVolume(ctx, databaseID, volume.Name, r.opts...)
+		if err := r.record("volume", volume.Name, string(volumeID), created, err); err != nil {
+			return err
+		}
+		r.report.Volumes[volume.Name] = volumeID
+		for _, folder := range volume.Folders {
+			name := volume.Name + "/" + strings.Trim(folder, "/")
+			// Every created folder is a step of its own, named by its own path, so
+			// that each can be rolled back
+			created := false
+			folderID, err := c.ensureFolderPath(ctx, volumeID, folder, func(folderPath string, id FileID) {
+				r.record("folder", volume.Name+"/"+folderPath, string(id), true, nil)
+				created = true
+			}, r.opts...)
+			if err != nil || !created {
+				if err := r.record("folder", name, string(folderID), false, err); err != nil {
+					return err
+				}
+			}
+			r.report.Folders[name] = folderID
+		}
+	}
+
+	for _, table := range plan.Tables {
+		tableID, created, err := r.ensureTable(ctx, databaseID, table)
+		if err := r.record("table", table.Name, strconv.FormatInt(int64(tableID), 10), created, err); err != nil {
+			return err
+		}
+		r.report.Tables[table.Name] = tableID
+	}
+	return nil
+}
+
+// ensureTable returns the ID of the table of the plan, creating it if it is missing.
+func (r *provisionRun) ensureTable(ctx context.Context, databaseID DatabaseID, table TablePlan) (TableID, bool, error) {
+	c := r.client
+	return ensureObject("table", func() (TableID, bool, error) {
+		ids, err := c.ResolveTables(ctx, databaseID, []string{table.Name}, r.opts...)
+		if err != nil {
+			return 0, false, err
+		}
+		id, ok := ids[table.Name]
+		return id, ok, nil
+	}, func() (TableID, error) {
+		resp, err := c.raw.CreateTable(ctx, &TableCreateRequest{
+			DatabaseID: databaseID,
+			Name:       table.Name,
+			Columns:    table.Columns,
+			Comment:    table.Comment,
+		}, r.opts...)
+		if err != nil {
+			return 0, err
+		}
+		return resp.TableID, nil
+	})
+}
+
+// record adds the steps of an object to the report: a created object is recorded
+// even if a later part of its step failed, so that it is rolled back. It returns err,
+// annotated, if the step failed.
+func (r *provisionRun) record(kind, name, id string, created bool, err error) error {
+	if created {
+		r.report.Steps = append(r.report.Steps, ProvisionStep{Kind: kind, Name: name, ID: id, Action: ProvisionCreated})
+	}
+	if err != nil {
+		r.report.Steps = append(r.report.Steps, ProvisionStep{Kind: kind, Name: name, Action: ProvisionFailed, Err: err})
+		return fmt.Errorf("failed to provision %s %s: %w", kind, name, err)
+	}
+	if !created {
+		r.report.Steps = append(r.report.Steps, ProvisionStep{Kind: kind, Name: name, ID: id, Action: ProvisionExisting})
+	}
+	return nil
+}
+
+// rollback deletes the created objects in reverse order and returns the joined errors
+// of the deletions that failed.
+func (r *provisionRun) rollback(ctx context.Context) error {
+	var errs []error
+	for i := len(r.report.Steps) - 1; i >= 0; i-- {
+		step := &r.report.Steps[i]
+		if step.Action != ProvisionCreated {
+			continue
+		}
+		if err := r.delete(ctx, step.Kind, step.ID); err != nil {
+			step.Action = ProvisionRollbackFailed
+			step.Err = err
+			errs = append(errs, fmt.Errorf("failed to roll back %s %s: %w", step.Kind, step.Name, err))
+			continue
+		}
+		step.Action = ProvisionRolledBack
+	}
+	return errors.Join(errs...)
+}
+
+// delete deletes the object of the given kind and ID.
+func (r *provisionRun) delete(ctx context.Context, kind, id string) error {
+	raw := r.client.raw
+	var err error
+	switch kind {
+	case "folder":
+		_, err = raw.DeleteFolder(ctx, &FolderDeleteRequest{FolderID: FileID(id)}, r.opts...)
+	case "volume":
+		_, err = raw.DeleteVolume(ctx, &VolumeDeleteRequest{VolumeID: VolumeID(id)}, r.opts...)
+	default:
+		num, parseErr := strconv.ParseInt(id, 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("invalid id %q: %w", id, parseErr)
+		}
+		switch kind {
+		case "catalog":
+			_, err = raw.DeleteCatalog(ctx, &CatalogDeleteRequest{CatalogID: CatalogID(num)}, r.opts...)
+		case "database":
+			_, err = raw.DeleteDatabase(ctx, &DatabaseDeleteRequest{DatabaseID: DatabaseID(num)}, r.opts...)
+		case "table":
+			_, err = raw.DeleteTable(ctx, &TableDeleteRequest{TableID: TableID(num)}, r.opts...)
+		default:
+			return fmt.Errorf("unsupported kind %s", kind)
+		}
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// provisionFake serves an existing catalog "sales" and records the calls that create
+// and delete objects.
+type provisionFake struct {
+	t           *testing.T
+	calls       []string
+	failTable   bool
+	failDeletes bool
+}
+
+func (f *provisionFake) client() *SDKClient {
+	return NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var body map[string]interface{}
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/catalog/list":
+			return envelopeResponse(`{"list":[{"id":1,"name":"sales"}]}`), nil
+		case "/catalog/database/list":
+			return envelopeResponse(`{"list":[]}`), nil
+		case "/catalog/database/children":
+			return envelopeResponse(`{"list":[]}`), nil
+		case "/catalog/file/list":
+			return envelopeResponse(`{"total":0,"list":[]}`), nil
+		case "/catalog/database/create":
+			f.calls = append(f.calls, "create database")
+			return envelopeResponse(`{"id":10}`), nil
+		case "/catalog/volume/create":
+			f.calls = append(f.calls, "create volume "+body["name"].(string))
+			return envelopeResponse(`{"id":"v-` + body["name"].(string) + `"}`), nil
+		case "/catalog/folder/create":
+			f.calls = append(f.calls, "create folder "+body["name"].(string))
+			return envelopeResponse(`{"id":"d-` + body["name"].(string) + `"}`), nil
+		case "/catalog/table/create":
+			f.calls = append(f.calls, "create table")
+			if f.failTable {
+				return errorEnvelopeResponse("ErrInvalidArgument", "bad column type"), nil
+			}
+			return envelopeResponse(`{"id":100}`), nil
+		case "/catalog/delete", "/catalog/database/delete", "/catalog/volume/delete", "/catalog/folder/delete", "/catalog/table/delete":
+			f.calls = append(f.calls, r.URL.Path)
+			if f.failDeletes && r.URL.Path == "/catalog/volume/delete" {
+				return errorEnvelopeResponse("ErrPermissionDenied", "denied"), nil
+			}
+			return envelopeResponse(`{}`), nil
+		}
+		f.t.Fatalf("unexpected call to %s", r.URL.Path)
+		return nil, nil
+	}))
+}
+
+var testProvisionPlan = ProvisionPlan{
+	Catalog:  "sales",
+	Database: "orders",
+	Volumes:  []VolumePlan{{Name: "raw", Folders: []string{"2024/q1"}}},
+	Tables:   []TablePlan{{Name: "line_items", Columns: []Column{{Name: "id", Type: "int", IsPk: true}}}},
+}
+
+func TestProvisioner(t *testing.T) {
+	t.Parallel()
+	fake := &provisionFake{t: t}
+	report, err := NewProvisioner(fake.client()).Provision(context.Background(), testProvisionPlan)
+	require.NoError(t, err)
+	require.False(t, report.RolledBack)
+	require.Equal(t, CatalogID(1), report.CatalogID)
+	require.Equal(t, DatabaseID(10), report.DatabaseID)
+	require.Equal(t, map[string]VolumeID{"raw": "v-raw"}, report.Volumes)
+	require.Equal(t, map[string]FileID{"raw/2024/q1": "d-q1"}, report.Folders)
+	require.Equal(t, map[string]TableID{"line_items": 100}, report.Tables)
+	require.Equal(t, []ProvisionStep{
+		{Kind: "catalog", Name: "sales", ID: "1", Action: ProvisionExisting},
+		{Kind: "database", Name: "orders", ID: "10", Action: ProvisionCreated},
+		{Kind: "volume", Name: "raw", ID: "v-raw", Action: ProvisionCreated},
+		{Kind: "folder", Name: "raw/2024", ID: "d-2024", Action: ProvisionCreated},
+		{Kind: "folder", Name: "raw/2024/q1", ID: "d-q1", Action: ProvisionCreated},
+		{Kind: "table", Name: "line_items", ID: "100", Action: ProvisionCreated},
+	}, report.Steps)
+	require.Len(t, report.Created(), 5)
+}
+
+func TestProvisioner_Rollback(t *testing.T) {
+	t.Parallel()
+	fake := &provisionFake{t: t, failTable: true}
+	report, err := NewProvisioner(fake.client()).Provision(context.Background(), testProvisionPlan)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	require.ErrorContains(t, err, "failed to provision table line_items")
+	require.True(t, report.RolledBack)
+	require.Empty(t, report.Created())
+	// The existing catalog is kept
+	require.Equal(t, []string{
+		"create database", "create volume raw", "create folder 2024", "create folder q1", "create table",
+		"/catalog/folder/delete", "/catalog/folder/delete", "/catalog/volume/delete", "/catalog/database/delete",
+	}, fake.calls)
+	require.Equal(t, ProvisionFailed, report.Steps[len(report.Steps)-1].Action)
+	require.Equal(t, ProvisionRolledBack, report.Steps[1].Action)
+}
+
+func TestProvisioner_RollbackFailure(t *testing.T) {
+	t.Parallel()
+	fake := &provisionFake{t: t, failTable: true, failDeletes: true}
+	report, err := NewProvisioner(fake.client()).Provision(context.Background(), testProvisionPlan)
+	require.ErrorIs(t, err, ErrInvalidArgument)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.ErrorContains(t, err, "failed to roll back volume raw")
+	require.Equal(t, ProvisionRollbackFailed, report.Steps[2].Action)
+	require.Equal(t, ProvisionRolledBack, report.Steps[1].Action)
+}
+
+func TestProvisioner_InvalidPlan(t *testing.T) {
+	t.Parallel()
+	p := NewProvisioner(NewSDKClient(newFakeClient(nil)))
+	_, err := p.Provision(context.Background(), ProvisionPlan{})
+	require.ErrorContains(t, err, "catalog is required")
+	_, err = p.Provision(context.Background(), ProvisionPlan{Catalog: "c", Volumes: []VolumePlan{{Name: "v"}}})
+	require.ErrorContains(t, err, "database is required")
+	_, err = p.Provision(context.Background(), ProvisionPlan{Catalog: "c", Database: "d", Tables: []TablePlan{{Name: "t"}}})
+	require.ErrorContains(t, err, "columns are required")
+}
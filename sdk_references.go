@@ -0,0 +1,271 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ReferenceKind identifies the kind of reference reported by VerifyReferences.
+type ReferenceKind string
+
+const (
+	RefWorkflowSourceVolume ReferenceKind = "workflow_source_volume" // Workflow to one of its source volumes
+	RefWorkflowTargetVolume ReferenceKind = "workflow_target_volume" // Workflow to its target volume
+	RefArtifactSourceFile   ReferenceKind = "artifact_source_file"   // Workflow output file to its source file (RefFileID)
+	RefKnowledgeTable       ReferenceKind = "knowledge_table"        // NL2SQL knowledge entry to an associated table
+)
+
+// ReferenceScope selects the references checked by VerifyReferences.
+type ReferenceScope struct {
+	// Workflows checks the source and target volumes of every workflow.
+	Workflows bool
+
+	// ArtifactVolumeIDs checks the source file of every file with a RefFileID in these
+	// volumes, typically the target volumes of workflows.
+	ArtifactVolumeIDs []VolumeID
+
+	// Knowledge checks the tables associated with NL2SQL knowledge entries.
+	Knowledge bool
+
+	// KnowledgeType restricts the knowledge check to entries of this type.
+	KnowledgeType string
+}
+
+// DanglingReference is a reference whose target no longer exists.
+type DanglingReference struct {
+	Kind       ReferenceKind
+	OwnerID    string // Workflow ID, file ID or knowledge entry ID holding the reference
+	OwnerName  string
+	TargetID   string // Missing volume ID, source file ID or table name
+	Suggestion string // How to repair the reference
+}
+
+// ReferenceReport is the outcome of VerifyReferences.
+type ReferenceReport struct {
+	// Checked is the number of references inspected.
+	Checked int
+	// Dangling are the references whose target no longer exists.
+	Dangling []DanglingReference
+}
+
+// OK reports whether no dangling reference was found.
+func (r *ReferenceReport) OK() bool {
+	return len(r.Dangling) == 0
+}
+
+// VerifyReferences cross-checks the references between catalog objects selected by
+// scope and reports those whose target was deleted, with a suggested fix for each.
+//
+// Broken references otherwise only surface as runtime failures, e.g. a workflow whose
+// source volume was deleted fails on its next run. Nothing is modified; artifacts
+// whose source file is gone can be purged with CollectOrphanedArtifacts.
+//
+// Example:
+//
+//	report, err := sdkClient.VerifyReferences(ctx, sdk.ReferenceScope{
+//		Workflows:         true,
+//		ArtifactVolumeIDs: []sdk.VolumeID{"target-volume-id"},
+//		Knowledge:         true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, ref := range report.Dangling {
+//		fmt.Printf("%s %s -> %s: %s\n", ref.Kind, ref.OwnerID, ref.TargetID, ref.Suggestion)
+//	}
+func (c *SDKClient) VerifyReferences(ctx context.Context, scope ReferenceScope, opts ...CallOption) (*ReferenceReport, error) {
+	report := &ReferenceReport{}
+	if scope.Workflows {
+		if err := c.verifyWorkflowReferences(ctx, report, opts...); err != nil {
+			return nil, err
+		}
+	}
+	for _, volumeID := range scope.ArtifactVolumeIDs {
+		if err := c.verifyArtifactReferences(ctx, report, volumeID, opts...); err != nil {
+			return nil, err
+		}
+	}
+	if scope.Knowledge {
+		if err := c.verifyKnowledgeReferences(ctx, report, scope.KnowledgeType, opts...); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// listPageSize is the page size used by helpers that walk a complete list.
+const listPageSize = 100
+
+func (c *SDKClient) verifyWorkflowReferences(ctx context.Context, report *ReferenceReport, opts ...CallOption) error {
+	volumeExists := make(map[string]bool)
+	checkVolume := func(volumeID string) (bool, error) {
+		if exists, ok := volumeExists[volumeID]; ok {
+			return exists, nil
+		}
+		_, err := c.raw.GetVolume(ctx, &VolumeInfoRequest{VolumeID: VolumeID(volumeID)}, opts...)
+		switch {
+		case err == nil:
+			volumeExists[volumeID] = true
+		case IsNotFound(err):
+			volumeExists[volumeID] = false
+		default:
+			return false, fmt.Errorf("failed to check volume %s: %w", volumeID, err)
+		}
+		return volumeExists[volumeID], nil
+	}
+
+	seen := 0
+	for page := 1; ; page++ {
+		if err := c.raw.checkPageLimit(page); err != nil {
+			return err
+		}
+		resp, err := c.raw.ListWorkflows(ctx, &WorkflowListRequest{Page: page, PageSize: listPageSize}, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to list workflows: %w", err)
+		}
+		for _, wf := range resp.Workflows {
+			for _, volumeID := range parseIDList(wf.SourceVolumeIDs) {
+				report.Checked++
+				exists, err := checkVolume(volumeID)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					report.Dangling = append(report.Dangling, DanglingReference{
+						Kind:       RefWorkflowSourceVolume,
+						OwnerID:    wf.ID,
+						OwnerName:  wf.Name,
+						TargetID:   volumeID,
+						Suggestion: fmt.Sprintf("remove volume %s from the source volumes of workflow %s with UpdateWorkflow", volumeID, wf.ID),
+					})
+				}
+			}
+			if wf.TargetVolumeID != "" {
+				report.Checked++
+				exists, err := checkVolume(wf.TargetVolumeID)
+				if err != nil {
+					return err
+				}
+				if !exists {
+					report.Dangling = append(report.Dangling, DanglingReference{
+						Kind:       RefWorkflowTargetVolume,
+						OwnerID:    wf.ID,
+						OwnerName:  wf.Name,
+						TargetID:   wf.TargetVolumeID,
+						Suggestion: fmt.Sprintf("set a new target volume for workflow %s with UpdateWorkflow, or delete the workflow", wf.ID),
+					})
+				}
+			}
+		}
+		seen += len(resp.Workflows)
+		if len(resp.Workflows) == 0 || seen >= resp.Total {
+			return nil
+		}
+	}
+}
+
+func (c *SDKClient) verifyArtifactReferences(ctx context.Context, report *ReferenceReport, volumeID VolumeID, opts ...CallOption) error {
+	files, err := c.listAllFiles(ctx, []CommonFilter{
+		{Name: "volume_id", Values: []string{string(volumeID)}},
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to list files of volume %s: %w", volumeID, err)
+	}
+	sourceExists := make(map[string]bool)
+	for _, file := range files {
+		if file.RefFileID == "" {
+			continue
+		}
+		report.Checked++
+		exists, checked := sourceExists[file.RefFileID]
+		if !checked {
+			_, err := c.raw.GetFile(ctx, &FileInfoRequest{FileID: FileID(file.RefFileID)}, opts...)
+			switch {
+			case err == nil:
+				exists = true
+			case IsNotFound(err):
+				exists = false
+			default:
+				return fmt.Errorf("failed to check source file %s: %w", file.RefFileID, err)
+			}
+			sourceExists[file.RefFileID] = exists
+		}
+		if !exists {
+			report.Dangling = append(report.Dangling, DanglingReference{
+				Kind:       RefArtifactSourceFile,
+				OwnerID:    file.ID,
+				OwnerName:  file.Name,
+				TargetID:   file.RefFileID,
+				Suggestion: fmt.Sprintf("purge the artifacts of source file %s with CollectOrphanedArtifacts or DeleteFileRef", file.RefFileID),
+			})
+		}
+	}
+	return nil
+}
+
+func (c *SDKClient) verifyKnowledgeReferences(ctx context.Context, report *ReferenceReport, knowledgeType string, opts ...CallOption) error {
+	tree, err := c.raw.GetCatalogTree(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to get catalog tree: %w", err)
+	}
+	tables := make(map[string]bool)
+	var collect func(nodes []*TreeNode, database string)
+	collect = func(nodes []*TreeNode, database string) {
+		for _, node := range nodes {
+			switch {
+			case strings.EqualFold(node.Typ, "database"):
+				collect(node.NodeList, node.Name)
+			case strings.EqualFold(node.Typ, "table"):
+				tables[strings.ToLower(node.Name)] = true
+				if database != "" {
+					tables[strings.ToLower(database+"."+node.Name)] = true
+				}
+			default:
+				collect(node.NodeList, database)
+			}
+		}
+	}
+	collect(tree.Tree, "")
+
+	entries, err := c.listAllKnowledge(ctx, knowledgeType, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to list knowledge: %w", err)
+	}
+	for _, entry := range entries {
+		for _, table := range entry.AssociateTables {
+			report.Checked++
+			if tables[strings.ToLower(table)] {
+				continue
+			}
+			report.Dangling = append(report.Dangling, DanglingReference{
+				Kind:       RefKnowledgeTable,
+				OwnerID:    fmt.Sprint(entry.ID),
+				OwnerName:  entry.Key,
+				TargetID:   table,
+				Suggestion: fmt.Sprintf("remove table %s from the associated tables of knowledge %d with UpdateKnowledge, or delete the entry", table, entry.ID),
+			})
+		}
+	}
+	return nil
+}
+
+// parseIDList parses a list of IDs stored as a string, either as a JSON array or as
+// comma-separated values.
+func parseIDList(s string) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	var ids []string
+	if strings.HasPrefix(s, "[") && json.Unmarshal([]byte(s), &ids) == nil {
+		return ids
+	}
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyReferences(t *testing.T) {
+	t.Parallel()
+
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var body map[string]interface{}
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		switch r.URL.Path {
+		case "/v1/genai/workflow":
+			return envelopeResponse(`{"total":2,"workflows":[
+				{"id":"wf-1","name":"ingest","source_volume_ids":"[\"vol-live\",\"vol-gone\"]","target_volume_id":"vol-live"},
+				{"id":"wf-2","name":"legacy","source_volume_ids":"vol-live","target_volume_id":"vol-gone"}
+			]}`), nil
+		case "/catalog/volume/info":
+			if body["id"] == "vol-gone" {
+				return errorEnvelopeResponse("ErrNotFound", "volume not found"), nil
+			}
+			return envelopeResponse(`{"id":"vol-live"}`), nil
+		case "/catalog/file/list":
+			return envelopeResponse(`{"total":2,"list":[
+				{"id":"a1","name":"chunk-1","ref_file_id":"src-gone"},
+				{"id":"a2","name":"chunk-2","ref_file_id":"src-live"}
+			]}`), nil
+		case "/catalog/file/info":
+			if body["id"] == "src-gone" {
+				return errorEnvelopeResponse("ErrInternal", "file not exist"), nil
+			}
+			return envelopeResponse(`{"id":"src-live"}`), nil
+		case "/catalog/tree":
+			return envelopeResponse(`{"tree":[{"type":"catalog","name":"c","node_list":[
+				{"type":"database","name":"sales","node_list":[{"type":"table","name":"orders"}]}
+			]}]}`), nil
+		case "/catalog/nl2sql_knowledge/list":
+			return envelopeResponse(`{"total":1,"list":[
+				{"id":7,"key":"monthly revenue","associate_tables":["sales.orders","sales.refunds"]}
+			]}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+
+	report, err := client.VerifyReferences(context.Background(), ReferenceScope{
+		Workflows:         true,
+		ArtifactVolumeIDs: []VolumeID{"target"},
+		Knowledge:         true,
+	})
+	require.NoError(t, err)
+	require.False(t, report.OK())
+	require.Equal(t, 9, report.Checked)
+
+	var kinds []ReferenceKind
+	var targets []string
+	for _, ref := range report.Dangling {
+		kinds = append(kinds, ref.Kind)
+		targets = append(targets, ref.TargetID)
+		require.NotEmpty(t, ref.Suggestion)
+	}
+	require.Equal(t, []ReferenceKind{RefWorkflowSourceVolume, RefWorkflowTargetVolume, RefArtifactSourceFile, RefKnowledgeTable}, kinds)
+	require.Equal(t, []string{"vol-gone", "vol-gone", "src-gone", "sales.refunds"}, targets)
+	require.Equal(t, "wf-2", report.Dangling[1].OwnerID)
+	require.Equal(t, "7", report.Dangling[3].OwnerID)
+}
+
+func TestParseIDList(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, parseIDList(""))
+	require.Equal(t, []string{"a", "b"}, parseIDList(`["a","b"]`))
+	require.Equal(t, []string{"a", "b"}, parseIDList("a, b,"))
+}
@@ -0,0 +1,257 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolvedPath holds the IDs of the objects named by a path passed to Resolve.
+// IDs below the depth of the path are left empty.
+type ResolvedPath struct {
+	CatalogID  CatalogID
+	DatabaseID DatabaseID
+	VolumeID   VolumeID
+	// FileID is the file or folder named by the segments after the volume
+	FileID FileID
+	// IsFolder reports whether FileID is a folder
+	IsFolder bool
+}
+
+// ResolvedTable holds the IDs of the objects named by a table name passed to ResolveTable.
+type ResolvedTable struct {
+	CatalogID  CatalogID
+	DatabaseID DatabaseID
+	TableID    TableID
+}
+
+// Resolve translates a path of names, "catalog/database/volume/folder/file", into the
+// IDs of the objects it names.
+//
+// The path may stop at any level: "sales" resolves a catalog, "sales/orders/raw" a
+// volume and "sales/orders/raw/2024/q1.csv" a file below folder "2024". Names are
+// matched exactly. A missing object fails with an error matching ErrNotFound that
+// names the part of the path that could not be resolved.
+//
+// Resolved names are cached by the client, so later lookups sharing a prefix only
+// list the levels not seen before. Renaming or deleting objects with the client drops
+// the names it affects; call ClearResolveCache after changes made elsewhere, or set
+// an expiry with WithCache. WithNoCache bypasses the cached names.
+//
+// Example:
+//
+//	resolved, err := sdkClient.Resolve(ctx, "sales/orders/raw/2024/q1.csv")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("volume %s, file %s\n", resolved.VolumeID, resolved.FileID)
+func (c *SDKClient) Resolve(ctx context.Context, path string, opts ...CallOption) (*ResolvedPath, error) {
+	segments, err := splitResolvePath(path, "/")
+	if err != nil {
+		return nil, err
+	}
+	result := &ResolvedPath{}
+	if result.CatalogID, err = c.resolveCatalog(ctx, segments, opts...); err != nil {
+		return nil, err
+	}
+	if len(segments) == 1 {
+		return result, nil
+	}
+	if result.DatabaseID, err = c.resolveDatabase(ctx, result.CatalogID, segments, "/", opts...); err != nil {
+		return nil, err
+	}
+	if len(segments) == 2 {
+		return result, nil
+	}
+	entry, err := c.resolveName(ctx, cacheKey{kind: "volume", parent: strconv.FormatInt(int64(result.DatabaseID), 10), name: segments[2]}, strings.Join(segments[:3], "/"), func() (map[string]nameEntry, error) {
+		resp, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: result.DatabaseID}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		entries := make(map[string]nameEntry)
+		for _, child := range resp.List {
+			if strings.EqualFold(child.Typ, "volume") {
+				entries[child.Name] = nameEntry{id: child.ID}
+			}
+		}
+		return entries, nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result.VolumeID = VolumeID(entry.id)
+
+	for i := 3; i < len(segments); i++ {
+		if i > 3 && !result.IsFolder {
+			return nil, fmt.Errorf("%s is not a folder", strings.Join(segments[:i], "/"))
+		}
+		parentID := result.FileID
+		entry, err := c.resolveName(ctx, cacheKey{kind: "file", parent: string(result.VolumeID) + "/" + string(parentID), name: segments[i]}, strings.Join(segments[:i+1], "/"), func() (map[string]nameEntry, error) {
+			children, err := c.listAllFiles(ctx, []CommonFilter{
+				{Name: "volume_id", Values: []string{string(result.VolumeID)}},
+				{Name: "parent_id", Values: []string{string(parentID)}},
+			}, opts...)
+			if err != nil {
+				return nil, err
+			}
+			entries := make(map[string]nameEntry, len(children))
+			for _, child := range children {
+				entries[child.Name] = nameEntry{id: child.ID, folder: child.IsFolder()}
+			}
+			return entries, nil
+		}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		result.FileID = FileID(entry.id)
+		result.IsFolder = entry.folder
+	}
+	return result, nil
+}
+
+// ResolveTable translates a table name qualified by its catalog and database,
+// "catalog.database.table", into the IDs of the objects it names.
+//
+// Lookups are cached like those of Resolve, and a missing object fails with an error
+// matching ErrNotFound.
+//
+// Example:
+//
+//	resolved, err := sdkClient.ResolveTable(ctx, "sales.orders.line_items")
+//	if err != nil {
+//		return err
+//	}
+//	rows, err := rawClient.PreviewTable(ctx, &sdk.TablePreviewRequest{TableID: resolved.TableID})
+func (c *SDKClient) ResolveTable(ctx context.Context, name string, opts ...CallOption) (*ResolvedTable, error) {
+	segments, err := splitResolvePath(name, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) != 3 {
+		return nil, fmt.Errorf("table name %q must have the form catalog.database.table", name)
+	}
+	result := &ResolvedTable{}
+	if result.CatalogID, err = c.resolveCatalog(ctx, segments, opts...); err != nil {
+		return nil, err
+	}
+	if result.DatabaseID, err = c.resolveDatabase(ctx, result.CatalogID, segments, ".", opts...); err != nil {
+		return nil, err
+	}
+	entry, err := c.resolveName(ctx, cacheKey{kind: "table", parent: strconv.FormatInt(int64(result.DatabaseID), 10), name: segments[2]}, name, func() (map[string]nameEntry, error) {
+		resp, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: result.DatabaseID}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		entries := make(map[string]nameEntry)
+		for _, child := range resp.List {
+			if !strings.EqualFold(child.Typ, "table") {
+				continue
+			}
+			id, err := strconv.ParseInt(child.ID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid id %q of table %s: %w", child.ID, child.Name, err)
+			}
+			entries[child.Name] = nameEntry{num: id}
+		}
+		return entries, nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result.TableID = TableID(entry.num)
+	return result, nil
+}
+
+// ClearResolveCache drops the names cached by Resolve and ResolveTable, so the next
+// lookups list the server again. It drops the rest of the client's cache too (see
+// RawClient.InvalidateCache).
+func (c *SDKClient) ClearResolveCache() {
+	c.raw.InvalidateCache()
+}
+
+// resolveCatalog resolves the first segment of a path to a catalog.
+func (c *SDKClient) resolveCatalog(ctx context.Context, segments []string, opts ...CallOption) (CatalogID, error) {
+	entry, err := c.resolveName(ctx, cacheKey{kind: "catalog", name: segments[0]}, segments[0], func() (map[string]nameEntry, error) {
+		resp, err := c.raw.ListCatalogs(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		entries := make(map[string]nameEntry, len(resp.List))
+		for _, catalog := range resp.List {
+			entries[catalog.CatalogName] = nameEntry{num: int64(catalog.CatalogID)}
+		}
+		return entries, nil
+	}, opts...)
+	return CatalogID(entry.num), err
+}
+
+// resolveDatabase resolves the second segment of a path, separated by sep, to a database
+// of the catalog.
+func (c *SDKClient) resolveDatabase(ctx context.Context, catalogID CatalogID, segments []string, sep string, opts ...CallOption) (DatabaseID, error) {
+	entry, err := c.resolveName(ctx, cacheKey{kind: "database", parent: strconv.FormatInt(int64(catalogID), 10), name: segments[1]}, segments[0]+sep+segments[1], func() (map[string]nameEntry, error) {
+		resp, err := c.raw.ListDatabases(ctx, &DatabaseListRequest{CatalogID: catalogID}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		entries := make(map[string]nameEntry, len(resp.List))
+		for _, db := range resp.List {
+			entries[db.DatabaseName] = nameEntry{num: int64(db.DatabaseID)}
+		}
+		return entries, nil
+	}, opts...)
+	return DatabaseID(entry.num), err
+}
+
+// resolveName returns the cached entry for key, or calls list to fetch the siblings of
+// key, caches all of them and returns the one named by key. path is the part of the
+// resolved path reported in errors.
+func (c *SDKClient) resolveName(ctx context.Context, key cacheKey, path string, list func() (map[string]nameEntry, error), opts ...CallOption) (nameEntry, error) {
+	cache := c.raw.cache
+	key.workspace = c.raw.workspaceFor(ctx)
+	if !newCallOptions(opts...).noCache {
+		if value, ok := cache.get(key); ok {
+			return value.(nameEntry), nil
+		}
+	}
+	gen := cache.generation()
+	entries, err := list()
+	if err != nil {
+		return nameEntry{}, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	values := make(map[cacheKey]interface{}, len(entries))
+	for name, entry := range entries {
+		sibling := key
+		sibling.name = name
+		values[sibling] = entry
+	}
+	cache.put(gen, values)
+	entry, ok := entries[key.name]
+	if !ok {
+		return nameEntry{}, fmt.Errorf("%w: %s %s", ErrNotFound, key.kind, path)
+	}
+	return entry, nil
+}
+
+// splitResolvePath splits a path of names on sep, ignoring a leading and trailing
+// separator.
+func splitResolvePath(path, sep string) ([]string, error) {
+	trimmed := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(path), sep), sep)
+	if trimmed == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	segments := strings.Split(trimmed, sep)
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("path %q has an empty name", path)
+		}
+	}
+	return segments, nil
+}
+
+// nameEntry is a resolved object.
+type nameEntry struct {
+	id     string // volume and file IDs
+	num    int64  // catalog, database and table IDs
+	folder bool
+}
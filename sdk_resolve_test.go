@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newResolveFake(t *testing.T) (*SDKClient, map[string]int) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/catalog/list":
+			return envelopeResponse(`{"list":[{"id":1,"name":"sales"},{"id":2,"name":"hr"}]}`), nil
+		case "/catalog/database/list":
+			return envelopeResponse(`{"list":[{"id":10,"name":"orders"}]}`), nil
+		case "/catalog/database/children":
+			return envelopeResponse(`{"list":[
+				{"id":"v1","name":"raw","type":"volume"},
+				{"id":"100","name":"line_items","type":"table"}]}`), nil
+		case "/catalog/file/list":
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			switch req.Filters[1].Values[0] {
+			case "":
+				return envelopeResponse(`{"total":2,"list":[
+					{"id":"d1","name":"2024","file_type":"dir"},
+					{"id":"f0","name":"README.md"}]}`), nil
+			case "d1":
+				return envelopeResponse(`{"total":1,"list":[{"id":"f1","name":"q1.csv"}]}`), nil
+			}
+		}
+		return envelopeResponse(`{"total":0,"list":[]}`), nil
+	}))
+	return client, calls
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+	client, calls := newResolveFake(t)
+	ctx := context.Background()
+
+	resolved, err := client.Resolve(ctx, "/sales/orders/raw/2024/q1.csv")
+	require.NoError(t, err)
+	require.Equal(t, &ResolvedPath{CatalogID: 1, DatabaseID: 10, VolumeID: "v1", FileID: "f1"}, resolved)
+
+	resolved, err = client.Resolve(ctx, "sales/orders/raw/2024/")
+	require.NoError(t, err)
+	require.Equal(t, &ResolvedPath{CatalogID: 1, DatabaseID: 10, VolumeID: "v1", FileID: "d1", IsFolder: true}, resolved)
+	resolved, err = client.Resolve(ctx, "sales/orders/raw/README.md")
+	require.NoError(t, err)
+	require.Equal(t, FileID("f0"), resolved.FileID)
+	resolved, err = client.Resolve(ctx, "hr")
+	require.NoError(t, err)
+	require.Equal(t, &ResolvedPath{CatalogID: 2}, resolved)
+
+	// Every level was listed once
+	require.Equal(t, map[string]int{
+		"/catalog/list":              1,
+		"/catalog/database/list":     1,
+		"/catalog/database/children": 1,
+		"/catalog/file/list":         2,
+	}, calls)
+
+	_, err = client.Resolve(ctx, "sales/orders/raw/2024/q2.csv")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorContains(t, err, "sales/orders/raw/2024/q2.csv")
+	_, err = client.Resolve(ctx, "sales/orders/raw/README.md/x")
+	require.ErrorContains(t, err, "sales/orders/raw/README.md is not a folder")
+	_, err = client.Resolve(ctx, "sales//raw")
+	require.ErrorContains(t, err, "empty name")
+	_, err = client.Resolve(ctx, "")
+	require.ErrorContains(t, err, "path is required")
+
+	client.ClearResolveCache()
+	_, err = client.Resolve(ctx, "sales")
+	require.NoError(t, err)
+	require.Equal(t, 2, calls["/catalog/list"])
+}
+
+func TestResolveTable(t *testing.T) {
+	t.Parallel()
+	client, calls := newResolveFake(t)
+	ctx := context.Background()
+
+	resolved, err := client.ResolveTable(ctx, "sales.orders.line_items")
+	require.NoError(t, err)
+	require.Equal(t, &ResolvedTable{CatalogID: 1, DatabaseID: 10, TableID: 100}, resolved)
+	_, err = client.ResolveTable(ctx, "sales.orders.line_items")
+	require.NoError(t, err)
+	require.Equal(t, 1, calls["/catalog/database/children"])
+
+	_, err = client.ResolveTable(ctx, "sales.missing.line_items")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorContains(t, err, "database sales.missing")
+	_, err = client.ResolveTable(ctx, "sales.orders")
+	require.ErrorContains(t, err, "catalog.database.table")
+}
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testRoleInfo = `{"id":5,"name":"analyst","description":"read only",
+	"authority_list":[{"code":"U1"},{"code":"R1"}],
+	"obj_authority_list":[{"id":"123","category":"table","name":"orders","authority_code_list":[
+		{"code":"DT8","black_column_list":["salary"]},{"code":"DT9"}
+	]}]}`
+
+func newRoleFakeClient(t *testing.T, updates *[]RoleUpdateInfoRequest) *SDKClient {
+	t.Helper()
+	return NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/role/info":
+			return envelopeResponse(testRoleInfo), nil
+		case "/role/update_info":
+			var req RoleUpdateInfoRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*updates = append(*updates, req)
+			return envelopeResponse(`{"id":5}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+}
+
+func TestListRolePrivileges(t *testing.T) {
+	t.Parallel()
+
+	client := newRoleFakeClient(t, nil)
+	privs, err := client.ListRolePrivileges(context.Background(), 5)
+	require.NoError(t, err)
+	require.Len(t, privs, 4)
+	require.Equal(t, RolePrivilege{Code: "U1"}, privs[0])
+	require.Equal(t, "DT8", privs[2].Code)
+	require.Equal(t, "orders", privs[2].ObjName)
+	require.Equal(t, []string{"salary"}, privs[2].BlackColumnList)
+
+	_, err = client.ListRolePrivileges(context.Background(), 0)
+	require.ErrorContains(t, err, "role_id is required")
+}
+
+func TestGrantAndRevokeRolePrivileges(t *testing.T) {
+	t.Parallel()
+
+	var updates []RoleUpdateInfoRequest
+	client := newRoleFakeClient(t, &updates)
+	ctx := context.Background()
+
+	err := client.GrantPrivilegeToRole(ctx, 5, []RolePrivilege{
+		{Code: "C1"},
+		{Code: "DT8", ObjType: "table", ObjID: "123"},
+		{Code: "DT8", ObjType: "table", ObjID: "456"},
+	})
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	got := updates[0]
+	require.Equal(t, "read only", got.Comment)
+	require.Equal(t, []string{"U1", "R1", "C1"}, got.PrivList)
+	require.Len(t, got.ObjPrivList, 2)
+	require.Equal(t, "orders", got.ObjPrivList[0].ObjName)
+	require.Len(t, got.ObjPrivList[0].AuthorityCodeList, 2)
+	require.Empty(t, got.ObjPrivList[0].AuthorityCodeList[0].BlackColumnList, "regranting replaces restrictions")
+	require.Equal(t, "456", got.ObjPrivList[1].ObjID)
+
+	err = client.RevokePrivilegeFromRole(ctx, 5, []RolePrivilege{
+		{Code: "R1"},
+		{Code: "DT9", ObjType: "table", ObjID: "123"},
+		{Code: "DT8", ObjType: "table", ObjID: "999"},
+	})
+	require.NoError(t, err)
+	got = updates[1]
+	require.Equal(t, []string{"U1"}, got.PrivList)
+	require.Len(t, got.ObjPrivList, 1)
+	require.Len(t, got.ObjPrivList[0].AuthorityCodeList, 1)
+	require.Equal(t, "DT8", got.ObjPrivList[0].AuthorityCodeList[0].Code)
+
+	err = client.RevokePrivilegeFromRole(ctx, 5, []RolePrivilege{
+		{Code: "U1"}, {Code: "R1"},
+		{Code: "DT8", ObjType: "table", ObjID: "123"}, {Code: "DT9", ObjType: "table", ObjID: "123"},
+	})
+	require.NoError(t, err)
+	got = updates[2]
+	require.NotNil(t, got.PrivList)
+	require.Empty(t, got.PrivList)
+	require.Empty(t, got.ObjPrivList)
+
+	require.ErrorContains(t, client.GrantPrivilegeToRole(ctx, 5, nil), "privileges cannot be empty")
+	require.ErrorContains(t, client.GrantPrivilegeToRole(ctx, 5, []RolePrivilege{{Code: "DT8", ObjType: "table"}}), "must be set together")
+	require.Len(t, updates, 3)
+}
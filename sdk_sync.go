@@ -0,0 +1,295 @@
+package sdk
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SyncOptions controls how SyncDirToVolume mirrors a local directory.
+type SyncOptions struct {
+	// DeleteRemote removes remote files and folders that are not present locally.
+	DeleteRemote bool
+	// DryRun computes and reports the changes without modifying the volume.
+	DryRun bool
+	// Exclude, if set, skips local entries for which it returns true. relPath uses
+	// forward slashes and is relative to the synced directory. Excluding a directory
+	// skips its whole subtree. Remote entries considered for deletion are passed with
+	// a nil entry, so excluded paths are never deleted.
+	Exclude func(relPath string, entry fs.DirEntry) bool
+}
+
+// SyncResult summarizes the changes made (or, in dry-run mode, planned) by SyncDirToVolume.
+// All paths use forward slashes and are relative to the synced directory.
+type SyncResult struct {
+	CreatedFolders []string
+	Uploaded       []string // New files
+	Updated        []string // Files whose content changed
+	Deleted        []string // Remote files and folders removed because DeleteRemote was set
+	Unchanged      []string
+	DryRun         bool
+}
+
+// Summary returns a one-line, human-readable description of the sync result.
+func (r *SyncResult) Summary() string {
+	if r == nil {
+		return ""
+	}
+	prefix := ""
+	if r.DryRun {
+		prefix = "dry run: "
+	}
+	return fmt.Sprintf("%s%d folders created, %d uploaded, %d updated, %d deleted, %d unchanged",
+		prefix, len(r.CreatedFolders), len(r.Uploaded), len(r.Updated), len(r.Deleted), len(r.Unchanged))
+}
+
+// SyncDirToVolume mirrors a local directory tree into a volume folder.
+//
+// Folders missing remotely are created, new files are uploaded and files whose content
+// differs are replaced; the old copy is only deleted once the new one is uploaded.
+// Files are compared by MD5 hash when the server reports one and by size otherwise.
+// With SyncOptions.DeleteRemote, remote entries that no longer exist locally are
+// deleted. Pass an empty folderID to sync into the volume root.
+//
+// Example:
+//
+//	result, err := sdkClient.SyncDirToVolume(ctx, "./docs", "volume-id-123", "", sdk.SyncOptions{
+//		DeleteRemote: true,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(result.Summary())
+func (c *SDKClient) SyncDirToVolume(ctx context.Context, localPath string, volumeID VolumeID, folderID FileID, syncOpts SyncOptions, opts ...CallOption) (*SyncResult, error) {
+	opts = withoutIdempotencyKey(opts)
+	if strings.TrimSpace(localPath) == "" {
+		return nil, fmt.Errorf("local_path is required")
+	}
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	info, err := os.Stat(localPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat local path: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("local path %s is not a directory", localPath)
+	}
+
+	s := &dirSyncer{
+		client:   c,
+		volumeID: volumeID,
+		options:  syncOpts,
+		callOpts: opts,
+		result:   &SyncResult{DryRun: syncOpts.DryRun},
+	}
+	if err := s.syncDir(ctx, localPath, "", folderID, true); err != nil {
+		return s.result, err
+	}
+	return s.result, nil
+}
+
+// dirSyncer carries the state of a single SyncDirToVolume call.
+type dirSyncer struct {
+	client   *SDKClient
+	volumeID VolumeID
+	options  SyncOptions
+	callOpts []CallOption
+	result   *SyncResult
+}
+
+// syncDir syncs localDir into the remote folder parentID. remoteExists is false when
+// the remote folder was not created because of dry-run mode, in which case every
+// local entry is reported as new.
+func (s *dirSyncer) syncDir(ctx context.Context, localDir, relDir string, parentID FileID, remoteExists bool) error {
+	remote := make(map[string]VolumeChildrenResponse)
+	if remoteExists {
+		children, err := s.client.listAllFiles(ctx, []CommonFilter{
+			{Name: "volume_id", Values: []string{string(s.volumeID)}},
+			{Name: "parent_id", Values: []string{string(parentID)}},
+		}, s.callOpts...)
+		if err != nil {
+			return fmt.Errorf("failed to list remote folder %q: %w", relDir, err)
+		}
+		for _, child := range children {
+			remote[child.Name] = child
+		}
+	}
+
+	entries, err := os.ReadDir(localDir)
+	if err != nil {
+		return fmt.Errorf("failed to read local directory: %w", err)
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		rel := path.Join(relDir, entry.Name())
+		if s.options.Exclude != nil && s.options.Exclude(rel, entry) {
+			continue
+		}
+		if !entry.IsDir() && !entry.Type().IsRegular() {
+			continue
+		}
+		seen[entry.Name()] = true
+		existing, found := remote[entry.Name()]
+		localEntryPath := filepath.Join(localDir, entry.Name())
+
+		if entry.IsDir() {
+			if found && !existing.IsFolder() {
+				return fmt.Errorf("cannot sync directory %q: a remote file with the same name exists", rel)
+			}
+			childID := FileID(existing.ID)
+			childExists := found
+			if !found {
+				s.result.CreatedFolders = append(s.result.CreatedFolders, rel)
+				if !s.options.DryRun {
+					resp, err := s.client.raw.CreateFolder(ctx, &FolderCreateRequest{
+						Name:     entry.Name(),
+						VolumeID: s.volumeID,
+						ParentID: parentID,
+					}, s.callOpts...)
+					if err != nil {
+						return fmt.Errorf("failed to create folder %q: %w", rel, err)
+					}
+					childID = resp.FolderID
+					childExists = true
+				}
+			}
+			if err := s.syncDir(ctx, localEntryPath, rel, childID, childExists); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if found && existing.IsFolder() {
+			return fmt.Errorf("cannot sync file %q: a remote folder with the same name exists", rel)
+		}
+		if err := s.syncFile(ctx, localEntryPath, rel, parentID, existing, found); err != nil {
+			return err
+		}
+	}
+
+	if !s.options.DeleteRemote {
+		return nil
+	}
+	names := make([]string, 0, len(remote))
+	for name := range remote {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		entry := remote[name]
+		rel := path.Join(relDir, name)
+		if s.options.Exclude != nil && s.options.Exclude(rel, nil) {
+			continue
+		}
+		s.result.Deleted = append(s.result.Deleted, rel)
+		if s.options.DryRun {
+			continue
+		}
+		var err error
+		if entry.IsFolder() {
+			_, err = s.client.raw.DeleteFolder(ctx, &FolderDeleteRequest{FolderID: FileID(entry.ID)}, s.callOpts...)
+		} else {
+			_, err = s.client.raw.DeleteFile(ctx, &FileDeleteRequest{FileID: FileID(entry.ID)}, s.callOpts...)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete remote %q: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+func (s *dirSyncer) syncFile(ctx context.Context, localFile, rel string, parentID FileID, existing VolumeChildrenResponse, found bool) error {
+	f, err := os.Open(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to open %q: %w", rel, err)
+	}
+	defer f.Close()
+
+	if found {
+		size, hash, err := fileSizeAndMD5(f)
+		if err != nil {
+			return fmt.Errorf("failed to hash %q: %w", rel, err)
+		}
+		same := size == existing.Size
+		if existing.Hash != "" {
+			same = strings.EqualFold(existing.Hash, hash)
+		}
+		if same {
+			s.result.Unchanged = append(s.result.Unchanged, rel)
+			return nil
+		}
+		s.result.Updated = append(s.result.Updated, rel)
+		if s.options.DryRun {
+			return nil
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		return s.replaceFile(ctx, f, rel, parentID, existing)
+	}
+
+	s.result.Uploaded = append(s.result.Uploaded, rel)
+	if s.options.DryRun {
+		return nil
+	}
+	if _, err := s.upload(ctx, f, parentID, filepath.Base(localFile)); err != nil {
+		return fmt.Errorf("failed to upload %q: %w", rel, err)
+	}
+	return nil
+}
+
+// replaceFile replaces the remote file existing with the content of f. The new
+// content is uploaded under a temporary name first, so that the remote copy is only
+// deleted once the upload succeeded.
+func (s *dirSyncer) replaceFile(ctx context.Context, f *os.File, rel string, parentID FileID, existing VolumeChildrenResponse) error {
+	tmpName := fmt.Sprintf(".%s.sync-%d", existing.Name, time.Now().UnixNano())
+	uploaded, err := s.upload(ctx, f, parentID, tmpName)
+	if err != nil {
+		return fmt.Errorf("failed to upload %q: %w", rel, err)
+	}
+	if _, err := s.client.raw.DeleteFile(ctx, &FileDeleteRequest{FileID: FileID(existing.ID)}, s.callOpts...); err != nil {
+		_, _ = s.client.raw.DeleteFile(ctx, &FileDeleteRequest{FileID: uploaded.FileID}, s.callOpts...)
+		return fmt.Errorf("failed to replace %q: %w", rel, err)
+	}
+	if _, err := s.client.raw.UpdateFile(ctx, &FileUpdateRequest{FileID: uploaded.FileID, Name: existing.Name}, s.callOpts...); err != nil {
+		return fmt.Errorf("failed to rename %q uploaded as %q: %w", rel, tmpName, err)
+	}
+	return nil
+}
+
+func (s *dirSyncer) upload(ctx context.Context, f *os.File, parentID FileID, name string) (*FileUploadResponse, error) {
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return s.client.raw.UploadFileContent(ctx, &FileContentUploadRequest{
+		VolumeID: s.volumeID,
+		ParentID: parentID,
+		Name:     name,
+		Reader:   f,
+		Size:     stat.Size(),
+	}, s.callOpts...)
+}
+
+func fileSizeAndMD5(r io.Reader) (int64, string, error) {
+	h := md5.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,139 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSyncTestDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keep.txt"), []byte("same"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "changed.txt"), []byte("new content"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("hello"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "inner.txt"), []byte("inner"), 0644))
+	return dir
+}
+
+func newSyncTestClient(t *testing.T, calls map[string]int) *SDKClient {
+	t.Helper()
+	var mu sync.Mutex
+	return NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/catalog/file/list":
+			var req FileListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Filters[1].Values[0] != "" {
+				return envelopeResponse(`{"total":0,"list":[]}`), nil
+			}
+			return envelopeResponse(`{"total":3,"list":[
+				{"id":"f1","name":"keep.txt","file_type":"1","size":4},
+				{"id":"f2","name":"changed.txt","file_type":"1","size":3},
+				{"id":"f3","name":"stale.txt","file_type":"1","size":1}
+			]}`), nil
+		case "/catalog/file/upload":
+			_, _ = io.Copy(io.Discard, r.Body)
+			return envelopeResponse(`{"id":"uploaded"}`), nil
+		case "/catalog/folder/create":
+			return envelopeResponse(`{"id":"folder-sub","name":"sub"}`), nil
+		case "/catalog/file/delete":
+			return envelopeResponse(`{"id":"deleted"}`), nil
+		case "/catalog/file/update":
+			var req FileUpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, FileUpdateRequest{FileID: "uploaded", Name: "changed.txt"}, req)
+			return envelopeResponse(`{"id":"uploaded"}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+}
+
+func TestSyncDirToVolume_DryRun(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]int{}
+	client := newSyncTestClient(t, calls)
+
+	result, err := client.SyncDirToVolume(context.Background(), newSyncTestDir(t), "vol", "", SyncOptions{DryRun: true, DeleteRemote: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{"sub"}, result.CreatedFolders)
+	require.Equal(t, []string{"new.txt", "sub/inner.txt"}, result.Uploaded)
+	require.Equal(t, []string{"changed.txt"}, result.Updated)
+	require.Equal(t, []string{"stale.txt"}, result.Deleted)
+	require.Equal(t, []string{"keep.txt"}, result.Unchanged)
+	require.Equal(t, "dry run: 1 folders created, 2 uploaded, 1 updated, 1 deleted, 1 unchanged", result.Summary())
+	require.Equal(t, map[string]int{"/catalog/file/list": 1}, calls)
+}
+
+func TestSyncDirToVolume_Apply(t *testing.T) {
+	t.Parallel()
+
+	calls := map[string]int{}
+	client := newSyncTestClient(t, calls)
+
+	result, err := client.SyncDirToVolume(context.Background(), newSyncTestDir(t), "vol", "", SyncOptions{
+		DeleteRemote: true,
+		Exclude:      func(relPath string, _ fs.DirEntry) bool { return relPath == "new.txt" },
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"sub/inner.txt"}, result.Uploaded)
+	require.Equal(t, []string{"stale.txt"}, result.Deleted)
+	require.Equal(t, 2, calls["/catalog/file/upload"])   // changed.txt, sub/inner.txt
+	require.Equal(t, 2, calls["/catalog/file/delete"])   // changed.txt replaced, stale.txt removed
+	require.Equal(t, 1, calls["/catalog/file/update"])   // changed.txt renamed into place
+	require.Equal(t, 1, calls["/catalog/folder/create"]) // sub
+	require.Equal(t, 2, calls["/catalog/file/list"])     // root, sub
+}
+
+func TestSyncDirToVolume_FailedUploadKeepsRemoteFile(t *testing.T) {
+	t.Parallel()
+
+	var deleted []FileDeleteRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/file/list":
+			return envelopeResponse(`{"total":1,"list":[{"id":"f2","name":"changed.txt","file_type":"1","size":3}]}`), nil
+		case "/catalog/file/upload":
+			_, _ = io.Copy(io.Discard, r.Body)
+			return errorEnvelopeResponse("ErrInternal", "storage unavailable"), nil
+		case "/catalog/file/delete":
+			var req FileDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			deleted = append(deleted, req)
+			return envelopeResponse(`{}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "changed.txt"), []byte("new content"), 0644))
+	_, err := client.SyncDirToVolume(context.Background(), dir, "vol", "", SyncOptions{})
+	require.ErrorContains(t, err, "storage unavailable")
+	require.Empty(t, deleted)
+}
+
+func TestSyncDirToVolume_Validation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := NewSDKClient(&RawClient{})
+
+	_, err := client.SyncDirToVolume(ctx, "", "vol", "", SyncOptions{})
+	require.ErrorContains(t, err, "local_path is required")
+	_, err = client.SyncDirToVolume(ctx, t.TempDir(), "", "", SyncOptions{})
+	require.ErrorContains(t, err, "volume_id is required")
+}
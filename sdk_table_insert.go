@@ -0,0 +1,147 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// InsertStructs appends the values of rows to a table, one row per element, using
+// InsertRows.
+//
+// T must be a struct or a pointer to a struct. Each exported field becomes a column
+// named by its `moi` tag, or else by the name in its `json` tag, or else by the
+// field name. Fields tagged "-" are skipped, and the fields of embedded structs are
+// inlined. Nil pointer elements are rejected.
+//
+// Example:
+//
+//	type Order struct {
+//		ID     int64   `moi:"id"`
+//		Amount float64 `moi:"amount"`
+//		Note   string  `moi:"-"`
+//	}
+//
+//	resp, err := sdk.InsertStructs(ctx, sdkClient, 456, orders,
+//		sdk.WithInsertBatchSize(500))
+func InsertStructs[T any](ctx context.Context, c *SDKClient, tableID TableID, rows []T, opts ...CallOption) (*TableInsertResponse, error) {
+	if c == nil || c.raw == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("rows are required")
+	}
+	fields, err := structColumns(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	maps := make([]map[string]any, len(rows))
+	for i := range rows {
+		v := reflect.ValueOf(&rows[i]).Elem()
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil, fmt.Errorf("row %d is nil", i)
+			}
+			v = v.Elem()
+		}
+		row := make(map[string]any, len(fields))
+		for _, f := range fields {
+			fv, ok := fieldByIndex(v, f.index)
+			if !ok {
+				row[f.column] = nil
+				continue
+			}
+			row[f.column] = fv.Interface()
+		}
+		maps[i] = row
+	}
+	return c.raw.InsertRows(ctx, &TableInsertRequest{TableID: tableID, Rows: maps}, opts...)
+}
+
+// structColumn is a struct field mapped to a table column.
+type structColumn struct {
+	column string
+	index  []int
+}
+
+// structColumns returns the columns that InsertStructs and ScanTableRows map to the
+// fields of type t.
+func structColumns(t reflect.Type) ([]structColumn, error) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct type", t)
+	}
+	var cols []structColumn
+	seen := make(map[string]bool)
+	var walk func(t reflect.Type, index []int) error
+	walk = func(t reflect.Type, index []int) error {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			idx := append(append([]int(nil), index...), i)
+			name, tagged := structColumnName(f)
+			if name == "-" {
+				continue
+			}
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if f.Anonymous && !tagged && ft.Kind() == reflect.Struct {
+				if err := walk(ft, idx); err != nil {
+					return err
+				}
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+			key := strings.ToLower(name)
+			if seen[key] {
+				return fmt.Errorf("column %q is mapped by more than one field of %s", name, t)
+			}
+			seen[key] = true
+			cols = append(cols, structColumn{column: name, index: idx})
+		}
+		return nil
+	}
+	if err := walk(t, nil); err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return nil, fmt.Errorf("%s has no exported fields", t)
+	}
+	return cols, nil
+}
+
+// structColumnName returns the column name of f and whether it was set by a tag.
+func structColumnName(f reflect.StructField) (string, bool) {
+	for _, key := range []string{"moi", "json"} {
+		tag, ok := f.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name != "" {
+			return name, true
+		}
+	}
+	return f.Name, false
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but reports false instead of
+// panicking when it meets a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type insertBase struct {
+	ID int64 `moi:"id"`
+}
+
+type insertOrder struct {
+	insertBase
+	Amount   float64 `json:"amount,omitempty"`
+	Customer string
+	Internal string `moi:"-"`
+	note     string
+}
+
+func TestInsertStructs(t *testing.T) {
+	t.Parallel()
+	var got TableInsertRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/table/insert", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		return envelopeResponse(`{"inserted":2}`), nil
+	}))
+
+	orders := []*insertOrder{
+		{insertBase: insertBase{ID: 1}, Amount: 9.5, Customer: "alice", Internal: "x", note: "y"},
+		{insertBase: insertBase{ID: 2}, Customer: "bob"},
+	}
+	resp, err := InsertStructs(context.Background(), client, 7, orders)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Inserted)
+	require.Equal(t, TableID(7), got.TableID)
+	require.Equal(t, []map[string]any{
+		{"id": float64(1), "amount": 9.5, "Customer": "alice"},
+		{"id": float64(2), "amount": float64(0), "Customer": "bob"},
+	}, got.Rows)
+}
+
+func TestInsertStructsErrors(t *testing.T) {
+	t.Parallel()
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request %s", r.URL.Path)
+		return nil, nil
+	}))
+	ctx := context.Background()
+
+	_, err := InsertStructs(ctx, client, 7, []int{1})
+	require.ErrorContains(t, err, "not a struct type")
+
+	_, err = InsertStructs(ctx, client, 7, []*insertOrder{nil})
+	require.ErrorContains(t, err, "row 0 is nil")
+
+	type dup struct {
+		A string `moi:"name"`
+		B string `json:"name"`
+	}
+	_, err = InsertStructs(ctx, client, 7, []dup{{}})
+	require.ErrorContains(t, err, "more than one field")
+
+	_, err = InsertStructs(ctx, client, 7, []insertOrder(nil))
+	require.ErrorContains(t, err, "rows are required")
+}
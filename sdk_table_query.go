@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Maps returns the rows keyed by column name.
+func (r *TableQueryResponse) Maps() []map[string]interface{} {
+	if r == nil {
+		return nil
+	}
+	rows := make([]map[string]interface{}, len(r.Rows))
+	for i, values := range r.Rows {
+		row := make(map[string]interface{}, len(r.Columns))
+		for j, col := range r.Columns {
+			if j < len(values) {
+				row[col.Name] = values[j]
+			}
+		}
+		rows[i] = row
+	}
+	return rows
+}
+
+// ScanTableRows decodes the rows of a QueryTable response into values of type T.
+//
+// Columns are matched to struct fields as in InsertStructs, ignoring case. Columns
+// without a matching field are ignored, and fields without a matching column keep
+// their zero value. T may be a struct or a pointer to a struct.
+//
+// Example:
+//
+//	type Order struct {
+//		ID     int64   `moi:"id"`
+//		Amount float64 `moi:"amount"`
+//	}
+//
+//	resp, err := client.QueryTable(ctx, &sdk.TableQueryRequest{TableID: 456})
+//	if err != nil {
+//		return err
+//	}
+//	orders, err := sdk.ScanTableRows[Order](resp)
+func ScanTableRows[T any](resp *TableQueryResponse) ([]T, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("response is required")
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	fields, err := structColumns(t)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string][]int, len(fields))
+	for _, f := range fields {
+		byName[strings.ToLower(f.column)] = f.index
+	}
+	targets := make([][]int, len(resp.Columns))
+	for i, col := range resp.Columns {
+		targets[i] = byName[strings.ToLower(col.Name)]
+	}
+
+	out := make([]T, len(resp.Rows))
+	for i, values := range resp.Rows {
+		v := reflect.ValueOf(&out[i]).Elem()
+		if v.Kind() == reflect.Pointer {
+			v.Set(reflect.New(t.Elem()))
+			v = v.Elem()
+		}
+		for j, index := range targets {
+			if index == nil || j >= len(values) || values[j] == nil {
+				continue
+			}
+			fv, err := allocFieldByIndex(v, index)
+			if err != nil {
+				return nil, err
+			}
+			// Round-trip through JSON so that numbers, strings and nested values
+			// convert to the field type the same way they would from the response
+			data, err := json.Marshal(values[j])
+			if err != nil {
+				return nil, fmt.Errorf("row %d, column %q: %w", i, resp.Columns[j].Name, err)
+			}
+			if err := json.Unmarshal(data, fv.Addr().Interface()); err != nil {
+				return nil, fmt.Errorf("row %d, column %q: %w", i, resp.Columns[j].Name, err)
+			}
+		}
+	}
+	return out, nil
+}
+
+// allocFieldByIndex is like reflect.Value.FieldByIndex but allocates nil embedded
+// struct pointers on the way.
+func allocFieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer of unexported type %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
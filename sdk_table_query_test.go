@@ -0,0 +1,37 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanTableRows(t *testing.T) {
+	t.Parallel()
+	resp := &TableQueryResponse{
+		Columns: []Column{{Name: "ID"}, {Name: "amount"}, {Name: "Customer"}, {Name: "extra"}},
+		Rows: [][]interface{}{
+			{float64(1), 9.5, "alice", "ignored"},
+			{float64(2), nil, "bob", nil},
+		},
+	}
+
+	orders, err := ScanTableRows[insertOrder](resp)
+	require.NoError(t, err)
+	require.Equal(t, []insertOrder{
+		{insertBase: insertBase{ID: 1}, Amount: 9.5, Customer: "alice"},
+		{insertBase: insertBase{ID: 2}, Customer: "bob"},
+	}, orders)
+
+	ptrs, err := ScanTableRows[*insertOrder](resp)
+	require.NoError(t, err)
+	require.Len(t, ptrs, 2)
+	require.Equal(t, "bob", ptrs[1].Customer)
+
+	resp.Rows = [][]interface{}{{"not a number"}}
+	_, err = ScanTableRows[insertOrder](resp)
+	require.ErrorContains(t, err, `row 0, column "ID"`)
+
+	_, err = ScanTableRows[int](resp)
+	require.ErrorContains(t, err, "not a struct type")
+}
@@ -0,0 +1,53 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ResolveTables returns the IDs of the tables with the given names in a database,
+// keyed by name, using a single listing of the database.
+//
+// Names are matched exactly. Names without a table in the database are absent from
+// the returned map, so callers can tell missing tables apart by looking them up.
+//
+// Example:
+//
+//	ids, err := sdkClient.ResolveTables(ctx, 123, []string{"orders", "customers"})
+//	if err != nil {
+//		return err
+//	}
+//	if _, ok := ids["customers"]; !ok {
+//		fmt.Println("customers table is missing")
+//	}
+func (c *SDKClient) ResolveTables(ctx context.Context, databaseID DatabaseID, names []string, opts ...CallOption) (map[string]TableID, error) {
+	if databaseID == 0 {
+		return nil, fmt.Errorf("database_id is required")
+	}
+	ids := make(map[string]TableID, len(names))
+	if len(names) == 0 {
+		return ids, nil
+	}
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	resp, err := c.raw.GetDatabaseChildren(ctx, &DatabaseChildrenRequest{DatabaseID: databaseID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list database children: %w", err)
+	}
+	for _, child := range resp.List {
+		if !wanted[child.Name] || !strings.EqualFold(child.Typ, "table") {
+			continue
+		}
+		id, err := strconv.ParseInt(child.ID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q of table %s: %w", child.ID, child.Name, err)
+		}
+		ids[child.Name] = TableID(id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,30 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTables(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		calls++
+		require.Equal(t, "/catalog/database/children", r.URL.Path)
+		return envelopeResponse(`{"list":[
+			{"id":"10","name":"orders","type":"table"},
+			{"id":"v1","name":"customers","type":"volume"},
+			{"id":"11","name":"items","type":"table"}]}`), nil
+	}))
+
+	ids, err := client.ResolveTables(context.Background(), 5, []string{"orders", "customers", "items", "missing"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]TableID{"orders": 10, "items": 11}, ids)
+	require.Equal(t, 1, calls)
+
+	_, err = client.ResolveTables(context.Background(), 0, []string{"orders"})
+	require.ErrorContains(t, err, "database_id is required")
+}
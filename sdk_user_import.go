@@ -0,0 +1,231 @@
+package sdk
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// UserImportOptions controls ImportUsersFromCSV.
+type UserImportOptions struct {
+	// DefaultRoleIDs are assigned to every imported user, in addition to the roles
+	// listed in the row.
+	DefaultRoleIDs []RoleID
+}
+
+// UserImportRow is the outcome of one row of ImportUsersFromCSV.
+type UserImportRow struct {
+	// Line is the line of the row in the file, starting from 1.
+	Line     int
+	UserName string
+	// UserID is the ID of the new user; it is zero when Err is set.
+	UserID UserID
+	Err    error
+}
+
+// UserImportResult reports the outcome of ImportUsersFromCSV.
+type UserImportResult struct {
+	// Rows lists every data row, in file order.
+	Rows []UserImportRow
+}
+
+// Created returns the number of users that were created.
+func (r *UserImportResult) Created() int {
+	if r == nil {
+		return 0
+	}
+	n := 0
+	for _, row := range r.Rows {
+		if row.Err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Err returns nil if every row was imported, or an error joining all row failures.
+func (r *UserImportResult) Err() error {
+	if r == nil {
+		return nil
+	}
+	var errs []error
+	for _, row := range r.Rows {
+		if row.Err != nil {
+			errs = append(errs, fmt.Errorf("line %d (%s): %w", row.Line, row.UserName, row.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ImportUsersFromCSV creates the users described by the CSV read from r.
+//
+// The first row is a header naming the columns name, password, email, phone,
+// description and roles; only name and password are required. The roles cell lists
+// role names or IDs separated by ";". Users are created with CreateUsersBatch.
+//
+// Rows that cannot be parsed, reference unknown roles or are rejected by the
+// service are reported in the result and do not stop the import; when the batch
+// request itself fails, its error is reported on every row sent with it. An error
+// is only returned when r cannot be read as a whole or the roles cannot be listed.
+// Passwords are used as written, including leading and trailing spaces.
+//
+// Example:
+//
+//	f, err := os.Open("new-hires.csv")
+//	if err != nil {
+//		return err
+//	}
+//	defer f.Close()
+//	result, err := sdkClient.ImportUsersFromCSV(ctx, f, sdk.UserImportOptions{
+//		DefaultRoleIDs: []sdk.RoleID{5},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("created %d users\n", result.Created())
+func (c *SDKClient) ImportUsersFromCSV(ctx context.Context, r io.Reader, importOpts UserImportOptions, opts ...CallOption) (*UserImportResult, error) {
+	if r == nil {
+		return nil, fmt.Errorf("reader cannot be nil")
+	}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "password"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header is missing the %q column", required)
+		}
+	}
+
+	result := &UserImportResult{}
+	var reqs []UserCreateRequest
+	var reqRows []int // index in result.Rows of each request
+	var roleNames map[string]RoleID
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			result.Rows = append(result.Rows, UserImportRow{Line: parseErr.StartLine, Err: err})
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		rawCell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(row) {
+				return row[i]
+			}
+			return ""
+		}
+		cell := func(name string) string {
+			return strings.TrimSpace(rawCell(name))
+		}
+
+		req := UserCreateRequest{
+			UserName: cell("name"),
+			// Spaces are kept in passwords, where they are significant
+			Password:    rawCell("password"),
+			Email:       cell("email"),
+			Phone:       cell("phone"),
+			Description: cell("description"),
+			RoleIDList:  append([]RoleID(nil), importOpts.DefaultRoleIDs...),
+		}
+		importRow := UserImportRow{Line: line, UserName: req.UserName}
+		switch {
+		case req.UserName == "":
+			importRow.Err = fmt.Errorf("name is required")
+		case req.Password == "":
+			importRow.Err = fmt.Errorf("password is required")
+		}
+		for _, role := range strings.Split(cell("roles"), ";") {
+			if importRow.Err != nil {
+				break
+			}
+			role = strings.TrimSpace(role)
+			if role == "" {
+				continue
+			}
+			if id, err := strconv.ParseUint(role, 10, 64); err == nil {
+				req.RoleIDList = append(req.RoleIDList, RoleID(id))
+				continue
+			}
+			if roleNames == nil {
+				if roleNames, err = c.roleIDsByName(ctx, opts...); err != nil {
+					return nil, fmt.Errorf("failed to list roles: %w", err)
+				}
+			}
+			id, ok := roleNames[role]
+			if !ok {
+				importRow.Err = fmt.Errorf("role %q not found", role)
+				break
+			}
+			req.RoleIDList = append(req.RoleIDList, id)
+		}
+		if importRow.Err == nil {
+			reqs = append(reqs, req)
+			reqRows = append(reqRows, len(result.Rows))
+		}
+		result.Rows = append(result.Rows, importRow)
+	}
+
+	batch, err := c.raw.CreateUsersBatch(ctx, reqs, opts...)
+	if err != nil {
+		// The whole batch was rejected: every row sent with it failed
+		for _, i := range reqRows {
+			result.Rows[i].Err = err
+		}
+		return result, nil
+	}
+	for _, item := range batch.Items {
+		row := &result.Rows[reqRows[item.Index]]
+		if item.Err != nil {
+			row.Err = item.Err
+			continue
+		}
+		id, err := strconv.ParseUint(item.ID, 10, 64)
+		if err != nil {
+			row.Err = fmt.Errorf("invalid user id %q: %w", item.ID, err)
+			continue
+		}
+		row.UserID = UserID(id)
+	}
+	return result, nil
+}
+
+// roleIDsByName returns the IDs of all roles keyed by role name.
+func (c *SDKClient) roleIDsByName(ctx context.Context, opts ...CallOption) (map[string]RoleID, error) {
+	ids := make(map[string]RoleID)
+	seen := 0
+	for page := 1; ; page++ {
+		if err := c.raw.checkPageLimit(page); err != nil {
+			return nil, err
+		}
+		resp, err := c.raw.ListRoles(ctx, &RoleListRequest{
+			CommonCondition: CommonCondition{Page: page, PageSize: listPageSize},
+		}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, role := range resp.List {
+			ids[role.RoleName] = role.RoleID
+		}
+		seen += len(resp.List)
+		if len(resp.List) == 0 || seen >= resp.Total {
+			return ids, nil
+		}
+	}
+}
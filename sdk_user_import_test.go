@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportUsersFromCSV(t *testing.T) {
+	t.Parallel()
+
+	var created []UserCreateRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/role/list":
+			return envelopeResponse(`{"total":2,"role_list":[{"id":5,"name":"analyst"},{"id":6,"name":"ops"}]}`), nil
+		case "/user/batch_create":
+			var body struct {
+				List []UserCreateRequest `json:"list"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			created = body.List
+			return envelopeResponse(`{"items":[{"index":0,"id":"11"},{"index":1,"code":"ErrDuplicate","msg":"exists"}]}`), nil
+		}
+		t.Fatalf("unexpected request to %s", r.URL.Path)
+		return nil, nil
+	}))
+
+	input := "name,password,email,roles\n" +
+		"alice,pw1,alice@example.com,analyst;7\n" +
+		"bob,pw2,,ops\n" +
+		"carol,,,\n" +
+		"dave,pw4,,auditor\n"
+	result, err := client.ImportUsersFromCSV(context.Background(), strings.NewReader(input), UserImportOptions{DefaultRoleIDs: []RoleID{1}})
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 4)
+	require.Equal(t, 1, result.Created())
+
+	require.Len(t, created, 2)
+	require.Equal(t, "alice@example.com", created[0].Email)
+	require.Equal(t, []RoleID{1, 5, 7}, created[0].RoleIDList)
+	require.Equal(t, []RoleID{1, 6}, created[1].RoleIDList)
+
+	require.Equal(t, UserID(11), result.Rows[0].UserID)
+	require.Equal(t, 2, result.Rows[0].Line)
+	require.True(t, IsAlreadyExists(result.Rows[1].Err))
+	require.ErrorContains(t, result.Rows[2].Err, "password is required")
+	require.ErrorContains(t, result.Rows[3].Err, `role "auditor" not found`)
+	require.ErrorContains(t, result.Err(), "line 5 (dave)")
+
+	_, err = client.ImportUsersFromCSV(context.Background(), strings.NewReader("email\n"), UserImportOptions{})
+	require.ErrorContains(t, err, `missing the "name" column`)
+}
+
+func TestImportUsersFromCSV_MalformedRow(t *testing.T) {
+	t.Parallel()
+
+	var created []UserCreateRequest
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var body struct {
+			List []UserCreateRequest `json:"list"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		created = body.List
+		return envelopeResponse(`{"items":[{"index":0,"id":"11"}]}`), nil
+	}))
+
+	input := "name,password\n" +
+		"\"bad\"x,pw\n" +
+		"alice, pw with spaces \n"
+	result, err := client.ImportUsersFromCSV(context.Background(), strings.NewReader(input), UserImportOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 2)
+	require.ErrorContains(t, result.Rows[0].Err, "parse error")
+	require.Equal(t, 2, result.Rows[0].Line)
+	require.NoError(t, result.Rows[1].Err)
+	require.Equal(t, 3, result.Rows[1].Line)
+
+	require.Len(t, created, 1)
+	require.Equal(t, " pw with spaces ", created[0].Password)
+}
+
+func TestImportUsersFromCSV_BatchFailure(t *testing.T) {
+	t.Parallel()
+
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return errorEnvelopeResponse("ErrInternal", "boom"), nil
+	}))
+
+	input := "name,password\n" +
+		"alice,pw1\n" +
+		"bob,\n"
+	result, err := client.ImportUsersFromCSV(context.Background(), strings.NewReader(input), UserImportOptions{})
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 2)
+	require.ErrorContains(t, result.Rows[0].Err, "boom")
+	require.ErrorContains(t, result.Rows[1].Err, "password is required")
+	require.Zero(t, result.Created())
+}
@@ -0,0 +1,152 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+)
+
+// VolumeTreeNode is a file or folder in a volume hierarchy built by GetVolumeTree.
+type VolumeTreeNode struct {
+	// Entry is the file or folder. It is zero-valued for the volume root.
+	Entry VolumeChildrenResponse
+	// Path is the slash-separated path from the volume root, empty for the root itself.
+	Path string
+	// Children are the entries of a folder, sorted by name. Nil for files.
+	Children []*VolumeTreeNode
+}
+
+// GetVolumeTree recursively lists a volume and returns its folder/file hierarchy.
+//
+// Folders are listed in parallel; use WithConcurrency to bound the number of
+// concurrent ListFiles requests. The first listing error aborts the traversal.
+//
+// Example:
+//
+//	root, err := sdkClient.GetVolumeTree(ctx, "volume-id-123", sdk.WithConcurrency(8))
+//	if err != nil {
+//		return err
+//	}
+//	for _, child := range root.Children {
+//		fmt.Println(child.Path)
+//	}
+func (c *SDKClient) GetVolumeTree(ctx context.Context, volumeID VolumeID, opts ...CallOption) (*VolumeTreeNode, error) {
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume_id is required")
+	}
+	callOpts := newCallOptions(opts...)
+
+	walkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	root := &VolumeTreeNode{}
+	sem := make(chan struct{}, callOpts.concurrency)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var visit func(node *VolumeTreeNode)
+	visit = func(node *VolumeTreeNode) {
+		defer wg.Done()
+		select {
+		case sem <- struct{}{}:
+		case <-walkCtx.Done():
+			return
+		}
+		children, err := c.listAllFiles(walkCtx, []CommonFilter{
+			{Name: "volume_id", Values: []string{string(volumeID)}},
+			{Name: "parent_id", Values: []string{node.Entry.ID}},
+		}, opts...)
+		<-sem
+		if err != nil {
+			fail(fmt.Errorf("failed to list %q: %w", "/"+node.Path, err))
+			return
+		}
+
+		sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+		node.Children = make([]*VolumeTreeNode, 0, len(children))
+		for _, child := range children {
+			childNode := &VolumeTreeNode{Entry: child, Path: path.Join(node.Path, child.Name)}
+			node.Children = append(node.Children, childNode)
+			if child.IsFolder() {
+				wg.Add(1)
+				go visit(childNode)
+			}
+		}
+	}
+
+	wg.Add(1)
+	go visit(root)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// WalkVolume calls fn for every file and folder in a volume, in depth-first order with
+// entries of a folder sorted by name. Folders are visited before their contents.
+//
+// If fn returns fs.SkipDir for a folder, its contents are skipped (for a file, the
+// remaining entries of its folder are skipped); fs.SkipAll stops the
+// walk without error. Any other error stops the walk and is returned. The hierarchy is
+// fetched with GetVolumeTree before fn is first called, so fn is never called concurrently.
+//
+// Example:
+//
+//	err := sdkClient.WalkVolume(ctx, "volume-id-123", func(entry sdk.VolumeChildrenResponse, p string) error {
+//		if entry.IsFolder() && entry.Name == "archive" {
+//			return fs.SkipDir
+//		}
+//		fmt.Println(p)
+//		return nil
+//	})
+func (c *SDKClient) WalkVolume(ctx context.Context, volumeID VolumeID, fn func(entry VolumeChildrenResponse, path string) error, opts ...CallOption) error {
+	if fn == nil {
+		return fmt.Errorf("walk function is required")
+	}
+	root, err := c.GetVolumeTree(ctx, volumeID, opts...)
+	if err != nil {
+		return err
+	}
+	if err := walkVolumeTree(root, fn); err != nil && !errors.Is(err, fs.SkipAll) {
+		return err
+	}
+	return nil
+}
+
+func walkVolumeTree(node *VolumeTreeNode, fn func(entry VolumeChildrenResponse, path string) error) error {
+	for _, child := range node.Children {
+		err := fn(child.Entry, child.Path)
+		if err != nil {
+			if errors.Is(err, fs.SkipDir) {
+				if child.Entry.IsFolder() {
+					continue
+				}
+				// As with filepath.WalkDir, SkipDir on a file skips the rest of its folder
+				return nil
+			}
+			return err
+		}
+		if err := walkVolumeTree(child, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newVolumeTreeTestClient(t *testing.T) *SDKClient {
+	t.Helper()
+	listings := map[string]string{
+		"":   `{"total":3,"list":[{"id":"d2","name":"docs","file_type":"10"},{"id":"f1","name":"a.txt","file_type":"1"},{"id":"d1","name":"archive","file_type":"10"}]}`,
+		"d1": `{"total":1,"list":[{"id":"f2","name":"old.txt","file_type":"1"}]}`,
+		"d2": `{"total":2,"list":[{"id":"f3","name":"guide.pdf","file_type":"2"},{"id":"d3","name":"img","file_type":"10"}]}`,
+		"d3": `{"total":0,"list":[]}`,
+	}
+	return NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		var req FileListRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, "vol", req.Filters[0].Values[0])
+		listing, ok := listings[req.Filters[1].Values[0]]
+		if !ok {
+			return errorEnvelopeResponse("ErrNotFound", "folder not exist"), nil
+		}
+		return envelopeResponse(listing), nil
+	}))
+}
+
+func TestGetVolumeTree(t *testing.T) {
+	t.Parallel()
+
+	root, err := newVolumeTreeTestClient(t).GetVolumeTree(context.Background(), "vol", WithConcurrency(2))
+	require.NoError(t, err)
+	require.Len(t, root.Children, 3)
+	require.Equal(t, "a.txt", root.Children[0].Path)
+	require.Equal(t, "archive/old.txt", root.Children[1].Children[0].Path)
+	require.Equal(t, "docs/img", root.Children[2].Children[1].Path)
+	require.Empty(t, root.Children[2].Children[1].Children)
+}
+
+func TestWalkVolume(t *testing.T) {
+	t.Parallel()
+
+	var visited []string
+	err := newVolumeTreeTestClient(t).WalkVolume(context.Background(), "vol", func(entry VolumeChildrenResponse, p string) error {
+		visited = append(visited, p)
+		if entry.IsFolder() && entry.Name == "archive" {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.txt", "archive", "docs", "docs/guide.pdf", "docs/img"}, visited)
+
+	visited = nil
+	err = newVolumeTreeTestClient(t).WalkVolume(context.Background(), "vol", func(entry VolumeChildrenResponse, p string) error {
+		visited = append(visited, p)
+		return fs.SkipAll
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.txt"}, visited)
+}
+
+func TestGetVolumeTree_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := NewSDKClient(&RawClient{})
+	_, err := client.GetVolumeTree(context.Background(), "")
+	require.ErrorContains(t, err, "volume_id is required")
+	require.ErrorContains(t, client.WalkVolume(context.Background(), "vol", nil), "walk function is required")
+}
@@ -0,0 +1,126 @@
+// Package sdkmetrics exports the request metrics of MOI SDK clients to Prometheus.
+//
+// A Collector registers its metrics on a prometheus.Registerer and is plugged into
+// clients with sdk.WithMetricsRecorder:
+//
+//	metrics, err := sdkmetrics.New(prometheus.DefaultRegisterer, sdkmetrics.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithMetricsRecorder(metrics))
+//
+// The following metrics are exported, prefixed with the namespace (moi_sdk by
+// default):
+//
+//	requests_total{endpoint,method,status}     requests sent; status is 0 without a response
+//	errors_total{endpoint,code}                failed requests by API error code or HTTP status
+//	request_duration_seconds{endpoint,method}  time until the response headers arrive
+//	inflight_requests{endpoint}                requests waiting for their response headers
+package sdkmetrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+const defaultNamespace = "moi_sdk"
+
+// Options customizes the metrics of a Collector.
+type Options struct {
+	// Namespace prefixes the metric names. Defaults to moi_sdk.
+	Namespace string
+	// ConstLabels are added to every metric, e.g. to tell apart several clients
+	// registered on the same registerer under different namespaces.
+	ConstLabels prometheus.Labels
+	// Buckets are the upper bounds of the request duration histogram, in seconds.
+	// Defaults to prometheus.DefBuckets.
+	Buckets []float64
+}
+
+// Collector records the requests of SDK clients as Prometheus metrics. It implements
+// sdk.MetricsRecorder and can be shared by several clients.
+type Collector struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inflight *prometheus.GaugeVec
+}
+
+var _ sdk.MetricsRecorder = (*Collector)(nil)
+
+// New creates a Collector and registers its metrics on reg, or on
+// prometheus.DefaultRegisterer when reg is nil.
+//
+// Example:
+//
+//	reg := prometheus.NewRegistry()
+//	metrics, err := sdkmetrics.New(reg, sdkmetrics.Options{Namespace: "billing_moi"})
+//	if err != nil {
+//		return err
+//	}
+//	http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+func New(reg prometheus.Registerer, opts Options) (*Collector, error) {
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = defaultNamespace
+	}
+	if len(opts.Buckets) == 0 {
+		opts.Buckets = prometheus.DefBuckets
+	}
+	c := &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "requests_total",
+			Help:        "Requests sent by the MOI SDK, by endpoint, method and HTTP status (0 when no response was received).",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint", "method", "status"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "errors_total",
+			Help:        "Failed requests of the MOI SDK, by endpoint and API error code, HTTP status, or timeout, canceled or transport.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Time from sending a request of the MOI SDK to receiving the response headers.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.Buckets,
+		}, []string{"endpoint", "method"}),
+		inflight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   opts.Namespace,
+			Name:        "inflight_requests",
+			Help:        "Requests of the MOI SDK waiting for their response headers.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint"}),
+	}
+	for _, collector := range []prometheus.Collector{c.requests, c.errors, c.duration, c.inflight} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// RequestStarted implements sdk.MetricsRecorder.
+func (c *Collector) RequestStarted(endpoint string) {
+	c.inflight.WithLabelValues(endpoint).Inc()
+}
+
+// RequestFinished implements sdk.MetricsRecorder.
+func (c *Collector) RequestFinished(endpoint, method string, status int, duration time.Duration) {
+	c.inflight.WithLabelValues(endpoint).Dec()
+	c.requests.WithLabelValues(endpoint, method, strconv.Itoa(status)).Inc()
+	c.duration.WithLabelValues(endpoint, method).Observe(duration.Seconds())
+}
+
+// RequestFailed implements sdk.MetricsRecorder.
+func (c *Collector) RequestFailed(endpoint, code string) {
+	c.errors.WithLabelValues(endpoint, code).Inc()
+}
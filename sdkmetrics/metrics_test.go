@@ -0,0 +1,77 @@
+package sdkmetrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+	reg := prometheus.NewRegistry()
+	metrics, err := New(reg, Options{})
+	require.NoError(t, err)
+
+	client, err := sdk.NewRawClient("https://moi.test", "key",
+		sdk.WithMetricsRecorder(metrics),
+		sdk.WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/catalog/info":
+				return jsonResponse(http.StatusOK, `{"code":"OK","msg":"OK","data":{"catalog_id":1}}`), nil
+			case "/catalog/delete":
+				return jsonResponse(http.StatusOK, `{"code":"ErrCatalogNotFound","msg":"not found"}`), nil
+			case "/catalog/create":
+				return jsonResponse(http.StatusBadGateway, `bad gateway`), nil
+			default:
+				return nil, errors.New("connection refused")
+			}
+		})}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	_, err = client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	_, err = client.DeleteCatalog(ctx, &sdk.CatalogDeleteRequest{CatalogID: 1})
+	require.Error(t, err)
+	_, err = client.CreateCatalog(ctx, &sdk.CatalogCreateRequest{CatalogName: "c"})
+	require.Error(t, err)
+	_, err = client.ListCatalogs(ctx)
+	require.Error(t, err)
+
+	require.Equal(t, 2.0, testutil.ToFloat64(metrics.requests.WithLabelValues("/catalog/info", "POST", "200")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues("/catalog/create", "POST", "502")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("/catalog/delete", "ErrCatalogNotFound")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("/catalog/create", "502")))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.errors.WithLabelValues("/catalog/list", "transport")))
+	require.Equal(t, 0.0, testutil.ToFloat64(metrics.inflight.WithLabelValues("/catalog/info")))
+	require.Equal(t, 4, testutil.CollectAndCount(metrics.duration))
+
+	// Registering twice on the same registerer fails
+	_, err = New(reg, Options{})
+	require.Error(t, err)
+	_, err = New(reg, Options{Namespace: "other"})
+	require.NoError(t, err)
+}
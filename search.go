@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Search finds catalogs, databases, tables, volumes, folders and files whose name or
+// description matches a query, ranked by relevance.
+//
+// This replaces issuing one fuzzy list call per object type, e.g. to back a global
+// search box. Only objects the caller is allowed to see are returned.
+//
+// Example:
+//
+//	resp, err := client.Search(ctx, &sdk.SearchRequest{
+//		Query: "invoice",
+//		Types: []sdk.CatalogObjectType{sdk.CatalogObjectTable, sdk.CatalogObjectFile},
+//		Scope: sdk.SearchScope{CatalogID: 123},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, hit := range resp.List {
+//		fmt.Printf("%s %s (%.2f)\n", hit.Type, strings.Join(hit.Path.NameList, "/"), hit.Score)
+//	}
+func (c *RawClient) Search(ctx context.Context, req *SearchRequest, opts ...CallOption) (*SearchResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	for _, typ := range req.Types {
+		switch typ {
+		case CatalogObjectCatalog, CatalogObjectDatabase, CatalogObjectTable,
+			CatalogObjectVolume, CatalogObjectFolder, CatalogObjectFile:
+		default:
+			return nil, fmt.Errorf("unsupported object type %q", typ)
+		}
+	}
+	var resp SearchResponse
+	if err := c.postJSON(ctx, "/catalog/search", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,42 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/search", r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Equal(t, "invoice", body["query"])
+		require.Equal(t, []interface{}{"table"}, body["type_list"])
+		require.Equal(t, map[string]interface{}{"catalog_id": float64(1)}, body["scope"])
+		return envelopeResponse(`{"total":1,"list":[{"type":"table","id":"100","name":"invoices","score":0.9,
+			"full_path":{"id_list":["1","10","100"],"name_list":["sales","orders","invoices"]}}]}`), nil
+	})
+
+	resp, err := client.Search(context.Background(), &SearchRequest{
+		Query: "invoice",
+		Types: []CatalogObjectType{CatalogObjectTable},
+		Scope: SearchScope{CatalogID: 1},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.List, 1)
+	require.Equal(t, CatalogObjectTable, resp.List[0].Type)
+	require.Equal(t, []string{"sales", "orders", "invoices"}, resp.List[0].Path.NameList)
+
+	_, err = client.Search(context.Background(), nil)
+	require.ErrorIs(t, err, ErrNilRequest)
+	_, err = client.Search(context.Background(), &SearchRequest{Query: " "})
+	require.ErrorContains(t, err, "query is required")
+	_, err = client.Search(context.Background(), &SearchRequest{Query: "x", Types: []CatalogObjectType{"workflow"}})
+	require.ErrorContains(t, err, "unsupported object type")
+}
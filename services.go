@@ -0,0 +1,45 @@
+package sdk
+
+import "context"
+
+// CatalogService is the part of RawClient that manages catalogs. Code that depends
+// on it rather than on *RawClient can be unit tested with the mocks of the mock
+// package.
+type CatalogService interface {
+	CreateCatalog(ctx context.Context, req *CatalogCreateRequest, opts ...CallOption) (*CatalogCreateResponse, error)
+	DeleteCatalog(ctx context.Context, req *CatalogDeleteRequest, opts ...CallOption) (*CatalogDeleteResponse, error)
+	UpdateCatalog(ctx context.Context, req *CatalogUpdateRequest, opts ...CallOption) (*CatalogUpdateResponse, error)
+	GetCatalog(ctx context.Context, req *CatalogInfoRequest, opts ...CallOption) (*CatalogInfoResponse, error)
+	ListCatalogs(ctx context.Context, opts ...CallOption) (*CatalogListResponse, error)
+	ListCatalogsFiltered(ctx context.Context, req *CatalogListRequest, opts ...CallOption) (*CatalogListResponse, error)
+}
+
+// FileService is the part of RawClient that manages the files and folders of volumes.
+type FileService interface {
+	CreateFile(ctx context.Context, req *FileCreateRequest, opts ...CallOption) (*FileCreateResponse, error)
+	UpdateFile(ctx context.Context, req *FileUpdateRequest, opts ...CallOption) (*FileUpdateResponse, error)
+	DeleteFile(ctx context.Context, req *FileDeleteRequest, opts ...CallOption) (*FileDeleteResponse, error)
+	GetFile(ctx context.Context, req *FileInfoRequest, opts ...CallOption) (*FileInfoResponse, error)
+	ListFiles(ctx context.Context, req *FileListRequest, opts ...CallOption) (*FileListResponse, error)
+	CreateFolder(ctx context.Context, req *FolderCreateRequest, opts ...CallOption) (*FolderCreateResponse, error)
+	UpdateFolder(ctx context.Context, req *FolderUpdateRequest, opts ...CallOption) (*FolderUpdateResponse, error)
+	DeleteFolder(ctx context.Context, req *FolderDeleteRequest, opts ...CallOption) (*FolderDeleteResponse, error)
+}
+
+// WorkflowService is the part of RawClient that manages GenAI workflows.
+type WorkflowService interface {
+	CreateWorkflow(ctx context.Context, req *WorkflowMetadata, opts ...CallOption) (*WorkflowCreateResponse, error)
+	GetWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowResponse, error)
+	UpdateWorkflow(ctx context.Context, workflowID string, req *WorkflowMetadata, opts ...CallOption) (*WorkflowResponse, error)
+	DeleteWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowDeleteResponse, error)
+	ListWorkflows(ctx context.Context, req *WorkflowListRequest, opts ...CallOption) (*WorkflowListResponse, error)
+	StartWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowStateResponse, error)
+	PauseWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowStateResponse, error)
+	ResumeWorkflow(ctx context.Context, workflowID string, opts ...CallOption) (*WorkflowStateResponse, error)
+}
+
+var (
+	_ CatalogService  = (*RawClient)(nil)
+	_ FileService     = (*RawClient)(nil)
+	_ WorkflowService = (*RawClient)(nil)
+)
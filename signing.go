@@ -0,0 +1,167 @@
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	headerSignatureTimestamp = "X-Moi-Timestamp"
+	headerSignatureDigest    = "X-Moi-Content-SHA256"
+	headerSignatureKeyID     = "X-Moi-Key-Id"
+	headerSignature          = "X-Moi-Signature"
+
+	// unsignedPayload is the body digest of requests whose body is streamed and so
+	// cannot be hashed before it is sent.
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// RequestSigner signs the requests of a client configured with WithRequestSigner.
+//
+// SignRequest is called for every request to the service just before it is sent,
+// after all other headers have been set, and again for each retry. It receives a copy
+// of the request that it may modify, typically by adding headers. It must be safe
+// for concurrent use. An error aborts the request.
+type RequestSigner interface {
+	SignRequest(req *http.Request) error
+}
+
+// RequestSignerFunc adapts a function to a RequestSigner.
+type RequestSignerFunc func(req *http.Request) error
+
+// SignRequest calls f(req).
+func (f RequestSignerFunc) SignRequest(req *http.Request) error { return f(req) }
+
+// WithRequestSigner signs every request the client sends to the service with signer,
+// for gateways that only accept signed requests. HMACSigner is a reference
+// implementation.
+//
+// Requests to presigned storage links, such as file downloads, are not signed.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithRequestSigner(&sdk.HMACSigner{
+//			KeyID:  "etl-prod",
+//			Secret: []byte(os.Getenv("MOI_SIGNING_SECRET")),
+//		}))
+func WithRequestSigner(signer RequestSigner) ClientOption {
+	return func(o *clientOptions) {
+		o.requestSigner = signer
+	}
+}
+
+// HMACSigner signs requests with HMAC-SHA256.
+//
+// It adds the following headers to each request:
+//
+//	X-Moi-Timestamp:      Unix time of signing, in seconds
+//	X-Moi-Content-SHA256: hex SHA-256 of the body, or UNSIGNED-PAYLOAD for streamed bodies
+//	X-Moi-Key-Id:         KeyID
+//	X-Moi-Signature:      hex HMAC-SHA256 of the string to sign, keyed with Secret
+//
+// The string to sign joins with newlines the method, the escaped URL path, the
+// query string with its parameters sorted by key, the timestamp and the body
+// digest. The body of a streamed upload cannot be read in advance, so such requests
+// are signed with the UNSIGNED-PAYLOAD digest; gateways that require a digest of
+// every body must reject them.
+type HMACSigner struct {
+	KeyID  string
+	Secret []byte
+
+	now func() time.Time // Overridden in tests
+}
+
+// SignRequest implements RequestSigner.
+func (s *HMACSigner) SignRequest(req *http.Request) error {
+	if len(s.Secret) == 0 {
+		return fmt.Errorf("hmac signer: secret is required")
+	}
+	digest, err := bodyDigest(req)
+	if err != nil {
+		return fmt.Errorf("hmac signer: %w", err)
+	}
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	timestamp := strconv.FormatInt(now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, s.Secret)
+	io.WriteString(mac, HMACStringToSign(req, timestamp, digest))
+	req.Header.Set(headerSignatureTimestamp, timestamp)
+	req.Header.Set(headerSignatureDigest, digest)
+	if s.KeyID != "" {
+		req.Header.Set(headerSignatureKeyID, s.KeyID)
+	}
+	req.Header.Set(headerSignature, hex.EncodeToString(mac.Sum(nil)))
+	return nil
+}
+
+// HMACStringToSign returns the string HMACSigner signs for req, so that gateways and
+// tests can compute the expected signature.
+func HMACStringToSign(req *http.Request, timestamp, digest string) string {
+	return strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		timestamp,
+		digest,
+	}, "\n")
+}
+
+// bodyDigest returns the hex SHA-256 of the body of req, read from a copy obtained
+// with GetBody, or unsignedPayload when the body cannot be copied.
+func bodyDigest(req *http.Request) (string, error) {
+	h := sha256.New()
+	if req.Body == nil || req.Body == http.NoBody {
+		return hex.EncodeToString(h.Sum(nil)), nil
+	}
+	if req.GetBody == nil {
+		return unsignedPayload, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", fmt.Errorf("copy body: %w", err)
+	}
+	defer body.Close()
+	if _, err := io.Copy(h, body); err != nil {
+		return "", fmt.Errorf("hash body: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// signingTransport signs the requests to the service hosts.
+type signingTransport struct {
+	base   http.RoundTripper
+	hosts  map[string]bool
+	signer RequestSigner
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if !t.hosts[req.URL.Host] {
+		return base.RoundTrip(req)
+	}
+	// A RoundTripper must not modify the request it is given.
+	signed := req.Clone(req.Context())
+	if err := t.signer.SignRequest(signed); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("sign request: %w", err)
+	}
+	return base.RoundTrip(signed)
+}
+
+func (t *signingTransport) unwrap() http.RoundTripper { return t.base }
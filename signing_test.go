@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACSigner_SignsServiceRequests(t *testing.T) {
+	t.Parallel()
+	secret := []byte("s3cret")
+	signer := &HMACSigner{KeyID: "etl", Secret: secret, now: func() time.Time { return time.Unix(1700000000, 0) }}
+
+	var signed *http.Request
+	var body []byte
+	client, err := NewRawClient("https://moi.test", "key",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			signed = r
+			body, _ = io.ReadAll(r.Body)
+			return envelopeResponse(`{}`), nil
+		})}),
+		WithRequestSigner(signer))
+	require.NoError(t, err)
+
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 7}, WithQueryParam("b", "2"), WithQueryParam("a", "1"))
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(body)
+	digest := hex.EncodeToString(sum[:])
+	require.Equal(t, "1700000000", signed.Header.Get("X-Moi-Timestamp"))
+	require.Equal(t, digest, signed.Header.Get("X-Moi-Content-SHA256"))
+	require.Equal(t, "etl", signed.Header.Get("X-Moi-Key-Id"))
+
+	toSign := HMACStringToSign(signed, "1700000000", digest)
+	require.Equal(t, "POST\n/catalog/info\na=1&b=2\n1700000000\n"+digest, toSign)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(toSign))
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), signed.Header.Get("X-Moi-Signature"))
+}
+
+func TestHMACSigner_StreamedBody(t *testing.T) {
+	t.Parallel()
+	req, err := http.NewRequest(http.MethodPost, "https://moi.test/upload", io.NopCloser(strings.NewReader("data")))
+	require.NoError(t, err)
+	require.NoError(t, (&HMACSigner{Secret: []byte("k")}).SignRequest(req))
+	require.Equal(t, "UNSIGNED-PAYLOAD", req.Header.Get("X-Moi-Content-SHA256"))
+	require.Empty(t, req.Header.Get("X-Moi-Key-Id"))
+
+	require.ErrorContains(t, (&HMACSigner{}).SignRequest(req), "secret is required")
+}
+
+func TestWithRequestSigner_SkipsStorageLinksAndFails(t *testing.T) {
+	t.Parallel()
+	var calls int
+	client, err := NewRawClient("https://moi.test", "key",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			require.Empty(t, r.Header.Get("X-Moi-Signature"))
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+		})}),
+		WithRequestSigner(RequestSignerFunc(func(r *http.Request) error {
+			return errors.New("hsm offline")
+		})))
+	require.NoError(t, err)
+
+	resp, err := client.httpClient.Get("https://storage.example.com/object")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 7})
+	require.ErrorContains(t, err, "hsm offline")
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,337 @@
+package sdk
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RunSQLWithParams executes a SQL statement with ? placeholders using the NL2SQL
+// RunSQL operation.
+//
+// Each ? outside of string literals, quoted identifiers and comments is replaced by
+// the next argument rendered as an escaped SQL literal (see BindSQL), so values never
+// need to be formatted into the statement by hand. Arguments of type CallOption are
+// not bound; they are applied to the call.
+//
+// Example:
+//
+//	resp, err := sdkClient.RunSQLWithParams(ctx,
+//		"select * from sales.orders where customer = ? and amount > ?",
+//		customerName, 100,
+//		sdk.WithRequestID("req-123"))
+func (c *SDKClient) RunSQLWithParams(ctx context.Context, statement string, args ...interface{}) (*NL2SQLRunSQLResponse, error) {
+	var params []interface{}
+	var opts []CallOption
+	for _, arg := range args {
+		if opt, ok := arg.(CallOption); ok {
+			opts = append(opts, opt)
+			continue
+		}
+		params = append(params, arg)
+	}
+	bound, err := BindSQL(statement, params...)
+	if err != nil {
+		return nil, err
+	}
+	return c.RunSQL(ctx, bound, opts...)
+}
+
+// BindSQL replaces the ? placeholders of statement with args rendered as SQL literals.
+//
+// Placeholders inside string literals, quoted identifiers and comments are left
+// untouched. Supported argument types are nil, string, []byte, bool, integer and
+// floating-point types, time.Time and driver.Valuer. The number of arguments must
+// match the number of placeholders.
+//
+// Example:
+//
+//	stmt, err := sdk.BindSQL("select * from t where name = ? and id in (?, ?)", "O'Brien", 1, 2)
+//	// select * from t where name = 'O\'Brien' and id in (1, 2)
+func BindSQL(statement string, args ...interface{}) (string, error) {
+	var b strings.Builder
+	next := 0
+	for i := 0; i < len(statement); i++ {
+		ch := statement[i]
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := skipQuoted(statement, i)
+			b.WriteString(statement[i:end])
+			i = end - 1
+		case ch == '#' || (ch == '-' && strings.HasPrefix(statement[i:], "-- ")):
+			end := strings.IndexByte(statement[i:], '\n')
+			if end < 0 {
+				end = len(statement) - i
+			}
+			b.WriteString(statement[i : i+end])
+			i += end - 1
+		case ch == '/' && strings.HasPrefix(statement[i:], "/*"):
+			end := strings.Index(statement[i+2:], "*/")
+			if end < 0 {
+				end = len(statement) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(statement[i : i+end])
+			i += end - 1
+		case ch == '?':
+			if next >= len(args) {
+				return "", fmt.Errorf("statement has more placeholders than the %d arguments given", len(args))
+			}
+			literal, err := sqlLiteral(args[next])
+			if err != nil {
+				return "", fmt.Errorf("argument %d: %w", next+1, err)
+			}
+			b.WriteString(literal)
+			next++
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	if next != len(args) {
+		return "", fmt.Errorf("statement has %d placeholders but %d arguments were given", next, len(args))
+	}
+	return b.String(), nil
+}
+
+// skipQuoted returns the index just past the quoted string or identifier starting at
+// statement[start], or len(statement) if it is not terminated.
+func skipQuoted(statement string, start int) int {
+	quote := statement[start]
+	for i := start + 1; i < len(statement); i++ {
+		switch statement[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			if i+1 < len(statement) && statement[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(statement)
+}
+
+// sqlLiteral renders v as a SQL literal.
+func sqlLiteral(v interface{}) (string, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return "", err
+		}
+		v = value
+	}
+	switch x := v.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return quoteSQLString(x), nil
+	case []byte:
+		if x == nil {
+			return "NULL", nil
+		}
+		return "X'" + hex.EncodeToString(x) + "'", nil
+	case bool:
+		if x {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case int:
+		return strconv.FormatInt(int64(x), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(x), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(x), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(x), 10), nil
+	case int64:
+		return strconv.FormatInt(x, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(x), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(x), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(x), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(x), 10), nil
+	case uint64:
+		return strconv.FormatUint(x, 10), nil
+	case float32:
+		return formatSQLFloat(float64(x), 32)
+	case float64:
+		return formatSQLFloat(x, 64)
+	case time.Time:
+		return "'" + x.Format("2006-01-02 15:04:05.999999") + "'", nil
+	}
+	return "", fmt.Errorf("unsupported argument type %T", v)
+}
+
+func formatSQLFloat(f float64, bitSize int) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%v cannot be represented in SQL", f)
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize), nil
+}
+
+// quoteSQLString quotes s as a SQL string literal, escaping the characters MySQL-style
+// parsers treat specially.
+func quoteSQLString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		switch ch := s[i]; ch {
+		case 0:
+			b.WriteString(`\0`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case 0x1a:
+			b.WriteString(`\Z`)
+		case '\\', '\'', '"':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// QuoteIdentifier quotes name as a SQL identifier, so that it can be used as a
+// column, table or database name whatever characters it contains.
+//
+// Example:
+//
+//	sdk.QuoteIdentifier("order items") // `order items`
+func QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// QuoteQualifiedName quotes each part of a dotted name such as catalog.database.table
+// and joins them with dots.
+//
+// Example:
+//
+//	sdk.QuoteQualifiedName("sales", "orders") // `sales`.`orders`
+func QuoteQualifiedName(parts ...string) string {
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = QuoteIdentifier(part)
+	}
+	return strings.Join(quoted, ".")
+}
+
+// SelectBuilder builds a SELECT statement whose identifiers are quoted and whose
+// values are passed as ? arguments. Create one with Select.
+type SelectBuilder struct {
+	columns []string
+	from    []string
+	where   []string
+	args    []interface{}
+	orderBy []string
+	limit   int
+}
+
+// Select starts a SELECT statement for columns; with no columns, all columns are
+// selected. Column names are quoted with QuoteIdentifier, except "*".
+//
+// Example:
+//
+//	stmt, args, err := sdk.Select("id", "amount").
+//		From("sales", "orders").
+//		Where("customer = ?", customerName).
+//		Where("amount > ?", 100).
+//		OrderBy("amount", true).
+//		Limit(10).
+//		Build()
+//	if err != nil {
+//		return err
+//	}
+//	resp, err := sdkClient.RunSQLWithParams(ctx, stmt, args...)
+func Select(columns ...string) *SelectBuilder {
+	return &SelectBuilder{columns: columns}
+}
+
+// From sets the table to select from, given as the parts of its qualified name,
+// e.g. From("database", "table") or From("catalog", "database", "table").
+func (b *SelectBuilder) From(path ...string) *SelectBuilder {
+	b.from = path
+	return b
+}
+
+// Where adds a condition, combined with the previous ones by AND. The condition is
+// written as SQL with ? placeholders for args; it is not escaped, so values must be
+// passed as args and never formatted into it.
+func (b *SelectBuilder) Where(condition string, args ...interface{}) *SelectBuilder {
+	b.where = append(b.where, condition)
+	b.args = append(b.args, args...)
+	return b
+}
+
+// OrderBy adds a sort column, in descending order if desc is true.
+func (b *SelectBuilder) OrderBy(column string, desc bool) *SelectBuilder {
+	order := QuoteIdentifier(column)
+	if desc {
+		order += " DESC"
+	}
+	b.orderBy = append(b.orderBy, order)
+	return b
+}
+
+// Limit limits the number of rows returned; 0 means no limit.
+func (b *SelectBuilder) Limit(n int) *SelectBuilder {
+	b.limit = n
+	return b
+}
+
+// Build returns the statement and its arguments, ready for RunSQLWithParams.
+func (b *SelectBuilder) Build() (string, []interface{}, error) {
+	if len(b.from) == 0 {
+		return "", nil, fmt.Errorf("table is required")
+	}
+	for _, part := range b.from {
+		if strings.TrimSpace(part) == "" {
+			return "", nil, fmt.Errorf("table name parts cannot be empty")
+		}
+	}
+	if b.limit < 0 {
+		return "", nil, fmt.Errorf("limit cannot be negative")
+	}
+
+	columns := "*"
+	if len(b.columns) > 0 {
+		quoted := make([]string, len(b.columns))
+		for i, column := range b.columns {
+			if column == "*" {
+				quoted[i] = column
+				continue
+			}
+			quoted[i] = QuoteIdentifier(column)
+		}
+		columns = strings.Join(quoted, ", ")
+	}
+
+	var stmt strings.Builder
+	stmt.WriteString("SELECT " + columns + " FROM " + QuoteQualifiedName(b.from...))
+	if len(b.where) > 0 {
+		stmt.WriteString(" WHERE (" + strings.Join(b.where, ") AND (") + ")")
+	}
+	if len(b.orderBy) > 0 {
+		stmt.WriteString(" ORDER BY " + strings.Join(b.orderBy, ", "))
+	}
+	if b.limit > 0 {
+		stmt.WriteString(" LIMIT " + strconv.Itoa(b.limit))
+	}
+	return stmt.String(), b.args, nil
+}
@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindSQL(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 3, 1, 12, 30, 0, 500000000, time.UTC)
+	tests := []struct {
+		name      string
+		statement string
+		args      []interface{}
+		want      string
+	}{
+		{"Values", "select ?, ?, ?, ?, ?, ?", []interface{}{nil, true, 42, uint8(7), 1.5, ts},
+			"select NULL, TRUE, 42, 7, 1.5, '2024-03-01 12:30:00.5'"},
+		{"EscapedString", "where name = ?", []interface{}{"O'Brien\\\n\"x\""},
+			`where name = 'O\'Brien\\\n\"x\"'`},
+		{"Injection", "where id = ?", []interface{}{"1' or '1'='1"},
+			`where id = '1\' or \'1\'=\'1'`},
+		{"Bytes", "values (?)", []interface{}{[]byte{0xde, 0xad}}, "values (X'dead')"},
+		{"PlaceholdersInQuotes", "select '?', \"a\\\"?\", `?``?`, ? -- ?\n/* ? */ # ?", []interface{}{1},
+			"select '?', \"a\\\"?\", `?``?`, 1 -- ?\n/* ? */ # ?"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := BindSQL(tc.statement, tc.args...)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := BindSQL("select ?, ?", 1)
+	require.ErrorContains(t, err, "more placeholders")
+	_, err = BindSQL("select ?", 1, 2)
+	require.ErrorContains(t, err, "1 placeholders but 2 arguments")
+	_, err = BindSQL("select ?", struct{}{})
+	require.ErrorContains(t, err, "unsupported argument type")
+	_, err = BindSQL("select ?", math.NaN())
+	require.Error(t, err)
+}
+
+func TestSelectBuilder(t *testing.T) {
+	t.Parallel()
+
+	stmt, args, err := Select("id", "order`name", "*").
+		From("cat", "sales", "orders").
+		Where("customer = ?", "bob").
+		Where("amount > ? or amount < ?", 100, 5).
+		OrderBy("amount", true).
+		OrderBy("id", false).
+		Limit(10).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT `id`, `order``name`, * FROM `cat`.`sales`.`orders` "+
+		"WHERE (customer = ?) AND (amount > ? or amount < ?) ORDER BY `amount` DESC, `id` LIMIT 10", stmt)
+	require.Equal(t, []interface{}{"bob", 100, 5}, args)
+
+	stmt, args, err = Select().From("db", "t").Build()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM `db`.`t`", stmt)
+	require.Empty(t, args)
+
+	_, _, err = Select("id").Build()
+	require.ErrorContains(t, err, "table is required")
+	_, _, err = Select("id").From("db", "").Build()
+	require.Error(t, err)
+}
+
+func TestRunSQLWithParams(t *testing.T) {
+	t.Parallel()
+
+	var got NL2SQLRunSQLRequest
+	var requestID string
+	client := NewSDKClient(newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/nl2sql/run_sql", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		requestID = r.Header.Get(headerRequestID)
+		return envelopeResponse(`{"results":[{"columns":["id"],"rows":[["1"]]}]}`), nil
+	}))
+
+	resp, err := client.RunSQLWithParams(context.Background(),
+		"select id from `db`.`t` where name = ?", "x'y", WithRequestID("req-1"))
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, RunSQL, got.Operation)
+	require.Equal(t, "select id from `db`.`t` where name = 'x\\'y'", got.Statement)
+	require.Equal(t, "req-1", requestID)
+
+	_, err = client.RunSQLWithParams(context.Background(), "select ?")
+	require.ErrorContains(t, err, "more placeholders")
+}
@@ -0,0 +1,339 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// ClientStats is a point-in-time snapshot of the HTTP traffic of a client.
+//
+// A request counts as an error when it fails at the transport level, returns an
+// HTTP status of 400 or above, or returns an API error envelope. Latency is measured
+// until the response headers arrive, so long downloads and streams do not skew it.
+type ClientStats struct {
+	Since          time.Time                `json:"since"` // When counting started (client creation or last ResetStats)
+	Requests       int64                    `json:"requests"`
+	Errors         int64                    `json:"errors"`
+	Retries        int64                    `json:"retries"` // Requests that repeated an earlier failed attempt
+	BytesSent      int64                    `json:"bytes_sent"`
+	BytesReceived  int64                    `json:"bytes_received"`
+	AverageLatency time.Duration            `json:"average_latency"`
+	Endpoints      map[string]EndpointStats `json:"endpoints"` // Keyed by API path, or by host for signed URLs
+}
+
+// EndpointStats holds the counters of a single endpoint in a ClientStats snapshot.
+type EndpointStats struct {
+	Requests       int64         `json:"requests"`
+	Errors         int64         `json:"errors"`
+	Retries        int64         `json:"retries"`
+	BytesSent      int64         `json:"bytes_sent"`
+	BytesReceived  int64         `json:"bytes_received"`
+	AverageLatency time.Duration `json:"average_latency"`
+}
+
+// ErrorRate returns the fraction of requests that failed, between 0 and 1.
+func (s ClientStats) ErrorRate() float64 {
+	return errorRate(s.Errors, s.Requests)
+}
+
+// ErrorRate returns the fraction of requests to the endpoint that failed, between 0 and 1.
+func (s EndpointStats) ErrorRate() float64 {
+	return errorRate(s.Errors, s.Requests)
+}
+
+// String returns a one-line summary suitable for periodic logging.
+func (s ClientStats) String() string {
+	return fmt.Sprintf("%d requests (%d errors, %.1f%%), %d retries, avg latency %s, %d bytes sent, %d bytes received, %d endpoints",
+		s.Requests, s.Errors, s.ErrorRate()*100, s.Retries, s.AverageLatency.Round(time.Millisecond),
+		s.BytesSent, s.BytesReceived, len(s.Endpoints))
+}
+
+func errorRate(errs, requests int64) float64 {
+	if requests == 0 {
+		return 0
+	}
+	return float64(errs) / float64(requests)
+}
+
+// Stats returns a snapshot of the requests sent by the client since it was created
+// or since the last call to ResetStats.
+//
+// Clients derived with WithSpecialUser share their counters with the original client.
+// Clients that were not created with NewRawClient report empty stats.
+//
+// Example:
+//
+//	go func() {
+//		for range time.Tick(time.Minute) {
+//			log.Printf("moi sdk: %s", client.Stats())
+//		}
+//	}()
+func (c *RawClient) Stats() ClientStats {
+	if c == nil || c.stats == nil {
+		return ClientStats{Endpoints: map[string]EndpointStats{}}
+	}
+	return c.stats.snapshot()
+}
+
+// ResetStats clears all counters reported by Stats.
+func (c *RawClient) ResetStats() {
+	if c == nil || c.stats == nil {
+		return
+	}
+	c.stats.reset()
+}
+
+// clientStats collects per-endpoint counters. The endpoint map is guarded by mu;
+// the counters themselves are updated atomically.
+type clientStats struct {
+	mu        sync.Mutex
+	since     time.Time
+	endpoints map[string]*endpointCounters
+	// basePaths maps the hosts of the API and LLM Proxy base URLs to their path
+	// prefix, which is stripped from endpoint keys.
+	basePaths map[string]string
+	inFlight  atomic.Int64
+	// recentErrors is a ring buffer of the last maxRecentErrors failures, guarded by mu.
+	recentErrors []DebugError
+	nextError    int
+}
+
+type endpointCounters struct {
+	requests      atomic.Int64
+	errors        atomic.Int64
+	retries       atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+	latency       atomic.Int64 // Total, in nanoseconds
+}
+
+func newClientStats(baseURLs ...string) *clientStats {
+	s := &clientStats{
+		since:     time.Now(),
+		endpoints: make(map[string]*endpointCounters),
+		basePaths: make(map[string]string),
+	}
+	for _, raw := range baseURLs {
+		if raw == "" {
+			continue
+		}
+		if u, err := url.Parse(raw); err == nil && u.Host != "" {
+			s.basePaths[u.Host] = strings.TrimRight(u.Path, "/")
+		}
+	}
+	return s
+}
+
+// endpointKey returns the API path for requests to a known base URL and the host
+// for anything else, so that signed download URLs do not create a key per file.
+func (s *clientStats) endpointKey(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	prefix, ok := s.basePaths[u.Host]
+	if !ok {
+		return u.Host
+	}
+	return ensureLeadingSlash(strings.TrimPrefix(u.Path, prefix))
+}
+
+func (s *clientStats) counters(key string) *endpointCounters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ec, ok := s.endpoints[key]
+	if !ok {
+		ec = &endpointCounters{}
+		s.endpoints[key] = ec
+	}
+	return ec
+}
+
+// recordAPIError counts an error envelope returned with a successful HTTP status.
+func (s *clientStats) recordAPIError(req *http.Request, apiErr *APIError) {
+	if s == nil || req == nil {
+		return
+	}
+	key := s.endpointKey(req.URL)
+	s.counters(key).errors.Add(1)
+	s.recordRecentError(DebugError{
+		Endpoint:   key,
+		StatusCode: apiErr.HTTPStatus,
+		Code:       apiErr.Code,
+		Message:    apiErr.Message,
+		RequestID:  apiErr.RequestID,
+	})
+}
+
+func (s *clientStats) recordRecentError(e DebugError) {
+	e.Time = time.Now()
+	if len(e.Message) > maxRecentErrorMessage {
+		n := maxRecentErrorMessage
+		for n > 0 && !utf8.RuneStart(e.Message[n]) {
+			n--
+		}
+		e.Message = e.Message[:n] + "..."
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.recentErrors) < maxRecentErrors {
+		s.recentErrors = append(s.recentErrors, e)
+		return
+	}
+	s.recentErrors[s.nextError] = e
+	s.nextError = (s.nextError + 1) % maxRecentErrors
+}
+
+// recentErrorsSnapshot returns the recorded errors, most recent first.
+func (s *clientStats) recentErrorsSnapshot() []DebugError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]DebugError, 0, len(s.recentErrors))
+	for i := len(s.recentErrors) - 1; i >= 0; i-- {
+		out = append(out, s.recentErrors[(s.nextError+i)%len(s.recentErrors)])
+	}
+	return out
+}
+
+func (s *clientStats) snapshot() ClientStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := ClientStats{Since: s.since, Endpoints: make(map[string]EndpointStats, len(s.endpoints))}
+	var totalLatency int64
+	for key, ec := range s.endpoints {
+		es := EndpointStats{
+			Requests:      ec.requests.Load(),
+			Errors:        ec.errors.Load(),
+			Retries:       ec.retries.Load(),
+			BytesSent:     ec.bytesSent.Load(),
+			BytesReceived: ec.bytesReceived.Load(),
+		}
+		latency := ec.latency.Load()
+		if es.Requests > 0 {
+			es.AverageLatency = time.Duration(latency / es.Requests)
+		}
+		out.Endpoints[key] = es
+		out.Requests += es.Requests
+		out.Errors += es.Errors
+		out.Retries += es.Retries
+		out.BytesSent += es.BytesSent
+		out.BytesReceived += es.BytesReceived
+		totalLatency += latency
+	}
+	if out.Requests > 0 {
+		out.AverageLatency = time.Duration(totalLatency / out.Requests)
+	}
+	return out
+}
+
+func (s *clientStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.since = time.Now()
+	s.endpoints = make(map[string]*endpointCounters)
+	s.recentErrors = nil
+	s.nextError = 0
+}
+
+// statsTransport records every request that passes through the client's transport,
+// including streaming and download requests that bypass doRaw.
+type statsTransport struct {
+	base  http.RoundTripper
+	stats *clientStats
+}
+
+func (t *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := t.stats.endpointKey(req.URL)
+	ec := t.stats.counters(key)
+	ec.requests.Add(1)
+	if isRetryAttempt(req.Context()) {
+		ec.retries.Add(1)
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		req = req.Clone(req.Context())
+		req.Body = &countingReadCloser{ReadCloser: req.Body, counter: &ec.bytesSent}
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	start := time.Now()
+	t.stats.inFlight.Add(1)
+	resp, err := base.RoundTrip(req)
+	t.stats.inFlight.Add(-1)
+	ec.latency.Add(int64(time.Since(start)))
+	if err != nil {
+		ec.errors.Add(1)
+		t.stats.recordRecentError(DebugError{Endpoint: key, Message: err.Error()})
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		ec.errors.Add(1)
+		t.stats.recordRecentError(DebugError{Endpoint: key, StatusCode: resp.StatusCode, Message: resp.Status})
+	}
+	if resp.Request == nil {
+		// http.Transport always sets it; custom transports may not, and doJSON
+		// relies on it to attribute API errors
+		resp.Request = req
+	}
+	if resp.Body != nil {
+		resp.Body = &countingReadCloser{ReadCloser: resp.Body, counter: &ec.bytesReceived}
+	}
+	return resp, nil
+}
+
+func (t *statsTransport) unwrap() http.RoundTripper { return t.base }
+
+// withTransport returns a shallow copy of client that uses transport. The caller's
+// client is left untouched.
+func withTransport(client *http.Client, transport http.RoundTripper) *http.Client {
+	wrapped := *client
+	wrapped.Transport = transport
+	return &wrapped
+}
+
+// baseTransport returns the transport configured by the user, below the SDK's
+// own instrumentation layers.
+func baseTransport(rt http.RoundTripper) http.RoundTripper {
+	for {
+		w, ok := rt.(interface{ unwrap() http.RoundTripper })
+		if !ok {
+			return rt
+		}
+		rt = w.unwrap()
+	}
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	counter *atomic.Int64
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.counter.Add(int64(n))
+	return n, err
+}
+
+type retryAttemptKey struct{}
+
+// withRetryAttempt marks requests made with the returned context as retries of an
+// earlier failed attempt.
+func withRetryAttempt(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryAttemptKey{}, true)
+}
+
+func isRetryAttempt(ctx context.Context) bool {
+	retry, _ := ctx.Value(retryAttemptKey{}).(bool)
+	return retry
+}
@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newStatsTestClient(t *testing.T, handler roundTripperFunc) *RawClient {
+	t.Helper()
+	client, err := NewRawClient("https://moi.test/api", "key", WithHTTPClient(&http.Client{Transport: handler}))
+	require.NoError(t, err)
+	return client
+}
+
+func TestClientStats(t *testing.T) {
+	t.Parallel()
+
+	client := newStatsTestClient(t, func(r *http.Request) (*http.Response, error) {
+		if r.Body != nil {
+			_, _ = io.Copy(io.Discard, r.Body)
+		}
+		switch r.URL.Path {
+		case "/api/catalog/file/info":
+			return envelopeResponse(`{"id":"f1"}`), nil
+		case "/api/catalog/file/delete":
+			return errorEnvelopeResponse("ErrNotFound", "file not found"), nil
+		default:
+			return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader("bad gateway"))}, nil
+		}
+	})
+	ctx := context.Background()
+
+	_, err := client.GetFile(ctx, &FileInfoRequest{FileID: "f1"})
+	require.NoError(t, err)
+	_, err = client.GetFile(ctx, &FileInfoRequest{FileID: "f1"})
+	require.NoError(t, err)
+	_, err = client.DeleteFile(ctx, &FileDeleteRequest{FileID: "f1"})
+	require.Error(t, err)
+	_, err = client.GetFile(withRetryAttempt(ctx), &FileInfoRequest{FileID: "f1"})
+	require.NoError(t, err)
+	_, err = client.CreateFolder(ctx, &FolderCreateRequest{Name: "x"})
+	require.Error(t, err)
+
+	stats := client.Stats()
+	require.EqualValues(t, 5, stats.Requests)
+	require.EqualValues(t, 2, stats.Errors)
+	require.EqualValues(t, 1, stats.Retries)
+	require.InDelta(t, 0.4, stats.ErrorRate(), 1e-9)
+	require.Positive(t, stats.BytesSent)
+	require.Positive(t, stats.BytesReceived)
+
+	info := stats.Endpoints["/catalog/file/info"]
+	require.EqualValues(t, 3, info.Requests)
+	require.EqualValues(t, 0, info.Errors)
+	require.EqualValues(t, 1, info.Retries)
+	require.EqualValues(t, 1, stats.Endpoints["/catalog/file/delete"].Errors)
+	require.EqualValues(t, 1, stats.Endpoints["/catalog/folder/create"].Errors)
+	require.Contains(t, stats.String(), "5 requests (2 errors, 40.0%)")
+
+	// Clients derived for another user share the counters
+	_, err = client.WithSpecialUser("other").GetFile(ctx, &FileInfoRequest{FileID: "f1"})
+	require.NoError(t, err)
+	require.EqualValues(t, 6, client.Stats().Requests)
+
+	client.ResetStats()
+	require.Zero(t, client.Stats().Requests)
+	require.Empty(t, client.Stats().Endpoints)
+}
+
+func TestClientStats_ExternalHost(t *testing.T) {
+	t.Parallel()
+
+	client := newStatsTestClient(t, func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("content"))}, nil
+	})
+	req, err := http.NewRequest(http.MethodGet, "https://storage.test/bucket/object-1?sig=abc", nil)
+	require.NoError(t, err)
+	resp, err := client.httpClient.Do(req)
+	require.NoError(t, err)
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	stats := client.Stats()
+	require.EqualValues(t, 1, stats.Endpoints["storage.test"].Requests)
+	require.EqualValues(t, len("content"), stats.Endpoints["storage.test"].BytesReceived)
+}
+
+func TestClientStats_Nil(t *testing.T) {
+	t.Parallel()
+
+	client := &RawClient{}
+	require.Zero(t, client.Stats().Requests)
+	client.ResetStats()
+}
+
+func TestClientStats_RecentErrorTruncated(t *testing.T) {
+	t.Parallel()
+
+	var s clientStats
+	s.recordRecentError(DebugError{Message: strings.Repeat("错误", 100)})
+	msg := s.recentErrorsSnapshot()[0].Message
+	require.True(t, utf8.ValidString(msg))
+	require.True(t, strings.HasSuffix(msg, "..."))
+	require.LessOrEqual(t, len(msg), maxRecentErrorMessage+len("..."))
+}
@@ -1,9 +1,59 @@
 package sdk
 
 import (
+	"compress/gzip"
 	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
+// LoadFormat is the format of table data read by LoadTableFromReader and
+// written by ExportTable.
+type LoadFormat string
+
+const (
+	LoadFormatCSV     LoadFormat = "csv"
+	LoadFormatParquet LoadFormat = "parquet"
+	LoadFormatJSONL   LoadFormat = "jsonl" // One JSON object per line, keyed by column name
+)
+
+// LoadErrorPolicy decides what happens to a load when a row cannot be written.
+type LoadErrorPolicy string
+
+const (
+	// LoadErrorAbort fails the whole load on the first rejected row. Nothing is written.
+	LoadErrorAbort LoadErrorPolicy = "abort"
+	// LoadErrorSkip writes every valid row and reports the rejected ones.
+	LoadErrorSkip LoadErrorPolicy = "skip"
+)
+
+// LoadOptions describes data streamed by LoadTableFromReader.
+type LoadOptions struct {
+	Format LoadFormat // Format defaults to LoadFormatCSV
+	// Delimiter separates CSV fields; it defaults to ",". Only used for CSV.
+	Delimiter string
+	// HeaderRow tells that the first CSV line holds column names, which are then
+	// matched to table columns by name instead of by position. Only used for CSV.
+	HeaderRow bool
+	// NullString is the CSV field value read as NULL, such as `\N`. When empty, no
+	// value is read as NULL. Only used for CSV.
+	NullString string
+	// ErrorPolicy defaults to LoadErrorAbort.
+	ErrorPolicy LoadErrorPolicy
+	// Size is the total data size in bytes, used only for progress reporting.
+	// Leave zero when unknown.
+	Size int64
+	// OnProgress, if set, is called as data is sent with the number of bytes sent
+	// so far and Size.
+	OnProgress func(sent, total int64)
+}
+
 // CreateTable creates a new table in the specified database.
 //
 // The table is created with the specified schema and properties.
@@ -87,6 +137,39 @@ func (c *RawClient) GetMultiTable(ctx context.Context, req *MultiTableInfoReques
 	return &resp, nil
 }
 
+// ListTables lists the tables of a database whose names match a filter, one page
+// at a time.
+//
+// Filters and ordering of the embedded CommonCondition apply as for the other list
+// calls, and the response reports the total number of matching tables.
+//
+// Example:
+//
+//	resp, err := client.ListTables(ctx, &sdk.TableListRequest{
+//		DatabaseID:      123,
+//		NameFilter:      "orders",
+//		CommonCondition: sdk.CommonCondition{Page: 1, PageSize: 50},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, table := range resp.List {
+//		fmt.Printf("%d %s\n", table.TableID, table.Name)
+//	}
+func (c *RawClient) ListTables(ctx context.Context, req *TableListRequest, opts ...CallOption) (*TableListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.DatabaseID == 0 {
+		return nil, fmt.Errorf("database_id is required")
+	}
+	var resp TableListResponse
+	if err := c.postJSON(ctx, "/catalog/table/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetTableOverview retrieves an overview of all tables.
 //
 // Returns a summary list of tables with basic information.
@@ -135,6 +218,78 @@ func (c *RawClient) CheckTableExists(ctx context.Context, req *TableExistRequest
 	return exists, nil
 }
 
+// TablesBatchResult is the result of GetTablesBatch. Tables[i] holds the table of
+// the i-th input ID, or nil when the lookup of that table failed.
+type TablesBatchResult struct {
+	BatchResult
+	Tables []*TableInfoResponse
+}
+
+// GetTablesBatch retrieves the metadata of many tables in as few round trips as
+// possible.
+//
+// The lookups are packed into requests to the batch endpoint; when the gateway does
+// not provide it, they are sent individually, at most WithConcurrency at a time.
+// Failed lookups, such as tables that no longer exist, are reported per item; the
+// returned error is reserved for failures of the whole batch.
+//
+// Example:
+//
+//	result, err := client.GetTablesBatch(ctx, []sdk.TableID{456, 789})
+//	if err != nil {
+//		return err
+//	}
+//	for i, table := range result.Tables {
+//		if table == nil {
+//			fmt.Printf("table %d: %v\n", i, result.Items[i].Err)
+//			continue
+//		}
+//		fmt.Printf("%s: %d columns\n", table.Name, len(table.Columns))
+//	}
+func (c *RawClient) GetTablesBatch(ctx context.Context, tableIDs []TableID, opts ...CallOption) (*TablesBatchResult, error) {
+	tables := make([]*TableInfoResponse, len(tableIDs))
+	batch := c.Batch(ctx, opts...)
+	for i, id := range tableIDs {
+		tables[i] = &TableInfoResponse{}
+		op := NewBatchOp("/catalog/table/info", &TableInfoRequest{TableID: id}, tables[i])
+		op.ID = strconv.FormatInt(int64(id), 10)
+		batch.Add(op)
+	}
+	result, err := batch.Do()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range result.Items {
+		if item.Err != nil {
+			tables[item.Index] = nil
+		}
+	}
+	return &TablesBatchResult{BatchResult: *result, Tables: tables}, nil
+}
+
+// GetTableStats retrieves the row count, size and last load time of a table, and
+// a profile of each of its columns.
+//
+// Example:
+//
+//	stats, err := client.GetTableStats(ctx, &sdk.TableStatsRequest{TableID: 456})
+//	if err != nil {
+//		return err
+//	}
+//	for _, col := range stats.Columns {
+//		fmt.Printf("%s: %.0f%% null, ~%d distinct\n", col.Name, col.NullRatio*100, col.DistinctEstimate)
+//	}
+func (c *RawClient) GetTableStats(ctx context.Context, req *TableStatsRequest, opts ...CallOption) (*TableStatsResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp TableStatsResponse
+	if err := c.postJSON(ctx, "/catalog/table/stats", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // PreviewTable previews table data without loading it into memory.
 //
 // Returns a preview of the table data with limited rows.
@@ -209,6 +364,54 @@ func (c *RawClient) GetTableData(ctx context.Context, req *GetTableDataRequest,
 	return &resp, nil
 }
 
+// QueryTable reads rows of a table, with optional projection, filter, ordering and
+// paging, without going through NL2SQL.
+//
+// Use Maps to get the rows keyed by column name, or ScanTableRows to decode them
+// into structs.
+//
+// Example:
+//
+//	resp, err := client.QueryTable(ctx, &sdk.TableQueryRequest{
+//		TableID: 456,
+//		Columns: []string{"id", "amount"},
+//		Filter:  "amount > 100",
+//		OrderBy: []sdk.TableOrderBy{{Column: "amount", Desc: true}},
+//		Limit:   10,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, row := range resp.Maps() {
+//		fmt.Println(row["id"], row["amount"])
+//	}
+func (c *RawClient) QueryTable(ctx context.Context, req *TableQueryRequest, opts ...CallOption) (*TableQueryResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.TableID == 0 {
+		return nil, fmt.Errorf("table_id is required")
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, fmt.Errorf("limit and offset must not be negative")
+	}
+	for _, col := range req.Columns {
+		if strings.TrimSpace(col) == "" {
+			return nil, fmt.Errorf("column name is required")
+		}
+	}
+	for _, order := range req.OrderBy {
+		if strings.TrimSpace(order.Column) == "" {
+			return nil, fmt.Errorf("order by column is required")
+		}
+	}
+	var resp TableQueryResponse
+	if err := c.postJSON(ctx, "/catalog/table/query", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // LoadTable loads table data into memory for processing.
 //
 // This operation may take time for large tables.
@@ -229,6 +432,181 @@ func (c *RawClient) LoadTable(ctx context.Context, req *TableLoadRequest, opts .
 	return &resp, nil
 }
 
+// LoadTableAsync starts loading data into a table and returns without waiting for
+// the load to finish.
+//
+// Follow the load with GetLoadJob, or wait for it with WaitForTableJob, which reports
+// the rows processed and the rows rejected so far.
+//
+// Example:
+//
+//	resp, err := client.LoadTableAsync(ctx, &sdk.TableLoadRequest{
+//		TableID: 456,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	job, err := client.WaitForTableJob(ctx, resp.JobID, sdk.TableJobWaitOptions{})
+func (c *RawClient) LoadTableAsync(ctx context.Context, req *TableLoadRequest, opts ...CallOption) (*TableJobStartResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp TableJobStartResponse
+	if err := c.postJSON(ctx, "/catalog/table/load_async", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// LoadTableFromReader streams local data in CSV, Parquet or JSONL format into a
+// table, without staging it in a volume first.
+//
+// The data is sent as it is read from r, so inputs larger than memory can be loaded.
+// With LoadErrorSkip, rows that cannot be written are listed in the response instead
+// of failing the load.
+//
+// Example:
+//
+//	f, _ := os.Open("orders.csv")
+//	defer f.Close()
+//
+//	resp, err := client.LoadTableFromReader(ctx, 456, f, sdk.LoadOptions{
+//		Format:      sdk.LoadFormatCSV,
+//		HeaderRow:   true,
+//		NullString:  `\N`,
+//		ErrorPolicy: sdk.LoadErrorSkip,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, rowErr := range resp.Errors {
+//		fmt.Printf("row %d: %s\n", rowErr.Row, rowErr.Message)
+//	}
+func (c *RawClient) LoadTableFromReader(ctx context.Context, tableID TableID, r io.Reader, loadOpts LoadOptions, opts ...CallOption) (*TableStreamLoadResponse, error) {
+	if tableID == 0 {
+		return nil, fmt.Errorf("table_id is required")
+	}
+	if r == nil {
+		return nil, fmt.Errorf("reader is required")
+	}
+	fields, err := loadOpts.formFields()
+	if err != nil {
+		return nil, err
+	}
+	filename := "data." + fields[0][1]
+	fields = append([][2]string{{"id", strconv.FormatInt(int64(tableID), 10)}}, fields...)
+
+	body := r
+	if loadOpts.OnProgress != nil {
+		body = &progressReader{r: r, total: loadOpts.Size, fn: loadOpts.OnProgress}
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	formContentType := writer.FormDataContentType()
+
+	go func() {
+		for _, field := range fields {
+			if err := writer.WriteField(field[0], field[1]); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, filename))
+		header.Set(headerContentType, "application/octet-stream")
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, body); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(writer.Close())
+	}()
+
+	callOpts := newCallOptions(opts...)
+	resp, err := c.doRaw(ctx, http.MethodPost, "/catalog/table/load/stream", pr, callOpts, func(r *http.Request) {
+		r.Header.Set(headerContentType, formContentType)
+		r.Header.Set(headerAccept, mimeJSON)
+	})
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var loadResp TableStreamLoadResponse
+	if err := c.decodeResponse(resp, &loadResp); err != nil {
+		return nil, err
+	}
+	return &loadResp, nil
+}
+
+// formFields validates o and returns it as multipart form fields, format first.
+func (o LoadOptions) formFields() ([][2]string, error) {
+	format := o.Format
+	if format == "" {
+		format = LoadFormatCSV
+	}
+	policy := o.ErrorPolicy
+	if policy == "" {
+		policy = LoadErrorAbort
+	}
+	if policy != LoadErrorAbort && policy != LoadErrorSkip {
+		return nil, fmt.Errorf("unsupported error policy %q", policy)
+	}
+	fields := [][2]string{{"format", string(format)}, {"error_policy", string(policy)}}
+	switch format {
+	case LoadFormatCSV:
+		delimiter := o.Delimiter
+		if delimiter == "" {
+			delimiter = ","
+		}
+		if utf8.RuneCountInString(delimiter) != 1 || delimiter == "\n" || delimiter == "\r" || delimiter == `"` {
+			return nil, fmt.Errorf("invalid CSV delimiter %q", delimiter)
+		}
+		fields = append(fields,
+			[2]string{"delimiter", delimiter},
+			[2]string{"header_row", strconv.FormatBool(o.HeaderRow)},
+			[2]string{"null_string", o.NullString},
+		)
+	case LoadFormatParquet, LoadFormatJSONL:
+		if o.Delimiter != "" || o.HeaderRow || o.NullString != "" {
+			return nil, fmt.Errorf("delimiter, header row and null string only apply to CSV, not %s", format)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported load format %q", format)
+	}
+	return fields, nil
+}
+
+// GetLoadJob retrieves the progress of a job started by LoadTableAsync or
+// TruncateTableAsync.
+//
+// Example:
+//
+//	job, err := client.GetLoadJob(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%s: %d/%d rows, %d rejected\n", job.Status, job.RowsProcessed, job.RowsTotal, job.ErrorRows)
+func (c *RawClient) GetLoadJob(ctx context.Context, jobID string, opts ...CallOption) (*TableJob, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	var resp TableJob
+	if err := c.postJSON(ctx, "/catalog/table/job/info", &TableJobInfoRequest{JobID: jobID}, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.JobID == "" {
+		resp.JobID = jobID
+	}
+	return &resp, nil
+}
+
 // GetTableDownloadLink retrieves a download link for the table data.
 //
 // The link is a signed URL that can be used to download the table data.
@@ -253,6 +631,97 @@ func (c *RawClient) GetTableDownloadLink(ctx context.Context, req *TableDownload
 	return &resp, nil
 }
 
+// ExportOptions describes the data written by ExportTable.
+type ExportOptions struct {
+	Format  LoadFormat // Format defaults to LoadFormatCSV
+	Columns []string   // Columns to export, in order; all columns when empty
+	Filter  string     // SQL condition selecting the exported rows; all rows when empty
+	// Gzip compresses the output written to the writer with gzip.
+	Gzip bool
+}
+
+// TableExportResult describes a completed ExportTable transfer.
+type TableExportResult struct {
+	Size     int64 // Size is the number of bytes of exported data, before compression
+	Attempts int   // Attempts is the number of HTTP transfers needed, including resumes
+}
+
+// ExportTable writes a table's data to w in CSV, Parquet or JSONL format.
+//
+// The data is streamed from a signed link obtained from the service. Interrupted
+// transfers are resumed and an expired link is fetched again transparently, as with
+// DownloadFile; retries are controlled with WithDownloadRetries. Errors returned by
+// w are not retried.
+//
+// Example:
+//
+//	f, _ := os.Create("orders.csv.gz")
+//	defer f.Close()
+//
+//	result, err := client.ExportTable(ctx, 456, f, sdk.ExportOptions{
+//		Format:  sdk.LoadFormatCSV,
+//		Columns: []string{"id", "amount"},
+//		Filter:  "amount > 100",
+//		Gzip:    true,
+//	})
+func (c *RawClient) ExportTable(ctx context.Context, tableID TableID, w io.Writer, exportOpts ExportOptions, opts ...CallOption) (*TableExportResult, error) {
+	if tableID == 0 {
+		return nil, fmt.Errorf("table_id is required")
+	}
+	if w == nil {
+		return nil, fmt.Errorf("writer is required")
+	}
+	req := &TableExportRequest{TableID: tableID, Format: exportOpts.Format, Columns: exportOpts.Columns, Filter: strings.TrimSpace(exportOpts.Filter)}
+	if req.Format == "" {
+		req.Format = LoadFormatCSV
+	}
+	switch req.Format {
+	case LoadFormatCSV, LoadFormatParquet, LoadFormatJSONL:
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", req.Format)
+	}
+	seen := make(map[string]bool, len(req.Columns))
+	for _, col := range req.Columns {
+		key := strings.ToLower(strings.TrimSpace(col))
+		if key == "" {
+			return nil, fmt.Errorf("column name is required")
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("column %q is listed more than once", col)
+		}
+		seen[key] = true
+	}
+
+	callOpts := newCallOptions(opts...)
+	// Every attempt is sent with the request ID of the call
+	ctx = ContextWithRequestID(ctx, c.requestIDFor(ctx, callOpts))
+
+	out := w
+	var gz *gzip.Writer
+	if exportOpts.Gzip {
+		gz = gzip.NewWriter(w)
+		out = gz
+	}
+	dst := &downloadWriter{w: out}
+	result := &FileDownloadResult{}
+	getLink := func(ctx context.Context) (string, error) {
+		var resp TableDownloadResponse
+		if err := c.postJSON(ctx, "/catalog/table/export", req, &resp, opts...); err != nil {
+			return "", err
+		}
+		return resp.Url, nil
+	}
+	if err := c.downloadWithRetries(ctx, callOpts, getLink, dst, result, "table_id", tableID); err != nil {
+		return nil, fmt.Errorf("export table %d: %w", tableID, err)
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return nil, fmt.Errorf("export table %d: %w", tableID, err)
+		}
+	}
+	return &TableExportResult{Size: dst.written, Attempts: result.Attempts}, nil
+}
+
 // TruncateTable removes all data from the table while keeping the table structure.
 //
 // This operation is irreversible. All data in the table will be deleted.
@@ -273,6 +742,27 @@ func (c *RawClient) TruncateTable(ctx context.Context, req *TableTruncateRequest
 	return &resp, nil
 }
 
+// TruncateTableAsync starts removing all data from a table and returns without
+// waiting for it to finish. Follow the job with GetLoadJob or WaitForTableJob.
+//
+// This operation is irreversible. All data in the table will be deleted.
+//
+// Example:
+//
+//	resp, err := client.TruncateTableAsync(ctx, &sdk.TableTruncateRequest{
+//		TableID: 456,
+//	})
+func (c *RawClient) TruncateTableAsync(ctx context.Context, req *TableTruncateRequest, opts ...CallOption) (*TableJobStartResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp TableJobStartResponse
+	if err := c.postJSON(ctx, "/catalog/table/truncate_async", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // DeleteTable deletes the specified table.
 //
 // This operation will permanently delete the table and all its data.
@@ -293,6 +783,155 @@ func (c *RawClient) DeleteTable(ctx context.Context, req *TableDeleteRequest, op
 	return &resp, nil
 }
 
+// AlterTable adds, drops and modifies columns of a table, and optionally renames it.
+//
+// The request is first checked against the current schema fetched with GetTable:
+// added columns must not exist yet, dropped and modified columns must exist, a
+// column may only appear once in the request, and primary key columns can be
+// neither dropped nor modified. Violations are reported without calling the service
+// with an error matching ErrInvalidArgument.
+//
+// Example:
+//
+//	_, err := client.AlterTable(ctx, &sdk.TableAlterRequest{
+//		TableID:       456,
+//		AddColumns:    []sdk.Column{{Name: "region", Type: "varchar(32)"}},
+//		DropColumns:   []string{"legacy_flag"},
+//		ModifyColumns: []sdk.Column{{Name: "amount", Type: "decimal(18,2)"}},
+//	})
+func (c *RawClient) AlterTable(ctx context.Context, req *TableAlterRequest, opts ...CallOption) (*TableAlterResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.TableID == 0 {
+		return nil, fmt.Errorf("table_id is required")
+	}
+	if len(req.AddColumns) == 0 && len(req.DropColumns) == 0 && len(req.ModifyColumns) == 0 && strings.TrimSpace(req.RenameTo) == "" {
+		return nil, fmt.Errorf("%w: no change requested", ErrInvalidArgument)
+	}
+	table, err := c.GetTable(ctx, &TableInfoRequest{TableID: req.TableID}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table: %w", err)
+	}
+	if err := validateTableAlter(table, req); err != nil {
+		return nil, err
+	}
+	var resp TableAlterResponse
+	if err := c.postJSON(ctx, "/catalog/table/alter", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// validateTableAlter checks req against the current schema of table. Column names
+// are compared case-insensitively, as the database does.
+func validateTableAlter(table *TableInfoResponse, req *TableAlterRequest) error {
+	existing := make(map[string]Column, len(table.Columns))
+	for _, col := range table.Columns {
+		existing[strings.ToLower(col.Name)] = col
+	}
+	seen := make(map[string]bool)
+	claim := func(name string) error {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			return fmt.Errorf("%w: column name is required", ErrInvalidArgument)
+		}
+		if seen[key] {
+			return fmt.Errorf("%w: column %q appears more than once", ErrInvalidArgument, name)
+		}
+		seen[key] = true
+		return nil
+	}
+
+	for _, name := range req.DropColumns {
+		if err := claim(name); err != nil {
+			return err
+		}
+		col, ok := existing[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return fmt.Errorf("%w: cannot drop column %q: no such column", ErrInvalidArgument, name)
+		}
+		if col.IsPk {
+			return fmt.Errorf("%w: cannot drop primary key column %q", ErrInvalidArgument, name)
+		}
+	}
+	if len(req.AddColumns) == 0 && len(req.DropColumns) >= len(table.Columns) {
+		return fmt.Errorf("%w: cannot drop every column", ErrInvalidArgument)
+	}
+	for _, col := range req.ModifyColumns {
+		if err := claim(col.Name); err != nil {
+			return err
+		}
+		old, ok := existing[strings.ToLower(strings.TrimSpace(col.Name))]
+		if !ok {
+			return fmt.Errorf("%w: cannot modify column %q: no such column", ErrInvalidArgument, col.Name)
+		}
+		if strings.TrimSpace(col.Type) == "" {
+			return fmt.Errorf("%w: column %q: type is required", ErrInvalidArgument, col.Name)
+		}
+		if old.IsPk || col.IsPk {
+			return fmt.Errorf("%w: cannot change primary key column %q", ErrInvalidArgument, col.Name)
+		}
+	}
+	for _, col := range req.AddColumns {
+		if err := claim(col.Name); err != nil {
+			return err
+		}
+		if _, ok := existing[strings.ToLower(strings.TrimSpace(col.Name))]; ok {
+			return fmt.Errorf("%w: cannot add column %q: it already exists", ErrInvalidArgument, col.Name)
+		}
+		if strings.TrimSpace(col.Type) == "" {
+			return fmt.Errorf("%w: column %q: type is required", ErrInvalidArgument, col.Name)
+		}
+		if col.IsPk {
+			return fmt.Errorf("%w: cannot add primary key column %q", ErrInvalidArgument, col.Name)
+		}
+	}
+	if rename := strings.TrimSpace(req.RenameTo); rename != "" && rename == table.Name {
+		return fmt.Errorf("%w: table is already named %q", ErrInvalidArgument, rename)
+	}
+	return nil
+}
+
+// InsertRows appends rows to a table.
+//
+// Rows are sent in consecutive requests of at most 1000 rows, or the size set with
+// WithInsertBatchSize. If a request fails, the returned response counts the rows
+// written by the earlier requests, and the error names the failed range of rows.
+//
+// Example:
+//
+//	resp, err := client.InsertRows(ctx, &sdk.TableInsertRequest{
+//		TableID: 456,
+//		Rows: []map[string]any{
+//			{"id": 1, "name": "alice"},
+//			{"id": 2, "name": "bob"},
+//		},
+//	})
+func (c *RawClient) InsertRows(ctx context.Context, req *TableInsertRequest, opts ...CallOption) (*TableInsertResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.TableID == 0 {
+		return nil, fmt.Errorf("table_id is required")
+	}
+	if len(req.Rows) == 0 {
+		return nil, fmt.Errorf("rows are required")
+	}
+	batchSize := newCallOptions(opts...).insertBatchSize
+	total := &TableInsertResponse{}
+	for start := 0; start < len(req.Rows); start += batchSize {
+		end := min(start+batchSize, len(req.Rows))
+		chunk := &TableInsertRequest{TableID: req.TableID, Rows: req.Rows[start:end]}
+		var resp TableInsertResponse
+		if err := c.postJSON(ctx, "/catalog/table/insert", chunk, &resp, opts...); err != nil {
+			return total, fmt.Errorf("failed to insert rows %d-%d: %w", start, end-1, err)
+		}
+		total.Inserted += resp.Inserted
+	}
+	return total, nil
+}
+
 // GetTableFullPath retrieves the full path of the table in the catalog hierarchy.
 //
 // The path includes catalog, database, and table names.
@@ -1,11 +1,16 @@
 package sdk
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -114,12 +119,19 @@ func TestTableNilRequestErrors(t *testing.T) {
 		{"Preview", func() error { _, err := client.PreviewTable(ctx, nil); return err }},
 		{"GetTableData", func() error { _, err := client.GetTableData(ctx, nil); return err }},
 		{"Load", func() error { _, err := client.LoadTable(ctx, nil); return err }},
+		{"LoadAsync", func() error { _, err := client.LoadTableAsync(ctx, nil); return err }},
 		{"Download", func() error { _, err := client.GetTableDownloadLink(ctx, nil); return err }},
 		{"DownloadData", func() error { _, err := client.DownloadTableData(ctx, nil); return err }},
 		{"Truncate", func() error { _, err := client.TruncateTable(ctx, nil); return err }},
+		{"TruncateAsync", func() error { _, err := client.TruncateTableAsync(ctx, nil); return err }},
 		{"Delete", func() error { _, err := client.DeleteTable(ctx, nil); return err }},
 		{"FullPath", func() error { _, err := client.GetTableFullPath(ctx, nil); return err }},
 		{"RefList", func() error { _, err := client.GetTableRefList(ctx, nil); return err }},
+		{"Alter", func() error { _, err := client.AlterTable(ctx, nil); return err }},
+		{"Insert", func() error { _, err := client.InsertRows(ctx, nil); return err }},
+		{"Query", func() error { _, err := client.QueryTable(ctx, nil); return err }},
+		{"Stats", func() error { _, err := client.GetTableStats(ctx, nil); return err }},
+		{"List", func() error { _, err := client.ListTables(ctx, nil); return err }},
 	}
 
 	for _, tc := range tests {
@@ -795,3 +807,327 @@ func TestPreviewTable_NonExistentTable(t *testing.T) {
 		t.Logf("Preview succeeded for non-existent table (service may allow empty preview)")
 	}
 }
+
+func TestAlterTable(t *testing.T) {
+	t.Parallel()
+	var alterBody map[string]any
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/table/info":
+			return envelopeResponse(`{"name":"orders","columns":[{"name":"id","type":"int","is_pk":true},{"name":"Amount","type":"int"},{"name":"legacy","type":"varchar(8)"}]}`), nil
+		case "/catalog/table/alter":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&alterBody))
+			return envelopeResponse(`{"id":7}`), nil
+		}
+		return nil, fmt.Errorf("unexpected path %s", r.URL.Path)
+	})
+
+	resp, err := client.AlterTable(context.Background(), &TableAlterRequest{
+		TableID:       7,
+		AddColumns:    []Column{{Name: "region", Type: "varchar(32)"}},
+		DropColumns:   []string{"legacy"},
+		ModifyColumns: []Column{{Name: "amount", Type: "decimal(18,2)"}},
+		RenameTo:      "orders_v2",
+	})
+	require.NoError(t, err)
+	require.Equal(t, TableID(7), resp.TableID)
+	require.Equal(t, float64(7), alterBody["id"])
+	require.Equal(t, []any{"legacy"}, alterBody["drop_columns"])
+	require.Equal(t, "orders_v2", alterBody["rename_to"])
+}
+
+func TestAlterTableValidation(t *testing.T) {
+	t.Parallel()
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/catalog/table/info" {
+			return nil, fmt.Errorf("unexpected path %s", r.URL.Path)
+		}
+		return envelopeResponse(`{"name":"orders","columns":[{"name":"id","type":"int","is_pk":true},{"name":"amount","type":"int"}]}`), nil
+	})
+
+	tests := []struct {
+		name string
+		req  TableAlterRequest
+	}{
+		{"NoChange", TableAlterRequest{}},
+		{"AddExisting", TableAlterRequest{AddColumns: []Column{{Name: "AMOUNT", Type: "int"}}}},
+		{"AddWithoutType", TableAlterRequest{AddColumns: []Column{{Name: "region"}}}},
+		{"AddTwice", TableAlterRequest{AddColumns: []Column{{Name: "region", Type: "int"}, {Name: "region", Type: "int"}}}},
+		{"DropMissing", TableAlterRequest{DropColumns: []string{"nope"}}},
+		{"DropPrimaryKey", TableAlterRequest{DropColumns: []string{"id"}}},
+		{"ModifyMissing", TableAlterRequest{ModifyColumns: []Column{{Name: "nope", Type: "int"}}}},
+		{"ModifyPrimaryKey", TableAlterRequest{ModifyColumns: []Column{{Name: "id", Type: "bigint", IsPk: true}}}},
+		{"DropAndModify", TableAlterRequest{DropColumns: []string{"amount"}, ModifyColumns: []Column{{Name: "amount", Type: "bigint"}}}},
+		{"RenameToSame", TableAlterRequest{RenameTo: "orders"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.req.TableID = 7
+			_, err := client.AlterTable(context.Background(), &tc.req)
+			require.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
+
+func TestInsertRowsBatches(t *testing.T) {
+	t.Parallel()
+	var sizes []int
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/table/insert", r.URL.Path)
+		var req TableInsertRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, TableID(7), req.TableID)
+		sizes = append(sizes, len(req.Rows))
+		if len(sizes) == 3 {
+			return errorEnvelopeResponse("ErrInternal", "boom"), nil
+		}
+		return envelopeResponse(fmt.Sprintf(`{"inserted":%d}`, len(req.Rows))), nil
+	})
+
+	rows := make([]map[string]any, 5)
+	for i := range rows {
+		rows[i] = map[string]any{"id": i}
+	}
+	resp, err := client.InsertRows(context.Background(), &TableInsertRequest{TableID: 7, Rows: rows[:4]}, WithInsertBatchSize(2))
+	require.NoError(t, err)
+	require.Equal(t, int64(4), resp.Inserted)
+	require.Equal(t, []int{2, 2}, sizes)
+
+	resp, err = client.InsertRows(context.Background(), &TableInsertRequest{TableID: 7, Rows: rows}, WithInsertBatchSize(4))
+	require.ErrorContains(t, err, "rows 0-3")
+	require.Equal(t, int64(0), resp.Inserted)
+}
+
+func TestLoadTableFromReader(t *testing.T) {
+	t.Parallel()
+	var form map[string]string
+	var data string
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/table/load/stream", r.URL.Path)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		form = map[string]string{}
+		for k, v := range r.MultipartForm.Value {
+			form[k] = v[0]
+		}
+		f, _, err := r.FormFile("file")
+		require.NoError(t, err)
+		b, err := io.ReadAll(f)
+		require.NoError(t, err)
+		data = string(b)
+		return envelopeResponse(`{"rows_processed":3,"rows_loaded":2,"error_rows":1,"errors":[{"row":3,"column":"amount","message":"not a number"}]}`), nil
+	})
+
+	csv := "id;amount\n1;2\n2;\\N\n3;x\n"
+	var sent int64
+	resp, err := client.LoadTableFromReader(context.Background(), 7, strings.NewReader(csv), LoadOptions{
+		Delimiter:   ";",
+		HeaderRow:   true,
+		NullString:  `\N`,
+		ErrorPolicy: LoadErrorSkip,
+		OnProgress:  func(n, _ int64) { sent = n },
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"id":           "7",
+		"format":       "csv",
+		"error_policy": "skip",
+		"delimiter":    ";",
+		"header_row":   "true",
+		"null_string":  `\N`,
+	}, form)
+	require.Equal(t, csv, data)
+	require.Equal(t, int64(len(csv)), sent)
+	require.Equal(t, int64(2), resp.RowsLoaded)
+	require.Equal(t, []TableRowError{{Row: 3, Column: "amount", Message: "not a number"}}, resp.Errors)
+}
+
+func TestLoadTableFromReaderValidation(t *testing.T) {
+	t.Parallel()
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("unexpected request %s", r.URL.Path)
+	})
+	ctx := context.Background()
+	data := strings.NewReader("")
+
+	tests := []struct {
+		name    string
+		tableID TableID
+		opts    LoadOptions
+	}{
+		{"MissingTable", 0, LoadOptions{}},
+		{"UnknownFormat", 7, LoadOptions{Format: "xml"}},
+		{"UnknownPolicy", 7, LoadOptions{ErrorPolicy: "ignore"}},
+		{"LongDelimiter", 7, LoadOptions{Delimiter: "||"}},
+		{"CSVOptionOnParquet", 7, LoadOptions{Format: LoadFormatParquet, HeaderRow: true}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := client.LoadTableFromReader(ctx, tc.tableID, data, tc.opts)
+			require.Error(t, err)
+		})
+	}
+
+	_, err := client.LoadTableFromReader(ctx, 7, nil, LoadOptions{})
+	require.ErrorContains(t, err, "reader is required")
+}
+
+func TestExportTable(t *testing.T) {
+	t.Parallel()
+	const content = "id,amount\n1,200\n2,300\n"
+
+	var exportReq TableExportRequest
+	linkCalls, downloads := 0, 0
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/catalog/table/export" {
+			linkCalls++
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&exportReq))
+			return envelopeResponse(`{"url":"/storage/export.csv"}`), nil
+		}
+		require.Equal(t, "/storage/export.csv", r.URL.Path)
+		downloads++
+		if downloads == 1 {
+			// Signed link expired
+			return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader("expired"))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, ContentLength: int64(len(content)), Body: io.NopCloser(strings.NewReader(content))}, nil
+	})
+
+	var buf bytes.Buffer
+	result, err := client.ExportTable(context.Background(), 7, &buf, ExportOptions{
+		Columns: []string{"id", "amount"},
+		Filter:  " amount > 100 ",
+		Gzip:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TableExportRequest{TableID: 7, Format: LoadFormatCSV, Columns: []string{"id", "amount"}, Filter: "amount > 100"}, exportReq)
+	require.Equal(t, 2, linkCalls)
+	require.Equal(t, int64(len(content)), result.Size)
+	require.Equal(t, 2, result.Attempts)
+
+	zr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	require.Equal(t, content, string(data))
+}
+
+func TestExportTableValidation(t *testing.T) {
+	t.Parallel()
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("unexpected request %s", r.URL.Path)
+	})
+	ctx := context.Background()
+
+	_, err := client.ExportTable(ctx, 0, io.Discard, ExportOptions{})
+	require.ErrorContains(t, err, "table_id is required")
+	_, err = client.ExportTable(ctx, 7, nil, ExportOptions{})
+	require.ErrorContains(t, err, "writer is required")
+	_, err = client.ExportTable(ctx, 7, io.Discard, ExportOptions{Format: "xml"})
+	require.ErrorContains(t, err, "unsupported export format")
+	_, err = client.ExportTable(ctx, 7, io.Discard, ExportOptions{Columns: []string{"id", "ID"}})
+	require.ErrorContains(t, err, "more than once")
+}
+
+func TestQueryTable(t *testing.T) {
+	t.Parallel()
+	var got TableQueryRequest
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/table/query", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		return envelopeResponse(`{"columns":[{"name":"id","type":"int"},{"name":"amount","type":"double"}],"rows":[[2,300.5],[1,200]],"total":2}`), nil
+	})
+
+	req := &TableQueryRequest{
+		TableID: 7,
+		Columns: []string{"id", "amount"},
+		Filter:  "amount > 100",
+		OrderBy: []TableOrderBy{{Column: "amount", Desc: true}},
+		Limit:   10,
+	}
+	resp, err := client.QueryTable(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, *req, got)
+	require.Equal(t, int64(2), resp.Total)
+	require.Equal(t, []map[string]interface{}{
+		{"id": float64(2), "amount": 300.5},
+		{"id": float64(1), "amount": float64(200)},
+	}, resp.Maps())
+
+	_, err = client.QueryTable(context.Background(), &TableQueryRequest{TableID: 7, Limit: -1})
+	require.Error(t, err)
+	_, err = client.QueryTable(context.Background(), &TableQueryRequest{TableID: 7, OrderBy: []TableOrderBy{{}}})
+	require.Error(t, err)
+}
+
+func TestGetTableStats(t *testing.T) {
+	t.Parallel()
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/table/stats", r.URL.Path)
+		var req TableStatsRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, TableID(7), req.TableID)
+		return envelopeResponse(`{"id":7,"name":"orders","row_count":100,"size_bytes":4096,"last_loaded_at":"2026-01-02 03:04:05",
+			"columns":[{"name":"amount","type":"double","min_value":"1","max_value":"99","null_count":10,"null_ratio":0.1,"distinct_estimate":42}]}`), nil
+	})
+
+	stats, err := client.GetTableStats(context.Background(), &TableStatsRequest{TableID: 7})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), stats.RowCount)
+	require.Equal(t, "2026-01-02 03:04:05", stats.LastLoadedAt)
+	require.Equal(t, []ColumnProfile{{Name: "amount", Type: "double", MinValue: "1", MaxValue: "99", NullCount: 10, NullRatio: 0.1, DistinctEstimate: 42}}, stats.Columns)
+}
+
+func TestGetTablesBatch(t *testing.T) {
+	t.Parallel()
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/batch", r.URL.Path)
+		var body struct {
+			Ops []struct {
+				Path string          `json:"path"`
+				Body json.RawMessage `json:"body"`
+			} `json:"ops"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Ops, 2)
+		require.Equal(t, "/catalog/table/info", body.Ops[1].Path)
+		require.JSONEq(t, `{"id":9,"table_name":"","database_id":0}`, string(body.Ops[1].Body))
+		return envelopeResponse(`{"items":[
+			{"index":0,"code":"OK","data":{"name":"orders","columns":[{"name":"id","type":"int"}]}},
+			{"index":1,"code":"ErrNotFound","msg":"table 9 not exist"}]}`), nil
+	})
+
+	result, err := client.GetTablesBatch(context.Background(), []TableID{7, 9})
+	require.NoError(t, err)
+	require.Len(t, result.Tables, 2)
+	require.Equal(t, "orders", result.Tables[0].Name)
+	require.Nil(t, result.Tables[1])
+	require.Equal(t, "9", result.Items[1].ID)
+	require.True(t, IsNotFound(result.Items[1].Err))
+	require.Error(t, result.Err())
+}
+
+func TestListTables(t *testing.T) {
+	t.Parallel()
+	var got map[string]any
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/table/list", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		return envelopeResponse(`{"list":[{"id":10,"name":"orders","row_count":5}],"total":31}`), nil
+	})
+
+	resp, err := client.ListTables(context.Background(), &TableListRequest{
+		DatabaseID:      3,
+		NameFilter:      "ord",
+		CommonCondition: CommonCondition{Page: 2, PageSize: 30},
+	})
+	require.NoError(t, err)
+	require.Equal(t, float64(3), got["database_id"])
+	require.Equal(t, "ord", got["name_filter"])
+	require.Equal(t, float64(2), got["page"])
+	require.Equal(t, 31, resp.Total)
+	require.Equal(t, []TableListItem{{TableID: 10, Name: "orders", RowCount: 5}}, resp.List)
+
+	_, err = client.ListTables(context.Background(), &TableListRequest{})
+	require.ErrorContains(t, err, "database_id is required")
+}
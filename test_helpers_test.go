@@ -3,10 +3,17 @@ package sdk
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/matrixorigin/moi-go-sdk/vcr"
 )
 
 const (
@@ -14,9 +21,57 @@ const (
 	testBaseURL = "https://freetier-01.cn-hangzhou.cluster.cn-dev.matrixone.tech"
 )
 
+// roundTripperFunc adapts a function into an http.RoundTripper for offline tests.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// newFakeClient returns a client whose requests are served by handler instead of the network.
+func newFakeClient(handler roundTripperFunc) *RawClient {
+	return &RawClient{baseURL: "https://moi.test", httpClient: &http.Client{Transport: handler}, cache: newClientCache(0)}
+}
+
+// envelopeResponse builds a successful enveloped JSON response carrying data.
+func envelopeResponse(data string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{mimeJSON}},
+		Body:       io.NopCloser(strings.NewReader(`{"code":"OK","msg":"OK","data":` + data + `}`)),
+	}
+}
+
+// errorEnvelopeResponse builds an enveloped JSON response carrying an API error.
+func errorEnvelopeResponse(code, msg string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{mimeJSON}},
+		Body:       io.NopCloser(strings.NewReader(fmt.Sprintf(`{"code":%q,"msg":%q}`, code, msg))),
+	}
+}
+
+// newTestClient returns a client of the live test service.
+//
+// With MOI_VCR=record, the interactions of the test are recorded to
+// testdata/cassettes/<test name>.json; with MOI_VCR=replay, they are replayed from
+// there without reaching the service.
 func newTestClient(t *testing.T) *RawClient {
 	t.Helper()
-	client, err := NewRawClient(testBaseURL, testAPIKey)
+	var opts []ClientOption
+	if mode := os.Getenv("MOI_VCR"); mode != "" {
+		vcrMode := vcr.ModeReplay
+		if mode == "record" {
+			vcrMode = vcr.ModeRecord
+		}
+		rec, err := vcr.New(filepath.Join("testdata", "cassettes", t.Name()+".json"), vcr.Options{Mode: vcrMode})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			if err := rec.Save(); err != nil {
+				t.Errorf("save cassette: %v", err)
+			}
+		})
+		opts = append(opts, WithHTTPClient(&http.Client{Transport: rec}))
+	}
+	client, err := NewRawClient(testBaseURL, testAPIKey, opts...)
 	require.NoError(t, err)
 	return client
 }
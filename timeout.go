@@ -0,0 +1,282 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// TimeoutPhase names a phase of a request bounded by a TimeoutPolicy.
+type TimeoutPhase string
+
+const (
+	TimeoutPhaseConnect   TimeoutPhase = "connect"
+	TimeoutPhaseFirstByte TimeoutPhase = "first byte"
+	TimeoutPhaseRead      TimeoutPhase = "read"
+	TimeoutPhaseTotal     TimeoutPhase = "total"
+)
+
+// TimeoutPolicy bounds the phases of each request. Zero fields leave a phase
+// unbounded, or keep the client's limit when the policy is given to a single call.
+type TimeoutPolicy struct {
+	// Connect bounds obtaining a connection: DNS lookup, dial and TLS handshake, or
+	// waiting for a free connection of the pool.
+	Connect time.Duration
+	// FirstByte bounds the time from the request being written to the first byte of
+	// the response, i.e. how long the service may take before it starts answering.
+	FirstByte time.Duration
+	// Read bounds the time the response body may stay silent, between two reads.
+	// Long-running streams stay open as long as data keeps arriving.
+	Read time.Duration
+	// Total bounds the whole request, including reading the response body.
+	//
+	// Set on the client with WithTimeoutPolicy, it is the timeout of the http.Client,
+	// like WithHTTPTimeout, and does not apply to streaming calls such as
+	// AnalyzeDataStream, which can legitimately last long. Set on a call with
+	// WithCallTimeout or WithCallTimeoutPolicy, it applies to that call whatever its kind.
+	Total time.Duration
+}
+
+// merge returns p with the non-zero fields of override applied.
+func (p TimeoutPolicy) merge(override TimeoutPolicy) TimeoutPolicy {
+	if override.Connect > 0 {
+		p.Connect = override.Connect
+	}
+	if override.FirstByte > 0 {
+		p.FirstByte = override.FirstByte
+	}
+	if override.Read > 0 {
+		p.Read = override.Read
+	}
+	if override.Total > 0 {
+		p.Total = override.Total
+	}
+	return p
+}
+
+func (p TimeoutPolicy) isZero() bool {
+	return p == TimeoutPolicy{}
+}
+
+// TimeoutError reports that a request exceeded a limit of its TimeoutPolicy. It
+// matches context.DeadlineExceeded with errors.Is.
+type TimeoutError struct {
+	Phase TimeoutPhase
+	Limit time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s timeout of %s exceeded", e.Phase, e.Limit)
+}
+
+// Timeout reports true, like the timeout errors of the net package.
+func (e *TimeoutError) Timeout() bool { return true }
+
+func (e *TimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+// WithTimeoutPolicy sets the timeouts of every request of the client.
+//
+// A non-zero Total replaces the timeout of WithHTTPTimeout. WithCallTimeoutPolicy and
+// WithCallTimeout override the policy for single calls.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithTimeoutPolicy(sdk.TimeoutPolicy{
+//			Connect:   5 * time.Second,
+//			FirstByte: 20 * time.Second,
+//			Read:      30 * time.Second,
+//			Total:     2 * time.Minute,
+//		}))
+func WithTimeoutPolicy(policy TimeoutPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.timeoutPolicy = policy
+		if policy.Total > 0 {
+			WithHTTPTimeout(policy.Total)(o)
+		}
+	}
+}
+
+// WithCallTimeout bounds each request of a single call to d, including reading its
+// response, without changing the context passed to the call.
+//
+// Example:
+//
+//	resp, err := client.GetCatalog(ctx, req, sdk.WithCallTimeout(2*time.Second))
+//	if errors.Is(err, context.DeadlineExceeded) {
+//		// the catalog service did not answer in time
+//	}
+func WithCallTimeout(d time.Duration) CallOption {
+	return WithCallTimeoutPolicy(TimeoutPolicy{Total: d})
+}
+
+// WithCallTimeoutPolicy overrides the client's TimeoutPolicy for a single call: its
+// non-zero fields replace those of the client. A non-zero Read also sets the stream
+// read timeout of the call, like WithStreamReadTimeout.
+//
+// Example:
+//
+//	stream, err := client.AnalyzeDataStream(ctx, req,
+//		sdk.WithCallTimeoutPolicy(sdk.TimeoutPolicy{FirstByte: time.Minute, Read: 2 * time.Minute}))
+func WithCallTimeoutPolicy(policy TimeoutPolicy) CallOption {
+	return func(co *callOptions) {
+		co.timeoutPolicy = co.timeoutPolicy.merge(policy)
+		if policy.Read > 0 {
+			co.streamReadTimeout = policy.Read
+		}
+	}
+}
+
+type timeoutPolicyKey struct{}
+
+// requestContext returns the context of the requests of a call: ctx carrying the
+// timeouts set for the call.
+func (o callOptions) requestContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if o.timeoutPolicy.isZero() {
+		return ctx
+	}
+	return context.WithValue(ctx, timeoutPolicyKey{}, o.timeoutPolicy)
+}
+
+// timeoutTransport enforces the client's TimeoutPolicy, overridden by the one in
+// the request context.
+type timeoutTransport struct {
+	base     http.RoundTripper
+	defaults TimeoutPolicy // Total is enforced by the http.Client instead
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	policy := t.defaults
+	if p, ok := req.Context().Value(timeoutPolicyKey{}).(TimeoutPolicy); ok {
+		policy = policy.merge(p)
+	}
+	if policy.isZero() {
+		return base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithCancelCause(req.Context())
+	r := &timeoutRequest{cancel: cancel}
+	r.start(TimeoutPhaseTotal, policy.Total)
+	r.start(TimeoutPhaseConnect, policy.Connect)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) { r.stop(TimeoutPhaseConnect) },
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			r.stop(TimeoutPhaseConnect)
+			r.start(TimeoutPhaseFirstByte, policy.FirstByte)
+		},
+		GotFirstResponseByte: func() { r.stop(TimeoutPhaseFirstByte) },
+	}
+	ctx = httptrace.WithClientTrace(ctx, trace)
+
+	resp, err := base.RoundTrip(req.WithContext(ctx))
+	r.stop(TimeoutPhaseConnect)
+	r.stop(TimeoutPhaseFirstByte)
+	if err != nil {
+		r.close()
+		return nil, r.translate(ctx, err)
+	}
+	resp.Body = &timeoutBody{body: resp.Body, ctx: ctx, req: r, read: policy.Read}
+	return resp, nil
+}
+
+func (t *timeoutTransport) unwrap() http.RoundTripper { return t.base }
+
+// timeoutRequest holds the timers of a request.
+type timeoutRequest struct {
+	mu     sync.Mutex
+	timers map[TimeoutPhase]*time.Timer
+	cancel context.CancelCauseFunc
+}
+
+// start starts the timer of phase, replacing any running one.
+func (r *timeoutRequest) start(phase TimeoutPhase, limit time.Duration) {
+	if limit <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.timers == nil {
+		r.timers = make(map[TimeoutPhase]*time.Timer)
+	}
+	if timer := r.timers[phase]; timer != nil {
+		timer.Stop()
+	}
+	r.timers[phase] = time.AfterFunc(limit, func() {
+		r.cancel(&TimeoutError{Phase: phase, Limit: limit})
+	})
+}
+
+func (r *timeoutRequest) stop(phase TimeoutPhase) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if timer := r.timers[phase]; timer != nil {
+		timer.Stop()
+		delete(r.timers, phase)
+	}
+}
+
+// close stops all timers and releases the request context.
+func (r *timeoutRequest) close() {
+	r.mu.Lock()
+	for phase, timer := range r.timers {
+		timer.Stop()
+		delete(r.timers, phase)
+	}
+	r.mu.Unlock()
+	r.cancel(context.Canceled)
+}
+
+// translate returns the TimeoutError behind err if a timer cancelled the request.
+func (r *timeoutRequest) translate(ctx context.Context, err error) error {
+	var timeoutErr *TimeoutError
+	if errors.As(context.Cause(ctx), &timeoutErr) && !errors.As(err, &timeoutErr) {
+		return fmt.Errorf("%w: %w", timeoutErr, err)
+	}
+	return err
+}
+
+// timeoutBody is a response body whose reads are bounded by the Read timeout and
+// whose Close ends the request timers.
+type timeoutBody struct {
+	body io.ReadCloser
+	ctx  context.Context
+	req  *timeoutRequest
+	read time.Duration
+	once sync.Once
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	b.req.start(TimeoutPhaseRead, b.read)
+	n, err := b.body.Read(p)
+	b.req.stop(TimeoutPhaseRead)
+	if err == io.EOF {
+		b.done()
+		return n, err
+	}
+	if err != nil {
+		err = b.req.translate(b.ctx, err)
+	}
+	return n, err
+}
+
+func (b *timeoutBody) Close() error {
+	err := b.body.Close()
+	b.done()
+	return err
+}
+
+func (b *timeoutBody) done() {
+	b.once.Do(b.req.close)
+}
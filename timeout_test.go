@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stall blocks a test handler until the client gives up or the test ends.
+func stall(r *http.Request, done <-chan struct{}) {
+	select {
+	case <-r.Context().Done():
+	case <-done:
+	}
+}
+
+func requireTimeout(t *testing.T, err error, phase TimeoutPhase) {
+	t.Helper()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	var timeoutErr *TimeoutError
+	require.True(t, errors.As(err, &timeoutErr), "got %v", err)
+	require.Equal(t, phase, timeoutErr.Phase)
+}
+
+func TestTimeoutPolicy_FirstByteAndCallOverride(t *testing.T) {
+	t.Parallel()
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			stall(r, done)
+			return
+		}
+		w.Header().Set(headerContentType, mimeJSON)
+		_, _ = w.Write([]byte(`{"code":"OK","msg":"OK","data":{}}`))
+	}))
+	defer server.Close()
+	defer close(done)
+
+	client, err := NewRawClient(server.URL, "key", WithTimeoutPolicy(TimeoutPolicy{FirstByte: 50 * time.Millisecond}))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1}, WithQueryParam("slow", "1"))
+	requireTimeout(t, err, TimeoutPhaseFirstByte)
+
+	// A call-level Total shorter than the client's FirstByte wins
+	_, err = client.GetCatalog(ctx, &CatalogInfoRequest{CatalogID: 1}, WithQueryParam("slow", "1"),
+		WithCallTimeoutPolicy(TimeoutPolicy{FirstByte: time.Minute}), WithCallTimeout(30*time.Millisecond))
+	requireTimeout(t, err, TimeoutPhaseTotal)
+}
+
+func TestTimeoutPolicy_Read(t *testing.T) {
+	t.Parallel()
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerContentType, "text/event-stream")
+		_, _ = w.Write([]byte("data: {}\n\n"))
+		w.(http.Flusher).Flush()
+		stall(r, done)
+	}))
+	defer server.Close()
+	defer close(done)
+
+	client, err := NewRawClient(server.URL, "key")
+	require.NoError(t, err)
+	stream, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "q"},
+		WithStreamReadTimeout(time.Minute),
+		WithCallTimeoutPolicy(TimeoutPolicy{Read: 50 * time.Millisecond}))
+	require.NoError(t, err)
+	defer stream.Close()
+
+	buf := make([]byte, 64)
+	n, err := stream.Body.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "data: {}\n\n", string(buf[:n]))
+	_, err = stream.Body.Read(buf)
+	requireTimeout(t, err, TimeoutPhaseRead)
+}
+
+func TestTimeoutPolicy_Connect(t *testing.T) {
+	t.Parallel()
+	client, err := NewRawClient("http://moi.test", "key",
+		WithTransportOptions(TransportOptions{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		}),
+		WithTimeoutPolicy(TimeoutPolicy{Connect: 30 * time.Millisecond, Total: time.Minute}))
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, client.httpClient.Timeout)
+
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	requireTimeout(t, err, TimeoutPhaseConnect)
+}
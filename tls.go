@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// WithTLSConfig sets the TLS configuration of the client's connections.
+//
+// A copy of config is used, so later changes to it have no effect. WithCACertPool
+// and WithClientCertificate are applied on top of it. Like WithTransportOptions,
+// this tunes a copy of the transport, and NewRawClient returns an error if
+// WithHTTPClient supplies a transport other than *http.Transport. The settings apply
+// to every request of the client, including streams such as AnalyzeDataStream and
+// downloads of presigned storage links.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13}))
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsConfig = config
+	}
+}
+
+// WithCACertPool makes the client trust the certificate authorities in pool instead
+// of the system roots, e.g. for a service behind a gateway with a private CA.
+//
+// Example:
+//
+//	pem, err := os.ReadFile("/etc/moi/ca.pem")
+//	if err != nil {
+//		return err
+//	}
+//	pool := x509.NewCertPool()
+//	pool.AppendCertsFromPEM(pem)
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithCACertPool(pool))
+func WithCACertPool(pool *x509.CertPool) ClientOption {
+	return func(o *clientOptions) {
+		o.rootCAs = pool
+	}
+}
+
+// WithClientCertificate makes the client present the certificate in certFile, with
+// the private key in keyFile, to services that require mutual TLS. Both files are
+// PEM encoded and are read by NewRawClient, which returns an error if they cannot
+// be loaded.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithClientCertificate("/etc/moi/client.crt", "/etc/moi/client.key"),
+//		sdk.WithCACertPool(pool))
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(o *clientOptions) {
+		o.clientCertFile = certFile
+		o.clientKeyFile = keyFile
+	}
+}
+
+// applyTLS applies the TLS options to t.
+func (o *clientOptions) applyTLS(t *http.Transport) error {
+	switch {
+	case o.tlsConfig != nil:
+		t.TLSClientConfig = o.tlsConfig.Clone()
+	case t.TLSClientConfig == nil && (o.rootCAs != nil || o.clientCertFile != ""):
+		t.TLSClientConfig = &tls.Config{}
+	}
+	if o.rootCAs != nil {
+		t.TLSClientConfig.RootCAs = o.rootCAs
+	}
+	if o.clientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.clientCertFile, o.clientKeyFile)
+		if err != nil {
+			return fmt.Errorf("load client certificate: %w", err)
+		}
+		t.TLSClientConfig.Certificates = append(t.TLSClientConfig.Certificates, cert)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeClientCertificate writes a self-signed client certificate and its key to dir.
+func writeClientCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "moi-sdk-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "client.crt")
+	keyFile = filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestMutualTLS(t *testing.T) {
+	t.Parallel()
+	var clientNames []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientNames = append(clientNames, r.TLS.PeerCertificates[0].Subject.CommonName)
+		if r.Header.Get(headerAccept) == "text/event-stream" {
+			w.Header().Set(headerContentType, "text/event-stream")
+			_, _ = w.Write([]byte("data: {}\n\n"))
+			return
+		}
+		w.Header().Set(headerContentType, mimeJSON)
+		_, _ = w.Write([]byte(`{"code":"OK","msg":"OK","data":{"catalog_id":1}}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	certFile, keyFile := writeClientCertificate(t, t.TempDir())
+
+	// Without the CA, the server certificate is not trusted
+	client, err := NewRawClient(server.URL, "key")
+	require.NoError(t, err)
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	require.ErrorContains(t, err, "certificate")
+
+	client, err = NewRawClient(server.URL, "key",
+		WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}),
+		WithCACertPool(pool),
+		WithClientCertificate(certFile, keyFile))
+	require.NoError(t, err)
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+
+	stream, err := client.AnalyzeDataStream(context.Background(), &DataAnalysisRequest{Question: "q"})
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+	require.Equal(t, []string{"moi-sdk-test", "moi-sdk-test"}, clientNames)
+}
+
+func TestWithClientCertificate_LoadError(t *testing.T) {
+	t.Parallel()
+	_, err := NewRawClient("https://moi.test", "key",
+		WithClientCertificate(filepath.Join(t.TempDir(), "missing.crt"), "missing.key"))
+	require.ErrorContains(t, err, "load client certificate")
+}
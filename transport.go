@@ -0,0 +1,117 @@
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// TransportOptions tunes the connection pool of the client's HTTP transport.
+//
+// Zero fields keep the value of the transport being tuned, which is
+// http.DefaultTransport unless WithHTTPClient supplies another *http.Transport.
+type TransportOptions struct {
+	// MaxIdleConns limits the idle connections kept across all hosts.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits the idle connections kept per host. The net/http
+	// default of 2 is too low for jobs issuing many parallel requests, which then
+	// open and close connections constantly.
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits the connections per host, including those in use.
+	// Requests above the limit wait for a connection to become free.
+	MaxConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept before it is closed.
+	IdleConnTimeout time.Duration
+	// DisableHTTP2 makes the client speak HTTP/1.1 only. HTTP/2 multiplexes all
+	// requests to a host over one connection, which a per-connection proxy limit or
+	// a large number of parallel uploads can make a bottleneck.
+	DisableHTTP2 bool
+	// DialContext, if set, opens the network connections, e.g. to pin the service
+	// to an address or to go through a tunnel.
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// WithTransportOptions tunes the connection pool of the underlying transport
+// without replacing the whole http.Client.
+//
+// The options are applied to a copy of the transport, so an *http.Transport passed
+// through WithHTTPClient is left unchanged. A transport of any other type cannot be
+// tuned, and NewRawClient returns an error. Like the rest of the client, the tuned
+// transport is safe for concurrent use and is shared by clients derived with
+// WithSpecialUser.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey,
+//		sdk.WithTransportOptions(sdk.TransportOptions{
+//			MaxIdleConnsPerHost: 64,
+//			MaxConnsPerHost:     128,
+//			IdleConnTimeout:     2 * time.Minute,
+//		}))
+func WithTransportOptions(transportOpts TransportOptions) ClientOption {
+	return func(o *clientOptions) {
+		o.transportOptions = &transportOpts
+	}
+}
+
+// tuneTransport returns a copy of rt, or of http.DefaultTransport when rt is nil,
+// with the TLS and connection pool options applied.
+func (o *clientOptions) tuneTransport(rt http.RoundTripper) (*http.Transport, error) {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	base, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("transport and TLS options require an *http.Transport, got %T", rt)
+	}
+	t := base.Clone()
+	if err := o.applyTLS(t); err != nil {
+		return nil, err
+	}
+	if o.transportOptions != nil {
+		if err := o.transportOptions.apply(t); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// customTransport reports whether the options require a tuned copy of the transport.
+func (o *clientOptions) customTransport() bool {
+	return o.transportOptions != nil || o.tlsConfig != nil || o.rootCAs != nil || o.clientCertFile != ""
+}
+
+func (o *TransportOptions) apply(t *http.Transport) error {
+	if o.MaxIdleConns < 0 || o.MaxIdleConnsPerHost < 0 || o.MaxConnsPerHost < 0 || o.IdleConnTimeout < 0 {
+		return fmt.Errorf("transport options must not be negative")
+	}
+	if o.MaxIdleConns > 0 {
+		t.MaxIdleConns = o.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+	if o.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = o.MaxConnsPerHost
+	}
+	if o.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = o.IdleConnTimeout
+	}
+	if o.DialContext != nil {
+		t.DialContext = o.DialContext
+		// Keep HTTP/2, which net/http would otherwise drop for a custom dialer.
+		t.ForceAttemptHTTP2 = !o.DisableHTTP2
+	}
+	if o.DisableHTTP2 {
+		// A non-nil empty TLSNextProto is how net/http is told not to negotiate HTTP/2.
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		if t.TLSClientConfig != nil {
+			t.TLSClientConfig.NextProtos = nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTransportOptions_TunesCopyOfTransport(t *testing.T) {
+	t.Parallel()
+	user := &http.Transport{MaxIdleConns: 10, MaxIdleConnsPerHost: 2}
+	client, err := NewRawClient("https://api.example.com", "key",
+		WithHTTPClient(&http.Client{Transport: user}),
+		WithTransportOptions(TransportOptions{
+			MaxIdleConnsPerHost: 64,
+			MaxConnsPerHost:     128,
+			IdleConnTimeout:     time.Minute,
+			DisableHTTP2:        true,
+		}))
+	require.NoError(t, err)
+
+	tuned, ok := baseTransport(client.httpClient.Transport).(*http.Transport)
+	require.True(t, ok)
+	require.NotSame(t, user, tuned)
+	require.Equal(t, 10, tuned.MaxIdleConns)
+	require.Equal(t, 64, tuned.MaxIdleConnsPerHost)
+	require.Equal(t, 128, tuned.MaxConnsPerHost)
+	require.Equal(t, time.Minute, tuned.IdleConnTimeout)
+	require.False(t, tuned.ForceAttemptHTTP2)
+	require.NotNil(t, tuned.TLSNextProto)
+	require.Empty(t, tuned.TLSNextProto)
+	require.Equal(t, 2, user.MaxIdleConnsPerHost)
+}
+
+func TestWithTransportOptions_DialContext(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerContentType, mimeJSON)
+		_, _ = w.Write([]byte(`{"code":"OK","msg":"OK","data":{"catalog_id":1}}`))
+	}))
+	defer server.Close()
+
+	var dials atomic.Int32
+	var dialer net.Dialer
+	client, err := NewRawClient("http://catalog.invalid", "key",
+		WithTransportOptions(TransportOptions{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				dials.Add(1)
+				return dialer.DialContext(ctx, network, server.Listener.Addr().String())
+			},
+		}))
+	require.NoError(t, err)
+
+	tuned := baseTransport(client.httpClient.Transport).(*http.Transport)
+	require.True(t, tuned.ForceAttemptHTTP2)
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), dials.Load())
+}
+
+func TestWithTransportOptions_Errors(t *testing.T) {
+	t.Parallel()
+	_, err := NewRawClient("https://api.example.com", "key",
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil })}),
+		WithTransportOptions(TransportOptions{MaxConnsPerHost: 8}))
+	require.ErrorContains(t, err, "require an *http.Transport")
+
+	_, err = NewRawClient("https://api.example.com", "key",
+		WithTransportOptions(TransportOptions{MaxIdleConns: -1}))
+	require.ErrorContains(t, err, "must not be negative")
+}
@@ -2,6 +2,7 @@ package sdk
 
 import (
 	"context"
+	"fmt"
 )
 
 // CreateUser creates a new user account.
@@ -30,6 +31,49 @@ func (c *RawClient) CreateUser(ctx context.Context, req *UserCreateRequest, opts
 	return &resp, nil
 }
 
+// CreateUsersBatch creates many user accounts in one call.
+//
+// The server batch endpoint is used when available; otherwise the users are created
+// with individual requests, at most WithConcurrency at a time. The ID of each
+// successful item is the new user ID. API keys requested with GetApiKey are not
+// reported; fetch them per user afterwards.
+//
+// Example:
+//
+//	result, err := client.CreateUsersBatch(ctx, []sdk.UserCreateRequest{
+//		{UserName: "alice", Password: "secret-1", RoleIDList: []sdk.RoleID{5}},
+//		{UserName: "bob", Password: "secret-2", RoleIDList: []sdk.RoleID{5}},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, item := range result.Failed() {
+//		fmt.Printf("user %d not created: %v\n", item.Index, item.Err)
+//	}
+func (c *RawClient) CreateUsersBatch(ctx context.Context, reqs []UserCreateRequest, opts ...CallOption) (*BatchResult, error) {
+	if len(reqs) == 0 {
+		return &BatchResult{}, nil
+	}
+	var resp batchResponse
+	err := c.postJSON(ctx, "/user/batch_create", map[string]interface{}{"list": reqs}, &resp, opts...)
+	if err == nil {
+		return resp.toBatchResult(len(reqs)), nil
+	}
+	if !isBatchEndpointUnsupported(err) {
+		return nil, err
+	}
+
+	opts = withoutIdempotencyKey(opts)
+	callOpts := newCallOptions(opts...)
+	return runBatch(ctx, len(reqs), callOpts.concurrency, func(ctx context.Context, i int) (string, error) {
+		resp, err := c.CreateUser(ctx, &reqs[i], opts...)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%d", resp.UserID), nil
+	}), nil
+}
+
 // DeleteUser deletes the specified user account.
 //
 // This operation permanently removes the user and all associated data.
@@ -0,0 +1,415 @@
+// Package vcr records the HTTP interactions of a client to fixture files, called
+// cassettes, and replays them, so that tests written against the live MOI service can
+// run deterministically without it, e.g. in CI.
+//
+// A Recorder is an http.RoundTripper. In ModeRecord it forwards requests to the real
+// transport and records them; in ModeReplay it answers each request with the next
+// unused recorded interaction of the same method, path and query, and never touches
+// the network. Streaming responses, such as the server-sent events of
+// AnalyzeDataStream, are recorded as they are read and replayed as a whole.
+//
+// Credentials are removed from cassettes before they are written: sensitive headers
+// are replaced and JSON string fields that denote a password, secret, token,
+// signature or API key are masked, as is the secret returned by the API key
+// endpoints. Options.Sanitize can remove more.
+//
+// Example:
+//
+//	mode := vcr.ModeReplay
+//	if os.Getenv("MOI_VCR") == "record" {
+//		mode = vcr.ModeRecord
+//	}
+//	rec, err := vcr.New("testdata/cassettes/"+t.Name()+".json", vcr.Options{Mode: mode})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	t.Cleanup(func() { _ = rec.Save() })
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithHTTPClient(&http.Client{Transport: rec}))
+//
+// Replayed tests must send the same sequence of requests as when they were recorded:
+// values that change on every run, such as random names, should only appear in
+// request bodies, which are not matched by default.
+package vcr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode selects whether a Recorder records or replays.
+type Mode int
+
+const (
+	ModeReplay Mode = iota // Serve recorded interactions; requests never reach the network
+	ModeRecord             // Send requests to the real transport and record them
+)
+
+// ErrNoInteraction is returned in ModeReplay for a request that matches no unused
+// recorded interaction.
+var ErrNoInteraction = errors.New("vcr: no recorded interaction")
+
+// Redacted replaces the values of sensitive headers and body fields in cassettes.
+const Redacted = "REDACTED"
+
+// Cassette is the content of a fixture file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+
+	pending *bytes.Buffer // Response body read so far, while it is being recorded
+}
+
+// Request is a recorded request. URL holds the path and query only, so that
+// cassettes do not depend on the host they were recorded against.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded body. Text is stored as is and binary content in base64, so
+// that cassettes stay readable and diffable.
+type Body []byte
+
+// MarshalJSON encodes text bodies as a string and binary ones as {"base64": "..."}.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON decodes the encodings of MarshalJSON.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*b = Body(text)
+		return nil
+	}
+	var binary struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &binary); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(binary.Base64)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// Options configures a Recorder.
+type Options struct {
+	Mode Mode
+	// Transport sends the requests in ModeRecord. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+	// Sanitize, if set, is applied to every interaction after the built-in redaction,
+	// before it is matched or written; e.g. to mask IDs or hostnames in bodies. It is
+	// also applied to the requests to match in ModeReplay, whose Response is empty.
+	Sanitize func(*Interaction)
+	// Match reports whether a request to replay matches a recorded one. Defaults to
+	// comparing the method and URL.
+	Match func(req, recorded *Request) bool
+}
+
+// Recorder records or replays the interactions of a cassette file. It is safe for
+// concurrent use, although replayed concurrent requests with the same method and
+// URL are answered in an unspecified order.
+type Recorder struct {
+	path     string
+	opts     Options
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool // Interactions already replayed
+}
+
+// New returns a Recorder for the cassette at path. In ModeReplay the cassette must
+// exist; in ModeRecord it is created, or replaced, by Save.
+func New(path string, opts Options) (*Recorder, error) {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	if opts.Match == nil {
+		opts.Match = matchMethodAndURL
+	}
+	r := &Recorder{path: path, opts: opts}
+	if opts.Mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("vcr: read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("vcr: decode cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Interactions returns the interactions recorded so far, or loaded for replay.
+func (r *Recorder) Interactions() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*Interaction(nil), r.cassette.Interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory. It does nothing in ModeReplay. Responses that are still being read,
+// such as open streams, are saved with the part read so far.
+func (r *Recorder) Save() error {
+	if r.opts.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	cassette := Cassette{Interactions: make([]*Interaction, len(r.cassette.Interactions))}
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.pending != nil {
+			partial := *interaction
+			partial.Request.Header = partial.Request.Header.Clone()
+			partial.Response.Header = partial.Response.Header.Clone()
+			partial.Response.Body = append(Body(nil), interaction.pending.Bytes()...)
+			r.sanitizeResponse(&partial)
+			interaction = &partial
+		}
+		cassette.Interactions[i] = interaction
+	}
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("vcr: encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("vcr: create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("vcr: write cassette: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip records or replays req.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.opts.Mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("vcr: read request body: %w", err)
+	}
+	resp, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	interaction := &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.RequestURI(),
+			Header: req.Header.Clone(),
+			Body:   reqBody,
+		},
+		Response: Response{StatusCode: resp.StatusCode, Header: resp.Header.Clone()},
+		pending:  new(bytes.Buffer),
+	}
+	redactRequest(&interaction.Request)
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	resp.Body = &recordingBody{body: resp.Body, recorder: r, interaction: interaction}
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("vcr: read request body: %w", err)
+	}
+	incoming := &Interaction{Request: Request{
+		Method: req.Method,
+		URL:    req.URL.RequestURI(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	}}
+	redactRequest(&incoming.Request)
+	if r.opts.Sanitize != nil {
+		r.opts.Sanitize(incoming)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, recorded := range r.cassette.Interactions {
+		if r.used[i] || !r.opts.Match(&incoming.Request, &recorded.Request) {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.Response.StatusCode, http.StatusText(recorded.Response.StatusCode)),
+			StatusCode:    recorded.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(recorded.Response.Body)),
+			ContentLength: int64(len(recorded.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w for %s %s in %s", ErrNoInteraction, req.Method, req.URL.RequestURI(), r.path)
+}
+
+// redactRequest removes the credentials of a request.
+func redactRequest(req *Request) {
+	redactHeader(req.Header)
+	req.Body = redactBody(req.Body)
+}
+
+// sanitizeResponse removes the credentials of the response of i, then applies the
+// user's sanitizer to the whole interaction.
+func (r *Recorder) sanitizeResponse(i *Interaction) {
+	redactHeader(i.Response.Header)
+	i.Response.Body = redactBody(i.Response.Body)
+	if isAPIKeyEndpoint(i.Request.URL) {
+		i.Response.Body = Body(apiKeyField.ReplaceAll(i.Response.Body, []byte(`$1"`+Redacted+`"`)))
+	}
+	if r.opts.Sanitize != nil {
+		r.opts.Sanitize(i)
+	}
+}
+
+func matchMethodAndURL(req, recorded *Request) bool {
+	return req.Method == recorded.Method && req.URL == recorded.URL
+}
+
+// readRequestBody returns the body of req and restores it for sending.
+func readRequestBody(req *http.Request) (Body, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// recordingBody captures a response body as the client reads it, and stores it in
+// the interaction at EOF or Close.
+type recordingBody struct {
+	body        io.ReadCloser
+	recorder    *Recorder
+	interaction *Interaction
+	once        sync.Once
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.recorder.mu.Lock()
+	if b.interaction.pending != nil {
+		b.interaction.pending.Write(p[:n])
+	}
+	b.recorder.mu.Unlock()
+	if err == io.EOF {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	err := b.body.Close()
+	b.finish()
+	return err
+}
+
+func (b *recordingBody) finish() {
+	b.once.Do(func() {
+		r := b.recorder
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		b.interaction.Response.Body = b.interaction.pending.Bytes()
+		b.interaction.pending = nil
+		r.sanitizeResponse(b.interaction)
+	})
+}
+
+// redactHeader replaces the values of headers that may carry credentials.
+func redactHeader(h http.Header) {
+	for name, values := range h {
+		if !isSensitiveHeader(name) {
+			continue
+		}
+		for i := range values {
+			values[i] = Redacted
+		}
+	}
+}
+
+func isSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	switch lower {
+	case "authorization", "proxy-authorization", "cookie", "set-cookie", "moi-key":
+		return true
+	}
+	return strings.HasSuffix(lower, "-signature") || strings.HasSuffix(lower, "-token") ||
+		strings.HasSuffix(lower, "-api-key") || strings.HasSuffix(lower, "-secret")
+}
+
+// sensitiveField matches a JSON string member whose name denotes a credential.
+var sensitiveField = regexp.MustCompile(`(?i)("(?:[^"]*(?:password|passwd|secret|credential)[^"]*|[^"]*(?:token|signature|authorization|api_?key|access_?key|private_?key))"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// apiKeyField matches the "key" member holding the secret in the responses of the
+// API key endpoints. Elsewhere "key" is ordinary data, such as the question of an
+// NL2SQL knowledge entry, and is kept.
+var apiKeyField = regexp.MustCompile(`("key"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// isAPIKeyEndpoint reports whether rawURL is an API key endpoint, such as
+// /user/api-key/create or /user/me/api-key.
+func isAPIKeyEndpoint(rawURL string) bool {
+	p, _, _ := strings.Cut(rawURL, "?")
+	return strings.Contains(p, "/api-key")
+}
+
+// redactBody masks the credentials of a JSON or event stream body.
+func redactBody(body Body) Body {
+	if len(body) == 0 || !utf8.Valid(body) {
+		return body
+	}
+	return Body(sensitiveField.ReplaceAll(body, []byte(`$1"`+Redacted+`"`)))
+}
@@ -0,0 +1,132 @@
+package vcr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/matrixorigin/moi-go-sdk"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	names := []string{"first", "second"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/catalog/info":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Set-Cookie", "session=abc")
+			name := names[0]
+			names = names[1:]
+			_, _ = io.WriteString(w, `{"code":"OK","msg":"OK","data":{"id":1,"name":"`+name+`","access_token":"t0k3n"}}`)
+		case "/byoa/api/v1/data_asking/analyze":
+			w.Header().Set("Content-Type", "text/event-stream")
+			for _, event := range []string{`{"type":"classification"}`, `{"type":"answer"}`} {
+				_, _ = io.WriteString(w, "data: "+event+"\n\n")
+				w.(http.Flusher).Flush()
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// exercise sends the requests of a test: two catalog lookups and a stream.
+func exercise(t *testing.T, client *sdk.RawClient) []string {
+	t.Helper()
+	ctx := context.Background()
+	var got []string
+	for i := 0; i < 2; i++ {
+		info, err := client.GetCatalog(ctx, &sdk.CatalogInfoRequest{CatalogID: 1})
+		require.NoError(t, err)
+		got = append(got, info.CatalogName)
+	}
+	stream, err := client.AnalyzeDataStream(ctx, &sdk.DataAnalysisRequest{Question: "q"})
+	require.NoError(t, err)
+	defer stream.Close()
+	for {
+		event, err := stream.ReadEvent()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		got = append(got, event.Type)
+	}
+	return got
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+	server := newServer(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "catalog.json")
+	want := []string{"first", "second", "classification", "answer"}
+
+	rec, err := New(path, Options{Mode: ModeRecord, Sanitize: func(i *Interaction) {
+		i.Request.Header.Del("X-Request-Id")
+	}})
+	require.NoError(t, err)
+	client, err := sdk.NewRawClient(server.URL, "secret-api-key", sdk.WithHTTPClient(&http.Client{Transport: rec}))
+	require.NoError(t, err)
+	require.Equal(t, want, exercise(t, client))
+	require.NoError(t, rec.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "secret-api-key")
+	require.NotContains(t, string(data), "t0k3n")
+	require.NotContains(t, string(data), "session=abc")
+	require.NotContains(t, string(data), "X-Request-Id")
+	require.Contains(t, string(data), `\"access_token\":\"REDACTED\"`)
+
+	// Replay against another base URL, with the server gone
+	server.Close()
+	replay, err := New(path, Options{})
+	require.NoError(t, err)
+	client, err = sdk.NewRawClient("https://moi.test", "other-key", sdk.WithHTTPClient(&http.Client{Transport: replay}))
+	require.NoError(t, err)
+	require.Equal(t, want, exercise(t, client))
+
+	// Every interaction has been used
+	_, err = client.GetCatalog(context.Background(), &sdk.CatalogInfoRequest{CatalogID: 1})
+	require.ErrorIs(t, err, ErrNoInteraction)
+}
+
+func TestBody_JSON(t *testing.T) {
+	t.Parallel()
+	for _, body := range []Body{Body(`{"a":1}`), {0xff, 0x00, 0x10}} {
+		data, err := body.MarshalJSON()
+		require.NoError(t, err)
+		var decoded Body
+		require.NoError(t, decoded.UnmarshalJSON(data))
+		require.Equal(t, body, decoded)
+	}
+}
+
+func TestNew_MissingCassette(t *testing.T) {
+	t.Parallel()
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), Options{Mode: ModeReplay})
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSanitizeResponse_KeyMember(t *testing.T) {
+	t.Parallel()
+	r := &Recorder{}
+	body := Body(`{"code":"OK","data":{"key":"sk-123","api_key":"sk-456"}}`)
+
+	knowledge := &Interaction{Request: Request{URL: "https://moi.test/catalog/nl2sql_knowledge/get"}, Response: Response{Body: body}}
+	r.sanitizeResponse(knowledge)
+	require.Equal(t, `{"code":"OK","data":{"key":"sk-123","api_key":"REDACTED"}}`, string(knowledge.Response.Body))
+
+	apiKey := &Interaction{Request: Request{URL: "https://moi.test/user/api-key/create"}, Response: Response{Body: body}}
+	r.sanitizeResponse(apiKey)
+	require.Equal(t, `{"code":"OK","data":{"key":"REDACTED","api_key":"REDACTED"}}`, string(apiKey.Response.Body))
+}
@@ -0,0 +1,31 @@
+package sdk
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// sdkVersion is the version of this SDK.
+const sdkVersion = "0.1.0"
+
+// Version returns the version of the SDK, as reported in the default User-Agent.
+func Version() string {
+	return sdkVersion
+}
+
+// composeUserAgent prefixes the SDK User-Agent with the application token and appends
+// the Go runtime and platform, e.g. "app/1.2 matrixflow-sdk-go/0.1.0 (go1.22; linux/amd64)".
+func composeUserAgent(app, sdkUserAgent string) string {
+	return fmt.Sprintf("%s %s (%s; %s/%s)", app, sdkUserAgent, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+// productToken formats name and version as a User-Agent product token.
+func productToken(name, version string) string {
+	name = strings.Join(strings.Fields(name), "-")
+	version = strings.Join(strings.Fields(version), "-")
+	if name == "" || version == "" {
+		return name
+	}
+	return name + "/" + version
+}
@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAppInfo(t *testing.T) {
+	t.Parallel()
+
+	var userAgent string
+	client, err := NewRawClient("https://moi.test", "key",
+		WithAppInfo("billing sync", "1.2"),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			userAgent = r.Header.Get(headerUserAgent)
+			return envelopeResponse(`{}`), nil
+		})}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetCatalog(context.Background(), &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	want := "billing-sync/1.2 matrixflow-sdk-go/" + Version() + " (" + runtime.Version() + "; " + runtime.GOOS + "/" + runtime.GOARCH + ")"
+	require.Equal(t, want, userAgent)
+
+	// Without an application name the default User-Agent is kept
+	client, err = NewRawClient("https://moi.test", "key", WithAppInfo("", "1.2"))
+	require.NoError(t, err)
+	require.Equal(t, defaultUserAgent, client.userAgent)
+}
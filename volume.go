@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"context"
+	"fmt"
+	"strings"
 )
 
 // CreateVolume creates a new volume in the specified database.
@@ -96,6 +98,121 @@ func (c *RawClient) GetVolume(ctx context.Context, req *VolumeInfoRequest, opts
 	return &resp, nil
 }
 
+// ListVolumes lists the volumes of a database whose names match a filter, one page
+// at a time.
+//
+// Filters and ordering of the embedded CommonCondition apply as for the other list
+// calls, and the response reports the total number of matching volumes.
+//
+// Example:
+//
+//	resp, err := client.ListVolumes(ctx, &sdk.VolumeListRequest{
+//		DatabaseID: 123,
+//		NameFilter: "docs",
+//		CommonCondition: sdk.CommonCondition{
+//			Page: 1, PageSize: 50, OrderBy: "created_at", Order: "desc",
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, volume := range resp.List {
+//		fmt.Printf("%s %s\n", volume.VolumeID, volume.Name)
+//	}
+func (c *RawClient) ListVolumes(ctx context.Context, req *VolumeListRequest, opts ...CallOption) (*VolumeListResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.DatabaseID == 0 {
+		return nil, fmt.Errorf("database_id is required")
+	}
+	var resp VolumeListResponse
+	if err := c.postJSON(ctx, "/catalog/volume/list", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetVolumeUsage retrieves the bytes used, the file and folder counts, and the
+// largest files of a volume.
+//
+// Example:
+//
+//	usage, err := client.GetVolumeUsage(ctx, &sdk.VolumeUsageRequest{
+//		VolumeID: "volume-id-123",
+//		TopFiles: 10,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("%d bytes in %d files\n", usage.UsedBytes, usage.FileCount)
+func (c *RawClient) GetVolumeUsage(ctx context.Context, req *VolumeUsageRequest, opts ...CallOption) (*VolumeUsageResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.TopFiles < 0 {
+		return nil, fmt.Errorf("top_files must not be negative")
+	}
+	var resp VolumeUsageResponse
+	if err := c.postJSON(ctx, "/catalog/volume/usage", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetVolumeQuota retrieves the quota of a volume together with its current usage.
+//
+// Services without volume quotas answer with an HTTPError of status 404.
+//
+// Example:
+//
+//	quota, err := client.GetVolumeQuota(ctx, &sdk.VolumeQuotaRequest{
+//		VolumeID: "volume-id-123",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if quota.MaxBytes > 0 {
+//		fmt.Printf("%d of %d bytes used\n", quota.UsedBytes, quota.MaxBytes)
+//	}
+func (c *RawClient) GetVolumeQuota(ctx context.Context, req *VolumeQuotaRequest, opts ...CallOption) (*VolumeQuotaResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	var resp VolumeQuotaResponse
+	if err := c.postJSON(ctx, "/catalog/volume/quota/info", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetVolumeQuota sets the maximum size and number of files of a volume. A zero
+// limit removes it.
+//
+// Uploads and copies that would exceed the quota fail with an error matching
+// ErrQuotaExceeded. Lowering a quota below the current usage is allowed and only
+// blocks further growth.
+//
+// Example:
+//
+//	_, err := client.SetVolumeQuota(ctx, &sdk.VolumeQuotaSetRequest{
+//		VolumeID:    "volume-id-123",
+//		VolumeQuota: sdk.VolumeQuota{MaxBytes: 10 << 30},
+//	})
+func (c *RawClient) SetVolumeQuota(ctx context.Context, req *VolumeQuotaSetRequest, opts ...CallOption) (*VolumeQuotaSetResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.MaxBytes < 0 || req.MaxFiles < 0 {
+		return nil, fmt.Errorf("quota limits must not be negative")
+	}
+	var resp VolumeQuotaSetResponse
+	if err := c.postJSON(ctx, "/catalog/volume/quota/set", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetVolumeRefList retrieves the list of references to the specified volume.
 //
 // Returns a list of objects that reference this volume.
@@ -183,3 +300,48 @@ func (c *RawClient) RemoveVolumeWorkflowRef(ctx context.Context, req *VolumeRemo
 	}
 	return &resp, nil
 }
+
+// MountExternalVolume registers an existing S3/OSS bucket, or a prefix of it, as a
+// read-only volume in the specified database.
+//
+// The objects stay in the bucket: they are listed and read in place, so existing
+// corpora can be fed into workflows without uploading every object. Access uses
+// either the given Credentials or those of the connector ConnectorID.
+//
+// Example:
+//
+//	resp, err := client.MountExternalVolume(ctx, &sdk.ExternalVolumeRequest{
+//		DatabaseID:  123,
+//		Name:        "contracts-archive",
+//		Provider:    sdk.ExternalStorageOSS,
+//		Bucket:      "legal-docs",
+//		Prefix:      "contracts/2024/",
+//		Region:      "cn-hangzhou",
+//		ConnectorID: 42,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("Mounted volume ID: %s\n", resp.VolumeID)
+func (c *RawClient) MountExternalVolume(ctx context.Context, req *ExternalVolumeRequest, opts ...CallOption) (*ExternalVolumeResponse, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	if req.Provider == "" {
+		return nil, fmt.Errorf("provider cannot be empty")
+	}
+	if strings.TrimSpace(req.Bucket) == "" {
+		return nil, fmt.Errorf("bucket cannot be empty")
+	}
+	if (req.Credentials == nil) == (req.ConnectorID == 0) {
+		return nil, fmt.Errorf("exactly one of credentials and connector_id must be set")
+	}
+	var resp ExternalVolumeResponse
+	if err := c.postJSON(ctx, "/catalog/volume/mount_external", req, &resp, opts...); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
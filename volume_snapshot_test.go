@@ -0,0 +1,74 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeSnapshotNilRequestErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	client := &RawClient{}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"Create", func() error { _, err := client.CreateVolumeSnapshot(ctx, nil); return err }},
+		{"List", func() error { _, err := client.ListVolumeSnapshots(ctx, nil); return err }},
+		{"Restore", func() error { _, err := client.RestoreVolumeSnapshot(ctx, nil); return err }},
+		{"Delete", func() error { _, err := client.DeleteVolumeSnapshot(ctx, nil); return err }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorIs(t, tc.call(), ErrNilRequest)
+		})
+	}
+}
+
+func TestVolumeSnapshotFlow(t *testing.T) {
+	t.Parallel()
+
+	var restore VolumeSnapshotRestoreRequest
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/volume/snapshot/create":
+			var req VolumeSnapshotCreateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, VolumeSnapshotCreateRequest{VolumeID: "v1", Name: "before-reindex"}, req)
+			return envelopeResponse(`{"id":"s1"}`), nil
+		case "/catalog/volume/snapshot/list":
+			return envelopeResponse(`{"total":1,"list":[{"id":"s1","volume_id":"v1","name":"before-reindex","file_count":3}]}`), nil
+		case "/catalog/volume/snapshot/restore":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&restore))
+			return envelopeResponse(`{"volume_id":"v2","files_restored":3}`), nil
+		}
+		return errorEnvelopeResponse("ErrLegalHold", "volume is under legal hold"), nil
+	})
+	ctx := context.Background()
+
+	created, err := client.CreateVolumeSnapshot(ctx, &VolumeSnapshotCreateRequest{VolumeID: "v1", Name: "before-reindex"})
+	require.NoError(t, err)
+	require.Equal(t, "s1", created.SnapshotID)
+
+	list, err := client.ListVolumeSnapshots(ctx, &VolumeSnapshotListRequest{VolumeID: "v1"})
+	require.NoError(t, err)
+	require.Len(t, list.List, 1)
+	require.Equal(t, int64(3), list.List[0].FileCount)
+
+	restored, err := client.RestoreVolumeSnapshot(ctx, &VolumeSnapshotRestoreRequest{SnapshotID: "s1", TargetName: "docs-copy"})
+	require.NoError(t, err)
+	require.Equal(t, VolumeSnapshotRestoreRequest{SnapshotID: "s1", TargetName: "docs-copy"}, restore)
+	require.Equal(t, VolumeID("v2"), restored.VolumeID)
+
+	_, err = client.DeleteVolumeSnapshot(ctx, &VolumeSnapshotDeleteRequest{SnapshotID: "s1"})
+	require.True(t, IsLegalHold(err))
+
+	_, err = client.CreateVolumeSnapshot(ctx, &VolumeSnapshotCreateRequest{VolumeID: "v1"})
+	require.ErrorContains(t, err, "name cannot be empty")
+}
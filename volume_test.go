@@ -2,6 +2,8 @@ package sdk
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -88,6 +90,11 @@ func TestVolumeNilRequestErrors(t *testing.T) {
 		{"FullPath", func() error { _, err := client.GetVolumeFullPath(ctx, nil); return err }},
 		{"AddRefWorkflow", func() error { _, err := client.AddVolumeWorkflowRef(ctx, nil); return err }},
 		{"RemoveRefWorkflow", func() error { _, err := client.RemoveVolumeWorkflowRef(ctx, nil); return err }},
+		{"MountExternal", func() error { _, err := client.MountExternalVolume(ctx, nil); return err }},
+		{"List", func() error { _, err := client.ListVolumes(ctx, nil); return err }},
+		{"Usage", func() error { _, err := client.GetVolumeUsage(ctx, nil); return err }},
+		{"QuotaInfo", func() error { _, err := client.GetVolumeQuota(ctx, nil); return err }},
+		{"QuotaSet", func() error { _, err := client.SetVolumeQuota(ctx, nil); return err }},
 	}
 
 	for _, tc := range tests {
@@ -97,6 +104,55 @@ func TestVolumeNilRequestErrors(t *testing.T) {
 	}
 }
 
+func TestMountExternalVolume(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/volume/mount_external", r.URL.Path)
+		var req ExternalVolumeRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, ExternalStorageOSS, req.Provider)
+		require.Equal(t, "legal-docs", req.Bucket)
+		require.Equal(t, "contracts/", req.Prefix)
+		require.Equal(t, uint64(42), req.ConnectorID)
+		require.Nil(t, req.Credentials)
+		return envelopeResponse(`{"id":"vol-1","read_only":true}`), nil
+	})
+
+	resp, err := client.MountExternalVolume(context.Background(), &ExternalVolumeRequest{
+		DatabaseID:  1,
+		Name:        "archive",
+		Provider:    ExternalStorageOSS,
+		Bucket:      "legal-docs",
+		Prefix:      "contracts/",
+		ConnectorID: 42,
+	})
+	require.NoError(t, err)
+	require.Equal(t, VolumeID("vol-1"), resp.VolumeID)
+	require.True(t, resp.ReadOnly)
+}
+
+func TestMountExternalVolume_Validation(t *testing.T) {
+	t.Parallel()
+	client := &RawClient{}
+	base := ExternalVolumeRequest{Name: "archive", Provider: ExternalStorageS3, Bucket: "b", ConnectorID: 1}
+
+	noBucket := base
+	noBucket.Bucket = ""
+	_, err := client.MountExternalVolume(context.Background(), &noBucket)
+	require.ErrorContains(t, err, "bucket")
+
+	both := base
+	both.Credentials = &ExternalStorageCredentials{AccessKeyID: "ak", SecretAccessKey: "sk"}
+	_, err = client.MountExternalVolume(context.Background(), &both)
+	require.ErrorContains(t, err, "exactly one")
+
+	neither := base
+	neither.ConnectorID = 0
+	_, err = client.MountExternalVolume(context.Background(), &neither)
+	require.ErrorContains(t, err, "exactly one")
+}
+
 func TestVolumeDatabaseIDNotExists(t *testing.T) {
 	ctx := context.Background()
 	client := newTestClient(t)
@@ -387,3 +443,78 @@ func TestVolumeFullPath(t *testing.T) {
 
 	t.Logf("Volume full path: Names=%v, IDs=%v", path.NameList, path.IDList)
 }
+
+func TestGetVolumeUsage(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/volume/usage", r.URL.Path)
+		var req VolumeUsageRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, VolumeUsageRequest{VolumeID: "v1", TopFiles: 2}, req)
+		return envelopeResponse(`{"id":"v1","used_bytes":300,"file_count":3,"folder_count":1,
+			"largest_files":[{"id":"f1","name":"a.pdf","size":200},{"id":"f2","name":"b.pdf","parent_id":"d1","size":80}]}`), nil
+	})
+
+	usage, err := client.GetVolumeUsage(context.Background(), &VolumeUsageRequest{VolumeID: "v1", TopFiles: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(300), usage.UsedBytes)
+	require.Equal(t, int64(1), usage.FolderCount)
+	require.Len(t, usage.LargestFiles, 2)
+	require.Equal(t, FileID("d1"), usage.LargestFiles[1].ParentID)
+}
+
+func TestVolumeQuota(t *testing.T) {
+	t.Parallel()
+
+	var set map[string]any
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/catalog/volume/quota/set":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&set))
+			return envelopeResponse(`{"id":"v1"}`), nil
+		case "/catalog/volume/quota/info":
+			return envelopeResponse(`{"id":"v1","max_bytes":1024,"max_files":0,"used_bytes":512,"file_count":4}`), nil
+		}
+		return errorEnvelopeResponse("ErrQuotaExceeded", "volume quota exceeded"), nil
+	})
+	ctx := context.Background()
+
+	_, err := client.SetVolumeQuota(ctx, &VolumeQuotaSetRequest{VolumeID: "v1", VolumeQuota: VolumeQuota{MaxBytes: 1024}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"id": "v1", "max_bytes": float64(1024), "max_files": float64(0)}, set)
+
+	quota, err := client.GetVolumeQuota(ctx, &VolumeQuotaRequest{VolumeID: "v1"})
+	require.NoError(t, err)
+	require.Equal(t, VolumeQuota{MaxBytes: 1024}, quota.VolumeQuota)
+	require.Equal(t, int64(512), quota.UsedBytes)
+
+	_, err = client.SetVolumeQuota(ctx, &VolumeQuotaSetRequest{VolumeID: "v1", VolumeQuota: VolumeQuota{MaxFiles: -1}})
+	require.ErrorContains(t, err, "must not be negative")
+}
+
+func TestListVolumes(t *testing.T) {
+	t.Parallel()
+
+	var got map[string]any
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/volume/list", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		return envelopeResponse(`{"list":[{"id":"v1","name":"docs","size":10,"ref":true}],"total":1}`), nil
+	})
+
+	resp, err := client.ListVolumes(context.Background(), &VolumeListRequest{
+		DatabaseID:      3,
+		NameFilter:      "doc",
+		CommonCondition: CommonCondition{Page: 1, PageSize: 20, OrderBy: "size", Order: "desc"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, float64(3), got["database_id"])
+	require.Equal(t, "doc", got["name_filter"])
+	require.Equal(t, "size", got["order_by"])
+	require.Equal(t, 1, resp.Total)
+	require.Equal(t, []VolumeListItem{{VolumeID: "v1", Name: "docs", Size: 10, Ref: true}}, resp.List)
+
+	_, err = client.ListVolumes(context.Background(), &VolumeListRequest{})
+	require.ErrorContains(t, err, "database_id is required")
+}
@@ -0,0 +1,272 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultWaitPollInterval    = 2 * time.Second
+	defaultWaitMaxPollInterval = 30 * time.Second
+	waitBackoffFactor          = 1.5
+)
+
+// WaitOptions controls how Wait* helpers poll for the state of an asynchronous operation.
+//
+// The delay between polls starts at PollInterval and grows by half after every poll
+// until it reaches MaxPollInterval.
+type WaitOptions struct {
+	// PollInterval is the delay before the second poll (default: 2 seconds).
+	PollInterval time.Duration
+
+	// MaxPollInterval caps the delay between polls (default: 30 seconds).
+	MaxPollInterval time.Duration
+
+	// Timeout bounds the total wait. Zero means waiting until ctx is done.
+	Timeout time.Duration
+
+	// OnProgress, if set, is called after every poll.
+	OnProgress func(WaitProgress)
+}
+
+// WaitProgress describes the outcome of a single poll.
+type WaitProgress struct {
+	Attempt int           // 1 for the first poll
+	Elapsed time.Duration // Time since the wait started
+	Status  string        // Status observed by this poll; empty if the poll failed
+	Err     error         // Error returned by this poll, if any; polling continues unless it is permanent
+}
+
+func (o WaitOptions) withDefaults() WaitOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaultWaitPollInterval
+	}
+	if o.MaxPollInterval <= 0 {
+		o.MaxPollInterval = defaultWaitMaxPollInterval
+	}
+	if o.MaxPollInterval < o.PollInterval {
+		o.MaxPollInterval = o.PollInterval
+	}
+	return o
+}
+
+// WaitForWorkflowJob polls a workflow job until it reaches a terminal status.
+//
+// The final job is returned for both completed and failed jobs; check job.Status to
+// tell them apart. If the timeout or the deadline of ctx expires first, the error is
+// a *WaitTimeoutError carrying the last observed status. Transient poll failures are
+// retried; permission and invalid-argument errors are returned immediately.
+//
+// Example:
+//
+//	run, err := client.TriggerWorkflowRun(ctx, "workflow-123")
+//	if err != nil {
+//		return err
+//	}
+//	job, err := client.WaitForWorkflowJob(ctx, run.JobID, sdk.WaitOptions{
+//		Timeout: 10 * time.Minute,
+//		OnProgress: func(p sdk.WaitProgress) {
+//			log.Printf("job %s: %s after %s", run.JobID, p.Status, p.Elapsed)
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if job.Status == sdk.WorkflowJobStatusFailed {
+//		return fmt.Errorf("job %s failed", job.JobID)
+//	}
+func (c *RawClient) WaitForWorkflowJob(ctx context.Context, jobID string, waitOpts WaitOptions, opts ...CallOption) (*WorkflowJob, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	var job *WorkflowJob
+	err := pollUntil(ctx, waitOpts, "workflow job "+jobID, func(ctx context.Context) (string, bool, error) {
+		detail, err := c.GetWorkflowJob(ctx, jobID, opts...)
+		if err != nil {
+			return "", false, err
+		}
+		job = &detail.WorkflowJob
+		return job.Status.String(), job.Status.IsTerminal(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GenAIJobWaitOptions controls WaitForGenAIJob.
+type GenAIJobWaitOptions struct {
+	WaitOptions
+
+	// OnUpdate, if set, is called with the job state after every successful poll.
+	OnUpdate func(GenAIJobUpdate)
+}
+
+// GenAIJobUpdate describes the state of a GenAI job observed by a poll.
+type GenAIJobUpdate struct {
+	Elapsed       time.Duration              // Time since the wait started
+	Status        string                     // Job status
+	FinishedFiles int                        // Files that finished processing, successfully or not
+	TotalFiles    int                        // Files in the job
+	Job           *GenAIGetJobDetailResponse // Full job state
+}
+
+// WaitForGenAIJob polls a GenAI job until it reaches a terminal status.
+//
+// The completed job is returned on success. If the job fails or is cancelled, the error
+// is a *GenAIJobError carrying the final job state; if the timeout or the deadline of
+// ctx expires first, it is a *WaitTimeoutError. Polling backs off as described by
+// WaitOptions.
+//
+// Example:
+//
+//	job, err := client.WaitForGenAIJob(ctx, resp.JobID, sdk.GenAIJobWaitOptions{
+//		WaitOptions: sdk.WaitOptions{Timeout: 30 * time.Minute},
+//		OnUpdate: func(u sdk.GenAIJobUpdate) {
+//			log.Printf("%s: %d/%d files", u.Status, u.FinishedFiles, u.TotalFiles)
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+func (c *RawClient) WaitForGenAIJob(ctx context.Context, jobID string, waitOpts GenAIJobWaitOptions, opts ...CallOption) (*GenAIGetJobDetailResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	start := time.Now()
+	var job *GenAIGetJobDetailResponse
+	err := pollUntil(ctx, waitOpts.WaitOptions, "genai job "+jobID, func(ctx context.Context) (string, bool, error) {
+		j, err := c.GetGenAIJob(ctx, jobID, opts...)
+		if err != nil {
+			return "", false, err
+		}
+		job = j
+		if waitOpts.OnUpdate != nil {
+			waitOpts.OnUpdate(GenAIJobUpdate{
+				Elapsed:       time.Since(start),
+				Status:        j.Status,
+				FinishedFiles: j.FinishedFiles(),
+				TotalFiles:    len(j.Files),
+				Job:           j,
+			})
+		}
+		return j.Status, j.IsTerminal(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if genAIStatusKind(job.Status) != GenAIJobStatusCompleted {
+		return nil, &GenAIJobError{JobID: jobID, Status: job.Status, Job: job}
+	}
+	return job, nil
+}
+
+// TableJobWaitOptions controls WaitForTableJob.
+type TableJobWaitOptions struct {
+	WaitOptions
+
+	// OnUpdate, if set, is called with the job state after every successful poll.
+	OnUpdate func(*TableJob)
+}
+
+// WaitForTableJob polls a job started by LoadTableAsync or TruncateTableAsync until it
+// finishes.
+//
+// The finished job is returned when it succeeded, even if some rows were rejected;
+// check ErrorRows and Errors for partial failures. If the job fails or is cancelled,
+// the error is a *TableJobError carrying the final job state; if the timeout or the
+// deadline of ctx expires first, it is a *WaitTimeoutError.
+//
+// Example:
+//
+//	job, err := client.WaitForTableJob(ctx, resp.JobID, sdk.TableJobWaitOptions{
+//		WaitOptions: sdk.WaitOptions{Timeout: time.Hour},
+//		OnUpdate: func(j *sdk.TableJob) {
+//			log.Printf("%d/%d rows, %d rejected", j.RowsProcessed, j.RowsTotal, j.ErrorRows)
+//		},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	for _, rowErr := range job.Errors {
+//		log.Printf("row %d: %s", rowErr.Row, rowErr.Message)
+//	}
+func (c *RawClient) WaitForTableJob(ctx context.Context, jobID string, waitOpts TableJobWaitOptions, opts ...CallOption) (*TableJob, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	var job *TableJob
+	err := pollUntil(ctx, waitOpts.WaitOptions, "table job "+jobID, func(ctx context.Context) (string, bool, error) {
+		j, err := c.GetLoadJob(ctx, jobID, opts...)
+		if err != nil {
+			return "", false, err
+		}
+		job = j
+		if waitOpts.OnUpdate != nil {
+			waitOpts.OnUpdate(j)
+		}
+		return string(j.Status), j.Status.IsTerminal(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != TableJobStatusSucceeded {
+		return nil, &TableJobError{JobID: jobID, Status: job.Status, Job: job}
+	}
+	return job, nil
+}
+
+// pollUntil calls poll until it reports done, a permanent error occurs or the wait
+// times out. operation names what is awaited in timeout errors.
+func pollUntil(ctx context.Context, waitOpts WaitOptions, operation string, poll func(ctx context.Context) (status string, done bool, err error)) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	waitOpts = waitOpts.withDefaults()
+	if waitOpts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, waitOpts.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	interval := waitOpts.PollInterval
+	var lastStatus string
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		status, done, err := poll(ctx)
+		if err == nil {
+			lastStatus, lastErr = status, nil
+		} else if ctx.Err() == nil {
+			lastErr = err
+		}
+		if waitOpts.OnProgress != nil {
+			waitOpts.OnProgress(WaitProgress{Attempt: attempt, Elapsed: time.Since(start), Status: status, Err: err})
+		}
+		if err == nil && done {
+			return nil
+		}
+		if err != nil && isPermanentPollError(err) {
+			return err
+		}
+
+		if err := sleepWithContext(ctx, interval); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return &WaitTimeoutError{Operation: operation, LastStatus: lastStatus, Elapsed: time.Since(start), LastErr: lastErr}
+			}
+			return fmt.Errorf("wait for %s: %w", operation, err)
+		}
+		interval = time.Duration(float64(interval) * waitBackoffFactor)
+		if interval > waitOpts.MaxPollInterval {
+			interval = waitOpts.MaxPollInterval
+		}
+	}
+}
+
+// isPermanentPollError reports whether polling again cannot succeed.
+func isPermanentPollError(err error) bool {
+	return errors.Is(err, ErrNilRequest) || IsPermissionDenied(err) || IsInvalidArgument(err)
+}
@@ -0,0 +1,189 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForWorkflowJob_Completes(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/byoa/api/v1/workflow_job/job-1", r.URL.Path)
+		if polls.Add(1) < 3 {
+			return envelopeResponse(`{"id":"job-1","workflow_id":"wf-1","status":1,"start_time":"t0"}`), nil
+		}
+		return envelopeResponse(`{"id":"job-1","workflow_id":"wf-1","status":2,"start_time":"t0","end_time":"t1"}`), nil
+	})
+
+	var progress []WaitProgress
+	job, err := client.WaitForWorkflowJob(context.Background(), "job-1", WaitOptions{
+		PollInterval: time.Millisecond,
+		OnProgress:   func(p WaitProgress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+	require.Equal(t, WorkflowJobStatusCompleted, job.Status)
+	require.Equal(t, "t1", job.EndTime)
+	require.Len(t, progress, 3)
+	require.Equal(t, "running", progress[0].Status)
+	require.Equal(t, 3, progress[2].Attempt)
+}
+
+func TestWaitForWorkflowJob_Timeout(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return envelopeResponse(`{"id":"job-1","status":1}`), nil
+	})
+
+	_, err := client.WaitForWorkflowJob(context.Background(), "job-1", WaitOptions{
+		PollInterval: time.Millisecond,
+		Timeout:      20 * time.Millisecond,
+	})
+	require.ErrorIs(t, err, ErrWaitTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	var timeoutErr *WaitTimeoutError
+	require.True(t, errors.As(err, &timeoutErr))
+	require.Equal(t, "running", timeoutErr.LastStatus)
+	require.Contains(t, err.Error(), "workflow job job-1")
+}
+
+func TestWaitForWorkflowJob_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := (&RawClient{}).WaitForWorkflowJob(context.Background(), " ", WaitOptions{})
+	require.ErrorContains(t, err, "jobID cannot be empty")
+
+	// Transient failures are retried
+	var polls atomic.Int32
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		if polls.Add(1) == 1 {
+			return errorEnvelopeResponse("ErrInternal", "busy"), nil
+		}
+		return envelopeResponse(`{"id":"job-1","status":3}`), nil
+	})
+	job, err := client.WaitForWorkflowJob(context.Background(), "job-1", WaitOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	require.Equal(t, WorkflowJobStatusFailed, job.Status)
+
+	// Permission errors are not
+	client = newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return errorEnvelopeResponse("ErrPermissionDenied", "no access"), nil
+	})
+	_, err = client.WaitForWorkflowJob(context.Background(), "job-1", WaitOptions{PollInterval: time.Millisecond, Timeout: time.Second})
+	require.True(t, IsPermissionDenied(err))
+}
+
+func TestWaitOptionsDefaults(t *testing.T) {
+	t.Parallel()
+
+	o := WaitOptions{}.withDefaults()
+	require.Equal(t, defaultWaitPollInterval, o.PollInterval)
+	require.Equal(t, defaultWaitMaxPollInterval, o.MaxPollInterval)
+
+	o = WaitOptions{PollInterval: time.Minute, MaxPollInterval: time.Second}.withDefaults()
+	require.Equal(t, time.Minute, o.MaxPollInterval)
+}
+
+func TestWaitForGenAIJob(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/v1/genai/jobs/job-ok":
+			if polls.Add(1) < 2 {
+				return envelopeResponse(`{"status":"running","files":[{"file_name":"a.pdf","file_status":"completed"},{"file_name":"b.pdf","file_status":"processing"}]}`), nil
+			}
+			return envelopeResponse(`{"status":"completed","files":[{"file_name":"a.pdf","file_status":"completed"},{"file_name":"b.pdf","file_status":"completed"}]}`), nil
+		case "/v1/genai/jobs/job-bad":
+			return envelopeResponse(`{"status":"failed","files":[{"file_name":"a.pdf","file_status":"completed"},{"file_name":"b.pdf","file_status":"failed","error_message":"unsupported encoding"}]}`), nil
+		}
+		return envelopeResponse(`{"status":"pending"}`), nil
+	})
+	ctx := context.Background()
+
+	var updates []GenAIJobUpdate
+	job, err := client.WaitForGenAIJob(ctx, "job-ok", GenAIJobWaitOptions{
+		WaitOptions: WaitOptions{PollInterval: time.Millisecond},
+		OnUpdate:    func(u GenAIJobUpdate) { updates = append(updates, u) },
+	})
+	require.NoError(t, err)
+	require.Equal(t, GenAIJobStatusCompleted, job.Status)
+	require.Len(t, updates, 2)
+	require.Equal(t, 1, updates[0].FinishedFiles)
+	require.Equal(t, 2, updates[0].TotalFiles)
+	require.Equal(t, 2, updates[1].FinishedFiles)
+
+	_, err = client.WaitForGenAIJob(ctx, "job-bad", GenAIJobWaitOptions{WaitOptions: WaitOptions{PollInterval: time.Millisecond}})
+	require.ErrorIs(t, err, ErrJobFailed)
+	var jobErr *GenAIJobError
+	require.True(t, errors.As(err, &jobErr))
+	require.Equal(t, "failed", jobErr.Status)
+	require.Contains(t, err.Error(), "b.pdf: unsupported encoding")
+
+	_, err = client.WaitForGenAIJob(ctx, "job-slow", GenAIJobWaitOptions{WaitOptions: WaitOptions{PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond}})
+	require.ErrorIs(t, err, ErrWaitTimeout)
+
+	_, err = client.WaitForGenAIJob(ctx, "", GenAIJobWaitOptions{})
+	require.ErrorContains(t, err, "jobID cannot be empty")
+}
+
+func TestWaitForTableJob(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/catalog/table/job/info", r.URL.Path)
+		var body TableJobInfoRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch body.JobID {
+		case "job-ok":
+			if polls.Add(1) < 2 {
+				return envelopeResponse(`{"job_id":"job-ok","type":"load","status":"running","rows_processed":50,"rows_total":100,"error_rows":1}`), nil
+			}
+			return envelopeResponse(`{"job_id":"job-ok","type":"load","status":"succeeded","rows_processed":100,"rows_total":100,"rows_loaded":98,"error_rows":2,
+				"errors":[{"row":7,"column":"price","message":"invalid decimal"},{"row":42,"message":"too many columns"}]}`), nil
+		case "job-bad":
+			return envelopeResponse(`{"job_id":"job-bad","type":"load","status":"failed","rows_processed":10,"error_message":"file not readable"}`), nil
+		}
+		return envelopeResponse(`{"status":"pending"}`), nil
+	})
+	ctx := context.Background()
+	fast := WaitOptions{PollInterval: time.Millisecond}
+
+	var updates []*TableJob
+	job, err := client.WaitForTableJob(ctx, "job-ok", TableJobWaitOptions{
+		WaitOptions: fast,
+		OnUpdate:    func(j *TableJob) { updates = append(updates, j) },
+	})
+	require.NoError(t, err)
+	require.Equal(t, TableJobStatusSucceeded, job.Status)
+	require.Equal(t, int64(2), job.ErrorRows)
+	require.Len(t, job.Errors, 2)
+	require.Equal(t, "price", job.Errors[0].Column)
+	require.Len(t, updates, 2)
+	require.Equal(t, int64(50), updates[0].RowsProcessed)
+
+	_, err = client.WaitForTableJob(ctx, "job-bad", TableJobWaitOptions{WaitOptions: fast})
+	require.ErrorIs(t, err, ErrJobFailed)
+	var jobErr *TableJobError
+	require.True(t, errors.As(err, &jobErr))
+	require.Equal(t, TableJobStatusFailed, jobErr.Status)
+	require.Equal(t, int64(10), jobErr.Job.RowsProcessed)
+	require.Contains(t, err.Error(), "file not readable")
+
+	_, err = client.WaitForTableJob(ctx, "job-slow", TableJobWaitOptions{WaitOptions: WaitOptions{PollInterval: time.Millisecond, Timeout: 20 * time.Millisecond}})
+	require.ErrorIs(t, err, ErrWaitTimeout)
+
+	_, err = client.WaitForTableJob(ctx, "", TableJobWaitOptions{})
+	require.ErrorContains(t, err, "jobID cannot be empty")
+}
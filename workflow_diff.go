@@ -0,0 +1,289 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WorkflowDiff describes the changes needed to turn one workflow definition into another.
+type WorkflowDiff struct {
+	FieldChanges       []WorkflowFieldChange       `json:"field_changes,omitempty"`
+	ScheduleChange     *WorkflowScheduleChange     `json:"schedule_change,omitempty"`
+	NodesAdded         []CatalogWorkflowNode       `json:"nodes_added,omitempty"`
+	NodesRemoved       []CatalogWorkflowNode       `json:"nodes_removed,omitempty"`
+	ParameterChanges   []WorkflowParameterChange   `json:"parameter_changes,omitempty"`
+	ConnectionsAdded   []CatalogWorkflowConnection `json:"connections_added,omitempty"`
+	ConnectionsRemoved []CatalogWorkflowConnection `json:"connections_removed,omitempty"`
+}
+
+// WorkflowFieldChange describes a change to a top-level workflow field such as
+// the name, the source/target volumes or the file types.
+type WorkflowFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// WorkflowScheduleChange describes a change to the workflow process mode.
+// Old or New is nil when the process mode was added or removed.
+type WorkflowScheduleChange struct {
+	Old *ProcessMode `json:"old"`
+	New *ProcessMode `json:"new"`
+}
+
+// WorkflowParameterChange describes a change to a single init parameter of a node
+// that exists in both workflows. Old is nil for added parameters and New is nil
+// for removed parameters.
+type WorkflowParameterChange struct {
+	NodeID    string      `json:"node_id"`
+	Component string      `json:"component"`
+	Key       string      `json:"key"`
+	Old       interface{} `json:"old"`
+	New       interface{} `json:"new"`
+}
+
+// DiffWorkflows compares two workflow definitions and returns the changes from a to b.
+//
+// Nodes are matched by ID; a node whose type changed is reported as removed and
+// re-added. Parameter values are compared by their JSON representation, so a value
+// decoded from JSON (float64) and the same value written in Go (int) are equal.
+// Either argument may be nil, which is treated as an empty workflow. SDKClient.Apply
+// uses it to compare the graph of an existing workflow with its spec.
+//
+// Example:
+//
+//	diff := sdk.DiffWorkflows(current, desired)
+//	if !diff.IsEmpty() {
+//		fmt.Print(diff.Summary())
+//	}
+func DiffWorkflows(a, b *WorkflowMetadata) *WorkflowDiff {
+	if a == nil {
+		a = &WorkflowMetadata{}
+	}
+	if b == nil {
+		b = &WorkflowMetadata{}
+	}
+	diff := &WorkflowDiff{}
+
+	addField := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			diff.FieldChanges = append(diff.FieldChanges, WorkflowFieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	addField("name", a.Name, b.Name)
+	addField("source_volume_names", joinSortedStrings(a.SourceVolumeNames), joinSortedStrings(b.SourceVolumeNames))
+	addField("source_volume_ids", joinSortedStrings(a.SourceVolumeIDs), joinSortedStrings(b.SourceVolumeIDs))
+	addField("target_volume_name", a.TargetVolumeName, b.TargetVolumeName)
+	addField("target_volume_id", a.TargetVolumeID, b.TargetVolumeID)
+	addField("create_target_volume_name", a.CreateTargetVolumeName, b.CreateTargetVolumeName)
+	addField("file_types", joinSortedInts(a.FileTypes), joinSortedInts(b.FileTypes))
+
+	if !processModeEqual(a.ProcessMode, b.ProcessMode) {
+		diff.ScheduleChange = &WorkflowScheduleChange{Old: a.ProcessMode, New: b.ProcessMode}
+	}
+
+	diffWorkflowGraph(diff, a.Workflow, b.Workflow)
+	return diff
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d *WorkflowDiff) IsEmpty() bool {
+	if d == nil {
+		return true
+	}
+	return len(d.FieldChanges) == 0 &&
+		d.ScheduleChange == nil &&
+		len(d.NodesAdded) == 0 &&
+		len(d.NodesRemoved) == 0 &&
+		len(d.ParameterChanges) == 0 &&
+		len(d.ConnectionsAdded) == 0 &&
+		len(d.ConnectionsRemoved) == 0
+}
+
+// Summary returns a human-readable, line-oriented description of the diff,
+// suitable for CI logs and pull request comments. It returns "no changes\n"
+// for an empty diff.
+func (d *WorkflowDiff) Summary() string {
+	if d.IsEmpty() {
+		return "no changes\n"
+	}
+	var b strings.Builder
+	for _, c := range d.FieldChanges {
+		fmt.Fprintf(&b, "~ %s: %q -> %q\n", c.Field, c.Old, c.New)
+	}
+	if d.ScheduleChange != nil {
+		fmt.Fprintf(&b, "~ process_mode: %s -> %s\n", formatProcessMode(d.ScheduleChange.Old), formatProcessMode(d.ScheduleChange.New))
+	}
+	for _, n := range d.NodesRemoved {
+		fmt.Fprintf(&b, "- node %s (%s)\n", n.ID, n.Type)
+	}
+	for _, n := range d.NodesAdded {
+		fmt.Fprintf(&b, "+ node %s (%s)\n", n.ID, n.Type)
+	}
+	for _, c := range d.ParameterChanges {
+		switch {
+		case c.Old == nil:
+			fmt.Fprintf(&b, "+ param %s %s.%s = %s\n", c.NodeID, c.Component, c.Key, formatGraphParamValue(c.New))
+		case c.New == nil:
+			fmt.Fprintf(&b, "- param %s %s.%s = %s\n", c.NodeID, c.Component, c.Key, formatGraphParamValue(c.Old))
+		default:
+			fmt.Fprintf(&b, "~ param %s %s.%s: %s -> %s\n", c.NodeID, c.Component, c.Key, formatGraphParamValue(c.Old), formatGraphParamValue(c.New))
+		}
+	}
+	for _, c := range d.ConnectionsRemoved {
+		fmt.Fprintf(&b, "- connection %s\n", formatConnection(c))
+	}
+	for _, c := range d.ConnectionsAdded {
+		fmt.Fprintf(&b, "+ connection %s\n", formatConnection(c))
+	}
+	return b.String()
+}
+
+func diffWorkflowGraph(diff *WorkflowDiff, a, b *CatalogWorkflow) {
+	if a == nil {
+		a = &CatalogWorkflow{}
+	}
+	if b == nil {
+		b = &CatalogWorkflow{}
+	}
+
+	oldNodes := make(map[string]CatalogWorkflowNode, len(a.Nodes))
+	for _, n := range a.Nodes {
+		oldNodes[n.ID] = n
+	}
+	newNodes := make(map[string]CatalogWorkflowNode, len(b.Nodes))
+	for _, n := range b.Nodes {
+		newNodes[n.ID] = n
+	}
+
+	for _, n := range a.Nodes {
+		if nn, ok := newNodes[n.ID]; !ok || nn.Type != n.Type {
+			diff.NodesRemoved = append(diff.NodesRemoved, n)
+		}
+	}
+	for _, n := range b.Nodes {
+		on, ok := oldNodes[n.ID]
+		if !ok || on.Type != n.Type {
+			diff.NodesAdded = append(diff.NodesAdded, n)
+			continue
+		}
+		diff.ParameterChanges = append(diff.ParameterChanges, diffNodeParameters(n.ID, on.InitParameters, n.InitParameters)...)
+	}
+
+	oldConns := make(map[CatalogWorkflowConnection]bool, len(a.Connections))
+	for _, c := range a.Connections {
+		oldConns[c] = true
+	}
+	newConns := make(map[CatalogWorkflowConnection]bool, len(b.Connections))
+	for _, c := range b.Connections {
+		newConns[c] = true
+	}
+	for _, c := range a.Connections {
+		if !newConns[c] {
+			diff.ConnectionsRemoved = append(diff.ConnectionsRemoved, c)
+		}
+	}
+	for _, c := range b.Connections {
+		if !oldConns[c] {
+			diff.ConnectionsAdded = append(diff.ConnectionsAdded, c)
+		}
+	}
+}
+
+// diffNodeParameters compares init parameters component by component in sorted order.
+func diffNodeParameters(nodeID string, a, b map[string]map[string]interface{}) []WorkflowParameterChange {
+	components := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		components[k] = struct{}{}
+	}
+	for k := range b {
+		components[k] = struct{}{}
+	}
+
+	var changes []WorkflowParameterChange
+	for _, component := range sortedKeys(components) {
+		oldValues, newValues := a[component], b[component]
+		keys := make(map[string]struct{}, len(oldValues)+len(newValues))
+		for k := range oldValues {
+			keys[k] = struct{}{}
+		}
+		for k := range newValues {
+			keys[k] = struct{}{}
+		}
+		for _, key := range sortedKeys(keys) {
+			oldValue, hadOld := oldValues[key]
+			newValue, hasNew := newValues[key]
+			if hadOld && hasNew && jsonValueEqual(oldValue, newValue) {
+				continue
+			}
+			change := WorkflowParameterChange{NodeID: nodeID, Component: component, Key: key}
+			if hadOld {
+				change.Old = oldValue
+			}
+			if hasNew {
+				change.New = newValue
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonValueEqual(a, b interface{}) bool {
+	da, errA := json.Marshal(a)
+	db, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+	}
+	return bytes.Equal(da, db)
+}
+
+func processModeEqual(a, b *ProcessMode) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func formatProcessMode(p *ProcessMode) string {
+	if p == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("interval=%ds offset=%ds", p.Interval, p.Offset)
+}
+
+func formatConnection(c CatalogWorkflowConnection) string {
+	s := c.Sender + " -> " + c.Receiver
+	if label := connectionPortLabel(c); label != "" {
+		s += " [" + label + "]"
+	}
+	return s
+}
+
+func joinSortedStrings(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func joinSortedInts(values []int) string {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	parts := make([]string, len(sorted))
+	for i, v := range sorted {
+		parts[i] = fmt.Sprintf("%d", v)
+	}
+	return strings.Join(parts, ",")
+}
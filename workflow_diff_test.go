@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDiffWorkflow() *WorkflowMetadata {
+	return &WorkflowMetadata{
+		Name:              "docs",
+		SourceVolumeIDs:   []string{"v1", "v2"},
+		SourceVolumeNames: []string{},
+		TargetVolumeID:    "t1",
+		ProcessMode:       &ProcessMode{Interval: 60, Offset: 0},
+		FileTypes:         []int{int(FileTypePDF), int(FileTypeDOCX)},
+		Workflow:          newTestGraphWorkflow(),
+	}
+}
+
+func TestDiffWorkflows_NoChanges(t *testing.T) {
+	t.Parallel()
+
+	a := newTestDiffWorkflow()
+	b := newTestDiffWorkflow()
+	// Order of volumes and file types must not matter
+	b.SourceVolumeIDs = []string{"v2", "v1"}
+	b.FileTypes = []int{int(FileTypeDOCX), int(FileTypePDF)}
+
+	diff := DiffWorkflows(a, b)
+	require.True(t, diff.IsEmpty())
+	require.Equal(t, "no changes\n", diff.Summary())
+}
+
+func TestDiffWorkflows_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	a := newTestDiffWorkflow()
+	data, err := json.Marshal(a)
+	require.NoError(t, err)
+	var b WorkflowMetadata
+	require.NoError(t, json.Unmarshal(data, &b))
+
+	// chunk_size is int in a and float64 in b
+	require.True(t, DiffWorkflows(a, &b).IsEmpty())
+}
+
+func TestDiffWorkflows_Changes(t *testing.T) {
+	t.Parallel()
+
+	a := newTestDiffWorkflow()
+	b := newTestDiffWorkflow()
+	b.Name = "docs-v2"
+	b.ProcessMode = &ProcessMode{Interval: 300}
+	b.Workflow.Nodes[1].InitParameters["DocumentSplitter"]["chunk_size"] = 1024
+	b.Workflow.Nodes = append(b.Workflow.Nodes, CatalogWorkflowNode{ID: "EmbedNode_3", Type: "EmbedNode"})
+	b.Workflow.Connections = append(b.Workflow.Connections, CatalogWorkflowConnection{Sender: "ChunkNode_2", Receiver: "EmbedNode_3"})
+
+	diff := DiffWorkflows(a, b)
+	require.False(t, diff.IsEmpty())
+	require.Equal(t, []WorkflowFieldChange{{Field: "name", Old: "docs", New: "docs-v2"}}, diff.FieldChanges)
+	require.NotNil(t, diff.ScheduleChange)
+	require.Equal(t, 300, diff.ScheduleChange.New.Interval)
+	require.Len(t, diff.NodesAdded, 1)
+	require.Empty(t, diff.NodesRemoved)
+	require.Equal(t, []WorkflowParameterChange{{
+		NodeID: "ChunkNode_2", Component: "DocumentSplitter", Key: "chunk_size", Old: 512, New: 1024,
+	}}, diff.ParameterChanges)
+	require.Len(t, diff.ConnectionsAdded, 1)
+
+	summary := diff.Summary()
+	require.Contains(t, summary, `~ name: "docs" -> "docs-v2"`)
+	require.Contains(t, summary, "~ process_mode: interval=60s offset=0s -> interval=300s offset=0s")
+	require.Contains(t, summary, "+ node EmbedNode_3 (EmbedNode)")
+	require.Contains(t, summary, "~ param ChunkNode_2 DocumentSplitter.chunk_size: 512 -> 1024")
+	require.Contains(t, summary, "+ connection ChunkNode_2 -> EmbedNode_3")
+}
+
+func TestDiffWorkflows_NodeTypeChange(t *testing.T) {
+	t.Parallel()
+
+	a := newTestDiffWorkflow()
+	b := newTestDiffWorkflow()
+	b.Workflow.Nodes[1].Type = "SplitNode"
+
+	diff := DiffWorkflows(a, b)
+	require.Len(t, diff.NodesRemoved, 1)
+	require.Len(t, diff.NodesAdded, 1)
+	require.Empty(t, diff.ParameterChanges)
+}
+
+func TestDiffWorkflows_Nil(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, DiffWorkflows(nil, nil).IsEmpty())
+	diff := DiffWorkflows(nil, newTestDiffWorkflow())
+	require.Len(t, diff.NodesAdded, 2)
+	require.Equal(t, &WorkflowScheduleChange{New: &ProcessMode{Interval: 60}}, diff.ScheduleChange)
+}
@@ -0,0 +1,175 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxGraphParamValueLen limits how much of a parameter value is rendered in a node label.
+const maxGraphParamValueLen = 40
+
+// ToDOT renders the workflow as a Graphviz DOT digraph.
+//
+// Each node is labelled with its ID, type and a summary of its init parameters.
+// Connections are rendered as edges; sender and receiver ports, when set, are
+// used as the edge label. The output is deterministic so it can be committed
+// alongside workflow definitions and diffed in code review.
+//
+// Example:
+//
+//	dot := workflow.ToDOT()
+//	os.WriteFile("workflow.dot", []byte(dot), 0644)
+//	// dot -Tsvg workflow.dot -o workflow.svg
+func (w *CatalogWorkflow) ToDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph workflow {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	if w != nil {
+		for _, node := range w.Nodes {
+			lines := append([]string{node.ID, node.Type}, summarizeNodeParameters(node.InitParameters)...)
+			for i := range lines {
+				lines[i] = escapeDOT(lines[i])
+			}
+			fmt.Fprintf(&b, "  \"%s\" [label=\"%s\"];\n", escapeDOT(node.ID), strings.Join(lines, "\\n"))
+		}
+		for _, conn := range w.Connections {
+			label := connectionPortLabel(conn)
+			if label == "" {
+				fmt.Fprintf(&b, "  \"%s\" -> \"%s\";\n", escapeDOT(conn.Sender), escapeDOT(conn.Receiver))
+				continue
+			}
+			fmt.Fprintf(&b, "  \"%s\" -> \"%s\" [label=\"%s\"];\n", escapeDOT(conn.Sender), escapeDOT(conn.Receiver), escapeDOT(label))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// ToMermaid renders the workflow as a Mermaid flowchart.
+//
+// The output can be embedded directly in Markdown (for example in a pull request
+// description) inside a ```mermaid code block. Node IDs are sanitized into valid
+// Mermaid identifiers while the original IDs remain visible in the labels.
+//
+// Example:
+//
+//	fmt.Println("```mermaid")
+//	fmt.Print(workflow.ToMermaid())
+//	fmt.Println("```")
+func (w *CatalogWorkflow) ToMermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	if w != nil {
+		ids := make(map[string]string, len(w.Nodes))
+		mermaidID := func(id string) string {
+			if v, ok := ids[id]; ok {
+				return v
+			}
+			v := sanitizeMermaidID(id, len(ids))
+			ids[id] = v
+			return v
+		}
+		for _, node := range w.Nodes {
+			lines := append([]string{node.ID, node.Type}, summarizeNodeParameters(node.InitParameters)...)
+			for i := range lines {
+				lines[i] = escapeMermaid(lines[i])
+			}
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", mermaidID(node.ID), strings.Join(lines, "<br/>"))
+		}
+		for _, conn := range w.Connections {
+			label := connectionPortLabel(conn)
+			if label == "" {
+				fmt.Fprintf(&b, "  %s --> %s\n", mermaidID(conn.Sender), mermaidID(conn.Receiver))
+				continue
+			}
+			fmt.Fprintf(&b, "  %s -->|\"%s\"| %s\n", mermaidID(conn.Sender), escapeMermaid(label), mermaidID(conn.Receiver))
+		}
+	}
+	return b.String()
+}
+
+// summarizeNodeParameters flattens init parameters into sorted "component.key=value" lines.
+func summarizeNodeParameters(params map[string]map[string]interface{}) []string {
+	if len(params) == 0 {
+		return nil
+	}
+	components := make([]string, 0, len(params))
+	for component := range params {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	var lines []string
+	for _, component := range components {
+		values := params[component]
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s.%s=%s", component, key, formatGraphParamValue(values[key])))
+		}
+	}
+	return lines
+}
+
+func formatGraphParamValue(v interface{}) string {
+	var s string
+	switch val := v.(type) {
+	case string:
+		s = val
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			s = fmt.Sprintf("%v", val)
+		} else {
+			s = string(data)
+		}
+	}
+	if len(s) > maxGraphParamValueLen {
+		// Cut on a rune boundary, values are often Chinese text
+		n := maxGraphParamValueLen
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n] + "..."
+	}
+	return s
+}
+
+func connectionPortLabel(conn CatalogWorkflowConnection) string {
+	if conn.SenderPort == "" && conn.ReceiverPort == "" {
+		return ""
+	}
+	return conn.SenderPort + " -> " + conn.ReceiverPort
+}
+
+func escapeDOT(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "\"", "\\\"")
+	return strings.ReplaceAll(s, "\n", "\\n")
+}
+
+func escapeMermaid(s string) string {
+	s = strings.ReplaceAll(s, "\"", "#quot;")
+	return strings.ReplaceAll(s, "\n", "<br/>")
+}
+
+// sanitizeMermaidID converts an arbitrary node ID into a Mermaid-safe identifier.
+func sanitizeMermaidID(id string, index int) string {
+	var b strings.Builder
+	for _, r := range id {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	// Suffix with the index to keep IDs unique after sanitization
+	return fmt.Sprintf("n%d_%s", index, b.String())
+}
@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGraphWorkflow() *CatalogWorkflow {
+	return &CatalogWorkflow{
+		Nodes: []CatalogWorkflowNode{
+			{ID: "RootNode_1", Type: "RootNode", InitParameters: map[string]map[string]interface{}{}},
+			{
+				ID:   "ChunkNode_2",
+				Type: "ChunkNode",
+				InitParameters: map[string]map[string]interface{}{
+					"DocumentSplitter": {"enable_level_based_split": true, "chunk_size": 512},
+				},
+			},
+		},
+		Connections: []CatalogWorkflowConnection{
+			{Sender: "RootNode_1", Receiver: "ChunkNode_2"},
+		},
+	}
+}
+
+func TestCatalogWorkflow_ToDOT(t *testing.T) {
+	t.Parallel()
+
+	dot := newTestGraphWorkflow().ToDOT()
+	require.True(t, strings.HasPrefix(dot, "digraph workflow {"))
+	require.Contains(t, dot, `"RootNode_1" [label="RootNode_1\nRootNode"];`)
+	require.Contains(t, dot, `DocumentSplitter.chunk_size=512\nDocumentSplitter.enable_level_based_split=true`)
+	require.Contains(t, dot, `"RootNode_1" -> "ChunkNode_2";`)
+
+	// Output must be deterministic
+	require.Equal(t, dot, newTestGraphWorkflow().ToDOT())
+}
+
+func TestCatalogWorkflow_ToMermaid(t *testing.T) {
+	t.Parallel()
+
+	wf := newTestGraphWorkflow()
+	wf.Connections[0].SenderPort = "out"
+	wf.Connections[0].ReceiverPort = "in"
+
+	mermaid := wf.ToMermaid()
+	require.True(t, strings.HasPrefix(mermaid, "flowchart LR\n"))
+	require.Contains(t, mermaid, `n0_RootNode_1["RootNode_1<br/>RootNode"]`)
+	require.Contains(t, mermaid, `n0_RootNode_1 -->|"out -> in"| n1_ChunkNode_2`)
+}
+
+func TestCatalogWorkflow_GraphNil(t *testing.T) {
+	t.Parallel()
+
+	var wf *CatalogWorkflow
+	require.Equal(t, "digraph workflow {\n  rankdir=LR;\n  node [shape=box];\n}\n", wf.ToDOT())
+	require.Equal(t, "flowchart LR\n", wf.ToMermaid())
+}
+
+func TestFormatGraphParamValue_Truncate(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "short", formatGraphParamValue("short"))
+
+	long := formatGraphParamValue(strings.Repeat("文档", 20))
+	require.True(t, utf8.ValidString(long))
+	require.True(t, strings.HasSuffix(long, "..."))
+	require.LessOrEqual(t, len(long), maxGraphParamValueLen+len("..."))
+}
@@ -0,0 +1,281 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GetWorkflowJob retrieves a workflow job with node-level execution detail.
+//
+// Unlike ListWorkflowJobs, the response includes the status, timing, document counts
+// and error of every node, which is usually enough to find out why a job failed.
+//
+// Example:
+//
+//	job, err := client.GetWorkflowJob(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	for _, node := range job.FailedNodes() {
+//		fmt.Printf("%s (%s) failed: %s\n", node.NodeID, node.NodeType, node.Error)
+//	}
+func (c *RawClient) GetWorkflowJob(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowJobDetail, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	var raw workflowJobDetailRaw
+	if err := c.getJSON(ctx, workflowJobPath(jobID), &raw, opts...); err != nil {
+		return nil, err
+	}
+	detail := &WorkflowJobDetail{
+		WorkflowJob:  raw.toWorkflowJob(""),
+		ErrorMessage: raw.ErrorMessage,
+		Nodes:        raw.Nodes,
+	}
+	if detail.Nodes == nil {
+		detail.Nodes = []WorkflowJobNodeResult{}
+	}
+	return detail, nil
+}
+
+// GetWorkflowJobLogs retrieves the logs written so far by a workflow job.
+//
+// Use StreamWorkflowJobLogs to follow the logs of a running job.
+//
+// Example:
+//
+//	resp, err := client.GetWorkflowJobLogs(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	for _, entry := range resp.Logs {
+//		fmt.Printf("%s [%s] %s: %s\n", entry.Time, entry.Level, entry.NodeID, entry.Message)
+//	}
+func (c *RawClient) GetWorkflowJobLogs(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowJobLogsResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	resp := WorkflowJobLogsResponse{Logs: []WorkflowJobLogEntry{}}
+	if err := c.getJSON(ctx, workflowJobPath(jobID)+"/logs", &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.Logs == nil {
+		resp.Logs = []WorkflowJobLogEntry{}
+	}
+	return &resp, nil
+}
+
+// StreamWorkflowJobLogs follows the logs of a workflow job as they are written.
+//
+// The stream first replays the existing log lines and ends with io.EOF once the job
+// has finished. The returned stream must be closed by the caller; cancel ctx to stop
+// following a job that is still running. With WithLongPollFallback, a stream that
+// cannot be established is replaced by polling GetWorkflowJobLogs.
+//
+// Example:
+//
+//	stream, err := client.StreamWorkflowJobLogs(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	defer stream.Close()
+//	for {
+//		entry, err := stream.Next()
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Printf("[%s] %s\n", entry.Level, entry.Message)
+//	}
+func (c *RawClient) StreamWorkflowJobLogs(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowJobLogStream, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	callOpts := newCallOptions(opts...)
+	callOpts.query.Set("follow", "true")
+
+	httpReq, err := c.buildRequest(ctx, http.MethodGet, workflowJobPath(jobID)+"/logs", nil, callOpts)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set(headerAccept, "application/x-ndjson")
+
+	// Use a client with no timeout; the stream lasts as long as the job runs and
+	// can still be cancelled via context
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := c.send(streamClient, httpReq, callOpts)
+	if err != nil {
+		if callOpts.longPollFallback && isSSEHandshakeError(ctx, err) {
+			return c.pollWorkflowJobLogs(ctx, jobID, opts...), nil
+		}
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, newHTTPError(resp, data)
+	}
+	return &WorkflowJobLogStream{
+		Body:       resp.Body,
+		Header:     resp.Header.Clone(),
+		StatusCode: resp.StatusCode,
+		decoder:    json.NewDecoder(resp.Body),
+	}, nil
+}
+
+// RetryWorkflowJob reruns a failed or cancelled workflow job.
+//
+// The retry runs as a new job over the same source files; use the returned JobID to
+// follow it with WaitForWorkflowJob.
+//
+// Example:
+//
+//	retry, err := client.RetryWorkflowJob(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	job, err := client.WaitForWorkflowJob(ctx, retry.JobID, sdk.WaitOptions{Timeout: 10 * time.Minute})
+func (c *RawClient) RetryWorkflowJob(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowJobRetryResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	var resp WorkflowJobRetryResponse
+	if err := c.postJSON(ctx, workflowJobPath(jobID)+"/retry", nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.RetriedFrom == "" {
+		resp.RetriedFrom = jobID
+	}
+	return &resp, nil
+}
+
+// CancelWorkflowJob cancels a running workflow job.
+//
+// Nodes that are already running finish their current document; no further nodes
+// are started. Cancelling a job that has already finished returns an error.
+//
+// Example:
+//
+//	resp, err := client.CancelWorkflowJob(ctx, "job-123")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Printf("job %s is %s\n", resp.JobID, resp.Status)
+func (c *RawClient) CancelWorkflowJob(ctx context.Context, jobID string, opts ...CallOption) (*WorkflowJobCancelResponse, error) {
+	if strings.TrimSpace(jobID) == "" {
+		return nil, fmt.Errorf("jobID cannot be empty")
+	}
+	var resp WorkflowJobCancelResponse
+	if err := c.postJSON(ctx, workflowJobPath(jobID)+"/cancel", nil, &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.JobID == "" {
+		resp.JobID = jobID
+	}
+	return &resp, nil
+}
+
+// WorkflowJobLogStream reads newline-delimited log entries of a workflow job.
+type WorkflowJobLogStream struct {
+	// Body is the response body that must be closed by the caller
+	Body io.ReadCloser
+	// Header contains the HTTP response headers
+	Header http.Header
+	// StatusCode is the HTTP status code
+	StatusCode int
+	decoder    *json.Decoder
+}
+
+// Next returns the next log entry, or io.EOF when the job has finished.
+func (s *WorkflowJobLogStream) Next() (*WorkflowJobLogEntry, error) {
+	if s == nil || s.decoder == nil {
+		return nil, io.EOF
+	}
+	var entry WorkflowJobLogEntry
+	if err := s.decoder.Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Close releases the underlying HTTP response body.
+func (s *WorkflowJobLogStream) Close() error {
+	if s == nil || s.Body == nil {
+		return nil
+	}
+	return s.Body.Close()
+}
+
+func workflowJobPath(jobID string) string {
+	return fmt.Sprintf("/byoa/api/v1/workflow_job/%s", url.PathEscape(jobID))
+}
+
+// pollWorkflowJobLogs returns a log stream that polls GetWorkflowJobLogs until the job
+// has finished.
+func (c *RawClient) pollWorkflowJobLogs(ctx context.Context, jobID string, opts ...CallOption) *WorkflowJobLogStream {
+	pollCtx, cancel := context.WithCancel(ctx)
+	body := &jobLogPollReader{ctx: pollCtx, cancel: cancel, client: c, jobID: jobID, opts: opts}
+	return &WorkflowJobLogStream{
+		Body:       body,
+		Header:     make(http.Header),
+		StatusCode: http.StatusOK,
+		decoder:    json.NewDecoder(body),
+	}
+}
+
+// jobLogPollReader polls the logs of a workflow job and renders the new entries as
+// newline-delimited JSON.
+type jobLogPollReader struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	client *RawClient
+	jobID  string
+	opts   []CallOption
+	seen   int
+	done   bool
+	buf    bytes.Buffer
+}
+
+func (r *jobLogPollReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		// Check the status first so that no entry written before the job finished is missed
+		job, err := r.client.GetWorkflowJob(r.ctx, r.jobID, r.opts...)
+		if err != nil {
+			return 0, err
+		}
+		logs, err := r.client.GetWorkflowJobLogs(r.ctx, r.jobID, r.opts...)
+		if err != nil {
+			return 0, err
+		}
+		enc := json.NewEncoder(&r.buf)
+		for _, entry := range logs.Logs[min(r.seen, len(logs.Logs)):] {
+			if err := enc.Encode(entry); err != nil {
+				return 0, err
+			}
+		}
+		r.seen = max(r.seen, len(logs.Logs))
+		r.done = job.Status.IsTerminal()
+		if r.buf.Len() == 0 && !r.done {
+			if err := sleepWithContext(r.ctx, analysisLongPollInterval); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return r.buf.Read(p)
+}
+
+func (r *jobLogPollReader) Close() error {
+	r.cancel()
+	return nil
+}
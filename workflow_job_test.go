@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWorkflowJob(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/byoa/api/v1/workflow_job/job-1", r.URL.Path)
+		return envelopeResponse(`{"id":"job-1","workflow_id":"wf-1","status":3,"error_message":"node failed",
+			"nodes":[{"node_id":"n1","node_type":"ParseNode","status":"completed","duration_ms":120},
+			{"node_id":"n2","node_type":"ChunkNode","status":"failed","error":"out of memory"}]}`), nil
+	})
+
+	job, err := client.GetWorkflowJob(context.Background(), "job-1")
+	require.NoError(t, err)
+	require.Equal(t, "job-1", job.JobID)
+	require.Equal(t, "wf-1", job.WorkflowID)
+	require.Equal(t, "node failed", job.ErrorMessage)
+	require.Len(t, job.Nodes, 2)
+	require.Equal(t, []WorkflowJobNodeResult{{NodeID: "n2", NodeType: "ChunkNode", Status: "failed", Error: "out of memory"}}, job.FailedNodes())
+
+	_, err = client.GetWorkflowJob(context.Background(), " ")
+	require.Error(t, err)
+}
+
+func TestGetWorkflowJobLogs(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/byoa/api/v1/workflow_job/job-1/logs", r.URL.Path)
+		return envelopeResponse(`{"logs":[{"time":"2024-01-01T00:00:00Z","level":"error","node_id":"n2","message":"out of memory"}]}`), nil
+	})
+
+	resp, err := client.GetWorkflowJobLogs(context.Background(), "job-1")
+	require.NoError(t, err)
+	require.Equal(t, []WorkflowJobLogEntry{{Time: "2024-01-01T00:00:00Z", Level: "error", NodeID: "n2", Message: "out of memory"}}, resp.Logs)
+}
+
+func TestStreamWorkflowJobLogs(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, "/byoa/api/v1/workflow_job/job-1/logs", r.URL.Path)
+		require.Equal(t, "true", r.URL.Query().Get("follow"))
+		body := "{\"level\":\"info\",\"message\":\"started\"}\n{\"level\":\"info\",\"node_id\":\"n1\",\"message\":\"parsed 3 files\"}\n"
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/x-ndjson"}},
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}, nil
+	})
+
+	stream, err := client.StreamWorkflowJobLogs(context.Background(), "job-1")
+	require.NoError(t, err)
+	defer stream.Close()
+
+	var messages []string
+	for {
+		entry, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		messages = append(messages, entry.Message)
+	}
+	require.Equal(t, []string{"started", "parsed 3 files"}, messages)
+}
+
+func TestRetryAndCancelWorkflowJob(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodPost, r.Method)
+		switch r.URL.Path {
+		case "/byoa/api/v1/workflow_job/job-1/retry":
+			return envelopeResponse(`{"job_id":"job-2"}`), nil
+		case "/byoa/api/v1/workflow_job/job-2/cancel":
+			return envelopeResponse(`{"status":4}`), nil
+		}
+		return nil, fmt.Errorf("unexpected path %s", r.URL.Path)
+	})
+	ctx := context.Background()
+
+	retry, err := client.RetryWorkflowJob(ctx, "job-1")
+	require.NoError(t, err)
+	require.Equal(t, &WorkflowJobRetryResponse{JobID: "job-2", RetriedFrom: "job-1"}, retry)
+
+	cancel, err := client.CancelWorkflowJob(ctx, retry.JobID)
+	require.NoError(t, err)
+	require.Equal(t, "job-2", cancel.JobID)
+	require.Equal(t, WorkflowJobStatusCancelled, cancel.Status)
+	require.True(t, cancel.Status.IsTerminal())
+	require.Equal(t, "cancelled", cancel.Status.String())
+
+	_, err = client.RetryWorkflowJob(ctx, "")
+	require.Error(t, err)
+	_, err = client.CancelWorkflowJob(ctx, "")
+	require.Error(t, err)
+}
+
+func TestStreamWorkflowJobLogs_LongPollFallback(t *testing.T) {
+	t.Parallel()
+
+	var polls int
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case r.URL.Query().Get("follow") == "true":
+			return nil, errors.New("connection reset by proxy")
+		case r.URL.Path == "/byoa/api/v1/workflow_job/job-1":
+			polls++
+			if polls == 1 {
+				return envelopeResponse(`{"id":"job-1","status":1}`), nil
+			}
+			return envelopeResponse(`{"id":"job-1","status":2}`), nil
+		case r.URL.Path == "/byoa/api/v1/workflow_job/job-1/logs":
+			if polls == 1 {
+				return envelopeResponse(`{"logs":[{"level":"info","message":"started"}]}`), nil
+			}
+			return envelopeResponse(`{"logs":[{"level":"info","message":"started"},{"level":"info","message":"done"}]}`), nil
+		}
+		t.Fatalf("unexpected request %s %s", r.Method, r.URL)
+		return nil, nil
+	})
+
+	_, err := client.StreamWorkflowJobLogs(context.Background(), "job-1")
+	require.ErrorContains(t, err, "connection reset by proxy")
+
+	stream, err := client.StreamWorkflowJobLogs(context.Background(), "job-1", WithLongPollFallback())
+	require.NoError(t, err)
+	defer stream.Close()
+	var messages []string
+	for {
+		entry, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		messages = append(messages, entry.Message)
+	}
+	require.Equal(t, []string{"started", "done"}, messages)
+}
@@ -0,0 +1,257 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// ListWorkflowNodeTypes lists the node types available for workflows on the server,
+// together with the schema of their init parameters.
+//
+// The descriptors are machine-readable, so tools can render parameter forms from them
+// and workflows can be checked against the live schemas with ValidateWorkflow before
+// they are created.
+//
+// Example:
+//
+//	types, err := client.ListWorkflowNodeTypes(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	if err := types.ValidateWorkflow(metadata.Workflow); err != nil {
+//		return fmt.Errorf("invalid workflow: %w", err)
+//	}
+func (c *RawClient) ListWorkflowNodeTypes(ctx context.Context, opts ...CallOption) (*WorkflowNodeTypeListResponse, error) {
+	var resp WorkflowNodeTypeListResponse
+	if err := c.getJSON(ctx, "/v1/genai/workflow_node_type", &resp, opts...); err != nil {
+		return nil, err
+	}
+	if resp.NodeTypes == nil {
+		resp.NodeTypes = []WorkflowNodeType{}
+	}
+	return &resp, nil
+}
+
+// Lookup returns the descriptor of the given node type.
+func (r *WorkflowNodeTypeListResponse) Lookup(nodeType string) (*WorkflowNodeType, bool) {
+	if r == nil {
+		return nil, false
+	}
+	for i := range r.NodeTypes {
+		if r.NodeTypes[i].Type == nodeType {
+			return &r.NodeTypes[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidateWorkflow checks every node of w against the node type schemas: the node type
+// must exist, its init parameters must be known and well-typed, required parameters
+// must be set and connection ports must exist. All problems are reported, joined into
+// one error.
+func (r *WorkflowNodeTypeListResponse) ValidateWorkflow(w *CatalogWorkflow) error {
+	if w == nil {
+		return nil
+	}
+	var errs []error
+	nodeTypes := make(map[string]*WorkflowNodeType, len(w.Nodes))
+	for _, node := range w.Nodes {
+		nodeType, ok := r.Lookup(node.Type)
+		if !ok {
+			errs = append(errs, fmt.Errorf("node %s: unknown node type %q", node.ID, node.Type))
+			continue
+		}
+		nodeTypes[node.ID] = nodeType
+		for _, err := range nodeType.parameterErrors(node.InitParameters) {
+			errs = append(errs, fmt.Errorf("node %s: %w", node.ID, err))
+		}
+	}
+	for _, conn := range w.Connections {
+		if t, ok := nodeTypes[conn.Sender]; ok && conn.SenderPort != "" && !containsString(t.OutputPorts, conn.SenderPort) {
+			errs = append(errs, fmt.Errorf("connection %s -> %s: node type %s has no output port %q", conn.Sender, conn.Receiver, t.Type, conn.SenderPort))
+		}
+		if t, ok := nodeTypes[conn.Receiver]; ok && conn.ReceiverPort != "" && !containsString(t.InputPorts, conn.ReceiverPort) {
+			errs = append(errs, fmt.Errorf("connection %s -> %s: node type %s has no input port %q", conn.Sender, conn.Receiver, t.Type, conn.ReceiverPort))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateParameters checks init parameters, keyed by component name and then by
+// parameter name, against the schema of the node type.
+func (t *WorkflowNodeType) ValidateParameters(params map[string]map[string]interface{}) error {
+	if t == nil {
+		return nil
+	}
+	return errors.Join(t.parameterErrors(params)...)
+}
+
+func (t *WorkflowNodeType) parameterErrors(params map[string]map[string]interface{}) []error {
+	var errs []error
+	components := make(map[string]*WorkflowNodeComponent, len(t.Components))
+	for i := range t.Components {
+		components[t.Components[i].Name] = &t.Components[i]
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		component, ok := components[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown component %q", name))
+			continue
+		}
+		errs = append(errs, component.validate(params[name])...)
+	}
+	// Components that are not configured must not have required parameters
+	for _, component := range t.Components {
+		if _, ok := params[component.Name]; ok {
+			continue
+		}
+		for _, p := range component.Parameters {
+			if p.Required && p.Default == nil {
+				errs = append(errs, fmt.Errorf("%s.%s is required", component.Name, p.Name))
+			}
+		}
+	}
+	return errs
+}
+
+func (c *WorkflowNodeComponent) validate(values map[string]interface{}) []error {
+	var errs []error
+	known := make(map[string]bool, len(c.Parameters))
+	for _, p := range c.Parameters {
+		known[p.Name] = true
+		value, ok := values[p.Name]
+		if !ok || value == nil {
+			if p.Required && p.Default == nil {
+				errs = append(errs, fmt.Errorf("%s.%s is required", c.Name, p.Name))
+			}
+			continue
+		}
+		if err := p.validate(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s: %w", c.Name, p.Name, err))
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		if !known[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs = append(errs, fmt.Errorf("%s.%s: unknown parameter", c.Name, name))
+	}
+	return errs
+}
+
+func (p *WorkflowNodeParameter) validate(value interface{}) error {
+	if !matchesParameterType(p.Type, value) {
+		return fmt.Errorf("expected %s, got %T", p.Type, value)
+	}
+	if len(p.Enum) > 0 {
+		allowed := false
+		for _, v := range p.Enum {
+			if parameterValuesEqual(v, value) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("value %v is not one of %v", value, p.Enum)
+		}
+	}
+	if f, ok := toFloat(value); ok {
+		if p.Minimum != nil && f < *p.Minimum {
+			return fmt.Errorf("value %v is below the minimum %v", value, *p.Minimum)
+		}
+		if p.Maximum != nil && f > *p.Maximum {
+			return fmt.Errorf("value %v is above the maximum %v", value, *p.Maximum)
+		}
+	}
+	return nil
+}
+
+// matchesParameterType reports whether value is valid for a parameter of the given
+// schema type. Unknown schema types accept any value.
+func matchesParameterType(typ string, value interface{}) bool {
+	switch typ {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := toFloat(value)
+		return ok
+	case "integer":
+		f, ok := toFloat(value)
+		return ok && f == math.Trunc(f)
+	case "array":
+		kind := reflect.TypeOf(value).Kind()
+		return kind == reflect.Slice || kind == reflect.Array
+	case "object":
+		return reflect.TypeOf(value).Kind() == reflect.Map
+	default:
+		return true
+	}
+}
+
+// parameterValuesEqual compares values, treating all numeric types alike since
+// schemas decoded from JSON hold float64 values.
+func parameterValuesEqual(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
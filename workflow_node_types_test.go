@@ -0,0 +1,86 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testNodeTypes = `{"node_types":[
+	{"type":"RootNode","output_ports":["out"]},
+	{"type":"ChunkNode","input_ports":["in"],"output_ports":["out"],"components":[
+		{"name":"DocumentSplitter","parameters":[
+			{"name":"chunk_size","type":"integer","required":true,"minimum":64,"maximum":8192},
+			{"name":"split_by","type":"string","enum":["word","sentence","page"]},
+			{"name":"enable_level_based_split","type":"boolean"}]}]}]}`
+
+func TestListWorkflowNodeTypes(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/v1/genai/workflow_node_type", r.URL.Path)
+		return envelopeResponse(testNodeTypes), nil
+	})
+
+	resp, err := client.ListWorkflowNodeTypes(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resp.NodeTypes, 2)
+	chunk, ok := resp.Lookup("ChunkNode")
+	require.True(t, ok)
+	require.Equal(t, "chunk_size", chunk.Components[0].Parameters[0].Name)
+	require.EqualValues(t, 64, *chunk.Components[0].Parameters[0].Minimum)
+	_, ok = resp.Lookup("EmbedNode")
+	require.False(t, ok)
+}
+
+func TestWorkflowNodeTypes_ValidateWorkflow(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(func(r *http.Request) (*http.Response, error) {
+		return envelopeResponse(testNodeTypes), nil
+	})
+	types, err := client.ListWorkflowNodeTypes(context.Background())
+	require.NoError(t, err)
+
+	valid := &CatalogWorkflow{
+		Nodes: []CatalogWorkflowNode{
+			{ID: "root", Type: "RootNode", InitParameters: map[string]map[string]interface{}{}},
+			{ID: "chunk", Type: "ChunkNode", InitParameters: map[string]map[string]interface{}{
+				"DocumentSplitter": {"chunk_size": 512, "split_by": "sentence", "enable_level_based_split": true},
+			}},
+		},
+		Connections: []CatalogWorkflowConnection{{Sender: "root", Receiver: "chunk", SenderPort: "out", ReceiverPort: "in"}},
+	}
+	require.NoError(t, types.ValidateWorkflow(valid))
+
+	invalid := &CatalogWorkflow{
+		Nodes: []CatalogWorkflowNode{
+			{ID: "root", Type: "RootNode"},
+			{ID: "chunk", Type: "ChunkNode", InitParameters: map[string]map[string]interface{}{
+				"DocumentSplitter": {"chunk_size": 12.5, "split_by": "line", "overlap": 10},
+			}},
+			{ID: "chunk2", Type: "ChunkNode", InitParameters: map[string]map[string]interface{}{
+				"DocumentSplitter": {"chunk_size": 16},
+			}},
+			{ID: "chunk3", Type: "ChunkNode"},
+			{ID: "embed", Type: "EmbedNode"},
+		},
+		Connections: []CatalogWorkflowConnection{{Sender: "root", Receiver: "chunk", SenderPort: "images"}},
+	}
+	err = types.ValidateWorkflow(invalid)
+	require.Error(t, err)
+	for _, want := range []string{
+		"node chunk: DocumentSplitter.chunk_size: expected integer, got float64",
+		`node chunk: DocumentSplitter.split_by: value line is not one of [word sentence page]`,
+		"node chunk: DocumentSplitter.overlap: unknown parameter",
+		"node chunk2: DocumentSplitter.chunk_size: value 16 is below the minimum 64",
+		"node chunk3: DocumentSplitter.chunk_size is required",
+		`node embed: unknown node type "EmbedNode"`,
+		`connection root -> chunk: node type RootNode has no output port "images"`,
+	} {
+		require.Contains(t, err.Error(), want)
+	}
+}
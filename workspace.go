@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+)
+
+const headerWorkspaceID = "X-Workspace-ID"
+
+// WithWorkspace sends id in the X-Workspace-ID header of every request, for gateways
+// that route the requests of several tenants by workspace. ContextWithWorkspace
+// overrides it for the calls made with a context.
+//
+// Example:
+//
+//	client, err := sdk.NewRawClient(baseURL, apiKey, sdk.WithWorkspace("ws-analytics"))
+func WithWorkspace(id string) ClientOption {
+	return func(o *clientOptions) {
+		o.workspace = strings.TrimSpace(id)
+	}
+}
+
+type workspaceContextKey struct{}
+
+// ContextWithWorkspace returns a context whose API calls are routed to workspace id,
+// whatever the workspace set with WithWorkspace. It applies to every kind of call,
+// including uploads and streams, so that a multi-tenant service can share one client
+// and pass the tenant of each incoming request along.
+//
+// Example:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		ctx := sdk.ContextWithWorkspace(r.Context(), tenantOf(r))
+//		resp, err := client.ListCatalogs(ctx)
+//		...
+//	}
+func ContextWithWorkspace(ctx context.Context, id string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, workspaceContextKey{}, strings.TrimSpace(id))
+}
+
+// WorkspaceFromContext returns the workspace stored with ContextWithWorkspace, or "".
+func WorkspaceFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(workspaceContextKey{}).(string)
+	return id
+}
+
+// workspaceFor returns the workspace of a call: the one carried by ctx, else the
+// client's.
+func (c *RawClient) workspaceFor(ctx context.Context) string {
+	if id := WorkspaceFromContext(ctx); id != "" {
+		return id
+	}
+	if c == nil {
+		return ""
+	}
+	return c.workspace
+}
@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspace(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	workspaces := map[string]string{}
+	client, err := NewRawClient("https://moi.test", "key",
+		WithWorkspace(" ws-default "),
+		WithHTTPClient(&http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			workspaces[r.URL.Path] = r.Header.Get(headerWorkspaceID)
+			mu.Unlock()
+			if r.Header.Get(headerAccept) == "text/event-stream" {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+					Body:       io.NopCloser(strings.NewReader("data: {}\n\n")),
+				}, nil
+			}
+			return envelopeResponse(`{}`), nil
+		})}))
+	require.NoError(t, err)
+	ctx := context.Background()
+	tenantCtx := ContextWithWorkspace(ctx, "ws-tenant")
+	require.Equal(t, "ws-tenant", WorkspaceFromContext(tenantCtx))
+
+	_, err = client.ListCatalogs(ctx)
+	require.NoError(t, err)
+	_, err = client.GetCatalog(tenantCtx, &CatalogInfoRequest{CatalogID: 1})
+	require.NoError(t, err)
+	_, err = client.UploadLocalFile(tenantCtx, strings.NewReader("a,b"), "data.csv", []FileMeta{{Filename: "data.csv", Path: "/"}})
+	require.NoError(t, err)
+	stream, err := client.AnalyzeDataStream(tenantCtx, &DataAnalysisRequest{Question: "q"})
+	require.NoError(t, err)
+	require.NoError(t, stream.Close())
+	// An explicit header of the call wins
+	_, err = client.GetFile(tenantCtx, &FileInfoRequest{FileID: "f1"}, WithHeader(headerWorkspaceID, "ws-call"))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"/catalog/list":                    "ws-default",
+		"/catalog/info":                    "ws-tenant",
+		"/connectors/file/upload":          "ws-tenant",
+		"/byoa/api/v1/data_asking/analyze": "ws-tenant",
+		"/catalog/file/info":               "ws-call",
+	}, workspaces)
+
+	// Derived clients keep the workspace
+	delete(workspaces, "/catalog/list")
+	special := client.WithSpecialUser("other-key")
+	_, err = special.ListCatalogs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "ws-default", workspaces["/catalog/list"])
+}